@@ -2,47 +2,64 @@ package main
 
 import (
 	"context"
-	"flag"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"solid-go/internal/init/cli"
+	"solid-go/internal/init/config"
+	"solid-go/internal/init/variables"
+	"solid-go/internal/init/variables/extractors"
 	"solid-go/internal/logging"
 	"solid-go/internal/server"
-	"solid-go/internal/storage"
 )
 
 func main() {
-	// Create logger
-	logger := logging.NewBasicLogger(logging.Info)
+	// Command line flags take precedence over SOLID_* environment variables,
+	// which take precedence over the config file
+	cliVars, err := cli.NewCliExtractor(os.Args).Extract()
+	if err != nil {
+		logging.NewBasicLogger(logging.Error).Error("Error reading command line arguments: %v", err)
+		os.Exit(2)
+	}
+	envVars, err := extractors.NewEnvironmentVariableExtractor("SOLID_").Extract()
+	if err != nil {
+		logging.NewBasicLogger(logging.Error).Error("Error reading environment variables: %v", err)
+		os.Exit(2)
+	}
 
-	// Parse command line flags
-	port := flag.Int("port", 3000, "Port to listen on")
-	https := flag.Bool("https", false, "Use HTTPS")
-	certFile := flag.String("cert", "", "Path to TLS certificate file")
-	keyFile := flag.String("key", "", "Path to TLS private key file")
-	storagePath := flag.String("storage", "./data", "Path to storage directory")
-	flag.Parse()
+	// Load configuration
+	cfg := config.Default()
+	if configPath := findVariable("configPath", cliVars, findVariable("SOLID_CONFIG", envVars, "")); configPath != "" {
+		if cfg, err = config.Load(configPath); err != nil {
+			logging.NewBasicLogger(logging.Error).Error("Error loading config: %v", err)
+			os.Exit(1)
+		}
+	}
+	if err := cfg.Apply(envVars); err == nil {
+		err = cfg.Apply(cliVars)
+	}
 
-	// Create storage
-	store, err := storage.NewFileStorage(*storagePath)
+	// Create logger
+	logger := logging.NewBasicLogger(logging.NewLogUtil().GetLogLevel(cfg.Server.LogLevel))
 	if err != nil {
-		logger.Error("Error creating storage: %v", err)
+		logger.Error("Error reading variables: %v", err)
 		os.Exit(1)
 	}
 
 	// Create server options
-	options := &server.ServerOptions{
-		Port:     *port,
-		HTTPS:    *https,
-		CertFile: *certFile,
-		KeyFile:  *keyFile,
-		Storage:  store,
-		Logger:   logger,
+	options, err := cfg.ServerOptions(logger)
+	if err != nil {
+		logger.Error("Error configuring server: %v", err)
+		os.Exit(1)
 	}
 
 	// Create server
-	srv := server.NewServer(options)
+	srv, err := server.NewServer(options)
+	if err != nil {
+		logger.Error("Error creating server: %v", err)
+		os.Exit(1)
+	}
 
 	// Create context that listens for the interrupt signal from the OS
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
@@ -50,23 +67,13 @@ func main() {
 
 	// Start server
 	go func() {
-		var err error
-		if *https {
-			if *certFile == "" || *keyFile == "" {
-				logger.Error("TLS certificate and key files are required for HTTPS")
-				os.Exit(1)
-			}
-			err = srv.ListenAndServeTLS(*certFile, *keyFile)
-		} else {
-			err = srv.Start()
-		}
-		if err != nil {
+		if err := srv.Start(); err != nil {
 			logger.Error("Error starting server: %v", err)
 			os.Exit(1)
 		}
 	}()
 
-	logger.Info("Server listening on port %d", *port)
+	logger.Info("Server listening on port %d with base URL %s", options.Port, options.BaseURL)
 
 	// Wait for interrupt signal
 	<-ctx.Done()
@@ -79,3 +86,13 @@ func main() {
 
 	logger.Info("Server stopped")
 }
+
+// findVariable returns the string value of the named variable, or the fallback if it is not present
+func findVariable(name string, vars []variables.Variable, fallback string) string {
+	for _, variable := range vars {
+		if value, ok := variable.Value.(string); ok && variable.Name == name && value != "" {
+			return value
+		}
+	}
+	return fallback
+}
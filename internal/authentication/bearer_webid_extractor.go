@@ -2,39 +2,37 @@
 package authentication
 
 import (
-	"fmt"
 	"net/http"
 	"strings"
+
+	"solid-go/internal/util/errors"
 )
 
-// BearerWebIdExtractor extracts WebID from Bearer token
+// BearerWebIdExtractor extracts the WebID of a Solid-OIDC access token sent as Bearer token.
+// Tokens that are bound to a DPoP key are not accepted without their proof.
 type BearerWebIdExtractor struct {
-	// TODO: Add token verifier
+	verifier TokenVerifier
 }
 
 // NewBearerWebIdExtractor creates a new BearerWebIdExtractor
-func NewBearerWebIdExtractor() *BearerWebIdExtractor {
-	return &BearerWebIdExtractor{}
+func NewBearerWebIdExtractor(verifier TokenVerifier) *BearerWebIdExtractor {
+	return &BearerWebIdExtractor{verifier: verifier}
 }
 
 // Extract implements CredentialsExtractor
 func (e *BearerWebIdExtractor) Extract(r *http.Request) (*Credentials, error) {
 	auth := r.Header.Get("Authorization")
-	if auth == "" {
-		return nil, fmt.Errorf("no Bearer Authorization header specified")
+	scheme, token, found := strings.Cut(auth, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return nil, errors.NewNotImplementedError("No Bearer Authorization header specified.", nil)
 	}
-
-	// Check if it's a Bearer token
-	parts := strings.Split(auth, " ")
-	if len(parts) != 2 || parts[0] != "Bearer" {
-		return nil, fmt.Errorf("invalid Bearer token format")
+	if token == "" {
+		return nil, errors.NewValidationError("Empty Bearer token.", nil)
 	}
 
-	// TODO: Implement proper Bearer token verification
-	// For now, just use the token as the WebID
-	return &Credentials{
-		Agent: &Agent{
-			WebID: parts[1],
-		},
-	}, nil
+	claims, err := e.verifier.Verify(token, nil)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("Error verifying WebID via Bearer access token: "+err.Error(), nil)
+	}
+	return claimsCredentials(claims), nil
 }
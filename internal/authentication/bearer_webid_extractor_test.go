@@ -1,88 +1,57 @@
 package authentication
 
 import (
+	"fmt"
 	"net/http"
 	"testing"
+
+	"solid-go/internal/authentication/oidc"
+	"solid-go/internal/util/errors"
 )
 
 func TestBearerWebIdExtractor(t *testing.T) {
+	claims := &oidc.Claims{WebID: "https://example.org/user", Issuer: "https://idp.example/", AuthorizedBy: "https://app.example/id"}
 	tests := []struct {
-		name          string
-		authHeader    string
-		expectedWebID string
-		expectError   bool
-		errorMessage  string
+		name        string
+		authHeader  string
+		verifyError error
+		check       func(err error) bool
 	}{
-		{
-			name:          "Valid Bearer Token",
-			authHeader:    "Bearer https://example.org/user",
-			expectedWebID: "https://example.org/user",
-			expectError:   false,
-		},
-		{
-			name:          "Valid Bearer Token Lowercase",
-			authHeader:    "bearer https://example.org/user",
-			expectedWebID: "https://example.org/user",
-			expectError:   false,
-		},
-		{
-			name:          "Missing Authorization Header",
-			authHeader:    "",
-			expectedWebID: "",
-			expectError:   true,
-			errorMessage:  "no Bearer Authorization header specified",
-		},
-		{
-			name:          "Invalid Bearer Format",
-			authHeader:    "Basic https://example.org/user",
-			expectedWebID: "",
-			expectError:   true,
-			errorMessage:  "invalid Bearer token format",
-		},
-		{
-			name:          "Malformed Bearer Token",
-			authHeader:    "Bearer",
-			expectedWebID: "",
-			expectError:   true,
-			errorMessage:  "invalid Bearer token format",
-		},
+		{name: "Valid Bearer Token", authHeader: "Bearer token"},
+		{name: "Valid Bearer Token Lowercase", authHeader: "bearer token"},
+		{name: "Missing Authorization Header", check: errors.IsNotImplementedError},
+		{name: "Invalid Bearer Format", authHeader: "Basic token", check: errors.IsNotImplementedError},
+		{name: "Malformed Bearer Token", authHeader: "Bearer", check: errors.IsNotImplementedError},
+		{name: "Empty Bearer Token", authHeader: "Bearer ", check: errors.IsValidationError},
+		{name: "Invalid Token", authHeader: "Bearer token",
+			verifyError: fmt.Errorf("the issuer is not trusted"), check: errors.IsUnauthorizedError},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			extractor := NewBearerWebIdExtractor()
-			req := &http.Request{
-				Header: make(http.Header),
-			}
+			verifier := &mockTokenVerifier{claims: claims, err: tt.verifyError}
+			extractor := NewBearerWebIdExtractor(verifier)
+			req := &http.Request{Header: make(http.Header)}
 			if tt.authHeader != "" {
 				req.Header.Set("Authorization", tt.authHeader)
 			}
 
 			creds, err := extractor.Extract(req)
-			if tt.expectError {
-				if err == nil {
-					t.Error("Extract() expected error, got nil")
-					return
-				}
-				if err.Error() != tt.errorMessage {
-					t.Errorf("Extract() error = %v, want %v", err, tt.errorMessage)
+			if tt.check != nil {
+				if err == nil || !tt.check(err) {
+					t.Errorf("Extract() error = %v", err)
 				}
 				return
 			}
 			if err != nil {
-				t.Errorf("Extract() error = %v", err)
-				return
-			}
-			if creds.Agent.WebID != tt.expectedWebID {
-				t.Errorf("Extract() WebID = %v, want %v", creds.Agent.WebID, tt.expectedWebID)
+				t.Fatalf("Extract() error = %v", err)
 			}
-
-			// Test that other fields are nil
-			if creds.Client != nil {
-				t.Error("Extract() Client should be nil")
+			if verifier.token != "token" || verifier.dpop != nil {
+				t.Errorf("Verify() called with %q and %+v", verifier.token, verifier.dpop)
 			}
-			if creds.Issuer != nil {
-				t.Error("Extract() Issuer should be nil")
+			// The client of tokens without client_id claim is the authorized party
+			if creds.Agent.WebID != claims.WebID || creds.Client.ClientID != claims.AuthorizedBy || creds.Issuer.URL != claims.Issuer {
+				t.Errorf("Extract() = %+v", creds)
 			}
 		})
 	}
@@ -2,6 +2,8 @@
 package authentication
 
 // Credentials represents authentication credentials identifying an entity accessing or owning data.
+// The same credentials are passed from the credentials extractors to every permission reader,
+// so authorization can depend on the agent, the client application and the issuer of a request.
 type Credentials struct {
 	// Agent represents the agent making the request
 	Agent *Agent `json:"agent,omitempty"`
@@ -9,7 +11,9 @@ type Credentials struct {
 	Client *Client `json:"client,omitempty"`
 	// Issuer represents the issuer of the credentials
 	Issuer *Issuer `json:"issuer,omitempty"`
-	// Additional fields can be added here
+	// VerifiableCredentials are the types of the verifiable credentials the agent presented,
+	// which only credentials extractors that verified the credentials may set
+	VerifiableCredentials []string `json:"verifiableCredentials,omitempty"`
 }
 
 // Agent represents an agent making a request
@@ -19,10 +23,60 @@ type Agent struct {
 
 // Client represents a client making a request
 type Client struct {
-	ClientID string `json:"clientId"`
+	// ClientID is the client_id of the access token,
+	// which is the URL of the Client ID Document of clients that publish one
+	ClientID string `json:"clientId,omitempty"`
+	// Origin is the Origin header of the request, which browsers set for the requests of web applications
+	Origin string `json:"origin,omitempty"`
 }
 
 // Issuer represents an issuer of credentials
 type Issuer struct {
 	URL string `json:"url"`
 }
+
+// WebID returns the WebID of the agent, which is empty for unauthenticated requests
+func (c *Credentials) WebID() string {
+	if c == nil || c.Agent == nil {
+		return ""
+	}
+	return c.Agent.WebID
+}
+
+// ClientID returns the client ID of the client, which is empty if the client is unknown
+func (c *Credentials) ClientID() string {
+	if c == nil || c.Client == nil {
+		return ""
+	}
+	return c.Client.ClientID
+}
+
+// Origin returns the origin of the client, which is empty for requests that are not made by web applications
+func (c *Credentials) Origin() string {
+	if c == nil || c.Client == nil {
+		return ""
+	}
+	return c.Client.Origin
+}
+
+// IssuerURL returns the URL of the issuer, which is empty if the credentials were not issued by an identity provider
+func (c *Credentials) IssuerURL() string {
+	if c == nil || c.Issuer == nil {
+		return ""
+	}
+	return c.Issuer.URL
+}
+
+// CredentialTypes returns the types of the verifiable credentials the agent presented
+func (c *Credentials) CredentialTypes() []string {
+	if c == nil {
+		return nil
+	}
+	return c.VerifiableCredentials
+}
+
+// IsPublic checks whether the credentials identify nothing more than the public would
+func (c *Credentials) IsPublic() bool {
+	return c.WebID() == "" && c.ClientID() == "" && c.Origin() == "" && c.IssuerURL() == "" &&
+		len(c.CredentialTypes()) == 0
+}
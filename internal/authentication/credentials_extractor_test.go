@@ -15,14 +15,10 @@ func TestCredentialsExtractor(t *testing.T) {
 		expectedError error
 	}{
 		{
-			name:      "PublicCredentialsExtractor",
-			extractor: NewPublicCredentialsExtractor(),
-			request:   &http.Request{},
-			expectedCreds: &Credentials{
-				Agent: &Agent{
-					WebID: "https://example.org/public",
-				},
-			},
+			name:          "PublicCredentialsExtractor",
+			extractor:     NewPublicCredentialsExtractor(),
+			request:       &http.Request{},
+			expectedCreds: &Credentials{},
 			expectedError: nil,
 		},
 		{
@@ -44,7 +40,10 @@ func TestCredentialsExtractor(t *testing.T) {
 			if err != tt.expectedError {
 				t.Errorf("Extract() error = %v, want %v", err, tt.expectedError)
 			}
-			if creds.Agent.WebID != tt.expectedCreds.Agent.WebID {
+			if (creds.Agent == nil) != (tt.expectedCreds.Agent == nil) {
+				t.Fatalf("Extract() Agent = %v, want %v", creds.Agent, tt.expectedCreds.Agent)
+			}
+			if creds.Agent != nil && creds.Agent.WebID != tt.expectedCreds.Agent.WebID {
 				t.Errorf("Extract() WebID = %v, want %v", creds.Agent.WebID, tt.expectedCreds.Agent.WebID)
 			}
 		})
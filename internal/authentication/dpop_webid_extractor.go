@@ -2,58 +2,75 @@
 package authentication
 
 import (
-	"fmt"
 	"net/http"
 	"strings"
+
+	"solid-go/internal/authentication/oidc"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
 )
 
-// TargetExtractor extracts the target URL from a request
+// TargetExtractor extracts the original URL of a request
 type TargetExtractor interface {
-	Extract(r *http.Request) (string, error)
+	Handle(request *http.Request) (representation.ResourceIdentifier, error)
+}
+
+// TokenVerifier verifies Solid-OIDC access tokens, together with the DPoP proof of the request if there is one
+type TokenVerifier interface {
+	Verify(token string, dpop *oidc.DPoPRequest) (*oidc.Claims, error)
 }
 
-// DPoPWebIdExtractor extracts WebID from DPoP token
+// DPoPWebIdExtractor extracts the WebID of a DPoP-bound Solid-OIDC access token.
+// The proof has to be bound to the original URL of the request, as the client sent it.
 type DPoPWebIdExtractor struct {
 	originalURLExtractor TargetExtractor
-	// TODO: Add token verifier
+	verifier             TokenVerifier
 }
 
 // NewDPoPWebIdExtractor creates a new DPoPWebIdExtractor
-func NewDPoPWebIdExtractor(originalURLExtractor TargetExtractor) *DPoPWebIdExtractor {
+func NewDPoPWebIdExtractor(originalURLExtractor TargetExtractor, verifier TokenVerifier) *DPoPWebIdExtractor {
 	return &DPoPWebIdExtractor{
 		originalURLExtractor: originalURLExtractor,
+		verifier:             verifier,
 	}
 }
 
 // Extract implements CredentialsExtractor
 func (e *DPoPWebIdExtractor) Extract(r *http.Request) (*Credentials, error) {
 	auth := r.Header.Get("Authorization")
-	if auth == "" {
-		return nil, fmt.Errorf("no DPoP-bound Authorization header specified")
+	scheme, token, found := strings.Cut(auth, " ")
+	if !found || !strings.EqualFold(scheme, "DPoP") {
+		return nil, errors.NewNotImplementedError("No DPoP-bound Authorization header specified.", nil)
 	}
-
-	// Check if it's a DPoP token
-	parts := strings.Split(auth, " ")
-	if len(parts) != 2 || parts[0] != "DPoP" {
-		return nil, fmt.Errorf("invalid DPoP token format")
+	proof := r.Header.Get("DPoP")
+	if proof == "" {
+		return nil, errors.NewValidationError("No DPoP header specified.", nil)
 	}
-
-	dpop := r.Header.Get("DPoP")
-	if dpop == "" {
-		return nil, fmt.Errorf("no DPoP header specified")
+	originalURL, err := e.originalURLExtractor.Handle(r)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get the original URL
-	originalURL, err := e.originalURLExtractor.Extract(r)
+	claims, err := e.verifier.Verify(token, &oidc.DPoPRequest{Proof: proof, Method: r.Method, URL: originalURL.Path})
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract original URL: %w", err)
+		return nil, errors.NewUnauthorizedError("Error verifying WebID via DPoP-bound access token: "+err.Error(), nil)
 	}
+	return claimsCredentials(claims), nil
+}
 
-	// TODO: Implement proper DPoP token verification
-	// For now, just use the token as the WebID
-	return &Credentials{
-		Agent: &Agent{
-			WebID: parts[1],
-		},
-	}, nil
+// claimsCredentials returns the credentials of the verified claims of an access token
+func claimsCredentials(claims *oidc.Claims) *Credentials {
+	credentials := &Credentials{
+		Agent:  &Agent{WebID: claims.WebID},
+		Issuer: &Issuer{URL: claims.Issuer},
+	}
+	// Solid-OIDC, §5: the client_id claim identifies the client, older issuers only set azp
+	clientID := claims.ClientID
+	if clientID == "" {
+		clientID = claims.AuthorizedBy
+	}
+	if clientID != "" {
+		credentials.Client = &Client{ClientID: clientID}
+	}
+	return credentials
 }
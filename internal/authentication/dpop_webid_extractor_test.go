@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+
+	"solid-go/internal/authentication/oidc"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
 )
 
 type mockTargetExtractor struct {
@@ -11,85 +15,48 @@ type mockTargetExtractor struct {
 	err error
 }
 
-func (m *mockTargetExtractor) Extract(r *http.Request) (string, error) {
-	return m.url, m.err
+func (m *mockTargetExtractor) Handle(r *http.Request) (representation.ResourceIdentifier, error) {
+	return representation.ResourceIdentifier{Path: m.url}, m.err
+}
+
+type mockTokenVerifier struct {
+	claims *oidc.Claims
+	err    error
+	token  string
+	dpop   *oidc.DPoPRequest
+}
+
+func (m *mockTokenVerifier) Verify(token string, dpop *oidc.DPoPRequest) (*oidc.Claims, error) {
+	m.token, m.dpop = token, dpop
+	return m.claims, m.err
 }
 
 func TestDPoPWebIdExtractor(t *testing.T) {
+	claims := &oidc.Claims{WebID: "https://example.org/user", Issuer: "https://idp.example/", ClientID: "https://app.example/id"}
 	tests := []struct {
-		name          string
-		authHeader    string
-		dpopHeader    string
-		originalURL   string
-		urlError      error
-		expectedWebID string
-		expectError   bool
-		errorMessage  string
+		name        string
+		authHeader  string
+		dpopHeader  string
+		urlError    error
+		verifyError error
+		check       func(err error) bool
 	}{
-		{
-			name:          "Valid DPoP Token",
-			authHeader:    "DPoP https://example.org/user",
-			dpopHeader:    "valid-dpop-token",
-			originalURL:   "https://example.org/resource",
-			expectedWebID: "https://example.org/user",
-			expectError:   false,
-		},
-		{
-			name:          "Valid DPoP Token Lowercase",
-			authHeader:    "dpop https://example.org/user",
-			dpopHeader:    "valid-dpop-token",
-			originalURL:   "https://example.org/resource",
-			expectedWebID: "https://example.org/user",
-			expectError:   false,
-		},
-		{
-			name:          "Missing Authorization Header",
-			authHeader:    "",
-			dpopHeader:    "valid-dpop-token",
-			originalURL:   "https://example.org/resource",
-			expectedWebID: "",
-			expectError:   true,
-			errorMessage:  "no DPoP-bound Authorization header specified",
-		},
-		{
-			name:          "Missing DPoP Header",
-			authHeader:    "DPoP https://example.org/user",
-			dpopHeader:    "",
-			originalURL:   "https://example.org/resource",
-			expectedWebID: "",
-			expectError:   true,
-			errorMessage:  "no DPoP header specified",
-		},
-		{
-			name:          "Invalid DPoP Format",
-			authHeader:    "Bearer https://example.org/user",
-			dpopHeader:    "valid-dpop-token",
-			originalURL:   "https://example.org/resource",
-			expectedWebID: "",
-			expectError:   true,
-			errorMessage:  "invalid DPoP token format",
-		},
-		{
-			name:          "URL Extraction Error",
-			authHeader:    "DPoP https://example.org/user",
-			dpopHeader:    "valid-dpop-token",
-			originalURL:   "",
-			urlError:      fmt.Errorf("failed to extract URL"),
-			expectedWebID: "",
-			expectError:   true,
-			errorMessage:  "failed to extract original URL: failed to extract URL",
-		},
+		{name: "Valid DPoP Token", authHeader: "DPoP token", dpopHeader: "proof"},
+		{name: "Valid DPoP Token Lowercase", authHeader: "dpop token", dpopHeader: "proof"},
+		{name: "Missing Authorization Header", dpopHeader: "proof", check: errors.IsNotImplementedError},
+		{name: "Bearer Token", authHeader: "Bearer token", dpopHeader: "proof", check: errors.IsNotImplementedError},
+		{name: "Missing DPoP Header", authHeader: "DPoP token", check: errors.IsValidationError},
+		{name: "URL Extraction Error", authHeader: "DPoP token", dpopHeader: "proof",
+			urlError: errors.NewValidationError("Missing Host header", nil), check: errors.IsValidationError},
+		{name: "Invalid Token", authHeader: "DPoP token", dpopHeader: "proof",
+			verifyError: fmt.Errorf("the access token expired"), check: errors.IsUnauthorizedError},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			extractor := NewDPoPWebIdExtractor(&mockTargetExtractor{
-				url: tt.originalURL,
-				err: tt.urlError,
-			})
-			req := &http.Request{
-				Header: make(http.Header),
-			}
+			verifier := &mockTokenVerifier{claims: claims, err: tt.verifyError}
+			extractor := NewDPoPWebIdExtractor(&mockTargetExtractor{url: "https://example.org/resource", err: tt.urlError}, verifier)
+			req := &http.Request{Method: "PUT", Header: make(http.Header)}
 			if tt.authHeader != "" {
 				req.Header.Set("Authorization", tt.authHeader)
 			}
@@ -98,30 +65,20 @@ func TestDPoPWebIdExtractor(t *testing.T) {
 			}
 
 			creds, err := extractor.Extract(req)
-			if tt.expectError {
-				if err == nil {
-					t.Error("Extract() expected error, got nil")
-					return
-				}
-				if err.Error() != tt.errorMessage {
-					t.Errorf("Extract() error = %v, want %v", err, tt.errorMessage)
+			if tt.check != nil {
+				if err == nil || !tt.check(err) {
+					t.Errorf("Extract() error = %v", err)
 				}
 				return
 			}
 			if err != nil {
-				t.Errorf("Extract() error = %v", err)
-				return
+				t.Fatalf("Extract() error = %v", err)
 			}
-			if creds.Agent.WebID != tt.expectedWebID {
-				t.Errorf("Extract() WebID = %v, want %v", creds.Agent.WebID, tt.expectedWebID)
-			}
-
-			// Test that other fields are nil
-			if creds.Client != nil {
-				t.Error("Extract() Client should be nil")
+			if verifier.token != "token" || *verifier.dpop != (oidc.DPoPRequest{Proof: "proof", Method: "PUT", URL: "https://example.org/resource"}) {
+				t.Errorf("Verify() called with %q and %+v", verifier.token, verifier.dpop)
 			}
-			if creds.Issuer != nil {
-				t.Error("Extract() Issuer should be nil")
+			if creds.Agent.WebID != claims.WebID || creds.Client.ClientID != claims.ClientID || creds.Issuer.URL != claims.Issuer {
+				t.Errorf("Extract() = %+v", creds)
 			}
 		})
 	}
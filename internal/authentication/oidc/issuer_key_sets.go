@@ -0,0 +1,154 @@
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxDocumentSize limits the size of the discovery documents and key sets that are fetched
+const maxDocumentSize = 1 << 20
+
+// DefaultKeySetTTL is how long the key set of an issuer is cached
+const DefaultKeySetTTL = time.Hour
+
+// maxCacheEntries limits the size of the caches filled while verifying tokens,
+// since any issuer and WebID can be named in a token when no issuers are trusted explicitly
+const maxCacheEntries = 1000
+
+// keySet is a cached JSON Web Key Set of an issuer
+type keySet struct {
+	keys    []JWK
+	fetched time.Time
+}
+
+// IssuerKeySets fetches the JSON Web Key Sets of issuers through their OpenID Connect discovery documents.
+// The key sets are cached, and fetched again when they expire or a token refers to an unknown key,
+// so issuers can rotate their keys.
+type IssuerKeySets struct {
+	client *http.Client
+	ttl    time.Duration
+	// minRefresh prevents tokens with unknown key IDs from making the server fetch the key sets on every request
+	minRefresh time.Duration
+	now        func() time.Time
+
+	mu   sync.Mutex
+	sets map[string]*keySet
+}
+
+// NewIssuerKeySets creates a new IssuerKeySets that caches the key sets for the given duration
+func NewIssuerKeySets(client *http.Client, ttl time.Duration) *IssuerKeySets {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &IssuerKeySets{
+		client:     client,
+		ttl:        ttl,
+		minRefresh: time.Minute,
+		now:        time.Now,
+		sets:       make(map[string]*keySet),
+	}
+}
+
+// GetKeys returns the keys of the issuer with the key ID, all of its keys if the key ID is empty
+func (s *IssuerKeySets) GetKeys(issuer, kid string) ([]JWK, error) {
+	s.mu.Lock()
+	set := s.sets[issuer]
+	s.mu.Unlock()
+
+	now := s.now()
+	if set == nil || now.Sub(set.fetched) > s.ttl ||
+		(len(filterKeys(set.keys, kid)) == 0 && now.Sub(set.fetched) > s.minRefresh) {
+		keys, err := s.fetch(issuer)
+		if err != nil {
+			return nil, err
+		}
+		set = &keySet{keys: keys, fetched: now}
+		s.store(issuer, set, now)
+	}
+	keys := filterKeys(set.keys, kid)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("the issuer %s has no key %q", issuer, kid)
+	}
+	return keys, nil
+}
+
+// store caches the key set, removing the expired key sets first,
+// and the one that was fetched longest ago if the cache is still full
+func (s *IssuerKeySets) store(issuer string, set *keySet, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	oldest := ""
+	for cached, cachedSet := range s.sets {
+		if now.Sub(cachedSet.fetched) > s.ttl {
+			delete(s.sets, cached)
+		} else if oldest == "" || cachedSet.fetched.Before(s.sets[oldest].fetched) {
+			oldest = cached
+		}
+	}
+	if _, ok := s.sets[issuer]; !ok && len(s.sets) >= maxCacheEntries {
+		delete(s.sets, oldest)
+	}
+	s.sets[issuer] = set
+}
+
+// filterKeys returns the signing keys with the key ID, all of them if the key ID is empty
+func filterKeys(keys []JWK, kid string) []JWK {
+	var result []JWK
+	for _, key := range keys {
+		if (kid == "" || key.Kid == kid) && (key.Use == "" || key.Use == "sig") {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+// fetch reads the key set referenced by the discovery document of the issuer
+func (s *IssuerKeySets) fetch(issuer string) ([]JWK, error) {
+	var configuration struct {
+		Issuer  string `json:"issuer"`
+		JwksURI string `json:"jwks_uri"`
+	}
+	if err := s.fetchJSON(strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", &configuration); err != nil {
+		return nil, err
+	}
+	// OpenID Connect Discovery, §4.3: the issuer of the document has to be the one it was fetched for
+	if !sameIssuer(configuration.Issuer, issuer) {
+		return nil, fmt.Errorf("the discovery document of %s belongs to issuer %s", issuer, configuration.Issuer)
+	}
+	if configuration.JwksURI == "" {
+		return nil, fmt.Errorf("the discovery document of %s has no jwks_uri", issuer)
+	}
+	var set struct {
+		Keys []JWK `json:"keys"`
+	}
+	if err := s.fetchJSON(configuration.JwksURI, &set); err != nil {
+		return nil, err
+	}
+	return set.Keys, nil
+}
+
+// fetchJSON reads the JSON document at the URL
+func (s *IssuerKeySets) fetchJSON(url string, value any) error {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+	response, err := s.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("error fetching %s: %w", url, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("error fetching %s: status %d", url, response.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(response.Body, maxDocumentSize)).Decode(value); err != nil {
+		return fmt.Errorf("invalid JSON at %s: %w", url, err)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Header is the JOSE header of a JWT
+type Header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	// JWK is the public key of a DPoP proof
+	JWK *JWK `json:"jwk,omitempty"`
+}
+
+// Audience is the aud claim, which can be a single string or an array of strings
+type Audience []string
+
+// UnmarshalJSON accepts both the string and the array form
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("the aud claim is neither a string nor an array of strings")
+	}
+	*a = multiple
+	return nil
+}
+
+// Contains checks whether the audience includes the value
+func (a Audience) Contains(value string) bool {
+	for _, audience := range a {
+		if audience == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Confirmation is the cnf claim binding an access token to a key
+type Confirmation struct {
+	JKT string `json:"jkt,omitempty"`
+}
+
+// Claims are the claims of Solid-OIDC access tokens and DPoP proofs
+type Claims struct {
+	Issuer       string        `json:"iss,omitempty"`
+	Subject      string        `json:"sub,omitempty"`
+	Audience     Audience      `json:"aud,omitempty"`
+	Expiry       int64         `json:"exp,omitempty"`
+	IssuedAt     int64         `json:"iat,omitempty"`
+	NotBefore    int64         `json:"nbf,omitempty"`
+	WebID        string        `json:"webid,omitempty"`
+	ClientID     string        `json:"client_id,omitempty"`
+	AuthorizedBy string        `json:"azp,omitempty"`
+	Confirmation *Confirmation `json:"cnf,omitempty"`
+	// The claims of DPoP proofs, RFC 9449, §4.2
+	JTI string `json:"jti,omitempty"`
+	HTM string `json:"htm,omitempty"`
+	HTU string `json:"htu,omitempty"`
+	ATH string `json:"ath,omitempty"`
+}
+
+// JWT is a JSON Web Token in JWS compact serialization, as defined in RFC 7519
+type JWT struct {
+	Header       Header
+	Claims       Claims
+	signingInput string
+	signature    []byte
+}
+
+// signingAlgorithms are the supported asymmetric signature algorithms.
+// Symmetric algorithms and "none" are never accepted, since anyone knowing the key could forge tokens.
+var signingAlgorithms = map[string]crypto.Hash{
+	"RS256": crypto.SHA256, "RS384": crypto.SHA384, "RS512": crypto.SHA512,
+	"PS256": crypto.SHA256, "PS384": crypto.SHA384, "PS512": crypto.SHA512,
+	"ES256": crypto.SHA256, "ES384": crypto.SHA384, "ES512": crypto.SHA512,
+	"EdDSA": 0,
+}
+
+// ecdsaCurves are the curves the ECDSA algorithms require, RFC 7518, §3.4
+var ecdsaCurves = map[string]string{"ES256": "P-256", "ES384": "P-384", "ES512": "P-521"}
+
+// minRSABits is the minimal size of RSA keys, RFC 7518, §3.3
+const minRSABits = 2048
+
+// ParseJWT decodes the token without verifying its signature
+func ParseJWT(token string) (*JWT, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("the token is not a JWS in compact serialization")
+	}
+	jwt := &JWT{signingInput: parts[0] + "." + parts[1]}
+	if err := decodeSegment(parts[0], &jwt.Header); err != nil {
+		return nil, fmt.Errorf("invalid JWT header: %w", err)
+	}
+	if err := decodeSegment(parts[1], &jwt.Claims); err != nil {
+		return nil, fmt.Errorf("invalid JWT claims: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT signature: %w", err)
+	}
+	jwt.signature = signature
+	if _, ok := signingAlgorithms[jwt.Header.Alg]; !ok {
+		return nil, fmt.Errorf("unsupported signature algorithm %q", jwt.Header.Alg)
+	}
+	return jwt, nil
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a JWT
+func decodeSegment(segment string, value any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, value)
+}
+
+// Verify checks the signature of the token with the key
+func (t *JWT) Verify(key *JWK) error {
+	if key.Alg != "" && key.Alg != t.Header.Alg {
+		return fmt.Errorf("the key is meant for %s, not %s", key.Alg, t.Header.Alg)
+	}
+	publicKey, err := key.PublicKey()
+	if err != nil {
+		return err
+	}
+	hash := signingAlgorithms[t.Header.Alg]
+	var digest []byte
+	if hash != 0 {
+		hasher := hash.New()
+		hasher.Write([]byte(t.signingInput))
+		digest = hasher.Sum(nil)
+	}
+
+	valid := false
+	switch t.Header.Alg[:2] {
+	case "RS":
+		if rsaKey, ok := publicKey.(*rsa.PublicKey); ok && rsaKey.N.BitLen() >= minRSABits {
+			valid = rsa.VerifyPKCS1v15(rsaKey, hash, digest, t.signature) == nil
+		}
+	case "PS":
+		if rsaKey, ok := publicKey.(*rsa.PublicKey); ok && rsaKey.N.BitLen() >= minRSABits {
+			valid = rsa.VerifyPSS(rsaKey, hash, digest, t.signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}) == nil
+		}
+	case "ES":
+		// RFC 7518, §3.4: the signature is the concatenation of R and S, each as long as the curve order
+		ecKey, ok := publicKey.(*ecdsa.PublicKey)
+		if ok && ecKey.Curve.Params().Name == ecdsaCurves[t.Header.Alg] {
+			size := (ecKey.Curve.Params().BitSize + 7) / 8
+			if len(t.signature) == 2*size {
+				r := new(big.Int).SetBytes(t.signature[:size])
+				s := new(big.Int).SetBytes(t.signature[size:])
+				valid = ecdsa.Verify(ecKey, digest, r, s)
+			}
+		}
+	case "Ed":
+		if edKey, ok := publicKey.(ed25519.PublicKey); ok {
+			valid = ed25519.Verify(edKey, []byte(t.signingInput), t.signature)
+		}
+	}
+	if !valid {
+		return fmt.Errorf("invalid %s signature", t.Header.Alg)
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SolidAudience is the audience every Solid-OIDC access token has to include
+const SolidAudience = "solid"
+
+// DefaultProofMaxAge is how long a DPoP proof is accepted after it was issued
+const DefaultProofMaxAge = time.Minute
+
+// jtiSweepInterval is how often the jtis of expired proofs are forgotten
+const jtiSweepInterval = 10 * time.Second
+
+// KeySource provides the public keys of issuers
+type KeySource interface {
+	GetKeys(issuer, kid string) ([]JWK, error)
+}
+
+// IssuerVerifier checks whether the issuer may issue tokens for the WebID
+type IssuerVerifier interface {
+	VerifyIssuer(webID, issuer string) error
+}
+
+// DPoPRequest is the request a DPoP proof has to be bound to
+type DPoPRequest struct {
+	// Proof is the value of the DPoP header
+	Proof  string
+	Method string
+	// URL is the original URL of the request, as the client sent it
+	URL string
+}
+
+// TokenVerifier verifies Solid-OIDC access tokens and the DPoP proofs binding them to a request.
+// It remembers the jti of every accepted proof until the proof expires, so proofs cannot be replayed.
+type TokenVerifier struct {
+	keys           KeySource
+	issuerVerifier IssuerVerifier
+	// trustedIssuers are the only issuers whose tokens are accepted, all issuers are if it is empty
+	trustedIssuers map[string]bool
+	proofMaxAge    time.Duration
+	now            func() time.Time
+
+	mu        sync.Mutex
+	jtis      map[string]time.Time
+	nextSweep time.Time
+}
+
+// NewTokenVerifier creates a new TokenVerifier that verifies the signatures with the keys of the source.
+// The issuer verifier, if any, confirms the WebID of every token trusts its issuer,
+// and only tokens of the trusted issuers are accepted if there are any.
+func NewTokenVerifier(keys KeySource, issuerVerifier IssuerVerifier, trustedIssuers []string) *TokenVerifier {
+	trusted := make(map[string]bool, len(trustedIssuers))
+	for _, issuer := range trustedIssuers {
+		trusted[strings.TrimSuffix(issuer, "/")] = true
+	}
+	return &TokenVerifier{
+		keys:           keys,
+		issuerVerifier: issuerVerifier,
+		trustedIssuers: trusted,
+		proofMaxAge:    DefaultProofMaxAge,
+		now:            time.Now,
+		jtis:           make(map[string]time.Time),
+	}
+}
+
+// Verify checks the access token and returns its claims.
+// A DPoP-bound token is only accepted together with a valid proof for the request.
+func (v *TokenVerifier) Verify(token string, dpop *DPoPRequest) (*Claims, error) {
+	jwt, err := ParseJWT(token)
+	if err != nil {
+		return nil, err
+	}
+	claims := &jwt.Claims
+	if claims.Issuer == "" {
+		return nil, fmt.Errorf("the access token has no iss claim")
+	}
+	// Checked before fetching anything, so untrusted tokens cannot make the server send requests
+	if len(v.trustedIssuers) > 0 && !v.trustedIssuers[strings.TrimSuffix(claims.Issuer, "/")] {
+		return nil, fmt.Errorf("the issuer %s is not trusted", claims.Issuer)
+	}
+	if err := v.verifySignature(jwt); err != nil {
+		return nil, err
+	}
+
+	now := v.now().Unix()
+	if claims.Expiry == 0 {
+		return nil, fmt.Errorf("the access token has no exp claim")
+	}
+	if now >= claims.Expiry {
+		return nil, fmt.Errorf("the access token expired")
+	}
+	if claims.NotBefore != 0 && now < claims.NotBefore {
+		return nil, fmt.Errorf("the access token is not valid yet")
+	}
+	if !claims.Audience.Contains(SolidAudience) {
+		return nil, fmt.Errorf("the audience of the access token does not include %q", SolidAudience)
+	}
+	if webID, err := url.Parse(claims.WebID); err != nil || (webID.Scheme != "http" && webID.Scheme != "https") || webID.Host == "" {
+		return nil, fmt.Errorf("the access token has no valid webid claim")
+	}
+
+	bound := claims.Confirmation != nil && claims.Confirmation.JKT != ""
+	switch {
+	case dpop == nil && bound:
+		return nil, fmt.Errorf("the access token is DPoP-bound but no DPoP proof was given")
+	case dpop != nil && !bound:
+		return nil, fmt.Errorf("the access token is not DPoP-bound")
+	case dpop != nil:
+		if _, err := v.verifyProof(dpop, token, claims.Confirmation.JKT); err != nil {
+			return nil, fmt.Errorf("invalid DPoP proof: %w", err)
+		}
+	}
+	// Only checked for otherwise valid tokens, since it fetches the profile of the WebID
+	if v.issuerVerifier != nil {
+		if err := v.issuerVerifier.VerifyIssuer(claims.WebID, claims.Issuer); err != nil {
+			return nil, err
+		}
+	}
+	return claims, nil
+}
+
+// verifySignature checks the signature of the access token with the keys of its issuer
+func (v *TokenVerifier) verifySignature(jwt *JWT) error {
+	keys, err := v.keys.GetKeys(jwt.Claims.Issuer, jwt.Header.Kid)
+	if err != nil {
+		return err
+	}
+	for i := range keys {
+		if jwt.Verify(&keys[i]) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("the signature of the access token does not match the keys of %s", jwt.Claims.Issuer)
+}
+
+// VerifyProof checks a DPoP proof that is sent without access token, such as to the token endpoint
+// of an identity provider, RFC 9449, §5, and returns the JWK Thumbprint of its key
+func (v *TokenVerifier) VerifyProof(dpop *DPoPRequest) (string, error) {
+	return v.verifyProof(dpop, "", "")
+}
+
+// verifyProof checks the DPoP proof against the request, RFC 9449, §4.3, and returns the thumbprint of its key.
+// With an access token, the proof also has to be bound to it and to the key with the jkt the token is bound to.
+func (v *TokenVerifier) verifyProof(dpop *DPoPRequest, token, jkt string) (string, error) {
+	proof, err := ParseJWT(dpop.Proof)
+	if err != nil {
+		return "", err
+	}
+	if proof.Header.Typ != "dpop+jwt" {
+		return "", fmt.Errorf("the typ is not dpop+jwt")
+	}
+	if proof.Header.JWK == nil {
+		return "", fmt.Errorf("the header has no jwk")
+	}
+	if err := proof.Verify(proof.Header.JWK); err != nil {
+		return "", err
+	}
+	thumbprint, err := proof.Header.JWK.Thumbprint()
+	if err != nil {
+		return "", err
+	}
+	if token != "" && thumbprint != jkt {
+		return "", fmt.Errorf("the key does not match the jkt of the access token")
+	}
+
+	claims := proof.Claims
+	if claims.HTM != dpop.Method {
+		return "", fmt.Errorf("the htm %q does not match the method %s", claims.HTM, dpop.Method)
+	}
+	htu, err := normalizeHTU(claims.HTU)
+	if err != nil {
+		return "", fmt.Errorf("invalid htu: %w", err)
+	}
+	target, err := normalizeHTU(dpop.URL)
+	if err != nil {
+		return "", err
+	}
+	if htu != target {
+		return "", fmt.Errorf("the htu %s does not match the request URL %s", claims.HTU, dpop.URL)
+	}
+	if token != "" {
+		hash := sha256.Sum256([]byte(token))
+		if claims.ATH != base64.RawURLEncoding.EncodeToString(hash[:]) {
+			return "", fmt.Errorf("the ath does not match the access token")
+		}
+	}
+
+	now := v.now()
+	issued := time.Unix(claims.IssuedAt, 0)
+	if claims.IssuedAt == 0 || now.Sub(issued) > v.proofMaxAge || issued.Sub(now) > v.proofMaxAge {
+		return "", fmt.Errorf("the iat is not within %v of the current time", v.proofMaxAge)
+	}
+	if claims.JTI == "" {
+		return "", fmt.Errorf("the proof has no jti")
+	}
+	if err := v.rememberJTI(claims.JTI, issued.Add(v.proofMaxAge), now); err != nil {
+		return "", err
+	}
+	return thumbprint, nil
+}
+
+// rememberJTI fails if the jti was already used, and otherwise remembers it until the proof expires.
+// Expired jtis are only swept once per interval, and proofs are rejected while too many jtis are remembered,
+// since anyone can sign proofs with their own key.
+func (v *TokenVerifier) rememberJTI(jti string, expires, now time.Time) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !now.Before(v.nextSweep) {
+		for seen, until := range v.jtis {
+			if now.After(until) {
+				delete(v.jtis, seen)
+			}
+		}
+		v.nextSweep = now.Add(jtiSweepInterval)
+	}
+	if _, ok := v.jtis[jti]; ok {
+		return fmt.Errorf("the jti %q was already used", jti)
+	}
+	if len(v.jtis) >= maxCacheEntries {
+		return fmt.Errorf("too many DPoP proofs were received recently")
+	}
+	v.jtis[jti] = expires
+	return nil
+}
+
+// normalizeHTU removes the query and fragment of the URL, RFC 9449, §4.3,
+// and lowercases its scheme and host, since they are case-insensitive
+func normalizeHTU(value string) (string, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("%q is not an absolute URL", value)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.RawQuery, u.Fragment, u.RawFragment = "", "", ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,318 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testWebID = "https://alice.example/profile#me"
+
+// testKey is an ES256 key pair with its public JWK
+type testKey struct {
+	private *ecdsa.PrivateKey
+	jwk     JWK
+}
+
+func newTestKey(t *testing.T, kid string) *testKey {
+	t.Helper()
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testKey{private: private, jwk: JWK{
+		Kty: "EC", Kid: kid, Crv: "P-256",
+		X: base64.RawURLEncoding.EncodeToString(private.X.FillBytes(make([]byte, 32))),
+		Y: base64.RawURLEncoding.EncodeToString(private.Y.FillBytes(make([]byte, 32))),
+	}}
+}
+
+// sign creates an ES256 JWS of the header and claims
+func (k *testKey) sign(t *testing.T, header Header, claims any) string {
+	t.Helper()
+	header.Alg = "ES256"
+	encode := func(value any) string {
+		data, err := json.Marshal(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	input := encode(header) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(input))
+	r, s, err := ecdsa.Sign(rand.Reader, k.private, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// newTestIssuer serves the discovery document and key set of an issuer, counting the key set requests
+func newTestIssuer(t *testing.T, keys ...JWK) (*httptest.Server, *int32) {
+	t.Helper()
+	var fetches int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]string{"issuer": server.URL + "/", "jwks_uri": server.URL + "/jwks"})
+		case "/jwks":
+			atomic.AddInt32(&fetches, 1)
+			json.NewEncoder(w).Encode(map[string][]JWK{"keys": keys})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &fetches
+}
+
+func TestTokenVerifier(t *testing.T) {
+	issuerKey, clientKey, otherKey := newTestKey(t, "issuer"), newTestKey(t, "client"), newTestKey(t, "other")
+	server, _ := newTestIssuer(t, issuerKey.jwk)
+	issuer := server.URL + "/"
+	now := time.Now()
+	jkt, err := clientKey.jwk.Thumbprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := func(change func(claims *Claims)) string {
+		claims := Claims{Issuer: issuer, Audience: Audience{SolidAudience, "https://app.example/id"},
+			Expiry: now.Add(time.Hour).Unix(), IssuedAt: now.Unix(), WebID: testWebID,
+			ClientID: "https://app.example/id", Confirmation: &Confirmation{JKT: jkt}}
+		if change != nil {
+			change(&claims)
+		}
+		return issuerKey.sign(t, Header{Typ: "at+jwt", Kid: "issuer"}, claims)
+	}
+	jti := 0
+	proof := func(key *testKey, accessToken string, change func(claims *Claims)) string {
+		jti++
+		hash := sha256.Sum256([]byte(accessToken))
+		claims := Claims{HTM: "GET", HTU: "https://pod.example/resource", IssuedAt: now.Unix(),
+			JTI: "proof-" + strconv.Itoa(jti), ATH: base64.RawURLEncoding.EncodeToString(hash[:])}
+		if change != nil {
+			change(&claims)
+		}
+		return key.sign(t, Header{Typ: "dpop+jwt", JWK: &key.jwk}, claims)
+	}
+	request := func(proof string) *DPoPRequest {
+		return &DPoPRequest{Proof: proof, Method: "GET", URL: "https://pod.example/resource?query"}
+	}
+
+	valid := token(nil)
+	replayed := proof(clientKey, valid, nil)
+	tests := []struct {
+		name, token string
+		dpop        *DPoPRequest
+		wantErr     string
+	}{
+		{"valid DPoP-bound token", valid, request(replayed), ""},
+		{"replayed proof", valid, request(replayed), "already used"},
+		{"unbound token without proof", token(func(c *Claims) { c.Confirmation = nil }), nil, ""},
+		{"bound token without proof", valid, nil, "no DPoP proof"},
+		{"unbound token with proof", token(func(c *Claims) { c.Confirmation = nil }), request(proof(clientKey, valid, nil)), "not DPoP-bound"},
+		{"expired token", token(func(c *Claims) { c.Expiry = now.Add(-time.Minute).Unix() }), nil, "expired"},
+		{"missing exp", token(func(c *Claims) { c.Expiry = 0 }), nil, "no exp"},
+		{"wrong audience", token(func(c *Claims) { c.Audience = Audience{"other"} }), nil, "audience"},
+		{"missing webid", token(func(c *Claims) { c.WebID = "" }), nil, "webid"},
+		{"unknown issuer key", otherKey.sign(t, Header{Kid: "issuer"}, Claims{Issuer: issuer}), nil, "signature"},
+		{"unknown issuer", token(func(c *Claims) { c.Issuer = "http://127.0.0.1:1/" }), nil, "error fetching"},
+		{"unsigned token", "eyJhbGciOiJub25lIn0." + strings.Split(valid, ".")[1] + ".", nil, "unsupported signature algorithm"},
+		{"proof of another key", valid, request(proof(otherKey, valid, nil)), "jkt"},
+		{"wrong htm", valid, request(proof(clientKey, valid, func(c *Claims) { c.HTM = "POST" })), "htm"},
+		{"wrong htu", valid, request(proof(clientKey, valid, func(c *Claims) { c.HTU = "https://pod.example/other" })), "htu"},
+		{"htu with query and fragment", valid, request(proof(clientKey, valid, func(c *Claims) {
+			c.HTU = "HTTPS://Pod.Example/resource?other#fragment"
+		})), ""},
+		{"wrong ath", valid, request(proof(clientKey, valid, func(c *Claims) { c.ATH = "other" })), "ath"},
+		{"old proof", valid, request(proof(clientKey, valid, func(c *Claims) { c.IssuedAt = now.Add(-2 * time.Minute).Unix() })), "iat"},
+		{"missing jti", valid, request(proof(clientKey, valid, func(c *Claims) { c.JTI = "" })), "jti"},
+		{"wrong proof type", valid, request(clientKey.sign(t, Header{Typ: "jwt", JWK: &clientKey.jwk}, Claims{})), "typ"},
+	}
+	verifier := NewTokenVerifier(NewIssuerKeySets(server.Client(), time.Hour), nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(tt.token, tt.dpop)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Verify() error = %v", err)
+				}
+				if claims.WebID != testWebID || claims.ClientID != "https://app.example/id" {
+					t.Errorf("Verify() = %+v", claims)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Verify() error = %v, want an error about %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// mockIssuerVerifier trusts a single issuer
+type mockIssuerVerifier struct {
+	issuer string
+}
+
+func (m *mockIssuerVerifier) VerifyIssuer(webID, issuer string) error {
+	if issuer != m.issuer {
+		return fmt.Errorf("the WebID profile of %s does not trust the issuer %s", webID, issuer)
+	}
+	return nil
+}
+
+func TestTokenVerifier_Issuers(t *testing.T) {
+	issuerKey := newTestKey(t, "issuer")
+	server, fetches := newTestIssuer(t, issuerKey.jwk)
+	token := issuerKey.sign(t, Header{Kid: "issuer"}, Claims{Issuer: server.URL + "/", Audience: Audience{SolidAudience},
+		Expiry: time.Now().Add(time.Hour).Unix(), WebID: testWebID})
+
+	verifier := NewTokenVerifier(NewIssuerKeySets(server.Client(), time.Hour), nil, []string{"https://idp.example/"})
+	if _, err := verifier.Verify(token, nil); err == nil || !strings.Contains(err.Error(), "not trusted") || atomic.LoadInt32(fetches) != 0 {
+		t.Errorf("Verify() of an untrusted issuer = %v after %d fetches, want an error without fetching", err, atomic.LoadInt32(fetches))
+	}
+	verifier = NewTokenVerifier(NewIssuerKeySets(server.Client(), time.Hour), &mockIssuerVerifier{server.URL + "/"},
+		[]string{"https://idp.example/", server.URL})
+	if _, err := verifier.Verify(token, nil); err != nil {
+		t.Errorf("Verify() of a trusted issuer = %v", err)
+	}
+	verifier = NewTokenVerifier(NewIssuerKeySets(server.Client(), time.Hour), &mockIssuerVerifier{"https://idp.example/"}, nil)
+	if _, err := verifier.Verify(token, nil); err == nil || !strings.Contains(err.Error(), "does not trust") {
+		t.Errorf("Verify() of an issuer the WebID does not trust = %v", err)
+	}
+}
+
+func TestWebIDIssuerVerifier(t *testing.T) {
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		switch r.URL.Path {
+		case "/profile":
+			w.Header().Set("Content-Type", "text/turtle; charset=utf-8")
+			w.Write([]byte(`@prefix solid: <http://www.w3.org/ns/solid/terms#>.
+<#me> solid:oidcIssuer <https://idp.example>, <https://other.example/>.
+<#other> solid:oidcIssuer <https://attacker.example/>.`))
+		case "/html":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	verifier := NewWebIDIssuerVerifier(server.Client(), time.Hour)
+	webID := server.URL + "/profile#me"
+
+	if err := verifier.VerifyIssuer(webID, "https://idp.example/"); err != nil {
+		t.Errorf("VerifyIssuer() of a trusted issuer = %v", err)
+	}
+	if err := verifier.VerifyIssuer(webID, "https://idp.example/"); err != nil || atomic.LoadInt32(&fetches) != 1 {
+		t.Errorf("VerifyIssuer() of a confirmed issuer = %v after %d fetches, want no new fetch", err, atomic.LoadInt32(&fetches))
+	}
+	if err := verifier.VerifyIssuer(webID, "https://attacker.example/"); err == nil {
+		t.Errorf("VerifyIssuer() of an issuer trusted by another subject succeeded")
+	}
+	for _, webID := range []string{server.URL + "/html#me", server.URL + "/missing#me"} {
+		if err := verifier.VerifyIssuer(webID, "https://idp.example/"); err == nil {
+			t.Errorf("VerifyIssuer(%v) succeeded", webID)
+		}
+	}
+}
+
+func TestIssuerKeySets(t *testing.T) {
+	first, second := newTestKey(t, "first"), newTestKey(t, "second")
+	server, fetches := newTestIssuer(t, first.jwk, second.jwk)
+	keySets := NewIssuerKeySets(server.Client(), time.Hour)
+	now := time.Now()
+	keySets.now = func() time.Time { return now }
+
+	if keys, err := keySets.GetKeys(server.URL, "second"); err != nil || len(keys) != 1 || keys[0].Kid != "second" {
+		t.Fatalf("GetKeys() = %v, %v", keys, err)
+	}
+	if keys, err := keySets.GetKeys(server.URL, ""); err != nil || len(keys) != 2 || atomic.LoadInt32(fetches) != 1 {
+		t.Errorf("GetKeys() of a cached issuer = %v, %v after %d fetches", keys, err, atomic.LoadInt32(fetches))
+	}
+	if _, err := keySets.GetKeys(server.URL, "unknown"); err == nil || atomic.LoadInt32(fetches) != 1 {
+		t.Errorf("GetKeys() of an unknown key = %v after %d fetches, want no refresh right after fetching", err, atomic.LoadInt32(fetches))
+	}
+	now = now.Add(2 * time.Minute)
+	if _, err := keySets.GetKeys(server.URL, "unknown"); err == nil || atomic.LoadInt32(fetches) != 2 {
+		t.Errorf("GetKeys() of an unknown key = %v after %d fetches, want a refresh", err, atomic.LoadInt32(fetches))
+	}
+	now = now.Add(2 * time.Hour)
+	if _, err := keySets.GetKeys(server.URL, "first"); err != nil || atomic.LoadInt32(fetches) != 3 {
+		t.Errorf("GetKeys() of an expired key set = %v after %d fetches, want a refresh", err, atomic.LoadInt32(fetches))
+	}
+}
+
+func TestCacheLimits(t *testing.T) {
+	now := time.Now()
+	keySets := NewIssuerKeySets(nil, time.Hour)
+	keySets.store("https://expired.example/", &keySet{fetched: now.Add(-2 * time.Hour)}, now)
+	for i := 0; i < maxCacheEntries+10; i++ {
+		keySets.store("https://issuer"+strconv.Itoa(i)+".example/", &keySet{fetched: now.Add(time.Duration(i))}, now)
+	}
+	if _, ok := keySets.sets["https://expired.example/"]; ok || len(keySets.sets) != maxCacheEntries {
+		t.Errorf("key set cache has %d entries, want %d without the expired one", len(keySets.sets), maxCacheEntries)
+	}
+	if _, ok := keySets.sets["https://issuer0.example/"]; ok {
+		t.Errorf("key set cache kept the oldest key set")
+	}
+
+	verifier := NewWebIDIssuerVerifier(nil, time.Minute)
+	verifier.now = func() time.Time { return now }
+	verifier.confirm("expired")
+	now = now.Add(2 * time.Minute)
+	for i := 0; i < maxCacheEntries+10; i++ {
+		verifier.confirm(strconv.Itoa(i))
+	}
+	if _, ok := verifier.confirmed["expired"]; ok || len(verifier.confirmed) != maxCacheEntries {
+		t.Errorf("issuer cache has %d entries, want %d without the expired one", len(verifier.confirmed), maxCacheEntries)
+	}
+
+	tokenVerifier := NewTokenVerifier(nil, nil, nil)
+	if err := tokenVerifier.rememberJTI("expired", now.Add(time.Second), now); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < maxCacheEntries; i++ {
+		if err := tokenVerifier.rememberJTI(strconv.Itoa(i), now.Add(time.Minute), now); err != nil {
+			t.Fatalf("rememberJTI() error = %v", err)
+		}
+	}
+	if err := tokenVerifier.rememberJTI("full", now.Add(time.Minute), now.Add(2*time.Second)); err == nil {
+		t.Errorf("rememberJTI() accepted a proof while the jti cache was full")
+	}
+	if err := tokenVerifier.rememberJTI("swept", now.Add(time.Minute), now.Add(jtiSweepInterval)); err != nil {
+		t.Errorf("rememberJTI() error = %v after the expired jti was swept", err)
+	}
+	if _, ok := tokenVerifier.jtis["expired"]; ok {
+		t.Errorf("jti cache kept the expired jti")
+	}
+}
+
+func TestJWK_Thumbprint(t *testing.T) {
+	// RFC 7638, §3.1
+	key := JWK{Kty: "RSA", Kid: "2011-04-29", Alg: "RS256", E: "AQAB",
+		N: "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"}
+	if thumbprint, err := key.Thumbprint(); err != nil || thumbprint != "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs" {
+		t.Errorf("Thumbprint() = %v, %v", thumbprint, err)
+	}
+	if _, err := (&JWK{Kty: "oct"}).Thumbprint(); err == nil {
+		t.Errorf("Thumbprint() of a symmetric key succeeded")
+	}
+}
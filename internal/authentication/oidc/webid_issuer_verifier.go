@@ -0,0 +1,118 @@
+package oidc
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
+)
+
+// DefaultProfileTTL is how long a confirmed issuer of a WebID is remembered
+const DefaultProfileTTL = 10 * time.Minute
+
+// WebIDIssuerVerifier checks that the WebID profile document names the issuer of a token with solid:oidcIssuer,
+// so an issuer can only issue tokens for the agents that trust it, Solid-OIDC, §5.1.
+// Confirmed issuers are remembered for a while, so the profile is not fetched on every request.
+type WebIDIssuerVerifier struct {
+	client *http.Client
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	confirmed map[string]time.Time
+}
+
+// NewWebIDIssuerVerifier creates a new WebIDIssuerVerifier that remembers confirmed issuers for the given duration
+func NewWebIDIssuerVerifier(client *http.Client, ttl time.Duration) *WebIDIssuerVerifier {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &WebIDIssuerVerifier{
+		client:    client,
+		ttl:       ttl,
+		now:       time.Now,
+		confirmed: make(map[string]time.Time),
+	}
+}
+
+// VerifyIssuer fails if the profile of the WebID does not name the issuer
+func (v *WebIDIssuerVerifier) VerifyIssuer(webID, issuer string) error {
+	key := webID + " " + issuer
+	v.mu.Lock()
+	until, ok := v.confirmed[key]
+	v.mu.Unlock()
+	if ok && v.now().Before(until) {
+		return nil
+	}
+
+	issuers, err := v.readIssuers(webID)
+	if err != nil {
+		return err
+	}
+	for _, trusted := range issuers {
+		if sameIssuer(trusted.Value(), issuer) {
+			v.confirm(key)
+			return nil
+		}
+	}
+	return fmt.Errorf("the WebID profile of %s does not trust the issuer %s", webID, issuer)
+}
+
+// confirm remembers the confirmed issuer, removing the expired confirmations first,
+// and the one that expires soonest if the cache is still full
+func (v *WebIDIssuerVerifier) confirm(key string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	now := v.now()
+	soonest := ""
+	for confirmed, until := range v.confirmed {
+		if !now.Before(until) {
+			delete(v.confirmed, confirmed)
+		} else if soonest == "" || until.Before(v.confirmed[soonest]) {
+			soonest = confirmed
+		}
+	}
+	if _, ok := v.confirmed[key]; !ok && len(v.confirmed) >= maxCacheEntries {
+		delete(v.confirmed, soonest)
+	}
+	v.confirmed[key] = now.Add(v.ttl)
+}
+
+// readIssuers returns the solid:oidcIssuer objects of the WebID in its profile document
+func (v *WebIDIssuerVerifier) readIssuers(webID string) ([]n3.Term, error) {
+	document, _, _ := strings.Cut(webID, "#")
+	request, err := http.NewRequest(http.MethodGet, document, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "text/turtle")
+	response, err := v.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching the WebID profile %s: %w", document, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching the WebID profile %s: status %d", document, response.StatusCode)
+	}
+	format, ok := n3.FormatFromContentType(response.Header.Get("Content-Type"))
+	if !ok {
+		return nil, fmt.Errorf("the WebID profile %s is not in a supported RDF format", document)
+	}
+	// Relative IRIs are resolved against the URL the profile was found at after redirects
+	store, err := n3.NewParser(n3.ParserOptions{Format: format, BaseIRI: response.Request.URL.String()}).
+		ParseToStore(io.LimitReader(response.Body, maxDocumentSize))
+	if err != nil {
+		return nil, fmt.Errorf("the WebID profile %s is not valid RDF: %w", document, err)
+	}
+	return store.GetObjects(n3.NewNamedNode(webID), vocabularies.SOLID.OidcIssuer, nil), nil
+}
+
+// sameIssuer compares issuer URLs, which are often written both with and without trailing slash
+func sameIssuer(a, b string) bool {
+	return strings.TrimSuffix(a, "/") == strings.TrimSuffix(b, "/")
+}
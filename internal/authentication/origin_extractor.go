@@ -0,0 +1,37 @@
+// Package authentication provides implementations for authentication and credential management.
+package authentication
+
+import (
+	"net/http"
+)
+
+// OriginExtractor adds the origin of the web application that made a request, as given by its Origin header,
+// to the credentials determined by the source extractor.
+// The origin alone does not authenticate anything, so the errors of the source are returned as they are.
+type OriginExtractor struct {
+	source CredentialsExtractor
+}
+
+// NewOriginExtractor creates a new OriginExtractor
+func NewOriginExtractor(source CredentialsExtractor) *OriginExtractor {
+	return &OriginExtractor{source: source}
+}
+
+// Extract implements CredentialsExtractor
+func (e *OriginExtractor) Extract(r *http.Request) (*Credentials, error) {
+	credentials, err := e.source.Extract(r)
+	if err != nil {
+		return nil, err
+	}
+	origin := r.Header.Get("Origin")
+	// Browsers send "null" for opaque origins, which cannot be matched against anything
+	if origin == "" || origin == "null" {
+		return credentials, nil
+	}
+	if credentials == nil {
+		credentials = &Credentials{}
+	}
+	merged := *credentials
+	merged.Client = mergeClients(credentials.Client, &Client{Origin: origin})
+	return &merged, nil
+}
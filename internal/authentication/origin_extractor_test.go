@@ -0,0 +1,82 @@
+package authentication
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestOriginExtractor(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   CredentialsExtractor
+		origin   string
+		expected *Credentials
+	}{
+		{
+			name:     "Add Origin",
+			source:   &mockExtractor{creds: &Credentials{Agent: &Agent{WebID: "http://user.example.com/#me"}}},
+			origin:   "https://app.example",
+			expected: &Credentials{Agent: &Agent{WebID: "http://user.example.com/#me"}, Client: &Client{Origin: "https://app.example"}},
+		},
+		{
+			name:     "Keep Client ID",
+			source:   &mockExtractor{creds: &Credentials{Client: &Client{ClientID: "https://app.example/id"}}},
+			origin:   "https://app.example",
+			expected: &Credentials{Client: &Client{ClientID: "https://app.example/id", Origin: "https://app.example"}},
+		},
+		{
+			name:     "Without Origin",
+			source:   &mockExtractor{creds: &Credentials{}},
+			expected: &Credentials{},
+		},
+		{
+			name:     "Opaque Origin",
+			source:   &mockExtractor{creds: &Credentials{}},
+			origin:   "null",
+			expected: &Credentials{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &http.Request{Header: http.Header{}}
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			creds, err := NewOriginExtractor(tt.source).Extract(req)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if creds.WebID() != tt.expected.WebID() || creds.ClientID() != tt.expected.ClientID() ||
+				creds.Origin() != tt.expected.Origin() {
+				t.Errorf("Extract() = %+v, want %+v", creds, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOriginExtractor_Error(t *testing.T) {
+	req := &http.Request{Header: http.Header{"Origin": []string{"https://app.example"}}}
+	if _, err := NewOriginExtractor(&mockExtractor{err: fmt.Errorf("invalid token")}).Extract(req); err == nil {
+		t.Error("Extract() expected the error of the source")
+	}
+}
+
+func TestOriginExtractor_VerifiableCredentials(t *testing.T) {
+	source := NewUnionCredentialsExtractor(
+		&mockExtractor{creds: &Credentials{Agent: &Agent{WebID: "http://user.example.com/#me"}}},
+		&mockExtractor{creds: &Credentials{VerifiableCredentials: []string{"https://example.org/vc#Member"}}},
+	)
+	req := &http.Request{Header: http.Header{"Origin": []string{"https://app.example"}}}
+	creds, err := NewOriginExtractor(source).Extract(req)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if types := creds.CredentialTypes(); len(types) != 1 || types[0] != "https://example.org/vc#Member" {
+		t.Errorf("CredentialTypes() = %v, want [https://example.org/vc#Member]", types)
+	}
+	if creds.WebID() != "http://user.example.com/#me" || creds.Origin() != "https://app.example" {
+		t.Errorf("Extract() = %+v", creds)
+	}
+}
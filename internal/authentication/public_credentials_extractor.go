@@ -5,7 +5,8 @@ import (
 	"net/http"
 )
 
-// PublicCredentialsExtractor always returns public credentials
+// PublicCredentialsExtractor always returns empty credentials,
+// which identify the request as made by the public, unauthenticated agent
 type PublicCredentialsExtractor struct{}
 
 // NewPublicCredentialsExtractor creates a new PublicCredentialsExtractor
@@ -15,9 +16,5 @@ func NewPublicCredentialsExtractor() *PublicCredentialsExtractor {
 
 // Extract implements CredentialsExtractor
 func (e *PublicCredentialsExtractor) Extract(r *http.Request) (*Credentials, error) {
-	return &Credentials{
-		Agent: &Agent{
-			WebID: "https://example.org/public",
-		},
-	}, nil
+	return &Credentials{}, nil
 }
@@ -13,8 +13,8 @@ func TestPublicCredentialsExtractor(t *testing.T) {
 	if err != nil {
 		t.Errorf("Extract() error = %v", err)
 	}
-	if creds.Agent.WebID != "https://example.org/public" {
-		t.Errorf("Extract() WebID = %v, want %v", creds.Agent.WebID, "https://example.org/public")
+	if creds == nil {
+		t.Fatal("Extract() returned no credentials")
 	}
 
 	// Test with empty credentials
@@ -22,8 +22,8 @@ func TestPublicCredentialsExtractor(t *testing.T) {
 	if err != nil {
 		t.Errorf("Extract() error = %v", err)
 	}
-	if creds.Agent == nil {
-		t.Error("Extract() Agent is nil")
+	if creds.Agent != nil {
+		t.Error("Extract() Agent should be nil")
 	}
 	if creds.Client != nil {
 		t.Error("Extract() Client should be nil")
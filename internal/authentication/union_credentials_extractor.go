@@ -3,6 +3,8 @@ package authentication
 
 import (
 	"net/http"
+
+	"solid-go/internal/util/errors"
 )
 
 // UnionCredentialsExtractor combines multiple CredentialsExtractors.
@@ -18,13 +20,22 @@ func NewUnionCredentialsExtractor(extractors ...CredentialsExtractor) *UnionCred
 }
 
 // Extract implements CredentialsExtractor. Combines results from all extractors.
+// Extractors that do not support the request, which fail with a NotImplementedError, are skipped,
+// unless none of them supports it. Any other error, such as an invalid token, fails the extraction.
 func (u *UnionCredentialsExtractor) Extract(r *http.Request) (*Credentials, error) {
 	combined := &Credentials{}
+	var lastErr error
+	succeeded := false
 	for _, extractor := range u.extractors {
 		creds, err := extractor.Extract(r)
+		if errors.IsNotImplementedError(err) {
+			lastErr = err
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
+		succeeded = true
 		if creds == nil {
 			continue
 		}
@@ -32,11 +43,31 @@ func (u *UnionCredentialsExtractor) Extract(r *http.Request) (*Credentials, erro
 			combined.Agent = creds.Agent
 		}
 		if creds.Client != nil {
-			combined.Client = creds.Client
+			combined.Client = mergeClients(combined.Client, creds.Client)
 		}
 		if creds.Issuer != nil {
 			combined.Issuer = creds.Issuer
 		}
+		combined.VerifiableCredentials = append(combined.VerifiableCredentials, creds.VerifiableCredentials...)
+	}
+	if !succeeded && lastErr != nil {
+		return nil, lastErr
 	}
 	return combined, nil
 }
+
+// mergeClients combines what different extractors know about the client,
+// such as the client ID from the access token and the origin from the request headers
+func mergeClients(current, next *Client) *Client {
+	if current == nil {
+		return next
+	}
+	merged := *current
+	if next.ClientID != "" {
+		merged.ClientID = next.ClientID
+	}
+	if next.Origin != "" {
+		merged.Origin = next.Origin
+	}
+	return &merged
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+
+	"solid-go/internal/util/errors"
 )
 
 type mockExtractor struct {
@@ -68,11 +70,30 @@ func TestUnionCredentialsExtractor(t *testing.T) {
 			expectError: false,
 		},
 		{
-			name: "Skip Erroring Handlers",
+			name: "Merge Clients",
+			extractors: []CredentialsExtractor{
+				&mockExtractor{
+					creds: &Credentials{
+						Client: &Client{ClientID: "http://client.example.com/id"},
+					},
+				},
+				&mockExtractor{
+					creds: &Credentials{
+						Client: &Client{Origin: "http://client.example.com"},
+					},
+				},
+			},
+			expectedCreds: &Credentials{
+				Client: &Client{ClientID: "http://client.example.com/id", Origin: "http://client.example.com"},
+			},
+			expectError: false,
+		},
+		{
+			name: "Skip Unsupported Handlers",
 			extractors: []CredentialsExtractor{
 				&mockExtractor{
 					creds: nil,
-					err:   fmt.Errorf("error"),
+					err:   errors.NewNotImplementedError("unsupported", nil),
 				},
 				&mockExtractor{
 					creds: &Credentials{
@@ -87,15 +108,30 @@ func TestUnionCredentialsExtractor(t *testing.T) {
 			expectError: false,
 		},
 		{
-			name: "All Handlers Error",
+			name: "Propagate Failing Handlers",
+			extractors: []CredentialsExtractor{
+				&mockExtractor{
+					creds: nil,
+					err:   fmt.Errorf("invalid token"),
+				},
+				&mockExtractor{
+					creds: &Credentials{},
+					err:   nil,
+				},
+			},
+			expectedCreds: nil,
+			expectError:   true,
+		},
+		{
+			name: "All Handlers Unsupported",
 			extractors: []CredentialsExtractor{
 				&mockExtractor{
 					creds: nil,
-					err:   fmt.Errorf("error 1"),
+					err:   errors.NewNotImplementedError("unsupported 1", nil),
 				},
 				&mockExtractor{
 					creds: nil,
-					err:   fmt.Errorf("error 2"),
+					err:   errors.NewNotImplementedError("unsupported 2", nil),
 				},
 			},
 			expectedCreds: nil,
@@ -135,8 +171,8 @@ func TestUnionCredentialsExtractor(t *testing.T) {
 			if tt.expectedCreds.Client != nil {
 				if creds.Client == nil {
 					t.Error("Extract() Client is nil")
-				} else if creds.Client.ClientID != tt.expectedCreds.Client.ClientID {
-					t.Errorf("Extract() Client.ClientID = %v, want %v", creds.Client.ClientID, tt.expectedCreds.Client.ClientID)
+				} else if *creds.Client != *tt.expectedCreds.Client {
+					t.Errorf("Extract() Client = %+v, want %+v", *creds.Client, *tt.expectedCreds.Client)
 				}
 			} else if creds.Client != nil {
 				t.Error("Extract() Client should be nil")
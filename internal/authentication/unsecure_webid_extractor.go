@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+
+	"solid-go/internal/util/errors"
 )
 
 // UnsecureWebIdExtractor extracts WebID from Authorization header
@@ -19,14 +21,14 @@ func NewUnsecureWebIdExtractor() *UnsecureWebIdExtractor {
 func (e *UnsecureWebIdExtractor) Extract(r *http.Request) (*Credentials, error) {
 	auth := r.Header.Get("Authorization")
 	if !strings.HasPrefix(auth, "WebID ") {
-		return nil, ErrNotImplemented
+		return nil, errors.NewNotImplementedError("No WebID Authorization header specified.", nil)
 	}
 
 	// Extract WebID from Authorization header
 	re := regexp.MustCompile(`^WebID\s+(.*)$`)
 	matches := re.FindStringSubmatch(auth)
 	if len(matches) < 2 {
-		return nil, ErrNotImplemented
+		return nil, errors.NewNotImplementedError("No WebID Authorization header specified.", nil)
 	}
 
 	webID := matches[1]
@@ -15,8 +15,8 @@ func NewAgentAccessChecker() *AgentAccessChecker {
 
 // Handle implements the AccessChecker interface
 func (c *AgentAccessChecker) Handle(args AccessCheckerArgs) (bool, error) {
-	if args.Credentials.Agent != nil && args.Credentials.Agent.WebID != "" {
-		return args.ACL.CountQuads(args.Rule, vocabularies.ACL.Agent, args.Credentials.Agent.WebID, nil) != 0, nil
+	if webID := args.Credentials.WebID(); webID != "" {
+		return args.ACL.CountQuads(args.Rule, vocabularies.ACL.Agent, webID, nil) != 0, nil
 	}
 	return false, nil
 }
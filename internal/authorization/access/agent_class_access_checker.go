@@ -22,7 +22,7 @@ func (c *AgentClassAccessChecker) Handle(args AccessCheckerArgs) (bool, error) {
 	}
 
 	// Check if the agent is authenticated and if authenticated agents have access
-	if args.Credentials.Agent != nil && args.Credentials.Agent.WebID != "" {
+	if args.Credentials.WebID() != "" {
 		return args.ACL.CountQuads(args.Rule, vocabularies.ACL.AgentClass, vocabularies.ACL.AuthenticatedAgent, nil) != 0, nil
 	}
 	return false, nil
@@ -6,7 +6,6 @@ import (
 	"solid-go/internal/http/representation"
 	"solid-go/internal/util/fetch"
 	"solid-go/internal/util/n3"
-	"solid-go/internal/util/stream"
 	"solid-go/internal/util/vocabularies"
 )
 
@@ -20,10 +19,10 @@ func NewAgentGroupAccessChecker() *AgentGroupAccessChecker {
 
 // Handle implements the AccessChecker interface
 func (c *AgentGroupAccessChecker) Handle(args AccessCheckerArgs) (bool, error) {
-	if args.Credentials.Agent != nil && args.Credentials.Agent.WebID != "" {
+	if webID := args.Credentials.WebID(); webID != "" {
 		groups := args.ACL.GetObjects(args.Rule, vocabularies.ACL.AgentGroup, nil)
 		for _, group := range groups {
-			if isMember, err := c.isMemberOfGroup(args.Credentials.Agent.WebID, group); err == nil && isMember {
+			if isMember, err := c.isMemberOfGroup(webID, group); err == nil && isMember {
 				return true, nil
 			}
 		}
@@ -51,5 +50,5 @@ func (c *AgentGroupAccessChecker) fetchQuads(url string) (n3.Store, error) {
 	if err != nil {
 		return nil, err
 	}
-	return stream.ReadableToQuads(representation.Data), nil
+	return representation.Dataset, nil
 }
@@ -0,0 +1,27 @@
+// Package access implements the combination of access checkers.
+package access
+
+// UnionAccessChecker grants access if any of its checkers does
+type UnionAccessChecker struct {
+	checkers []AccessChecker
+}
+
+// NewUnionAccessChecker creates a new UnionAccessChecker
+func NewUnionAccessChecker(checkers ...AccessChecker) *UnionAccessChecker {
+	return &UnionAccessChecker{checkers: checkers}
+}
+
+// Handle implements the AccessChecker interface.
+// The checkers are called in order until one of them grants access.
+func (c *UnionAccessChecker) Handle(args AccessCheckerArgs) (bool, error) {
+	for _, checker := range c.checkers {
+		allowed, err := checker.Handle(args)
+		if err != nil {
+			return false, err
+		}
+		if allowed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
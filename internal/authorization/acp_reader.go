@@ -2,113 +2,210 @@
 package authorization
 
 import (
-	"context"
-	"encoding/json"
-	"path/filepath"
+	"strings"
 
+	"solid-go/internal/authentication"
 	"solid-go/internal/authorization/permissions"
+	"solid-go/internal/http/auxiliary"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
 )
 
-// ACP represents an Access Control Policy document
-type ACP struct {
-	Policy []struct {
-		Allow []struct {
-			Mode []string `json:"mode"`
-		} `json:"allow"`
-		Deny []struct {
-			Mode []string `json:"mode"`
-		} `json:"deny"`
-	} `json:"policy"`
-}
-
-// ACPReader reads permissions from Access Control Policy files.
-// It parses ACP documents to determine permissions for resources.
+// ACPReader determines permissions with the Access Control Resources (ACRs) of the storage.
+// The policies of a resource are those applied by the acp:accessControl of its own ACR,
+// together with those applied by the acp:memberAccessControl of the ACRs of all its ancestors.
+// A resource without policies grants no access modes.
 type ACPReader struct {
-	storage Storage
+	acrStrategy        auxiliary.AuxiliaryIdentifierStrategy
+	acrStore           storage.ResourceStore
+	identifierStrategy IdentifierStrategy
 }
 
-// NewACPReader creates a new ACPReader with the given storage.
-func NewACPReader(storage Storage) *ACPReader {
+// NewACPReader creates a new ACPReader that reads the ACRs from the store
+func NewACPReader(acrStrategy auxiliary.AuxiliaryIdentifierStrategy, acrStore storage.ResourceStore,
+	identifierStrategy IdentifierStrategy) *ACPReader {
 	return &ACPReader{
-		storage: storage,
+		acrStrategy:        acrStrategy,
+		acrStore:           acrStore,
+		identifierStrategy: identifierStrategy,
 	}
 }
 
 // Read implements PermissionReader.
-// It reads and parses the ACP file for the given resource.
-func (r *ACPReader) Read(ctx context.Context, resource string) (permissions.PermissionSet, error) {
-	// Get the ACP file path
-	acpPath := r.getACPPath(resource)
-
-	// Read the ACP file
-	data, err := r.storage.Get(ctx, acpPath)
-	if err != nil {
-		return permissions.NewACLPermissionSet(), err
+// Every ACR is read at most once per call, since resources often share an ancestor.
+func (r *ACPReader) Read(input PermissionReaderInput) (map[string]permissions.PermissionSet, error) {
+	credentials := input.Credentials
+	if credentials == nil {
+		credentials = &authentication.Credentials{}
 	}
-
-	// Parse the ACP document
-	var acp ACP
-	if err := json.Unmarshal(data, &acp); err != nil {
-		return permissions.NewACLPermissionSet(), err
+	documents := make(map[string]*n3.BasicStore)
+	result := make(map[string]permissions.PermissionSet, len(input.RequestedModes))
+	for identifier := range input.RequestedModes {
+		policies, err := r.findPolicies(representation.ResourceIdentifier{Path: identifier}, documents)
+		if err != nil {
+			return nil, err
+		}
+		result[identifier] = determineAcpPermissions(policies, credentials)
 	}
+	return result, nil
+}
 
-	// Create a new permission set
-	perms := permissions.NewACLPermissionSet()
+// acpPolicy is a policy together with the ACR it is described in
+type acpPolicy struct {
+	acr    *n3.BasicStore
+	policy n3.Term
+}
 
-	// Process each policy
-	for _, policy := range acp.Policy {
-		// Process allow rules
-		for _, allow := range policy.Allow {
-			for _, mode := range allow.Mode {
-				switch mode {
-				case "Read":
-					perms.Add(permissions.Read)
-				case "Write":
-					perms.Add(permissions.Write)
-				case "Append":
-					perms.Add(permissions.Append)
-				case "Control":
-					perms.Add(permissions.Control)
+// findPolicies returns the policies that apply to the target
+func (r *ACPReader) findPolicies(target representation.ResourceIdentifier,
+	documents map[string]*n3.BasicStore) ([]acpPolicy, error) {
+	var policies []acpPolicy
+	identifier := target
+	for {
+		acr, err := readAuthorizationDocument(r.acrStore, r.acrStrategy.GetAuxiliaryIdentifier(identifier), documents)
+		if err != nil {
+			return nil, err
+		}
+		if acr != nil {
+			// ACP, §4.1: the access controls of ancestors only apply through acp:memberAccessControl
+			predicate := vocabularies.ACP.MemberAccessControl
+			if identifier.Path == target.Path {
+				predicate = vocabularies.ACP.AccessControl
+			}
+			for _, accessControl := range acr.GetObjects(nil, predicate, nil) {
+				for _, policy := range acr.GetObjects(accessControl, vocabularies.ACP.Apply, nil) {
+					policies = append(policies, acpPolicy{acr: acr, policy: policy})
 				}
 			}
 		}
+		if r.identifierStrategy.IsRootContainer(identifier) {
+			return policies, nil
+		}
+		if identifier, err = r.identifierStrategy.GetParentContainer(identifier); err != nil {
+			return nil, err
+		}
+	}
+}
 
-		// Process deny rules
-		for _, deny := range policy.Deny {
-			for _, mode := range deny.Mode {
-				switch mode {
-				case "Read":
-					perms.Remove(permissions.Read)
-				case "Write":
-					perms.Remove(permissions.Write)
-				case "Append":
-					perms.Remove(permissions.Append)
-				case "Control":
-					perms.Remove(permissions.Control)
-				}
+// determineAcpPermissions grants the modes allowed by the satisfied policies,
+// except for those denied by any of them
+func determineAcpPermissions(policies []acpPolicy, credentials *authentication.Credentials) permissions.PermissionSet {
+	result := permissions.NewACLPermissionSet()
+	denied := permissions.NewACLPermissionSet()
+	for _, p := range policies {
+		if !satisfiesPolicy(p.acr, p.policy, credentials) {
+			continue
+		}
+		for _, mode := range p.acr.GetObjects(p.policy, vocabularies.ACP.Allow, nil) {
+			grantAclMode(result, mode)
+		}
+		for _, mode := range p.acr.GetObjects(p.policy, vocabularies.ACP.Deny, nil) {
+			// Denying acl:Write does not deny acl:Append, so the modes are not mapped with their implications
+			switch mode.Value() {
+			case vocabularies.ACL.Read.Value():
+				denied.Add(permissions.Read)
+			case vocabularies.ACL.Write.Value():
+				denied.Add(permissions.Write)
+			case vocabularies.ACL.Append.Value():
+				denied.Add(permissions.Append)
+			case vocabularies.ACL.Control.Value():
+				denied.Add(permissions.Control)
 			}
 		}
 	}
+	for mode := range denied {
+		result.Remove(mode)
+	}
+	return result
+}
 
-	return perms, nil
+// satisfiesPolicy checks the matchers of the policy.
+// ACP, §5.2: all acp:allOf matchers and at least one acp:anyOf matcher have to be satisfied,
+// none of the acp:noneOf matchers can be, and a policy without allOf or anyOf matchers is never satisfied.
+func satisfiesPolicy(acr *n3.BasicStore, policy n3.Term, credentials *authentication.Credentials) bool {
+	allOf := acr.GetObjects(policy, vocabularies.ACP.AllOf, nil)
+	anyOf := acr.GetObjects(policy, vocabularies.ACP.AnyOf, nil)
+	if len(allOf) == 0 && len(anyOf) == 0 {
+		return false
+	}
+	for _, matcher := range allOf {
+		if !satisfiesMatcher(acr, matcher, credentials) {
+			return false
+		}
+	}
+	if len(anyOf) > 0 && !satisfiesAnyMatcher(acr, anyOf, credentials) {
+		return false
+	}
+	return !satisfiesAnyMatcher(acr, acr.GetObjects(policy, vocabularies.ACP.NoneOf, nil), credentials)
 }
 
-// getACPPath returns the path to the ACP file for a resource.
-func (r *ACPReader) getACPPath(resource string) string {
-	return filepath.Join(filepath.Dir(resource), ".acp")
+// satisfiesAnyMatcher checks whether at least one of the matchers is satisfied
+func satisfiesAnyMatcher(acr *n3.BasicStore, matchers []n3.Term, credentials *authentication.Credentials) bool {
+	for _, matcher := range matchers {
+		if satisfiesMatcher(acr, matcher, credentials) {
+			return true
+		}
+	}
+	return false
 }
 
-// GetAcpPath gets the ACP file path
-func (r *ACPReader) GetAcpPath(path string) string {
-	return filepath.Join(filepath.Dir(path), ".acp")
+// satisfiesMatcher checks the attributes of the matcher.
+// ACP, §5.3: every attribute that is defined needs a value matching the context,
+// and a matcher without attributes is never satisfied.
+func satisfiesMatcher(acr *n3.BasicStore, matcher n3.Term, credentials *authentication.Credentials) bool {
+	webID, clientID, issuer := credentials.WebID(), credentials.ClientID(), credentials.IssuerURL()
+	credentialTypes := credentials.CredentialTypes()
+	attributes := []struct {
+		predicate n3.Term
+		matches   func(value string) bool
+	}{
+		{vocabularies.ACP.Agent, func(value string) bool {
+			return value == vocabularies.ACP.PublicAgent.Value() ||
+				(webID != "" && (value == webID || value == vocabularies.ACP.AuthenticatedAgent.Value()))
+		}},
+		{vocabularies.ACP.Client, func(value string) bool {
+			return value == vocabularies.ACP.PublicClient.Value() || (clientID != "" && value == clientID)
+		}},
+		{vocabularies.ACP.Issuer, func(value string) bool {
+			return value == vocabularies.ACP.PublicIssuer.Value() || (issuer != "" && sameIssuer(value, issuer))
+		}},
+		// The values of acp:vc are the types of verifiable credentials the agent has to present
+		{vocabularies.ACP.Vc, func(value string) bool {
+			for _, credentialType := range credentialTypes {
+				if value == credentialType {
+					return true
+				}
+			}
+			return false
+		}},
+	}
+	defined := false
+	for _, attribute := range attributes {
+		values := acr.GetObjects(matcher, attribute.predicate, nil)
+		if len(values) == 0 {
+			continue
+		}
+		defined = true
+		if !matchesAny(values, attribute.matches) {
+			return false
+		}
+	}
+	return defined
 }
 
-// IsAcp checks if a path is an ACP file
-func (r *ACPReader) IsAcp(path string) bool {
-	return filepath.Base(path) == ".acp"
+// sameIssuer compares issuer URLs, which are often written both with and without trailing slash
+func sameIssuer(a, b string) bool {
+	return strings.TrimSuffix(a, "/") == strings.TrimSuffix(b, "/")
 }
 
-// GetAcpResource gets the ACP resource for a path
-func (r *ACPReader) GetAcpResource(path string) string {
-	return filepath.Join(filepath.Dir(path), "acp")
+// matchesAny checks whether any of the values matches
+func matchesAny(values []n3.Term, matches func(value string) bool) bool {
+	for _, value := range values {
+		if matches(value.Value()) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,126 @@
+package authorization
+
+import (
+	"strings"
+	"testing"
+
+	"solid-go/internal/authentication"
+	"solid-go/internal/authorization/permissions"
+	"solid-go/internal/http/auxiliary"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage"
+	"solid-go/internal/util"
+	"solid-go/internal/util/identifiers"
+)
+
+const acpPrefixes = `@prefix acp: <http://www.w3.org/ns/solid/acp#>.
+@prefix acl: <http://www.w3.org/ns/auth/acl#>.
+`
+
+// newAcpReader creates an ACPReader on a store containing the given ACRs
+func newAcpReader(t *testing.T, documents map[string]string) *ACPReader {
+	t.Helper()
+	store := storage.NewDataAccessorBasedStore(storage.NewInMemoryDataAccessor(aclBaseURL), aclBaseURL)
+	for path, acr := range documents {
+		metadata := representation.NewRepresentationMetadata("").SetContentType(util.Turtle)
+		rep := representation.NewBasicRepresentation(strings.NewReader(acpPrefixes+acr), metadata, true)
+		if _, err := store.SetRepresentation(representation.ResourceIdentifier{Path: aclBaseURL + path}, rep, nil); err != nil {
+			t.Fatalf("SetRepresentation(%v) error = %v", path, err)
+		}
+	}
+	return NewACPReader(&auxiliary.SuffixAuxiliaryIdentifierStrategy{Suffix: auxiliary.AcrSuffix}, store,
+		identifiers.NewSingleRootIdentifierStrategy(aclBaseURL))
+}
+
+func TestACPReader(t *testing.T) {
+	alice, bob := "https://alice.example/profile#me", "https://bob.example/profile#me"
+	app, idp := "https://app.example/id", "https://idp.example/"
+	reader := newAcpReader(t, map[string]string{
+		".acr": `
+<#root> acp:accessControl <#rootAccess>; acp:memberAccessControl <#memberAccess>.
+<#rootAccess> acp:apply <#public>.
+<#memberAccess> acp:apply <#public>, <#owner>, <#trustedApp>, <#authenticated>.
+<#public> acp:allow acl:Read; acp:anyOf <#anyone>.
+<#owner> acp:allow acl:Read, acl:Write, acl:Control; acp:allOf <#alice>.
+<#trustedApp> acp:allow acl:Write; acp:allOf <#bob>, <#appFromIdp>.
+<#authenticated> acp:allow acl:Append; acp:anyOf <#anyAgent>; acp:noneOf <#alice>.
+<#anyone> acp:agent acp:PublicAgent.
+<#anyAgent> acp:agent acp:AuthenticatedAgent.
+<#alice> acp:agent <` + alice + `>.
+<#bob> acp:agent <` + bob + `>.
+<#appFromIdp> acp:client <` + app + `>; acp:issuer <` + idp + `>.`,
+		"private/.acr": `
+<#acr> acp:accessControl <#access>; acp:memberAccessControl <#access>.
+<#access> acp:apply <#denyRead>, <#withoutMatchers>, <#vc>.
+<#denyRead> acp:deny acl:Read; acp:anyOf <#anyone>; acp:noneOf <#alice>.
+<#withoutMatchers> acp:allow acl:Control; acp:noneOf <#alice>.
+<#vc> acp:allow acl:Control; acp:anyOf <#credential>.
+<#anyone> acp:agent acp:PublicAgent.
+<#alice> acp:agent <` + alice + `>.
+<#credential> acp:vc <https://vc.example/credential>.`,
+	})
+
+	tests := []struct {
+		name, path  string
+		credentials *authentication.Credentials
+		want        string
+	}{
+		{"own access control", "", nil, "read"},
+		{"member access control does not apply to the resource itself", "", &authentication.Credentials{
+			Agent: &authentication.Agent{WebID: alice}}, "read"},
+		{"member access control", "doc", nil, "read"},
+		{"allOf matcher", "a/b/doc", &authentication.Credentials{
+			Agent: &authentication.Agent{WebID: alice}}, "read append write control"},
+		{"authenticated agent", "doc", &authentication.Credentials{
+			Agent: &authentication.Agent{WebID: bob}}, "read append"},
+		{"all attributes of a matcher", "doc", &authentication.Credentials{
+			Agent:  &authentication.Agent{WebID: bob},
+			Client: &authentication.Client{ClientID: app},
+			Issuer: &authentication.Issuer{URL: idp}}, "read append write"},
+		{"missing attribute", "doc", &authentication.Credentials{
+			Agent:  &authentication.Agent{WebID: bob},
+			Client: &authentication.Client{ClientID: app}}, "read append"},
+		{"deny", "private/doc", &authentication.Credentials{
+			Agent: &authentication.Agent{WebID: bob}}, "append"},
+		{"noneOf matcher", "private/doc", &authentication.Credentials{
+			Agent: &authentication.Agent{WebID: alice}}, "read append write control"},
+		{"issuer without trailing slash", "doc", &authentication.Credentials{
+			Agent:  &authentication.Agent{WebID: bob},
+			Client: &authentication.Client{ClientID: app},
+			Issuer: &authentication.Issuer{URL: strings.TrimSuffix(idp, "/")}}, "read append write"},
+		{"verifiable credential", "private/doc", &authentication.Credentials{
+			Agent:                 &authentication.Agent{WebID: bob},
+			VerifiableCredentials: []string{"https://vc.example/other", "https://vc.example/credential"}}, "append control"},
+		{"other verifiable credential", "private/doc", &authentication.Credentials{
+			VerifiableCredentials: []string{"https://vc.example/other"}}, ""},
+		{"ancestor member and own access control", "private/", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := reader.Read(PermissionReaderInput{
+				Credentials:    tt.credentials,
+				RequestedModes: map[string]permissions.PermissionSet{aclBaseURL + tt.path: {permissions.Read: true}},
+			})
+			if err != nil {
+				t.Fatalf("Read(%v) error = %v", tt.path, err)
+			}
+			if got := modeString(result[aclBaseURL+tt.path]); got != tt.want {
+				t.Errorf("modes on %q = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestACPReader_WithoutAcr(t *testing.T) {
+	reader := newAcpReader(t, map[string]string{})
+	if got := modeString(readModes(t, reader, "doc", "https://alice.example/profile#me")); got != "" {
+		t.Errorf("modes without ACR = %q, want none", got)
+	}
+
+	reader = newAcpReader(t, map[string]string{"doc.acr": "<#acr> acp:accessControl"})
+	if _, err := reader.Read(PermissionReaderInput{
+		RequestedModes: map[string]permissions.PermissionSet{aclBaseURL + "doc": {permissions.Read: true}},
+	}); err == nil {
+		t.Errorf("Read() with an invalid ACR succeeded")
+	}
+}
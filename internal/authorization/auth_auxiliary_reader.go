@@ -2,79 +2,65 @@
 package authorization
 
 import (
-	"context"
-	"path/filepath"
-
 	"solid-go/internal/authorization/permissions"
+	"solid-go/internal/http/auxiliary"
+	"solid-go/internal/http/representation"
 )
 
-// AuthAuxiliaryReader reads permissions from authentication-related auxiliary resources.
-// It handles reading permissions from resources that are auxiliary to the main resource
-// and are specifically related to authentication.
+// AuthAuxiliaryReader determines the permissions of authorization resources, such as ACLs.
+// Access to them is not described by their own contents,
+// but requires the Control permission on the resource they apply to.
 type AuthAuxiliaryReader struct {
-	reader PermissionReader
+	reader       PermissionReader
+	authStrategy auxiliary.AuxiliaryIdentifierStrategy
 }
 
 // NewAuthAuxiliaryReader creates a new AuthAuxiliaryReader with the given reader.
-func NewAuthAuxiliaryReader(reader PermissionReader) *AuthAuxiliaryReader {
+func NewAuthAuxiliaryReader(reader PermissionReader, authStrategy auxiliary.AuxiliaryIdentifierStrategy) *AuthAuxiliaryReader {
 	return &AuthAuxiliaryReader{
-		reader: reader,
+		reader:       reader,
+		authStrategy: authStrategy,
 	}
 }
 
 // Read implements PermissionReader.
-// It reads permissions from authentication-related auxiliary resources associated with the given resource.
-func (r *AuthAuxiliaryReader) Read(ctx context.Context, resource string) (permissions.PermissionSet, error) {
-	// Get authentication-related auxiliary resource paths
-	auxPaths := r.getAuthAuxiliaryPaths(resource)
-
-	// Create a new permission set
-	perms := permissions.NewACLPermissionSet()
-
-	// Read permissions from each auxiliary resource
-	for _, path := range auxPaths {
-		auxPerms, err := r.reader.Read(ctx, path)
-		if err != nil {
-			continue // Skip this auxiliary resource if it returns an error
+// The authorization resources are replaced by their subjects when reading the permissions.
+func (r *AuthAuxiliaryReader) Read(input PermissionReaderInput) (map[string]permissions.PermissionSet, error) {
+	subjects := make(map[string]string)
+	requested := make(map[string]permissions.PermissionSet)
+	for identifier, modes := range input.RequestedModes {
+		id := representation.ResourceIdentifier{Path: identifier}
+		if !r.authStrategy.IsAuxiliaryIdentifier(id) {
+			requested[identifier] = modes.Union(requested[identifier])
+			continue
 		}
-
-		// Add all permissions from this auxiliary resource
-		for _, mode := range []permissions.Permission{
-			permissions.Read,
-			permissions.Write,
-			permissions.Append,
-			permissions.Control,
-		} {
-			if auxPerms.Has(mode) {
-				perms.Add(mode)
-			}
+		subject, err := r.authStrategy.GetSubjectIdentifier(id)
+		if err != nil {
+			return nil, err
 		}
+		subjects[identifier] = subject.Path
+		control := permissions.NewACLPermissionSet()
+		control.Add(permissions.Control)
+		requested[subject.Path] = control.Union(requested[subject.Path])
 	}
 
-	return perms, nil
-}
-
-// getAuthAuxiliaryPaths returns the paths of authentication-related auxiliary resources for the given resource.
-func (r *AuthAuxiliaryReader) getAuthAuxiliaryPaths(resource string) []string {
-	dir := filepath.Dir(resource)
-	base := filepath.Base(resource)
-	return []string{
-		filepath.Join(dir, "."+base+".auth"),
-		filepath.Join(dir, "."+base+".webid"),
+	result, err := r.reader.Read(PermissionReaderInput{Credentials: input.Credentials, RequestedModes: requested})
+	if err != nil {
+		return nil, err
 	}
+	for identifier, subject := range subjects {
+		result[identifier] = r.interpretControl(result[subject])
+	}
+	return result, nil
 }
 
-// GetAuthAuxiliaryPath gets the auth auxiliary resource path
-func (r *AuthAuxiliaryReader) GetAuthAuxiliaryPath(path string) string {
-	return filepath.Join(filepath.Dir(path), ".auth")
-}
-
-// IsAuthAuxiliary checks if a path is an auth auxiliary resource
-func (r *AuthAuxiliaryReader) IsAuthAuxiliary(path string) bool {
-	return filepath.Base(path) == ".auth"
-}
-
-// GetAuthResource gets the auth resource for a path
-func (r *AuthAuxiliaryReader) GetAuthResource(path string) string {
-	return filepath.Join(filepath.Dir(path), "auth")
+// interpretControl grants all access to an authorization resource if there is control over its subject
+func (r *AuthAuxiliaryReader) interpretControl(permissionSet permissions.PermissionSet) permissions.PermissionSet {
+	control := permissionSet.Has(permissions.Control)
+	return permissions.PermissionSet{
+		permissions.Read:    control,
+		permissions.Append:  control,
+		permissions.Write:   control,
+		permissions.Control: control,
+	}
 }
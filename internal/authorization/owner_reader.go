@@ -3,23 +3,37 @@ package authorization
 
 import (
 	"solid-go/internal/authorization/permissions"
-	"solid-go/internal/http/auxiliary"
 	"solid-go/internal/identity/interaction/pod"
-	"solid-go/internal/server/description"
 )
 
+// PodOwnerStore finds pods by their base URL and returns their owners.
+type PodOwnerStore interface {
+	FindByBaseURL(baseURL string) (*pod.Pod, error)
+	GetOwners(podID string) ([]pod.Owner, error)
+}
+
+// AuthIdentifierStrategy checks if an identifier is that of an authorization resource, such as an ACL.
+type AuthIdentifierStrategy interface {
+	IsAuxiliaryIdentifier(identifier string) bool
+}
+
+// StorageStrategy finds the root of the storage, such as a pod, an identifier is located in.
+type StorageStrategy interface {
+	GetStorageIdentifier(identifier string) (string, error)
+}
+
 // OwnerPermissionReader allows control access if the request is being made by an owner of the pod containing the resource.
 type OwnerPermissionReader struct {
-	podStore        pod.Store
-	authStrategy    auxiliary.IdentifierStrategy
-	storageStrategy description.StorageLocationStrategy
+	podStore        PodOwnerStore
+	authStrategy    AuthIdentifierStrategy
+	storageStrategy StorageStrategy
 }
 
 // NewOwnerPermissionReader creates a new OwnerPermissionReader.
 func NewOwnerPermissionReader(
-	podStore pod.Store,
-	authStrategy auxiliary.IdentifierStrategy,
-	storageStrategy description.StorageLocationStrategy,
+	podStore PodOwnerStore,
+	authStrategy AuthIdentifierStrategy,
+	storageStrategy StorageStrategy,
 ) *OwnerPermissionReader {
 	return &OwnerPermissionReader{
 		podStore:        podStore,
@@ -46,10 +60,7 @@ func (r *OwnerPermissionReader) Read(input PermissionReaderInput) (map[string]pe
 	}
 
 	// Get WebID from credentials
-	webID := ""
-	if input.Credentials != nil {
-		webID = input.Credentials.WebID
-	}
+	webID := input.Credentials.WebID()
 	if webID == "" {
 		return result, nil
 	}
@@ -139,16 +150,16 @@ func (r *OwnerPermissionReader) findOwners(pods map[string]string) (map[string][
 }
 
 // GetPodStore returns the pod store.
-func (r *OwnerPermissionReader) GetPodStore() pod.Store {
+func (r *OwnerPermissionReader) GetPodStore() PodOwnerStore {
 	return r.podStore
 }
 
 // GetAuthStrategy returns the auth strategy.
-func (r *OwnerPermissionReader) GetAuthStrategy() auxiliary.IdentifierStrategy {
+func (r *OwnerPermissionReader) GetAuthStrategy() AuthIdentifierStrategy {
 	return r.authStrategy
 }
 
 // GetStorageStrategy returns the storage strategy.
-func (r *OwnerPermissionReader) GetStorageStrategy() description.StorageLocationStrategy {
+func (r *OwnerPermissionReader) GetStorageStrategy() StorageStrategy {
 	return r.storageStrategy
 }
@@ -2,7 +2,7 @@
 package authorization
 
 import (
-	"path/filepath"
+	"strings"
 
 	"solid-go/internal/authorization/permissions"
 )
@@ -35,7 +35,7 @@ func (r *ParentContainerReader) Read(input PermissionReaderInput) (map[string]pe
 	for resource, modes := range input.RequestedModes {
 		combinedModes[resource] = modes
 	}
-	for resource, entry := range containerMap {
+	for _, entry := range containerMap {
 		container, modes := entry.container, entry.modes
 		if existing, ok := combinedModes[container]; ok {
 			// Merge with existing modes
@@ -77,7 +77,7 @@ func (r *ParentContainerReader) findParents(requestedModes map[string]permission
 	containerMap := make(map[string]containerEntry)
 	for resource, modes := range requestedModes {
 		if modes.Has(permissions.Create) || modes.Has(permissions.Delete) {
-			container := filepath.Dir(resource)
+			container := getParentContainer(resource)
 			containerMap[resource] = containerEntry{
 				container: container,
 				modes:     r.getParentModes(modes),
@@ -139,6 +139,12 @@ func (r *ParentContainerReader) interpretContainerPermission(resourcePermission,
 	return mergedPermission
 }
 
+// getParentContainer returns the identifier of the container the resource is in
+func getParentContainer(identifier string) string {
+	trimmed := strings.TrimSuffix(identifier, "/")
+	return trimmed[:strings.LastIndex(trimmed, "/")+1]
+}
+
 // GetReader returns the underlying permission reader.
 func (r *ParentContainerReader) GetReader() PermissionReader {
 	return r.reader
@@ -23,49 +23,69 @@ func TestParentContainerReader(t *testing.T) {
 		expectError    bool
 	}{
 		{
-			name: "Parent Container Access",
+			name: "Create Through Append On Parent",
 			reader: &mockParentReader{
 				modes: map[string]permissions.PermissionSet{
 					"https://example.org/container/": func() permissions.PermissionSet {
 						ps := permissions.NewPermissionSet()
-						ps.Add(permissions.Read)
+						ps.Add(permissions.Append)
 						return ps
 					}(),
 				},
 			},
 			requestedModes: map[string]permissions.PermissionSet{
-				"https://example.org/container/resource": permissions.NewPermissionSet(),
+				"https://example.org/container/resource": func() permissions.PermissionSet {
+					ps := permissions.NewPermissionSet()
+					ps.Add(permissions.Create)
+					return ps
+				}(),
 			},
 			expectedModes: map[string]permissions.PermissionSet{
 				"https://example.org/container/resource": func() permissions.PermissionSet {
 					ps := permissions.NewPermissionSet()
-					ps.Add(permissions.Read)
+					ps.Add(permissions.Create)
 					return ps
 				}(),
 			},
 			expectError: false,
 		},
 		{
-			name: "No Parent Container",
+			name: "No Parent Permissions",
 			reader: &mockParentReader{
 				modes: map[string]permissions.PermissionSet{},
 			},
 			requestedModes: map[string]permissions.PermissionSet{
+				"https://example.org/resource": func() permissions.PermissionSet {
+					ps := permissions.NewPermissionSet()
+					ps.Add(permissions.Create)
+					return ps
+				}(),
+			},
+			expectedModes: map[string]permissions.PermissionSet{
 				"https://example.org/resource": permissions.NewPermissionSet(),
 			},
-			expectedModes: map[string]permissions.PermissionSet{},
-			expectError:   false,
+			expectError: false,
 		},
 		{
-			name: "Multiple Resources",
+			name: "Delete Requires Write On Both",
 			reader: &mockParentReader{
 				modes: map[string]permissions.PermissionSet{
 					"https://example.org/container1/": func() permissions.PermissionSet {
 						ps := permissions.NewPermissionSet()
-						ps.Add(permissions.Read)
+						ps.Add(permissions.Write)
+						return ps
+					}(),
+					"https://example.org/container1/resource1": func() permissions.PermissionSet {
+						ps := permissions.NewPermissionSet()
+						ps.Add(permissions.Write)
 						return ps
 					}(),
 					"https://example.org/container2/": func() permissions.PermissionSet {
+						ps := permissions.NewPermissionSet()
+						ps.Add(permissions.Read)
+						return ps
+					}(),
+					"https://example.org/container2/resource2": func() permissions.PermissionSet {
 						ps := permissions.NewPermissionSet()
 						ps.Add(permissions.Write)
 						return ps
@@ -73,13 +93,22 @@ func TestParentContainerReader(t *testing.T) {
 				},
 			},
 			requestedModes: map[string]permissions.PermissionSet{
-				"https://example.org/container1/resource1": permissions.NewPermissionSet(),
-				"https://example.org/container2/resource2": permissions.NewPermissionSet(),
+				"https://example.org/container1/resource1": func() permissions.PermissionSet {
+					ps := permissions.NewPermissionSet()
+					ps.Add(permissions.Delete)
+					return ps
+				}(),
+				"https://example.org/container2/resource2": func() permissions.PermissionSet {
+					ps := permissions.NewPermissionSet()
+					ps.Add(permissions.Delete)
+					return ps
+				}(),
 			},
 			expectedModes: map[string]permissions.PermissionSet{
 				"https://example.org/container1/resource1": func() permissions.PermissionSet {
 					ps := permissions.NewPermissionSet()
-					ps.Add(permissions.Read)
+					ps.Add(permissions.Write)
+					ps.Add(permissions.Delete)
 					return ps
 				}(),
 				"https://example.org/container2/resource2": func() permissions.PermissionSet {
@@ -96,27 +125,26 @@ func TestParentContainerReader(t *testing.T) {
 				modes: map[string]permissions.PermissionSet{
 					"https://example.org/container/": func() permissions.PermissionSet {
 						ps := permissions.NewPermissionSet()
-						ps.Add(permissions.Read)
+						ps.Add(permissions.Append)
 						return ps
 					}(),
 					"https://example.org/container/nested/": func() permissions.PermissionSet {
 						ps := permissions.NewPermissionSet()
-						ps.Add(permissions.Write)
+						ps.Add(permissions.Read)
 						return ps
 					}(),
 				},
 			},
 			requestedModes: map[string]permissions.PermissionSet{
-				"https://example.org/container/nested/resource": permissions.NewPermissionSet(),
-			},
-			expectedModes: map[string]permissions.PermissionSet{
 				"https://example.org/container/nested/resource": func() permissions.PermissionSet {
 					ps := permissions.NewPermissionSet()
-					ps.Add(permissions.Read)
-					ps.Add(permissions.Write)
+					ps.Add(permissions.Create)
 					return ps
 				}(),
 			},
+			expectedModes: map[string]permissions.PermissionSet{
+				"https://example.org/container/nested/resource": permissions.NewPermissionSet(),
+			},
 			expectError: false,
 		},
 	}
@@ -2,63 +2,62 @@
 package authorization
 
 import (
-	"context"
-	"net/http"
-
-	"solid-go/internal/authorization/access"
+	"solid-go/internal/authentication"
 	"solid-go/internal/authorization/permissions"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
 )
 
-// PermissionBasedAuthorizer implements authorization based on permissions.
-// It checks if an agent has the required permissions for a resource.
+// PermissionBasedAuthorizer authorizes requests based on the available permissions.
+// Every requested mode needs to be present in the permissions of its resource.
 type PermissionBasedAuthorizer struct {
-	accessChecker access.AccessChecker
-	modeExtractor permissions.ModesExtractor
-	reader        PermissionReader
+	resourceSet permissions.ResourceSet
 }
 
 // NewPermissionBasedAuthorizer creates a new PermissionBasedAuthorizer.
-func NewPermissionBasedAuthorizer(
-	checker access.AccessChecker,
-	extractor permissions.ModesExtractor,
-	reader PermissionReader,
-) *PermissionBasedAuthorizer {
+// The resource set is used to decide whether a 404 can be returned instead of a 401 or 403.
+func NewPermissionBasedAuthorizer(resourceSet permissions.ResourceSet) *PermissionBasedAuthorizer {
 	return &PermissionBasedAuthorizer{
-		accessChecker: checker,
-		modeExtractor: extractor,
-		reader:        reader,
+		resourceSet: resourceSet,
 	}
 }
 
 // Authorize implements Authorizer.
-// It checks if the agent has the required permissions for the resource.
-func (a *PermissionBasedAuthorizer) Authorize(ctx context.Context, r *http.Request) error {
-	// Extract WebID from request
-	webID := r.Header.Get("X-WebID")
-	if webID == "" {
-		return ErrUnauthorized
-	}
-
-	// Get required permissions
-	modes := a.modeExtractor.Extract(r)
-
-	// Get current permissions
-	perms, err := a.reader.Read(ctx, r.URL.Path)
-	if err != nil {
-		return err
+// It returns an UnauthorizedError if a mode is missing for an unauthenticated agent, and a ForbiddenError otherwise.
+func (a *PermissionBasedAuthorizer) Authorize(input AuthorizerInput) error {
+	for identifier, modes := range input.RequestedModes {
+		permissionSet := input.AvailablePermissions[identifier]
+		for _, mode := range modes.GetPermissions() {
+			if permissionSet.Has(mode) {
+				continue
+			}
+			// If the operation will result in a 404 regardless, as the resource does not exist and is not being created,
+			// and the agent is allowed to know about its existence through read permissions,
+			// the 404 is returned immediately as it makes any other agent permissions irrelevant.
+			if permissionSet.Has(permissions.Read) && !modes.Has(permissions.Create) {
+				exists, err := a.resourceSet.HasResource(representation.ResourceIdentifier{Path: identifier})
+				if err != nil {
+					return err
+				}
+				if !exists {
+					return errors.NewNotFoundError(identifier+" does not exist", nil)
+				}
+			}
+			return a.missingModeError(input.Credentials, identifier, mode)
+		}
 	}
+	return nil
+}
 
-	// Check each required permission
-	for _, mode := range []permissions.Permission{
-		permissions.Read,
-		permissions.Write,
-		permissions.Append,
-		permissions.Control,
-	} {
-		if modes.Has(mode) && !perms.Has(mode) {
-			return ErrForbidden
-		}
+// missingModeError returns the error for an agent that lacks a mode on a resource
+func (a *PermissionBasedAuthorizer) missingModeError(credentials *authentication.Credentials, identifier string, mode permissions.AccessMode) error {
+	if isAuthenticated(credentials) {
+		return errors.NewForbiddenError("Missing "+string(mode)+" permission on "+identifier, nil)
 	}
+	return errors.NewUnauthorizedError("Missing "+string(mode)+" permission on "+identifier, nil)
+}
 
-	return nil
+// isAuthenticated checks if the credentials identify an agent by WebID
+func isAuthenticated(credentials *authentication.Credentials) bool {
+	return credentials.WebID() != ""
 }
@@ -0,0 +1,87 @@
+package authorization
+
+import (
+	"testing"
+
+	"solid-go/internal/authentication"
+	"solid-go/internal/authorization/permissions"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
+
+type mockResourceSet map[string]bool
+
+func (m mockResourceSet) HasResource(identifier representation.ResourceIdentifier) (bool, error) {
+	return m[identifier.Path], nil
+}
+
+func TestPermissionBasedAuthorizer(t *testing.T) {
+	authorizer := NewPermissionBasedAuthorizer(mockResourceSet{"https://example.org/doc": true})
+	agent := &authentication.Credentials{Agent: &authentication.Agent{WebID: "https://example.org/profile#me"}}
+	readOnly := func() permissions.PermissionSet {
+		ps := permissions.NewPermissionSet()
+		ps.Add(permissions.Read)
+		return ps
+	}
+	request := func(path string, modes ...permissions.AccessMode) permissions.AccessMap {
+		accessMap := make(permissions.AccessMap)
+		accessMap.Add(path, modes...)
+		return accessMap
+	}
+
+	tests := []struct {
+		name        string
+		credentials *authentication.Credentials
+		requested   permissions.AccessMap
+		available   map[string]permissions.PermissionSet
+		check       func(error) bool
+	}{
+		{
+			name:        "Allowed",
+			credentials: agent,
+			requested:   request("https://example.org/doc", permissions.Read),
+			available:   map[string]permissions.PermissionSet{"https://example.org/doc": readOnly()},
+			check:       func(err error) bool { return err == nil },
+		},
+		{
+			name:      "Unauthenticated",
+			requested: request("https://example.org/doc", permissions.Write),
+			available: map[string]permissions.PermissionSet{"https://example.org/doc": readOnly()},
+			check:     errors.IsUnauthorizedError,
+		},
+		{
+			name:        "Forbidden",
+			credentials: agent,
+			requested:   request("https://example.org/doc", permissions.Write),
+			available:   map[string]permissions.PermissionSet{"https://example.org/doc": readOnly()},
+			check:       errors.IsForbiddenError,
+		},
+		{
+			name:        "Missing Resource With Read Access",
+			credentials: agent,
+			requested:   request("https://example.org/missing", permissions.Write),
+			available:   map[string]permissions.PermissionSet{"https://example.org/missing": readOnly()},
+			check:       errors.IsNotFoundError,
+		},
+		{
+			name:        "Missing Resource Being Created",
+			credentials: agent,
+			requested:   request("https://example.org/missing", permissions.Append, permissions.Create),
+			available:   map[string]permissions.PermissionSet{"https://example.org/missing": readOnly()},
+			check:       errors.IsForbiddenError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authorizer.Authorize(AuthorizerInput{
+				Credentials:          tt.credentials,
+				RequestedModes:       tt.requested,
+				AvailablePermissions: tt.available,
+			})
+			if !tt.check(err) {
+				t.Errorf("Authorize() error = %v", err)
+			}
+		})
+	}
+}
@@ -1,15 +1,11 @@
 // Package permissions provides types and utilities for handling authorization permissions.
 package permissions
 
-// AclMode represents WebACL-specific modes.
-type AclMode string
+// Control permission allows reading and modifying the access control resource of a resource.
+// It is specific to WebACL and is never required by the modes extractors directly.
+const Control AccessMode = "control"
 
-const (
-	Control AclMode = "control"
-)
-
-// AclPermissionSet extends PermissionSet to include WebACL-specific modes.
-type AclPermissionSet struct {
-	PermissionSet
-	Control bool
+// NewACLPermissionSet creates a new empty permission set that can also hold the WebACL Control mode
+func NewACLPermissionSet() PermissionSet {
+	return NewPermissionSet()
 }
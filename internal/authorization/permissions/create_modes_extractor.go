@@ -2,7 +2,7 @@
 package permissions
 
 import (
-	"net/http"
+	"solid-go/internal/http"
 )
 
 // CreateModesExtractor adds the 'create' access mode if the target resource does not exist.
@@ -19,53 +19,32 @@ func NewCreateModesExtractor(source ModesExtractor, resourceSet ResourceSet) *Cr
 	}
 }
 
-// Extract implements ModesExtractor.
-// It extracts the required permissions for resource creation based on the HTTP request.
-func (e *CreateModesExtractor) Extract(r *http.Request) (AccessMap, error) {
-	accessMap, err := e.source.Extract(r)
+// CanHandle implements ModesExtractor.CanHandle
+func (e *CreateModesExtractor) CanHandle(operation *http.Operation) error {
+	return e.source.CanHandle(operation)
+}
+
+// Extract implements ModesExtractor.Extract
+func (e *CreateModesExtractor) Extract(operation *http.Operation) (AccessMap, error) {
+	accessMap, err := e.source.Extract(operation)
 	if err != nil {
 		return nil, err
 	}
 
-	target := r.URL.Path
-	exists, err := e.resourceSet.HasResource(target)
+	target := operation.Target.Path
+	if accessMap.Has(target, Create) {
+		return accessMap, nil
+	}
+	// Writing to a resource that does not exist yet creates it
+	if !accessMap.Has(target, Write) && !accessMap.Has(target, Append) {
+		return accessMap, nil
+	}
+	exists, err := e.resourceSet.HasResource(operation.Target)
 	if err != nil {
 		return nil, err
 	}
-
 	if !exists {
-		if _, ok := accessMap[target]; !ok {
-			accessMap[target] = make(map[AccessMode]struct{})
-		}
-		accessMap[target][Create] = struct{}{}
+		accessMap.Add(target, Create)
 	}
-
 	return accessMap, nil
 }
-
-// IsCreateRequest checks if the request is for resource creation.
-func (e *CreateModesExtractor) IsCreateRequest(r *http.Request) bool {
-	// POST requests with a Slug header are creation requests
-	if r.Method == http.MethodPost && r.Header.Get("Slug") != "" {
-		return true
-	}
-
-	// PUT requests to non-existent resources are creation requests
-	if r.Method == http.MethodPut {
-		// This would typically check if the resource exists
-		// For now, we'll assume all PUT requests are creation requests
-		return true
-	}
-
-	return false
-}
-
-// IsPostRequest checks if the request is a POST request.
-func (e *CreateModesExtractor) IsPostRequest(r *http.Request) bool {
-	return r.Method == http.MethodPost
-}
-
-// IsPutRequest checks if the request is a PUT request.
-func (e *CreateModesExtractor) IsPutRequest(r *http.Request) bool {
-	return r.Method == http.MethodPut
-}
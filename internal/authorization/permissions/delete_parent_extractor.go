@@ -2,10 +2,18 @@
 package permissions
 
 import (
-	"net/http"
+	"solid-go/internal/http"
+	"solid-go/internal/http/representation"
 )
 
+// IdentifierStrategy determines the root containers and the container hierarchy of identifiers.
+type IdentifierStrategy interface {
+	IsRootContainer(identifier representation.ResourceIdentifier) bool
+	GetParentContainer(identifier representation.ResourceIdentifier) (representation.ResourceIdentifier, error)
+}
+
 // DeleteParentExtractor adds read access on the parent container if the target resource does not exist during a delete operation.
+// Otherwise a 404 would reveal to agents without read access on the parent whether the resource exists.
 type DeleteParentExtractor struct {
 	source             ModesExtractor
 	resourceSet        ResourceSet
@@ -21,37 +29,32 @@ func NewDeleteParentExtractor(source ModesExtractor, resourceSet ResourceSet, id
 	}
 }
 
-// Extract implements ModesExtractor.
-func (e *DeleteParentExtractor) Extract(r *http.Request) (AccessMap, error) {
-	accessMap, err := e.source.Extract(r)
+// CanHandle implements ModesExtractor.CanHandle
+func (e *DeleteParentExtractor) CanHandle(operation *http.Operation) error {
+	return e.source.CanHandle(operation)
+}
+
+// Extract implements ModesExtractor.Extract
+func (e *DeleteParentExtractor) Extract(operation *http.Operation) (AccessMap, error) {
+	accessMap, err := e.source.Extract(operation)
 	if err != nil {
 		return nil, err
 	}
 
-	target := r.URL.Path
-	if _, ok := accessMap[target]; ok {
-		if _, ok := accessMap[target][Delete]; ok {
-			if !e.identifierStrategy.IsRootContainer(target) {
-				exists, err := e.resourceSet.HasResource(target)
-				if err != nil {
-					return nil, err
-				}
-				if !exists {
-					parent := e.identifierStrategy.GetParentContainer(target)
-					if _, ok := accessMap[parent]; !ok {
-						accessMap[parent] = make(map[AccessMode]struct{})
-					}
-					accessMap[parent][Read] = struct{}{}
-				}
-			}
+	target := operation.Target
+	if !accessMap.Has(target.Path, Delete) || e.identifierStrategy.IsRootContainer(target) {
+		return accessMap, nil
+	}
+	exists, err := e.resourceSet.HasResource(target)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		parent, err := e.identifierStrategy.GetParentContainer(target)
+		if err != nil {
+			return nil, err
 		}
+		accessMap.Add(parent.Path, Read)
 	}
-
 	return accessMap, nil
 }
-
-// IdentifierStrategy is an interface for handling resource identifiers.
-type IdentifierStrategy interface {
-	IsRootContainer(path string) bool
-	GetParentContainer(path string) string
-}
@@ -2,10 +2,20 @@
 package permissions
 
 import (
-	"net/http"
+	"strings"
+
+	"solid-go/internal/http"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
 )
 
+// ResourceSet checks the existence of resources, it is usually implemented by the ResourceStore.
+type ResourceSet interface {
+	HasResource(identifier representation.ResourceIdentifier) (bool, error)
+}
+
 // MethodModesExtractor extracts AccessModes based on HTTP method and resource existence.
+// PATCH requests are left to the extractors that interpret the patch body.
 type MethodModesExtractor struct {
 	resourceSet ResourceSet
 }
@@ -17,55 +27,54 @@ func NewMethodModesExtractor(resourceSet ResourceSet) *MethodModesExtractor {
 	}
 }
 
-// Extract implements ModesExtractor.
-func (e *MethodModesExtractor) Extract(r *http.Request) (AccessMap, error) {
+// CanHandle implements ModesExtractor.CanHandle
+func (e *MethodModesExtractor) CanHandle(operation *http.Operation) error {
+	switch operation.Method {
+	case "OPTIONS", "GET", "HEAD", "PUT", "POST", "DELETE":
+		return nil
+	case "PATCH":
+		return errors.NewUnsupportedMediaTypeError("Cannot determine permissions of a PATCH without a supported patch body", nil)
+	}
+	return errors.NewNotImplementedError("Cannot determine permissions of "+operation.Method, nil)
+}
+
+// Extract implements ModesExtractor.Extract
+func (e *MethodModesExtractor) Extract(operation *http.Operation) (AccessMap, error) {
 	requiredModes := make(AccessMap)
-	method := r.Method
-	target := r.URL.Path
+	target := operation.Target.Path
 
+	switch operation.Method {
 	// Reading requires Read permissions on the resource
-	if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions {
-		requiredModes[target] = map[AccessMode]struct{}{Read: {}}
-	}
-
-	if method == http.MethodPut {
-		exists, err := e.resourceSet.HasResource(target)
+	case "OPTIONS", "GET", "HEAD":
+		requiredModes.Add(target, Read)
+	case "PUT":
+		exists, err := e.resourceSet.HasResource(operation.Target)
 		if err != nil {
 			return nil, err
 		}
 		if exists {
 			// Replacing a resource's representation with PUT requires Write permissions
-			requiredModes[target] = map[AccessMode]struct{}{Write: {}}
+			requiredModes.Add(target, Write)
 		} else {
 			// Creating a new resource with PUT requires Append and Create permissions
-			requiredModes[target] = map[AccessMode]struct{}{Append: {}, Create: {}}
+			requiredModes.Add(target, Append, Create)
 		}
-	}
-
 	// Creating a new resource in a container requires Append access to that container
-	if method == http.MethodPost {
-		requiredModes[target] = map[AccessMode]struct{}{Append: {}}
-	}
-
+	case "POST":
+		requiredModes.Add(target, Append)
 	// Deleting a resource requires Delete access
-	if method == http.MethodDelete {
-		requiredModes[target] = map[AccessMode]struct{}{Delete: {}}
-		// If the target is a container, Read permissions are required as well
+	case "DELETE":
+		requiredModes.Add(target, Delete)
+		// If the target is a container, Read permissions are required as well,
+		// as deleting it exposes whether it is empty
 		if isContainerIdentifier(target) {
-			requiredModes[target] = map[AccessMode]struct{}{Read: {}}
+			requiredModes.Add(target, Read)
 		}
 	}
-
 	return requiredModes, nil
 }
 
-// ResourceSet is an interface for checking resource existence.
-type ResourceSet interface {
-	HasResource(path string) (bool, error)
-}
-
-// isContainerIdentifier checks if the given path is a container.
+// isContainerIdentifier checks if the given identifier is a container, i.e. ends with a slash.
 func isContainerIdentifier(path string) bool {
-	// Implement container check logic here
-	return false
+	return strings.HasSuffix(path, "/")
 }
@@ -2,10 +2,50 @@
 package permissions
 
 import (
-	"net/http"
+	"solid-go/internal/http"
+	"solid-go/internal/util/errors"
 )
 
 // ModesExtractor extracts all AccessModes necessary to execute a given Operation.
 type ModesExtractor interface {
-	Extract(r *http.Request) (AccessMap, error)
+	// CanHandle returns an error if the extractor can not determine the modes of the operation
+	CanHandle(operation *http.Operation) error
+	// Extract returns the modes required on every resource the operation touches
+	Extract(operation *http.Operation) (AccessMap, error)
+}
+
+// WaterfallModesExtractor extracts the modes with the first of its extractors that supports the operation
+type WaterfallModesExtractor struct {
+	extractors []ModesExtractor
+}
+
+// NewWaterfallModesExtractor creates a new WaterfallModesExtractor
+func NewWaterfallModesExtractor(extractors ...ModesExtractor) *WaterfallModesExtractor {
+	return &WaterfallModesExtractor{extractors: extractors}
+}
+
+// CanHandle implements ModesExtractor.CanHandle
+func (e *WaterfallModesExtractor) CanHandle(operation *http.Operation) error {
+	_, err := e.find(operation)
+	return err
+}
+
+// Extract implements ModesExtractor.Extract
+func (e *WaterfallModesExtractor) Extract(operation *http.Operation) (AccessMap, error) {
+	extractor, err := e.find(operation)
+	if err != nil {
+		return nil, err
+	}
+	return extractor.Extract(operation)
+}
+
+// find returns the first extractor that supports the operation, or the error of the last one
+func (e *WaterfallModesExtractor) find(operation *http.Operation) (ModesExtractor, error) {
+	err := errors.NewNotImplementedError("no extractor supports this operation", nil)
+	for _, extractor := range e.extractors {
+		if err = extractor.CanHandle(operation); err == nil {
+			return extractor, nil
+		}
+	}
+	return nil, err
 }
@@ -0,0 +1,154 @@
+package permissions
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"solid-go/internal/http"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/identifiers"
+	"solid-go/internal/util/n3"
+)
+
+const baseURL = "http://example.org/"
+
+type resourceSet map[string]bool
+
+func (s resourceSet) HasResource(identifier representation.ResourceIdentifier) (bool, error) {
+	return s[identifier.Path], nil
+}
+
+func operation(method, path string) *http.Operation {
+	return &http.Operation{Method: method, Target: representation.ResourceIdentifier{Path: baseURL + path}}
+}
+
+func modes(entries map[string][]AccessMode) AccessMap {
+	accessMap := make(AccessMap)
+	for path, list := range entries {
+		accessMap.Add(baseURL+path, list...)
+	}
+	return accessMap
+}
+
+func TestMethodModesExtractor(t *testing.T) {
+	extractor := NewMethodModesExtractor(resourceSet{baseURL + "doc": true})
+	tests := []struct {
+		operation *http.Operation
+		want      AccessMap
+	}{
+		{operation("GET", "doc"), modes(map[string][]AccessMode{"doc": {Read}})},
+		{operation("HEAD", "doc"), modes(map[string][]AccessMode{"doc": {Read}})},
+		{operation("PUT", "doc"), modes(map[string][]AccessMode{"doc": {Write}})},
+		{operation("PUT", "new"), modes(map[string][]AccessMode{"new": {Append, Create}})},
+		{operation("POST", ""), modes(map[string][]AccessMode{"": {Append}})},
+		{operation("DELETE", "doc"), modes(map[string][]AccessMode{"doc": {Delete}})},
+		{operation("DELETE", "container/"), modes(map[string][]AccessMode{"container/": {Delete, Read}})},
+	}
+	for _, tt := range tests {
+		if err := extractor.CanHandle(tt.operation); err != nil {
+			t.Errorf("CanHandle(%v) error = %v", tt.operation.Method, err)
+		}
+		got, err := extractor.Extract(tt.operation)
+		if err != nil {
+			t.Fatalf("Extract(%v) error = %v", tt.operation.Method, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Extract(%v %v) = %v, want %v", tt.operation.Method, tt.operation.Target.Path, got, tt.want)
+		}
+	}
+
+	if err := extractor.CanHandle(operation("PATCH", "doc")); !errors.IsUnsupportedMediaTypeError(err) {
+		t.Errorf("CanHandle(PATCH) error = %v, want UnsupportedMediaTypeError", err)
+	}
+	if err := extractor.CanHandle(operation("TRACE", "doc")); !errors.IsNotImplementedError(err) {
+		t.Errorf("CanHandle(TRACE) error = %v, want NotImplementedError", err)
+	}
+}
+
+func TestIntermediateCreateExtractor(t *testing.T) {
+	resources := resourceSet{baseURL: true, baseURL + "a/": true}
+	strategy := identifiers.NewSingleRootIdentifierStrategy(baseURL)
+	extractor := NewIntermediateCreateExtractor(NewMethodModesExtractor(resources), resources, strategy)
+
+	got, err := extractor.Extract(operation("PUT", "a/b/c/doc"))
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	want := modes(map[string][]AccessMode{"a/b/c/doc": {Append, Create}, "a/b/c/": {Create}, "a/b/": {Create}})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract() = %v, want %v", got, want)
+	}
+}
+
+func TestDeleteParentExtractor(t *testing.T) {
+	resources := resourceSet{baseURL: true, baseURL + "doc": true}
+	strategy := identifiers.NewSingleRootIdentifierStrategy(baseURL)
+	extractor := NewDeleteParentExtractor(NewMethodModesExtractor(resources), resources, strategy)
+
+	got, err := extractor.Extract(operation("DELETE", "doc"))
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if want := modes(map[string][]AccessMode{"doc": {Delete}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract() of an existing resource = %v, want %v", got, want)
+	}
+
+	got, err = extractor.Extract(operation("DELETE", "missing"))
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if want := modes(map[string][]AccessMode{"missing": {Delete}, "": {Read}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract() of a missing resource = %v, want %v", got, want)
+	}
+}
+
+func TestPatchModesExtractors(t *testing.T) {
+	resources := resourceSet{baseURL + "doc": true}
+	extractor := NewWaterfallModesExtractor(
+		NewMethodModesExtractor(resources),
+		NewN3PatchModesExtractor(resources),
+		NewSparqlUpdateModesExtractor(resources),
+	)
+	quad := n3.NewQuad(n3.NewNamedNode(baseURL+"s"), n3.NewNamedNode(baseURL+"p"), n3.NewVariable("o"), nil)
+	rep := representation.NewBasicRepresentation(nil, nil, true)
+
+	insertOnly := operation("PATCH", "new")
+	insertOnly.Body = representation.NewBasicN3Patch(rep, nil, []representation.Quad{quad}, nil)
+	deleteWhere := operation("PATCH", "doc")
+	deleteWhere.Body = representation.NewBasicN3Patch(rep, []representation.Quad{quad}, nil, []representation.Quad{quad})
+	algebra, err := n3.ParseSparqlUpdate(strings.NewReader("INSERT DATA { <s> <p> <o> }"), baseURL)
+	if err != nil {
+		t.Fatalf("ParseSparqlUpdate() error = %v", err)
+	}
+	sparql := operation("PATCH", "doc")
+	sparql.Body = representation.NewBasicSparqlUpdatePatch(rep, algebra)
+
+	tests := []struct {
+		operation *http.Operation
+		want      AccessMap
+	}{
+		{insertOnly, modes(map[string][]AccessMode{"new": {Append, Create}})},
+		{deleteWhere, modes(map[string][]AccessMode{"doc": {Read, Write}})},
+		{sparql, modes(map[string][]AccessMode{"doc": {Append}})},
+	}
+	for _, tt := range tests {
+		if err := extractor.CanHandle(tt.operation); err != nil {
+			t.Fatalf("CanHandle() error = %v", err)
+		}
+		got, err := extractor.Extract(tt.operation)
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Extract() = %v, want %v", got, tt.want)
+		}
+	}
+
+	unknown := operation("PATCH", "doc")
+	unknown.Body = rep
+	if err := extractor.CanHandle(unknown); err == nil {
+		t.Errorf("CanHandle() of an unparsed patch succeeded")
+	}
+}
@@ -2,17 +2,13 @@
 package permissions
 
 import (
-	"net/http"
+	"solid-go/internal/http"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
 )
 
-// N3Patch represents an N3 Patch document.
-type N3Patch struct {
-	Deletes    []string
-	Inserts    []string
-	Conditions []string
-}
-
 // N3PatchModesExtractor extracts required access modes from an N3 Patch.
+// The modes follow the Solid specification, §5.3.1.
 type N3PatchModesExtractor struct {
 	resourceSet ResourceSet
 }
@@ -24,45 +20,44 @@ func NewN3PatchModesExtractor(resourceSet ResourceSet) *N3PatchModesExtractor {
 	}
 }
 
-// Extract implements ModesExtractor.
-func (e *N3PatchModesExtractor) Extract(r *http.Request) (AccessMap, error) {
-	// TODO: Parse N3Patch from request body
-	var patch N3Patch
-	// For now, assume patch is parsed from r.Body
+// CanHandle implements ModesExtractor.CanHandle
+func (e *N3PatchModesExtractor) CanHandle(operation *http.Operation) error {
+	if _, ok := operation.Body.(representation.N3Patch); !ok {
+		return errors.NewNotImplementedError("Can only determine permissions of N3 Patch documents.", nil)
+	}
+	return nil
+}
+
+// Extract implements ModesExtractor.Extract
+func (e *N3PatchModesExtractor) Extract(operation *http.Operation) (AccessMap, error) {
+	patch, ok := operation.Body.(representation.N3Patch)
+	if !ok {
+		return nil, errors.NewNotImplementedError("Can only determine permissions of N3 Patch documents.", nil)
+	}
 
 	requiredModes := make(AccessMap)
-	target := r.URL.Path
+	target := operation.Target.Path
 
 	// When conditions are non-empty, treat as a Read operation
-	if len(patch.Conditions) > 0 {
-		if _, ok := requiredModes[target]; !ok {
-			requiredModes[target] = make(map[AccessMode]struct{})
-		}
-		requiredModes[target][Read] = struct{}{}
+	if len(patch.GetConditions()) > 0 {
+		requiredModes.Add(target, Read)
 	}
 
 	// When insertions are non-empty, treat as an Append operation
-	if len(patch.Inserts) > 0 {
-		if _, ok := requiredModes[target]; !ok {
-			requiredModes[target] = make(map[AccessMode]struct{})
-		}
-		requiredModes[target][Append] = struct{}{}
-		exists, err := e.resourceSet.HasResource(target)
+	if len(patch.GetInserts()) > 0 {
+		requiredModes.Add(target, Append)
+		exists, err := e.resourceSet.HasResource(operation.Target)
 		if err != nil {
 			return nil, err
 		}
 		if !exists {
-			requiredModes[target][Create] = struct{}{}
+			requiredModes.Add(target, Create)
 		}
 	}
 
 	// When deletions are non-empty, treat as a Read and Write operation
-	if len(patch.Deletes) > 0 {
-		if _, ok := requiredModes[target]; !ok {
-			requiredModes[target] = make(map[AccessMode]struct{})
-		}
-		requiredModes[target][Read] = struct{}{}
-		requiredModes[target][Write] = struct{}{}
+	if len(patch.GetDeletes()) > 0 {
+		requiredModes.Add(target, Read, Write)
 	}
 
 	return requiredModes, nil
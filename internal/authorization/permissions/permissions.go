@@ -17,8 +17,25 @@ const (
 	Delete AccessMode = "delete"
 )
 
-// AccessMap maps identifiers to a set of AccessModes.
-type AccessMap map[string]map[AccessMode]struct{}
+// AccessMap maps identifiers to the AccessModes required on them.
+type AccessMap map[string]PermissionSet
+
+// Add adds the modes to the set of the identifier
+func (m AccessMap) Add(identifier string, modes ...AccessMode) {
+	set, ok := m[identifier]
+	if !ok {
+		set = NewPermissionSet()
+		m[identifier] = set
+	}
+	for _, mode := range modes {
+		set.Add(mode)
+	}
+}
+
+// Has checks if the set of the identifier contains the mode
+func (m AccessMap) Has(identifier string, mode AccessMode) bool {
+	return m[identifier].Has(mode)
+}
 
 // PermissionSet represents a set of permissions
 // It maps access modes to boolean values indicating whether the permission is granted
@@ -2,14 +2,12 @@
 package permissions
 
 import (
-	"net/http"
+	"solid-go/internal/http"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
 )
 
-// SparqlUpdatePatch represents a SPARQL update patch.
-type SparqlUpdatePatch struct {
-	Algebra interface{} // TODO: Define proper SPARQL algebra type
-}
-
 // SparqlUpdateModesExtractor extracts required access modes from a SPARQL DELETE/INSERT body.
 type SparqlUpdateModesExtractor struct {
 	resourceSet ResourceSet
@@ -22,73 +20,84 @@ func NewSparqlUpdateModesExtractor(resourceSet ResourceSet) *SparqlUpdateModesEx
 	}
 }
 
-// Extract implements ModesExtractor.
-func (e *SparqlUpdateModesExtractor) Extract(r *http.Request) (AccessMap, error) {
-	// TODO: Parse SparqlUpdatePatch from request body
-	var patch SparqlUpdatePatch
-	// For now, assume patch is parsed from r.Body
+// CanHandle implements ModesExtractor.CanHandle
+func (e *SparqlUpdateModesExtractor) CanHandle(operation *http.Operation) error {
+	if _, ok := operation.Body.(representation.SparqlUpdatePatch); !ok {
+		return errors.NewNotImplementedError("Cannot determine permissions of non-SPARQL patches.", nil)
+	}
+	return nil
+}
+
+// Extract implements ModesExtractor.Extract
+func (e *SparqlUpdateModesExtractor) Extract(operation *http.Operation) (AccessMap, error) {
+	patch, ok := operation.Body.(representation.SparqlUpdatePatch)
+	if !ok {
+		return nil, errors.NewNotImplementedError("Cannot determine permissions of non-SPARQL patches.", nil)
+	}
+	algebra := patch.Algebra()
 
 	requiredModes := make(AccessMap)
-	target := r.URL.Path
+	target := operation.Target.Path
 
 	// Check if the update is a NOP
-	if e.isNop(patch.Algebra) {
+	if e.isNop(algebra) {
 		return requiredModes, nil
 	}
 
 	// Access modes inspired by the requirements on N3 Patch requests
-	if e.hasConditions(patch.Algebra) {
-		if _, ok := requiredModes[target]; !ok {
-			requiredModes[target] = make(map[AccessMode]struct{})
-		}
-		requiredModes[target][Read] = struct{}{}
+	if e.hasConditions(algebra) {
+		requiredModes.Add(target, Read)
 	}
 
-	if e.hasInserts(patch.Algebra) {
-		if _, ok := requiredModes[target]; !ok {
-			requiredModes[target] = make(map[AccessMode]struct{})
-		}
-		requiredModes[target][Append] = struct{}{}
-		exists, err := e.resourceSet.HasResource(target)
+	if e.hasInserts(algebra) {
+		requiredModes.Add(target, Append)
+		exists, err := e.resourceSet.HasResource(operation.Target)
 		if err != nil {
 			return nil, err
 		}
 		if !exists {
-			requiredModes[target][Create] = struct{}{}
+			requiredModes.Add(target, Create)
 		}
 	}
 
-	if e.hasDeletes(patch.Algebra) {
-		if _, ok := requiredModes[target]; !ok {
-			requiredModes[target] = make(map[AccessMode]struct{})
-		}
-		requiredModes[target][Read] = struct{}{}
-		requiredModes[target][Write] = struct{}{}
+	if e.hasDeletes(algebra) {
+		requiredModes.Add(target, Read, Write)
 	}
 
 	return requiredModes, nil
 }
 
-// isNop checks if the update is a NOP.
-func (e *SparqlUpdateModesExtractor) isNop(algebra interface{}) bool {
-	// TODO: Implement NOP check
-	return false
+// isNop checks if the update has no operations.
+func (e *SparqlUpdateModesExtractor) isNop(algebra *n3.SparqlUpdate) bool {
+	return algebra == nil || len(algebra.Operations) == 0
 }
 
-// hasConditions checks if the update has conditions.
-func (e *SparqlUpdateModesExtractor) hasConditions(algebra interface{}) bool {
-	// TODO: Implement conditions check
+// hasConditions checks if the update reads the resource to find the solutions of a WHERE pattern.
+func (e *SparqlUpdateModesExtractor) hasConditions(algebra *n3.SparqlUpdate) bool {
+	for _, operation := range algebra.Operations {
+		if operation.Type == n3.DeleteInsert && len(operation.Where) > 0 {
+			return true
+		}
+	}
 	return false
 }
 
 // hasInserts checks if the update has insertions.
-func (e *SparqlUpdateModesExtractor) hasInserts(algebra interface{}) bool {
-	// TODO: Implement insertions check
+func (e *SparqlUpdateModesExtractor) hasInserts(algebra *n3.SparqlUpdate) bool {
+	for _, operation := range algebra.Operations {
+		if len(operation.Insert) > 0 {
+			return true
+		}
+	}
 	return false
 }
 
 // hasDeletes checks if the update has deletions.
-func (e *SparqlUpdateModesExtractor) hasDeletes(algebra interface{}) bool {
-	// TODO: Implement deletions check
+func (e *SparqlUpdateModesExtractor) hasDeletes(algebra *n3.SparqlUpdate) bool {
+	for _, operation := range algebra.Operations {
+		if len(operation.Delete) > 0 {
+			return true
+		}
+	}
 	return false
 }
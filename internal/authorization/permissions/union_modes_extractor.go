@@ -1,10 +1,12 @@
 // Package permissions provides implementations for extracting required permissions from HTTP requests.
 package permissions
 
-import "net/http"
+import (
+	"solid-go/internal/http"
+	"solid-go/internal/util/errors"
+)
 
-// UnionModesExtractor combines permissions from multiple extractors.
-// It extracts permissions from each extractor and combines them into a single set.
+// UnionModesExtractor combines the modes of all its extractors that support the operation.
 type UnionModesExtractor struct {
 	extractors []ModesExtractor
 }
@@ -16,29 +18,34 @@ func NewUnionModesExtractor(extractors ...ModesExtractor) *UnionModesExtractor {
 	}
 }
 
-// Extract implements ModesExtractor.
-// It extracts permissions from each extractor and combines them into a single set.
-func (e *UnionModesExtractor) Extract(r *http.Request) PermissionSet {
-	// Create a new permission set
-	perms := NewACLPermissionSet()
-
-	// Extract permissions from each extractor
+// CanHandle implements ModesExtractor.CanHandle.
+// The union supports an operation if any of its extractors does.
+func (e *UnionModesExtractor) CanHandle(operation *http.Operation) error {
+	err := errors.NewNotImplementedError("no extractor supports this operation", nil)
 	for _, extractor := range e.extractors {
-		extractorPerms := extractor.Extract(r)
-		// Add all permissions from this extractor
-		for _, mode := range []Permission{
-			Read,
-			Write,
-			Append,
-			Control,
-		} {
-			if extractorPerms.Has(mode) {
-				perms.Add(mode)
-			}
+		if err = extractor.CanHandle(operation); err == nil {
+			return nil
 		}
 	}
+	return err
+}
 
-	return perms
+// Extract implements ModesExtractor.Extract
+func (e *UnionModesExtractor) Extract(operation *http.Operation) (AccessMap, error) {
+	result := make(AccessMap)
+	for _, extractor := range e.extractors {
+		if extractor.CanHandle(operation) != nil {
+			continue
+		}
+		modes, err := extractor.Extract(operation)
+		if err != nil {
+			return nil, err
+		}
+		for path, set := range modes {
+			result.Add(path, set.GetPermissions()...)
+		}
+	}
+	return result, nil
 }
 
 // AddExtractor adds a new extractor to the union.
@@ -46,42 +53,7 @@ func (e *UnionModesExtractor) AddExtractor(extractor ModesExtractor) {
 	e.extractors = append(e.extractors, extractor)
 }
 
-// RemoveExtractor removes an extractor from the union.
-func (e *UnionModesExtractor) RemoveExtractor(extractor ModesExtractor) {
-	for i, ex := range e.extractors {
-		if ex == extractor {
-			e.extractors = append(e.extractors[:i], e.extractors[i+1:]...)
-			break
-		}
-	}
-}
-
 // GetExtractors gets all extractors in the union.
 func (e *UnionModesExtractor) GetExtractors() []ModesExtractor {
 	return e.extractors
 }
-
-// ClearExtractors removes all extractors from the union.
-func (e *UnionModesExtractor) ClearExtractors() {
-	e.extractors = nil
-}
-
-// GetRequiredPermissions gets the required permissions from all extractors.
-func (e *UnionModesExtractor) GetRequiredPermissions(r *http.Request) PermissionSet {
-	return e.Extract(r)
-}
-
-// HasExtractor checks if the union contains a specific extractor.
-func (e *UnionModesExtractor) HasExtractor(extractor ModesExtractor) bool {
-	for _, ex := range e.extractors {
-		if ex == extractor {
-			return true
-		}
-	}
-	return false
-}
-
-// GetExtractorCount gets the number of extractors in the union.
-func (e *UnionModesExtractor) GetExtractorCount() int {
-	return len(e.extractors)
-}
@@ -0,0 +1,57 @@
+// Package authorization provides implementations for reading the permissions of dependent auxiliary resources.
+package authorization
+
+import (
+	"solid-go/internal/authorization/permissions"
+	"solid-go/internal/http/auxiliary"
+	"solid-go/internal/http/representation"
+)
+
+// SubjectAuxiliaryReader determines the permissions of auxiliary resources that are not authorized on their own,
+// such as description resources, which have the same permissions as their subject resource.
+type SubjectAuxiliaryReader struct {
+	reader            PermissionReader
+	auxiliaryStrategy auxiliary.AuxiliaryStrategy
+}
+
+// NewSubjectAuxiliaryReader creates a new SubjectAuxiliaryReader with the given reader.
+func NewSubjectAuxiliaryReader(reader PermissionReader, auxiliaryStrategy auxiliary.AuxiliaryStrategy) *SubjectAuxiliaryReader {
+	return &SubjectAuxiliaryReader{
+		reader:            reader,
+		auxiliaryStrategy: auxiliaryStrategy,
+	}
+}
+
+// Read implements PermissionReader.
+// The dependent auxiliary resources are replaced by their subjects when reading the permissions.
+func (r *SubjectAuxiliaryReader) Read(input PermissionReaderInput) (map[string]permissions.PermissionSet, error) {
+	subjects := make(map[string]string)
+	requested := make(map[string]permissions.PermissionSet)
+	for identifier, modes := range input.RequestedModes {
+		id := representation.ResourceIdentifier{Path: identifier}
+		if !r.auxiliaryStrategy.IsAuxiliaryIdentifier(id) || r.auxiliaryStrategy.UsesOwnAuthorization(id) {
+			requested[identifier] = modes.Union(requested[identifier])
+			continue
+		}
+		subject, err := r.auxiliaryStrategy.GetSubjectIdentifier(id)
+		if err != nil {
+			return nil, err
+		}
+		subjects[identifier] = subject.Path
+		requested[subject.Path] = modes.Union(requested[subject.Path])
+	}
+
+	result, err := r.reader.Read(PermissionReaderInput{Credentials: input.Credentials, RequestedModes: requested})
+	if err != nil {
+		return nil, err
+	}
+	for identifier, subject := range subjects {
+		// Copied explicitly, since a union of the sets would turn denied modes into granted ones
+		permissionSet := permissions.NewPermissionSet()
+		for mode, granted := range result[subject] {
+			permissionSet[mode] = granted
+		}
+		result[identifier] = permissionSet
+	}
+	return result, nil
+}
@@ -58,7 +58,7 @@ func (r *UnionPermissionReader) mergePermissionMaps(permissionMap, result map[st
 func (r *UnionPermissionReader) mergePermissions(permissions, result permissions.PermissionSet) {
 	for mode, value := range permissions {
 		// Only update if the current value is not false
-		if !result.Has(mode) || !result[mode] {
+		if current, ok := result[mode]; !ok || current {
 			result[mode] = value
 		}
 	}
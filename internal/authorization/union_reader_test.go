@@ -74,7 +74,7 @@ func TestUnionPermissionReader(t *testing.T) {
 					modes: map[string]permissions.PermissionSet{
 						"https://example.org/resource": func() permissions.PermissionSet {
 							ps := permissions.NewPermissionSet()
-							ps.Remove(permissions.Read)
+							ps[permissions.Read] = false
 							return ps
 						}(),
 					},
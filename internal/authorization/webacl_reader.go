@@ -2,124 +2,192 @@
 package authorization
 
 import (
-	"context"
-	"encoding/json"
-	"path/filepath"
+	"io"
+	"strings"
 
+	"solid-go/internal/authentication"
+	"solid-go/internal/authorization/access"
 	"solid-go/internal/authorization/permissions"
+	"solid-go/internal/http/auxiliary"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
 )
 
-// Storage interface for reading ACL data
-type Storage interface {
-	Get(ctx context.Context, path string) ([]byte, error)
+// IdentifierStrategy determines the containers of the identifiers in the storage
+type IdentifierStrategy interface {
+	IsRootContainer(identifier representation.ResourceIdentifier) bool
+	GetParentContainer(identifier representation.ResourceIdentifier) (representation.ResourceIdentifier, error)
 }
 
-// WebACL represents a WebACL document
-type WebACL struct {
-	AccessTo      []string `json:"accessTo"`
-	Default       []string `json:"default,omitempty"`
-	AccessToClass []string `json:"accessToClass,omitempty"`
-	Mode          []string `json:"mode"`
-	Agent         []string `json:"agent,omitempty"`
-	AgentClass    []string `json:"agentClass,omitempty"`
-	AgentGroup    []string `json:"agentGroup,omitempty"`
-}
-
-// WebACLReader reads permissions from WebACL files.
-// It parses WebACL documents to determine permissions for resources.
+// WebACLReader determines permissions with the Web Access Control (WAC) documents of the storage.
+// The authorizations of a resource are the acl:accessTo rules of its own ACL document.
+// A resource without ACL document inherits the acl:default rules of the ACL document of its nearest ancestor.
+// Every rule grants its modes to the agents accepted by the access checker.
 type WebACLReader struct {
-	storage Storage
+	aclStrategy        auxiliary.AuxiliaryIdentifierStrategy
+	aclStore           storage.ResourceStore
+	identifierStrategy IdentifierStrategy
+	accessChecker      access.AccessChecker
 }
 
-// NewWebACLReader creates a new WebACLReader with the given storage.
-func NewWebACLReader(storage Storage) *WebACLReader {
+// NewWebACLReader creates a new WebACLReader that reads the ACL documents from the store
+func NewWebACLReader(aclStrategy auxiliary.AuxiliaryIdentifierStrategy, aclStore storage.ResourceStore,
+	identifierStrategy IdentifierStrategy, accessChecker access.AccessChecker) *WebACLReader {
 	return &WebACLReader{
-		storage: storage,
+		aclStrategy:        aclStrategy,
+		aclStore:           aclStore,
+		identifierStrategy: identifierStrategy,
+		accessChecker:      accessChecker,
 	}
 }
 
 // Read implements PermissionReader.
-// It reads and parses the WebACL file for the given resource.
-func (r *WebACLReader) Read(ctx context.Context, resource string) (permissions.PermissionSet, error) {
-	// Get the ACL file path
-	aclPath := r.getACLPath(resource)
-
-	// Read the ACL file
-	data, err := r.storage.Get(ctx, aclPath)
-	if err != nil {
-		return permissions.NewACLPermissionSet(), err
+// Every ACL document is read at most once per call, since resources often share an ancestor.
+func (r *WebACLReader) Read(input PermissionReaderInput) (map[string]permissions.PermissionSet, error) {
+	credentials := input.Credentials
+	if credentials == nil {
+		credentials = &authentication.Credentials{}
 	}
-
-	// Parse the ACL document
-	var acl WebACL
-	if err := json.Unmarshal(data, &acl); err != nil {
-		return permissions.NewACLPermissionSet(), err
+	documents := make(map[string]*n3.BasicStore)
+	result := make(map[string]permissions.PermissionSet, len(input.RequestedModes))
+	for identifier := range input.RequestedModes {
+		rules, err := r.findRules(representation.ResourceIdentifier{Path: identifier}, documents)
+		if err != nil {
+			return nil, err
+		}
+		if result[identifier], err = r.determinePermissions(rules, credentials); err != nil {
+			return nil, err
+		}
 	}
+	return result, nil
+}
 
-	// Create a new permission set
-	perms := permissions.NewACLPermissionSet()
-
-	// Add permissions based on the ACL document
-	for _, mode := range acl.Mode {
-		switch mode {
-		case "Read":
-			perms.Add(permissions.Read)
-		case "Write":
-			perms.Add(permissions.Write)
-		case "Append":
-			perms.Add(permissions.Append)
-		case "Control":
-			perms.Add(permissions.Control)
+// findRules returns the authorizations that apply to the target,
+// walking up the container hierarchy until an ACL document is found
+func (r *WebACLReader) findRules(target representation.ResourceIdentifier,
+	documents map[string]*n3.BasicStore) (*n3.BasicStore, error) {
+	identifier := target
+	for {
+		acl, err := r.readAcl(r.aclStrategy.GetAuxiliaryIdentifier(identifier), documents)
+		if err != nil {
+			return nil, err
+		}
+		if acl != nil {
+			// WAC, §5: the rules of the own ACL document apply through acl:accessTo, inherited rules through acl:default
+			predicate := vocabularies.ACL.Default
+			if identifier.Path == target.Path {
+				predicate = vocabularies.ACL.AccessTo
+			}
+			return filterRules(acl, predicate, identifier), nil
+		}
+		// WAC, §5.1: the root container always has an ACL document, so there always is an authorization to inherit
+		if r.identifierStrategy.IsRootContainer(identifier) {
+			return nil, errors.NewInternalError("no ACL document found for the root container "+identifier.Path, nil)
+		}
+		if identifier, err = r.identifierStrategy.GetParentContainer(identifier); err != nil {
+			return nil, err
 		}
 	}
-
-	return perms, nil
 }
 
-// getACLPath returns the path to the ACL file for a resource.
-func (r *WebACLReader) getACLPath(resource string) string {
-	return filepath.Join(filepath.Dir(resource), ".acl")
+// readAcl parses the ACL document with the given identifier, returning nil if it does not exist
+func (r *WebACLReader) readAcl(identifier representation.ResourceIdentifier,
+	documents map[string]*n3.BasicStore) (*n3.BasicStore, error) {
+	return readAuthorizationDocument(r.aclStore, identifier, documents)
 }
 
-// GetPermissions gets permissions for a resource and agent
-func (r *WebACLReader) GetPermissions(ctx context.Context, resource, agent string) (permissions.PermissionSet, error) {
-	// Read ACL document
-	acl, err := r.Read(ctx, resource)
+// readAuthorizationDocument parses the RDF document with the given identifier, returning nil if it does not exist.
+// The parsed documents are cached in the map.
+func readAuthorizationDocument(store storage.ResourceStore, identifier representation.ResourceIdentifier,
+	documents map[string]*n3.BasicStore) (*n3.BasicStore, error) {
+	if document, ok := documents[identifier.Path]; ok {
+		return document, nil
+	}
+	preferences := &representation.RepresentationPreferences{Type: representation.ValuePreferences{util.Turtle: 1}}
+	rep, err := store.GetRepresentation(identifier, preferences, nil)
 	if err != nil {
+		if errors.IsNotFoundError(err) {
+			documents[identifier.Path] = nil
+			return nil, nil
+		}
 		return nil, err
 	}
+	data := rep.GetData()
+	if closer, ok := data.(io.Closer); ok {
+		defer closer.Close()
+	}
+	format, ok := n3.FormatFromContentType(rep.GetMetadata().ContentType())
+	if !ok {
+		format = n3.FormatTurtle
+	}
+	document, err := n3.NewParser(n3.ParserOptions{Format: format, BaseIRI: identifier.Path}).ParseToStore(data)
+	if err != nil {
+		return nil, errors.NewInternalError("the authorization document "+identifier.Path+" is not valid RDF", err)
+	}
+	documents[identifier.Path] = document
+	return document, nil
+}
 
-	// Create permission set
-	perms := permissions.NewACLPermissionSet()
-
-	// Check if agent has access
-	if !contains(acl.Agent, agent) {
-		return perms, nil
+// filterRules returns the triples of the rules that target the identifier with the predicate
+func filterRules(acl *n3.BasicStore, predicate n3.Term, identifier representation.ResourceIdentifier) *n3.BasicStore {
+	rules := n3.NewBasicStore()
+	for _, rule := range acl.GetSubjects(predicate, n3.NewNamedNode(identifier.Path), nil) {
+		rules.AddQuads(acl.GetQuads(rule, nil, nil, nil))
 	}
+	return rules
+}
 
-	// Add permissions based on modes
-	for _, mode := range acl.Mode {
-		switch mode {
-		case "Read":
-			perms.Add(permissions.Read)
-		case "Write":
-			perms.Add(permissions.Write)
-		case "Append":
-			perms.Add(permissions.Append)
-		case "Control":
-			perms.Add(permissions.Control)
+// determinePermissions grants the modes of every authorization the credentials match
+func (r *WebACLReader) determinePermissions(rules *n3.BasicStore,
+	credentials *authentication.Credentials) (permissions.PermissionSet, error) {
+	result := permissions.NewACLPermissionSet()
+	for _, rule := range rules.GetSubjects(vocabularies.RDF.Type, vocabularies.ACL.Authorization, nil) {
+		allowed, err := r.accessChecker.Handle(access.AccessCheckerArgs{ACL: rules, Rule: rule, Credentials: credentials})
+		if err != nil {
+			return nil, err
+		}
+		if !allowed || !matchesOrigin(rules, rule, credentials.Origin()) {
+			continue
+		}
+		for _, mode := range rules.GetObjects(rule, vocabularies.ACL.Mode, nil) {
+			grantAclMode(result, mode)
 		}
 	}
-
-	return perms, nil
+	return result, nil
 }
 
-// Helper function to check if a string is in a slice
-func contains(slice []string, str string) bool {
-	for _, s := range slice {
-		if s == str {
+// matchesOrigin checks whether the rule applies to the web application the request comes from.
+// Rules with acl:origin only apply to the listed origins,
+// while requests without origin do not come from a browser and are not restricted.
+func matchesOrigin(rules *n3.BasicStore, rule n3.Term, origin string) bool {
+	origins := rules.GetObjects(rule, vocabularies.ACL.Origin, nil)
+	if len(origins) == 0 || origin == "" {
+		return true
+	}
+	for _, allowed := range origins {
+		if strings.EqualFold(strings.TrimSuffix(allowed.Value(), "/"), origin) {
 			return true
 		}
 	}
 	return false
 }
+
+// grantAclMode adds the access modes corresponding to the ACL mode IRI to the set
+func grantAclMode(set permissions.PermissionSet, mode n3.Term) {
+	switch mode.Value() {
+	case vocabularies.ACL.Read.Value():
+		set.Add(permissions.Read)
+	case vocabularies.ACL.Write.Value():
+		// WAC, §4.2: acl:Write implies acl:Append
+		set.Add(permissions.Write)
+		set.Add(permissions.Append)
+	case vocabularies.ACL.Append.Value():
+		set.Add(permissions.Append)
+	case vocabularies.ACL.Control.Value():
+		set.Add(permissions.Control)
+	}
+}
@@ -1,259 +1,166 @@
 package authorization
 
 import (
-	"context"
-	"encoding/json"
-	"errors"
-	"solid-go/internal/authorization/permissions"
+	"strings"
 	"testing"
+
+	"solid-go/internal/authentication"
+	"solid-go/internal/authorization/access"
+	"solid-go/internal/authorization/permissions"
+	"solid-go/internal/http/auxiliary"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage"
+	"solid-go/internal/util"
+	"solid-go/internal/util/identifiers"
 )
 
-type mockStorage struct {
-	data map[string][]byte
-	err  error
-}
+const aclBaseURL = "http://example.org/"
 
-func (m *mockStorage) Get(path string) ([]byte, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	if data, exists := m.data[path]; exists {
-		return data, nil
-	}
-	return nil, errors.New("not found")
-}
+const aclPrefixes = `@prefix acl: <http://www.w3.org/ns/auth/acl#>.
+@prefix foaf: <http://xmlns.com/foaf/0.1/>.
+`
 
-func (m *mockStorage) Delete(path string) error {
-	return nil
+// newAclReader creates a WebACLReader on a store containing the given ACL documents
+func newAclReader(t *testing.T, documents map[string]string) *WebACLReader {
+	t.Helper()
+	store := storage.NewDataAccessorBasedStore(storage.NewInMemoryDataAccessor(aclBaseURL), aclBaseURL)
+	for path, acl := range documents {
+		metadata := representation.NewRepresentationMetadata("").SetContentType(util.Turtle)
+		rep := representation.NewBasicRepresentation(strings.NewReader(aclPrefixes+acl), metadata, true)
+		if _, err := store.SetRepresentation(representation.ResourceIdentifier{Path: aclBaseURL + path}, rep, nil); err != nil {
+			t.Fatalf("SetRepresentation(%v) error = %v", path, err)
+		}
+	}
+	checker := access.NewUnionAccessChecker(access.NewAgentAccessChecker(), access.NewAgentClassAccessChecker())
+	return NewWebACLReader(&auxiliary.SuffixAuxiliaryIdentifierStrategy{Suffix: auxiliary.AclSuffix}, store,
+		identifiers.NewSingleRootIdentifierStrategy(aclBaseURL), checker)
 }
 
-func (m *mockStorage) Exists(path string) bool {
-	_, exists := m.data[path]
-	return exists
+// readModes returns the permissions of the agent on the resource, the public if the WebID is empty
+func readModes(t *testing.T, reader PermissionReader, path, webID string) permissions.PermissionSet {
+	t.Helper()
+	credentials := &authentication.Credentials{}
+	if webID != "" {
+		credentials.Agent = &authentication.Agent{WebID: webID}
+	}
+	result, err := reader.Read(PermissionReaderInput{
+		Credentials:    credentials,
+		RequestedModes: map[string]permissions.PermissionSet{aclBaseURL + path: {permissions.Read: true}},
+	})
+	if err != nil {
+		t.Fatalf("Read(%v) error = %v", path, err)
+	}
+	return result[aclBaseURL+path]
 }
 
-func (m *mockStorage) Put(path string, data []byte) error {
-	if m.err != nil {
-		return m.err
+// modeString lists the granted modes in a fixed order
+func modeString(set permissions.PermissionSet) string {
+	var modes []string
+	for _, mode := range []permissions.AccessMode{permissions.Read, permissions.Append, permissions.Write, permissions.Control} {
+		if set[mode] {
+			modes = append(modes, string(mode))
+		}
 	}
-	m.data[path] = data
-	return nil
+	return strings.Join(modes, " ")
 }
 
 func TestWebACLReader(t *testing.T) {
+	alice, bob := "https://alice.example/profile#me", "https://bob.example/profile#me"
+	reader := newAclReader(t, map[string]string{
+		".acl": `
+<#public> a acl:Authorization; acl:agentClass foaf:Agent; acl:accessTo <./>; acl:mode acl:Read.
+<#owner> a acl:Authorization; acl:agent <` + alice + `>; acl:accessTo <./>; acl:default <./>; acl:mode acl:Read, acl:Write, acl:Control.
+<#members> a acl:Authorization; acl:agentClass acl:AuthenticatedAgent; acl:default <./>; acl:mode acl:Append.`,
+		"shared/.acl": `
+<#bob> a acl:Authorization; acl:agent <` + bob + `>; acl:default <./>; acl:mode acl:Read.
+<#notARule> acl:agent <` + bob + `>; acl:accessTo <./>; acl:mode acl:Write.`,
+	})
+
 	tests := []struct {
-		name          string
-		storage       *mockStorage
-		resource      string
-		expectedModes permissions.PermissionSet
-		expectError   bool
+		name, path, webID, want string
 	}{
-		{
-			name: "Valid ACL File",
-			storage: &mockStorage{
-				data: map[string][]byte{
-					"/path/to/.acl": func() []byte {
-						acl := WebACL{
-							Mode:  []string{"Read", "Write"},
-							Agent: []string{"https://example.org/agent"},
-						}
-						data, _ := json.Marshal(acl)
-						return data
-					}(),
-				},
-			},
-			resource: "/path/to/resource",
-			expectedModes: func() permissions.PermissionSet {
-				ps := permissions.NewPermissionSet()
-				ps.Add(permissions.Read)
-				ps.Add(permissions.Write)
-				return ps
-			}(),
-			expectError: false,
-		},
-		{
-			name: "Missing ACL File",
-			storage: &mockStorage{
-				data: map[string][]byte{},
-			},
-			resource:      "/path/to/resource",
-			expectedModes: permissions.NewPermissionSet(),
-			expectError:   true,
-		},
-		{
-			name: "Invalid ACL File Content",
-			storage: &mockStorage{
-				data: map[string][]byte{
-					"/path/to/.acl": []byte("invalid json"),
-				},
-			},
-			resource:      "/path/to/resource",
-			expectedModes: permissions.NewPermissionSet(),
-			expectError:   true,
-		},
-		{
-			name: "All Access Modes",
-			storage: &mockStorage{
-				data: map[string][]byte{
-					"/path/to/.acl": func() []byte {
-						acl := WebACL{
-							Mode:  []string{"Read", "Write", "Append"},
-							Agent: []string{"https://example.org/agent"},
-						}
-						data, _ := json.Marshal(acl)
-						return data
-					}(),
-				},
-			},
-			resource: "/path/to/resource",
-			expectedModes: func() permissions.PermissionSet {
-				ps := permissions.NewPermissionSet()
-				ps.Add(permissions.Read)
-				ps.Add(permissions.Write)
-				ps.Add(permissions.Append)
-				return ps
-			}(),
-			expectError: false,
-		},
-		{
-			name: "Storage Error",
-			storage: &mockStorage{
-				err: errors.New("storage error"),
-			},
-			resource:      "/path/to/resource",
-			expectedModes: permissions.NewPermissionSet(),
-			expectError:   true,
-		},
+		{"accessTo rules of the own ACL", "", "", "read"},
+		{"multiple matching rules", "", alice, "read append write control"},
+		{"default rules do not apply to the resource itself", "", bob, "read"},
+		{"inherited default rules", "doc", alice, "read append write control"},
+		{"authenticated agents", "a/b/doc", bob, "append"},
+		{"accessTo rules are not inherited", "doc", "", ""},
+		{"nearest ACL without accessTo rules", "shared/", bob, ""},
+		{"nearest ACL replaces the ancestor rules", "shared/doc", alice, ""},
+		{"default rules of the nearest ACL", "shared/doc", bob, "read"},
 	}
-
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			reader := NewWebACLReader(tt.storage)
-			ctx := context.Background()
-
-			result, err := reader.Read(ctx, tt.resource)
-			if tt.expectError {
-				if err == nil {
-					t.Error("Read() expected error, got nil")
-				}
-				return
-			}
-			if err != nil {
-				t.Errorf("Read() error = %v", err)
-				return
-			}
-
-			// Compare each permission
-			for _, mode := range []permissions.AccessMode{
-				permissions.Read,
-				permissions.Write,
-				permissions.Append,
-			} {
-				expected := tt.expectedModes.Has(mode)
-				actual := result.Has(mode)
-				if expected != actual {
-					t.Errorf("Read() permission %v = %v, want %v",
-						mode, actual, expected)
-				}
+			if got := modeString(readModes(t, reader, tt.path, tt.webID)); got != tt.want {
+				t.Errorf("modes of %v on %q = %q, want %q", tt.webID, tt.path, got, tt.want)
 			}
 		})
 	}
 }
 
-func TestWebACLReaderGetPermissions(t *testing.T) {
+func TestWebACLReader_Origin(t *testing.T) {
+	alice := "https://alice.example/profile#me"
+	reader := newAclReader(t, map[string]string{
+		".acl": `
+<#public> a acl:Authorization; acl:agentClass foaf:Agent; acl:accessTo <./>; acl:mode acl:Read.
+<#app> a acl:Authorization; acl:agent <` + alice + `>; acl:origin <https://app.example>; acl:accessTo <./>; acl:mode acl:Write.`,
+	})
+
 	tests := []struct {
-		name          string
-		storage       *mockStorage
-		resource      string
-		agent         string
-		expectedModes permissions.PermissionSet
-		expectError   bool
+		name, origin, want string
 	}{
-		{
-			name: "Agent Has Access",
-			storage: &mockStorage{
-				data: map[string][]byte{
-					"/path/to/.acl": func() []byte {
-						acl := WebACL{
-							Mode:  []string{"Read", "Write"},
-							Agent: []string{"https://example.org/agent"},
-						}
-						data, _ := json.Marshal(acl)
-						return data
-					}(),
-				},
-			},
-			resource: "/path/to/resource",
-			agent:    "https://example.org/agent",
-			expectedModes: func() permissions.PermissionSet {
-				ps := permissions.NewPermissionSet()
-				ps.Add(permissions.Read)
-				ps.Add(permissions.Write)
-				return ps
-			}(),
-			expectError: false,
-		},
-		{
-			name: "Agent No Access",
-			storage: &mockStorage{
-				data: map[string][]byte{
-					"/path/to/.acl": func() []byte {
-						acl := WebACL{
-							Mode:  []string{"Read", "Write"},
-							Agent: []string{"https://example.org/other-agent"},
-						}
-						data, _ := json.Marshal(acl)
-						return data
-					}(),
-				},
-			},
-			resource:      "/path/to/resource",
-			agent:         "https://example.org/agent",
-			expectedModes: permissions.NewPermissionSet(),
-			expectError:   false,
-		},
-		{
-			name: "Missing ACL File",
-			storage: &mockStorage{
-				data: map[string][]byte{},
-			},
-			resource:      "/path/to/resource",
-			agent:         "https://example.org/agent",
-			expectedModes: permissions.NewPermissionSet(),
-			expectError:   true,
-		},
+		{"requests without origin", "", "read append write"},
+		{"listed origin", "https://app.example", "read append write"},
+		{"other origin", "https://evil.example", "read"},
 	}
-
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			reader := NewWebACLReader(tt.storage)
-			ctx := context.Background()
-
-			result, err := reader.GetPermissions(ctx, tt.resource, tt.agent)
-			if tt.expectError {
-				if err == nil {
-					t.Error("GetPermissions() expected error, got nil")
-				}
-				return
-			}
+			result, err := reader.Read(PermissionReaderInput{
+				Credentials: &authentication.Credentials{
+					Agent:  &authentication.Agent{WebID: alice},
+					Client: &authentication.Client{Origin: tt.origin},
+				},
+				RequestedModes: map[string]permissions.PermissionSet{aclBaseURL: {permissions.Read: true}},
+			})
 			if err != nil {
-				t.Errorf("GetPermissions() error = %v", err)
-				return
+				t.Fatalf("Read() error = %v", err)
 			}
-
-			// Compare each permission
-			for _, mode := range []permissions.AccessMode{
-				permissions.Read,
-				permissions.Write,
-				permissions.Append,
-			} {
-				expected := tt.expectedModes.Has(mode)
-				actual := result.Has(mode)
-				if expected != actual {
-					t.Errorf("GetPermissions() permission %v = %v, want %v",
-						mode, actual, expected)
-				}
+			if got := modeString(result[aclBaseURL]); got != tt.want {
+				t.Errorf("modes with origin %q = %q, want %q", tt.origin, got, tt.want)
 			}
 		})
 	}
 }
+
+func TestWebACLReader_Errors(t *testing.T) {
+	reader := newAclReader(t, map[string]string{})
+	if _, err := reader.Read(PermissionReaderInput{
+		RequestedModes: map[string]permissions.PermissionSet{aclBaseURL + "doc": {permissions.Read: true}},
+	}); err == nil {
+		t.Errorf("Read() without root ACL succeeded")
+	}
+
+	reader = newAclReader(t, map[string]string{"doc.acl": "<#rule> acl:mode"})
+	if _, err := reader.Read(PermissionReaderInput{
+		RequestedModes: map[string]permissions.PermissionSet{aclBaseURL + "doc": {permissions.Read: true}},
+	}); err == nil {
+		t.Errorf("Read() with an invalid ACL succeeded")
+	}
+}
+
+func TestSubjectAuxiliaryReader(t *testing.T) {
+	reader := newAclReader(t, map[string]string{
+		".acl":    `<#public> a acl:Authorization; acl:agentClass foaf:Agent; acl:default <./>; acl:mode acl:Append.`,
+		"doc.acl": `<#public> a acl:Authorization; acl:agentClass foaf:Agent; acl:accessTo <doc>; acl:mode acl:Read.`,
+	})
+	description := auxiliary.NewComposedAuxiliaryStrategy(&auxiliary.SuffixAuxiliaryIdentifierStrategy{Suffix: auxiliary.MetaSuffix},
+		nil, nil, false, false)
+	subjectReader := NewSubjectAuxiliaryReader(reader, description)
+
+	if got := modeString(readModes(t, subjectReader, "doc.meta", "")); got != "read" {
+		t.Errorf("modes of the description = %q, want those of its subject", got)
+	}
+	if got := modeString(readModes(t, reader, "doc.meta", "")); got != "append" {
+		t.Errorf("modes of the description without SubjectAuxiliaryReader = %q, want the inherited ones", got)
+	}
+}
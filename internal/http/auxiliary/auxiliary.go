@@ -0,0 +1,47 @@
+// Package auxiliary handles auxiliary resources such as ACL and description resources.
+package auxiliary
+
+import (
+	"solid-go/internal/storage/conversion"
+	"solid-go/internal/util/vocabularies"
+)
+
+// AclSuffix is appended to the identifier of a resource to get the identifier of its ACL resource
+const AclSuffix = ".acl"
+
+// AcrSuffix is appended to the identifier of a resource to get the identifier of its Access Control Resource
+const AcrSuffix = ".acr"
+
+// MetaSuffix is appended to the identifier of a resource to get the identifier of its description resource
+const MetaSuffix = ".meta"
+
+// NewAclStrategy creates the strategy of ACL resources.
+// They are linked from their subject with rel="acl", have to contain RDF,
+// are authorized on their own and are required in the root container.
+func NewAclStrategy(converter conversion.RepresentationConverter) *ComposedAuxiliaryStrategy {
+	return newAuthorizationStrategy(AclSuffix, converter)
+}
+
+// NewAcrStrategy creates the strategy of Access Control Resources, which behave like ACL resources.
+// ACP, §3.1: clients discover them through the same rel="acl" link.
+func NewAcrStrategy(converter conversion.RepresentationConverter) *ComposedAuxiliaryStrategy {
+	return newAuthorizationStrategy(AcrSuffix, converter)
+}
+
+// newAuthorizationStrategy creates the strategy of the authorization resources with the suffix
+func newAuthorizationStrategy(suffix string, converter conversion.RepresentationConverter) *ComposedAuxiliaryStrategy {
+	identifierStrategy := &SuffixAuxiliaryIdentifierStrategy{Suffix: suffix}
+	return NewComposedAuxiliaryStrategy(identifierStrategy,
+		NewLinkMetadataGenerator(vocabularies.ACL.AccessControl.Value(), identifierStrategy),
+		NewConcreteRdfValidator(converter), true, true)
+}
+
+// NewDescriptionStrategy creates the strategy of description resources.
+// They are linked from their subject with rel="describedby", have to contain RDF
+// and are authorized through their subject.
+func NewDescriptionStrategy(converter conversion.RepresentationConverter) *ComposedAuxiliaryStrategy {
+	identifierStrategy := &SuffixAuxiliaryIdentifierStrategy{Suffix: MetaSuffix}
+	return NewComposedAuxiliaryStrategy(identifierStrategy,
+		NewLinkMetadataGenerator(vocabularies.POWDER_S.DescribedBy.Value(), identifierStrategy),
+		NewConcreteRdfValidator(converter), false, false)
+}
@@ -9,15 +9,17 @@
 //   - Retrieve the subject resource identifier for a given auxiliary resource.
 package auxiliary
 
+import "solid-go/internal/http/representation"
+
 // AuxiliaryIdentifierStrategy is the interface that wraps the methods for
 // handling auxiliary-related ResourceIdentifiers.
 type AuxiliaryIdentifierStrategy interface {
 	// GetAuxiliaryIdentifier returns the identifier of the auxiliary resource corresponding to the given resource.
-	// This does not guarantee that the auxiliary resource exists. Should error if there are multiple results.
+	// This does not guarantee that the auxiliary resource exists.
 	//
 	// identifier: The ResourceIdentifier for which to find the auxiliary resource.
 	// Returns: The ResourceIdentifier of the corresponding auxiliary resource.
-	GetAuxiliaryIdentifier(identifier interface{}) interface{}
+	GetAuxiliaryIdentifier(identifier representation.ResourceIdentifier) representation.ResourceIdentifier
 
 	// GetAuxiliaryIdentifiers returns all identifiers of corresponding auxiliary resources.
 	// This can be used when there are potentially multiple results. In the case of a single result,
@@ -25,7 +27,7 @@ type AuxiliaryIdentifierStrategy interface {
 	//
 	// identifier: The ResourceIdentifier for which to find auxiliary resources.
 	// Returns: A slice of ResourceIdentifiers for the corresponding auxiliary resources.
-	GetAuxiliaryIdentifiers(identifier interface{}) []interface{}
+	GetAuxiliaryIdentifiers(identifier representation.ResourceIdentifier) []representation.ResourceIdentifier
 
 	// IsAuxiliaryIdentifier checks if the input identifier corresponds to an auxiliary resource.
 	// This does not check if that auxiliary resource exists, only if the identifier indicates
@@ -33,12 +35,12 @@ type AuxiliaryIdentifierStrategy interface {
 	//
 	// identifier: Identifier to check.
 	// Returns: true if the input identifier points to an auxiliary resource.
-	IsAuxiliaryIdentifier(identifier interface{}) bool
+	IsAuxiliaryIdentifier(identifier representation.ResourceIdentifier) bool
 
 	// GetSubjectIdentifier returns the identifier of the resource which this auxiliary resource is referring to.
 	// This does not guarantee that this resource exists.
 	//
 	// identifier: Identifier of the auxiliary resource.
-	// Returns: The ResourceIdentifier of the subject resource.
-	GetSubjectIdentifier(identifier interface{}) interface{}
+	// Returns: The ResourceIdentifier of the subject resource, or an error if the identifier is not auxiliary.
+	GetSubjectIdentifier(identifier representation.ResourceIdentifier) (representation.ResourceIdentifier, error)
 }
@@ -1,13 +1,19 @@
 // Package auxiliary provides the AuxiliaryStrategy interface.
 package auxiliary
 
+import "solid-go/internal/http/representation"
+
+// AuxiliaryStrategy adds the behaviour of auxiliary resources to the identifier strategy:
+// how they are authorized, which metadata their subject gets and which data they accept.
 type AuxiliaryStrategy interface {
-	// Returns true if the given identifier is an auxiliary resource.
-	IsAuxiliary(identifier interface{}) bool
-	// Returns the subject identifier for the given auxiliary identifier.
-	GetSubjectIdentifier(auxiliaryIdentifier interface{}) (interface{}, error)
-	// Returns the auxiliary identifier for the given subject identifier.
-	GetAuxiliaryIdentifier(subjectIdentifier interface{}) (interface{}, error)
-	// Returns the types of auxiliary resources handled by this strategy.
-	GetAuxiliaryTypes() []string
+	AuxiliaryIdentifierStrategy
+	// UsesOwnAuthorization returns true if the auxiliary resource is authorized on its own
+	// instead of through its subject resource.
+	UsesOwnAuthorization(identifier representation.ResourceIdentifier) bool
+	// IsRequiredInRoot returns true if the root container needs this auxiliary resource.
+	IsRequiredInRoot(identifier representation.ResourceIdentifier) bool
+	// AddMetadata adds metadata related to this auxiliary resource, e.g. a link on its subject.
+	AddMetadata(metadata *representation.RepresentationMetadata) error
+	// Validate returns an error if the representation is not valid for this kind of auxiliary resource.
+	Validate(rep representation.Representation) error
 }
@@ -1,14 +1,16 @@
 // Package auxiliary provides the ComposedAuxiliaryStrategy struct and related logic.
 package auxiliary
 
+import "solid-go/internal/http/representation"
+
 // ComposedAuxiliaryStrategy provides AuxiliaryStrategy functionality by combining
 // an AuxiliaryIdentifierStrategy, MetadataGenerator, and Validator.
 type ComposedAuxiliaryStrategy struct {
 	IdentifierStrategy AuxiliaryIdentifierStrategy
-	MetadataGenerator MetadataGenerator // optional
-	Validator         Validator        // optional
-	OwnAuthorization  bool
-	RequiredInRoot    bool
+	MetadataGenerator  MetadataGenerator // optional
+	Validator          Validator         // optional
+	OwnAuthorization   bool
+	RequiredInRoot     bool
 }
 
 // NewComposedAuxiliaryStrategy constructs a new ComposedAuxiliaryStrategy.
@@ -21,57 +23,53 @@ func NewComposedAuxiliaryStrategy(
 ) *ComposedAuxiliaryStrategy {
 	return &ComposedAuxiliaryStrategy{
 		IdentifierStrategy: identifierStrategy,
-		MetadataGenerator: metadataGenerator,
-		Validator:         validator,
-		OwnAuthorization:  ownAuthorization,
-		RequiredInRoot:    requiredInRoot,
+		MetadataGenerator:  metadataGenerator,
+		Validator:          validator,
+		OwnAuthorization:   ownAuthorization,
+		RequiredInRoot:     requiredInRoot,
 	}
 }
 
-func (c *ComposedAuxiliaryStrategy) GetAuxiliaryIdentifier(identifier interface{}) interface{} {
+func (c *ComposedAuxiliaryStrategy) GetAuxiliaryIdentifier(identifier representation.ResourceIdentifier) representation.ResourceIdentifier {
 	return c.IdentifierStrategy.GetAuxiliaryIdentifier(identifier)
 }
 
-func (c *ComposedAuxiliaryStrategy) GetAuxiliaryIdentifiers(identifier interface{}) []interface{} {
+func (c *ComposedAuxiliaryStrategy) GetAuxiliaryIdentifiers(identifier representation.ResourceIdentifier) []representation.ResourceIdentifier {
 	return c.IdentifierStrategy.GetAuxiliaryIdentifiers(identifier)
 }
 
-func (c *ComposedAuxiliaryStrategy) IsAuxiliaryIdentifier(identifier interface{}) bool {
+func (c *ComposedAuxiliaryStrategy) IsAuxiliaryIdentifier(identifier representation.ResourceIdentifier) bool {
 	return c.IdentifierStrategy.IsAuxiliaryIdentifier(identifier)
 }
 
-func (c *ComposedAuxiliaryStrategy) GetSubjectIdentifier(identifier interface{}) interface{} {
+func (c *ComposedAuxiliaryStrategy) GetSubjectIdentifier(identifier representation.ResourceIdentifier) (representation.ResourceIdentifier, error) {
 	return c.IdentifierStrategy.GetSubjectIdentifier(identifier)
 }
 
-func (c *ComposedAuxiliaryStrategy) UsesOwnAuthorization() bool {
+func (c *ComposedAuxiliaryStrategy) UsesOwnAuthorization(representation.ResourceIdentifier) bool {
 	return c.OwnAuthorization
 }
 
-func (c *ComposedAuxiliaryStrategy) IsRequiredInRoot() bool {
+func (c *ComposedAuxiliaryStrategy) IsRequiredInRoot(representation.ResourceIdentifier) bool {
 	return c.RequiredInRoot
 }
 
-func (c *ComposedAuxiliaryStrategy) AddMetadata(metadata interface{}) error {
+func (c *ComposedAuxiliaryStrategy) AddMetadata(metadata *representation.RepresentationMetadata) error {
 	if c.MetadataGenerator != nil {
 		return c.MetadataGenerator.HandleSafe(metadata)
 	}
 	return nil
 }
 
-func (c *ComposedAuxiliaryStrategy) Validate(representation interface{}) error {
+func (c *ComposedAuxiliaryStrategy) Validate(rep representation.Representation) error {
 	if c.Validator != nil {
-		// Attempt to extract identifier from the representation if possible
-		var identifier interface{}
-		if repMap, ok := representation.(map[string]interface{}); ok {
-			if meta, ok := repMap["metadata"].(map[string]interface{}); ok {
-				if id, ok := meta["identifier"]; ok {
-					identifier = id
-				}
-			}
+		// The identifier is taken from the metadata of the representation
+		var identifier representation.ResourceIdentifier
+		if metadata := rep.GetMetadata(); metadata != nil {
+			identifier.Path = metadata.GetIdentifier()
 		}
 		return c.Validator.HandleSafe(ValidatorInput{
-			Representation: representation,
+			Representation: rep,
 			Identifier:     identifier,
 		})
 	}
@@ -1,9 +1,15 @@
 // Package auxiliary provides the LinkMetadataGenerator struct and logic.
 package auxiliary
 
+import (
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
+)
+
 // LinkMetadataGenerator adds a link to the auxiliary resource when called on the subject resource.
 type LinkMetadataGenerator struct {
-	Link               string // Using string for NamedNode IRI
+	Link               string // IRI of the link relation
 	IdentifierStrategy AuxiliaryIdentifierStrategy
 }
 
@@ -15,23 +21,14 @@ func NewLinkMetadataGenerator(link string, identifierStrategy AuxiliaryIdentifie
 	}
 }
 
-// HandleSafe adds a link to the auxiliary resource if the input is not an auxiliary resource.
-// Expects metadata to be a map with keys "identifier" (map with key "path") and an "add" method.
-func (l *LinkMetadataGenerator) HandleSafe(metadata interface{}) error {
-	meta, ok := metadata.(map[string]interface{})
-	if !ok {
-		return nil // or return an error if you want strict type checking
-	}
-	identifierObj, ok := meta["identifier"].(map[string]interface{})
-	if !ok {
-		return nil
-	}
-	identifier := map[string]interface{}{"path": identifierObj["path"]}
+// HandleSafe adds a link to the auxiliary resource if the metadata is not of an auxiliary resource.
+// The link is only added to the response, it is not stored.
+func (l *LinkMetadataGenerator) HandleSafe(metadata *representation.RepresentationMetadata) error {
+	identifier := representation.ResourceIdentifier{Path: metadata.GetIdentifier()}
 	if !l.IdentifierStrategy.IsAuxiliaryIdentifier(identifier) {
-		if addFunc, ok := meta["add"].(func(link string, auxPath string, metaType string)); ok {
-			auxID := l.IdentifierStrategy.GetAuxiliaryIdentifier(identifier).(map[string]interface{})
-			addFunc(l.Link, auxID["path"].(string), "ResponseMetadata")
-		}
+		auxiliary := l.IdentifierStrategy.GetAuxiliaryIdentifier(identifier)
+		metadata.AddQuad(n3.NewQuad(n3.NewNamedNode(identifier.Path), n3.NewNamedNode(l.Link),
+			n3.NewNamedNode(auxiliary.Path), vocabularies.SOLID_META.ResponseMetadata))
 	}
 	return nil
 }
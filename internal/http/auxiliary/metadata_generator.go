@@ -1,17 +1,19 @@
 // Package auxiliary provides the MetadataGenerator interface and base struct.
 package auxiliary
 
+import "solid-go/internal/http/representation"
+
 // MetadataGenerator generates metadata for resources, e.g., adding RDF triples or link headers.
 type MetadataGenerator interface {
 	// HandleSafe generates or modifies metadata and returns an error if something goes wrong.
-	HandleSafe(metadata interface{}) error
+	HandleSafe(metadata *representation.RepresentationMetadata) error
 }
 
 // BaseMetadataGenerator can be embedded to provide a default no-op implementation of MetadataGenerator.
 type BaseMetadataGenerator struct{}
 
 // HandleSafe is the default implementation that does nothing and returns nil.
-func (b *BaseMetadataGenerator) HandleSafe(metadata interface{}) error {
+func (b *BaseMetadataGenerator) HandleSafe(metadata *representation.RepresentationMetadata) error {
 	// Default implementation does nothing.
 	return nil
 }
@@ -1,6 +1,16 @@
 // Package auxiliary provides the RdfValidator interface and base struct.
 package auxiliary
 
+import (
+	"bytes"
+	"io"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage/conversion"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+)
+
 // RdfValidator validates RDF data, such as checking for required triples or schema conformance.
 type RdfValidator interface {
 	// HandleSafe validates the input RDF data and returns an error if invalid.
@@ -16,27 +26,58 @@ func (b *BaseRdfValidator) HandleSafe(input ValidatorInput) error {
 	return nil
 }
 
+// dataSetter is a representation whose data stream can be replaced
+type dataSetter interface {
+	SetData(data io.Reader)
+}
+
 // ConcreteRdfValidator uses a converter to check RDF validity.
 type ConcreteRdfValidator struct {
-	Converter interface{} // TODO: replace with actual RepresentationConverter type
+	Converter conversion.RepresentationConverter
 }
 
-// HandleSafe validates the RDF data using the converter, similar to the TypeScript RdfValidator.
+// NewConcreteRdfValidator creates a new ConcreteRdfValidator.
+func NewConcreteRdfValidator(converter conversion.RepresentationConverter) *ConcreteRdfValidator {
+	return &ConcreteRdfValidator{Converter: converter}
+}
+
+// HandleSafe validates the RDF data by converting it to quads, similar to the TypeScript RdfValidator.
 func (v *ConcreteRdfValidator) HandleSafe(input ValidatorInput) error {
-	// Example logic (pseudo-code, replace with actual types and logic):
 	// If the data already is quads format we know it's RDF
-	metadata := input.Representation.(map[string]interface{})["metadata"]
-	if metadata != nil && metadata.(map[string]interface{})["contentType"] == "application/n-quads" {
+	metadata := input.Representation.GetMetadata()
+	if metadata != nil && metadata.ContentType() == util.InternalQuads {
+		return nil
+	}
+	if _, ok := input.Representation.(representation.RdfDatasetRepresentation); ok {
 		return nil
 	}
-	// preferences := map[string]interface{}{ "type": map[string]float64{"application/n-quads": 1} }
-	// result, err := v.Converter.HandleSafe({
-	//   identifier: input.Identifier,
-	//   representation: input.Representation,
-	//   preferences: preferences,
-	// })
-	// if err != nil { return err }
-	// Optionally drain the stream to ensure data was parsed correctly
-	// ...
+	if input.Representation.GetData() == nil {
+		return nil
+	}
+	// Buffer the data so it can still be written after the converter consumed it,
+	// which is only possible if the data of the representation can be replaced
+	setter, ok := input.Representation.(dataSetter)
+	if !ok {
+		return errors.NewInternalError("the data of the representation can not be validated without consuming it", nil)
+	}
+	data, err := io.ReadAll(input.Representation.GetData())
+	if err != nil {
+		return err
+	}
+	setter.SetData(bytes.NewReader(data))
+	args := conversion.RepresentationConverterArgs{
+		Identifier: input.Identifier,
+		Representation: representation.NewBasicRepresentation(
+			bytes.NewReader(data), metadata, input.Representation.IsBinary()),
+		Preferences: &representation.RepresentationPreferences{
+			Type: representation.ValuePreferences{util.InternalQuads: 1},
+		},
+	}
+	if err := v.Converter.CanHandle(args); err != nil {
+		return errors.NewValidationError("data is not valid RDF", err)
+	}
+	if _, err := v.Converter.Handle(args); err != nil {
+		return errors.NewValidationError("data is not valid RDF", err)
+	}
 	return nil
 }
@@ -0,0 +1,50 @@
+package auxiliary
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage/conversion"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+)
+
+// streamRepresentation is a representation other than BasicRepresentation whose data can be replaced
+type streamRepresentation struct {
+	*representation.BasicRepresentation
+	data io.Reader
+}
+
+func (r *streamRepresentation) GetData() io.Reader     { return r.data }
+func (r *streamRepresentation) SetData(data io.Reader) { r.data = data }
+
+func TestConcreteRdfValidator(t *testing.T) {
+	validator := NewConcreteRdfValidator(conversion.NewRdfToQuadConverter())
+	turtle := "<http://example.org/s> <http://example.org/p> <http://example.org/o>."
+	metadata := representation.NewRepresentationMetadata("http://example.org/doc.meta").SetContentType(util.Turtle)
+
+	rep := &streamRepresentation{BasicRepresentation: representation.NewBasicRepresentation(nil, metadata, false),
+		data: strings.NewReader(turtle)}
+	if err := validator.HandleSafe(ValidatorInput{Representation: rep}); err != nil {
+		t.Fatalf("HandleSafe() error = %v", err)
+	}
+	if data, _ := io.ReadAll(rep.GetData()); string(data) != turtle {
+		t.Errorf("data after validation = %q, want %q", data, turtle)
+	}
+
+	invalid := representation.NewBasicRepresentation(strings.NewReader("<s> <p"), metadata, false)
+	if err := validator.HandleSafe(ValidatorInput{Representation: invalid}); !errors.IsValidationError(err) {
+		t.Errorf("HandleSafe() of invalid Turtle error = %v, want a ValidationError", err)
+	}
+
+	// The data of representations without setter can not be restored, so it is not consumed
+	readOnly := struct{ representation.Representation }{representation.NewBasicRepresentation(strings.NewReader(turtle), metadata, false)}
+	if err := validator.HandleSafe(ValidatorInput{Representation: readOnly}); err == nil {
+		t.Error("HandleSafe() of a representation whose data can not be replaced should fail")
+	}
+	if data, _ := io.ReadAll(readOnly.GetData()); string(data) != turtle {
+		t.Errorf("data after failed validation = %q, want %q", data, turtle)
+	}
+}
@@ -1,6 +1,13 @@
 // Package auxiliary provides the RoutingAuxiliaryIdentifierStrategy struct and logic.
 package auxiliary
 
+import (
+	"fmt"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
+
 // RoutingAuxiliaryIdentifierStrategy is a strategy for routing auxiliary identifiers among multiple strategies.
 type RoutingAuxiliaryIdentifierStrategy struct {
 	Sources []AuxiliaryIdentifierStrategy
@@ -11,28 +18,37 @@ func NewRoutingAuxiliaryIdentifierStrategy(sources []AuxiliaryIdentifierStrategy
 	return &RoutingAuxiliaryIdentifierStrategy{Sources: sources}
 }
 
-// GetAuxiliaryIdentifier routes to the matching strategy.
-func (r *RoutingAuxiliaryIdentifierStrategy) GetAuxiliaryIdentifier(identifier map[string]string) map[string]string {
-	return r.getMatchingSource(identifier).GetAuxiliaryIdentifier(identifier)
+// GetAuxiliaryIdentifier is not supported since there is no single auxiliary identifier, use GetAuxiliaryIdentifiers.
+func (r *RoutingAuxiliaryIdentifierStrategy) GetAuxiliaryIdentifier(identifier representation.ResourceIdentifier) representation.ResourceIdentifier {
+	panic("RoutingAuxiliaryIdentifierStrategy has multiple auxiliary strategies and thus no single auxiliary identifier")
 }
 
-// GetAuxiliaryIdentifiers routes to the matching strategy.
-func (r *RoutingAuxiliaryIdentifierStrategy) GetAuxiliaryIdentifiers(identifier map[string]string) []map[string]string {
-	return r.getMatchingSource(identifier).GetAuxiliaryIdentifiers(identifier)
+// GetAuxiliaryIdentifiers returns the auxiliary identifiers of all strategies.
+func (r *RoutingAuxiliaryIdentifierStrategy) GetAuxiliaryIdentifiers(identifier representation.ResourceIdentifier) []representation.ResourceIdentifier {
+	var identifiers []representation.ResourceIdentifier
+	for _, source := range r.Sources {
+		identifiers = append(identifiers, source.GetAuxiliaryIdentifiers(identifier)...)
+	}
+	return identifiers
 }
 
 // IsAuxiliaryIdentifier checks if any strategy matches.
-func (r *RoutingAuxiliaryIdentifierStrategy) IsAuxiliaryIdentifier(identifier map[string]string) bool {
+func (r *RoutingAuxiliaryIdentifierStrategy) IsAuxiliaryIdentifier(identifier representation.ResourceIdentifier) bool {
 	return r.getMatchingSource(identifier) != nil
 }
 
 // GetSubjectIdentifier routes to the matching strategy.
-func (r *RoutingAuxiliaryIdentifierStrategy) GetSubjectIdentifier(identifier map[string]string) map[string]string {
-	return r.getMatchingSource(identifier).GetSubjectIdentifier(identifier)
+func (r *RoutingAuxiliaryIdentifierStrategy) GetSubjectIdentifier(identifier representation.ResourceIdentifier) (representation.ResourceIdentifier, error) {
+	source := r.getMatchingSource(identifier)
+	if source == nil {
+		return representation.ResourceIdentifier{}, errors.NewNotImplementedError(
+			fmt.Sprintf("could not find an AuxiliaryManager for %s", identifier.Path), nil)
+	}
+	return source.GetSubjectIdentifier(identifier)
 }
 
-// getMatchingSource returns the first matching strategy for the identifier.
-func (r *RoutingAuxiliaryIdentifierStrategy) getMatchingSource(identifier map[string]string) AuxiliaryIdentifierStrategy {
+// getMatchingSource returns the first matching strategy for the identifier, or nil if there is none.
+func (r *RoutingAuxiliaryIdentifierStrategy) getMatchingSource(identifier representation.ResourceIdentifier) AuxiliaryIdentifierStrategy {
 	for _, source := range r.Sources {
 		if source.IsAuxiliaryIdentifier(identifier) {
 			return source
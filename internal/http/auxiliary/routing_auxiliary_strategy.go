@@ -1,6 +1,8 @@
 // Package auxiliary provides the RoutingAuxiliaryStrategy struct and logic.
 package auxiliary
 
+import "solid-go/internal/http/representation"
+
 // RoutingAuxiliaryStrategy is a strategy for routing auxiliary resources among multiple strategies.
 type RoutingAuxiliaryStrategy struct {
 	RoutingAuxiliaryIdentifierStrategy
@@ -9,64 +11,61 @@ type RoutingAuxiliaryStrategy struct {
 
 // NewRoutingAuxiliaryStrategy constructs a new RoutingAuxiliaryStrategy.
 func NewRoutingAuxiliaryStrategy(sources []AuxiliaryStrategy) *RoutingAuxiliaryStrategy {
-	r := &RoutingAuxiliaryStrategy{
-		RoutingAuxiliaryIdentifierStrategy: *NewRoutingAuxiliaryIdentifierStrategy(nil),
-		Sources: sources,
-	}
+	r := &RoutingAuxiliaryStrategy{Sources: sources}
 	r.RoutingAuxiliaryIdentifierStrategy.Sources = make([]AuxiliaryIdentifierStrategy, len(sources))
 	for i, s := range sources {
-		r.RoutingAuxiliaryIdentifierStrategy.Sources[i] = s.(AuxiliaryIdentifierStrategy)
+		r.RoutingAuxiliaryIdentifierStrategy.Sources[i] = s
 	}
 	return r
 }
 
 // UsesOwnAuthorization returns whether the matching strategy uses its own authorization.
-func (r *RoutingAuxiliaryStrategy) UsesOwnAuthorization(identifier map[string]string) bool {
-	source := r.getMatchingSource(identifier)
-	if s, ok := source.(interface{ UsesOwnAuthorization(map[string]string) bool }); ok {
-		return s.UsesOwnAuthorization(identifier)
+func (r *RoutingAuxiliaryStrategy) UsesOwnAuthorization(identifier representation.ResourceIdentifier) bool {
+	if source := r.getMatchingStrategy(identifier); source != nil {
+		return source.UsesOwnAuthorization(identifier)
 	}
 	return false
 }
 
 // IsRequiredInRoot returns whether the matching strategy is required in root.
-func (r *RoutingAuxiliaryStrategy) IsRequiredInRoot(identifier map[string]string) bool {
-	source := r.getMatchingSource(identifier)
-	if s, ok := source.(interface{ IsRequiredInRoot(map[string]string) bool }); ok {
-		return s.IsRequiredInRoot(identifier)
+func (r *RoutingAuxiliaryStrategy) IsRequiredInRoot(identifier representation.ResourceIdentifier) bool {
+	if source := r.getMatchingStrategy(identifier); source != nil {
+		return source.IsRequiredInRoot(identifier)
 	}
 	return false
 }
 
-// AddMetadata calls addMetadata on the matching or all strategies as appropriate.
-func (r *RoutingAuxiliaryStrategy) AddMetadata(metadata interface{}) error {
-	identifier := map[string]string{"path": "TODO: extract from metadata"}
-	match := false
+// AddMetadata calls AddMetadata on the matching strategy if the metadata is of an auxiliary resource,
+// and on all strategies otherwise.
+func (r *RoutingAuxiliaryStrategy) AddMetadata(metadata *representation.RepresentationMetadata) error {
+	if source := r.getMatchingStrategy(representation.ResourceIdentifier{Path: metadata.GetIdentifier()}); source != nil {
+		return source.AddMetadata(metadata)
+	}
 	for _, source := range r.Sources {
-		if source.IsAuxiliaryIdentifier(identifier) {
-			if err := source.AddMetadata(metadata); err != nil {
-				return err
-			}
-			match = true
-			break
+		if err := source.AddMetadata(metadata); err != nil {
+			return err
 		}
 	}
-	if !match {
-		for _, source := range r.Sources {
-			if err := source.AddMetadata(metadata); err != nil {
-				return err
-			}
-		}
+	return nil
+}
+
+// Validate calls Validate on the matching strategy, representations of other resources are always valid.
+func (r *RoutingAuxiliaryStrategy) Validate(rep representation.Representation) error {
+	if rep.GetMetadata() == nil {
+		return nil
+	}
+	if source := r.getMatchingStrategy(representation.ResourceIdentifier{Path: rep.GetMetadata().GetIdentifier()}); source != nil {
+		return source.Validate(rep)
 	}
 	return nil
 }
 
-// Validate calls validate on the matching strategy.
-func (r *RoutingAuxiliaryStrategy) Validate(representation interface{}) error {
-	identifier := map[string]string{"path": "TODO: extract from representation"}
-	source := r.getMatchingSource(identifier)
-	if s, ok := source.(interface{ Validate(interface{}) error }); ok {
-		return s.Validate(representation)
+// getMatchingStrategy returns the first matching strategy for the identifier, or nil if there is none.
+func (r *RoutingAuxiliaryStrategy) getMatchingStrategy(identifier representation.ResourceIdentifier) AuxiliaryStrategy {
+	for _, source := range r.Sources {
+		if source.IsAuxiliaryIdentifier(identifier) {
+			return source
+		}
 	}
 	return nil
 }
@@ -1,7 +1,13 @@
 // Package auxiliary provides the SuffixAuxiliaryIdentifierStrategy struct and logic.
 package auxiliary
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
 
 // SuffixAuxiliaryIdentifierStrategy is a strategy for suffix-based auxiliary identifiers.
 type SuffixAuxiliaryIdentifierStrategy struct {
@@ -17,24 +23,25 @@ func NewSuffixAuxiliaryIdentifierStrategy(suffix string) (*SuffixAuxiliaryIdenti
 }
 
 // GetAuxiliaryIdentifier returns the identifier of the auxiliary resource corresponding to the given resource.
-func (s *SuffixAuxiliaryIdentifierStrategy) GetAuxiliaryIdentifier(identifier map[string]string) map[string]string {
-	return map[string]string{"path": identifier["path"] + s.Suffix}
+func (s *SuffixAuxiliaryIdentifierStrategy) GetAuxiliaryIdentifier(identifier representation.ResourceIdentifier) representation.ResourceIdentifier {
+	return representation.ResourceIdentifier{Path: identifier.Path + s.Suffix}
 }
 
 // GetAuxiliaryIdentifiers returns all the identifiers of corresponding auxiliary resources.
-func (s *SuffixAuxiliaryIdentifierStrategy) GetAuxiliaryIdentifiers(identifier map[string]string) []map[string]string {
-	return []map[string]string{s.GetAuxiliaryIdentifier(identifier)}
+func (s *SuffixAuxiliaryIdentifierStrategy) GetAuxiliaryIdentifiers(identifier representation.ResourceIdentifier) []representation.ResourceIdentifier {
+	return []representation.ResourceIdentifier{s.GetAuxiliaryIdentifier(identifier)}
 }
 
 // IsAuxiliaryIdentifier checks if the input identifier corresponds to an auxiliary resource.
-func (s *SuffixAuxiliaryIdentifierStrategy) IsAuxiliaryIdentifier(identifier map[string]string) bool {
-	return len(identifier["path"]) >= len(s.Suffix) && identifier["path"][len(identifier["path"])-len(s.Suffix):] == s.Suffix
+func (s *SuffixAuxiliaryIdentifierStrategy) IsAuxiliaryIdentifier(identifier representation.ResourceIdentifier) bool {
+	return strings.HasSuffix(identifier.Path, s.Suffix)
 }
 
 // GetSubjectIdentifier returns the identifier of the resource which this auxiliary resource is referring to.
-func (s *SuffixAuxiliaryIdentifierStrategy) GetSubjectIdentifier(identifier map[string]string) (map[string]string, error) {
+func (s *SuffixAuxiliaryIdentifierStrategy) GetSubjectIdentifier(identifier representation.ResourceIdentifier) (representation.ResourceIdentifier, error) {
 	if !s.IsAuxiliaryIdentifier(identifier) {
-		return nil, fmt.Errorf("%s does not end on %s so no conversion is possible.", identifier["path"], s.Suffix)
+		return representation.ResourceIdentifier{}, errors.NewValidationError(
+			fmt.Sprintf("%s does not end on %s so no conversion is possible.", identifier.Path, s.Suffix), nil)
 	}
-	return map[string]string{"path": identifier["path"][:len(identifier["path"])-len(s.Suffix)]}, nil
+	return representation.ResourceIdentifier{Path: strings.TrimSuffix(identifier.Path, s.Suffix)}, nil
 }
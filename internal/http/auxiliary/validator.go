@@ -1,10 +1,12 @@
 // Package auxiliary provides the Validator interface and base struct.
 package auxiliary
 
+import "solid-go/internal/http/representation"
+
 // ValidatorInput represents the input for a Validator, containing a Representation and its ResourceIdentifier.
 type ValidatorInput struct {
-	Representation map[string]interface{} // Use a map for flexible representation
-	Identifier     map[string]interface{} // Use a map for flexible identifier
+	Representation representation.Representation
+	Identifier     representation.ResourceIdentifier
 }
 
 // Validator validates Representations in some way, e.g., for shape or content.
@@ -2,25 +2,34 @@
 package input
 
 import (
-	"fmt"
+	"net/http"
+
+	solidhttp "solid-go/internal/http"
+	"solid-go/internal/http/input/body"
+	"solid-go/internal/http/input/conditions"
+	"solid-go/internal/http/input/identifier"
+	"solid-go/internal/http/input/metadata"
+	"solid-go/internal/http/input/preferences"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
 )
 
 // BasicRequestParserArgs contains the dependencies for BasicRequestParser.
 type BasicRequestParserArgs struct {
-	TargetExtractor    interface{} // TODO: define TargetExtractor interface
-	PreferenceParser   interface{} // TODO: define PreferenceParser interface
-	MetadataParser     interface{} // TODO: define MetadataParser interface
-	ConditionsParser   interface{} // TODO: define ConditionsParser interface
-	BodyParser         interface{} // TODO: define BodyParser interface
+	TargetExtractor  identifier.TargetExtractor
+	PreferenceParser preferences.PreferenceParser
+	MetadataParser   metadata.MetadataParser
+	ConditionsParser conditions.ConditionsParser
+	BodyParser       body.BodyParser
 }
 
 // BasicRequestParser aggregates input parsers to create an Operation from an HttpRequest.
 type BasicRequestParser struct {
-	targetExtractor    interface{}
-	preferenceParser   interface{}
-	metadataParser     interface{}
-	conditionsParser   interface{}
-	bodyParser         interface{}
+	targetExtractor  identifier.TargetExtractor
+	preferenceParser preferences.PreferenceParser
+	metadataParser   metadata.MetadataParser
+	conditionsParser conditions.ConditionsParser
+	bodyParser       body.BodyParser
 }
 
 // NewBasicRequestParser constructs a BasicRequestParser from its dependencies.
@@ -35,51 +44,39 @@ func NewBasicRequestParser(args BasicRequestParserArgs) *BasicRequestParser {
 }
 
 // Handle creates an Operation from an HttpRequest by aggregating the results of the input parsers.
-func (p *BasicRequestParser) Handle(request map[string]interface{}) (map[string]interface{}, error) {
-	method, ok := request["method"].(string)
-	if !ok || method == "" {
-		return nil, fmt.Errorf("No method specified on the HTTP request")
+func (p *BasicRequestParser) Handle(request *http.Request) (*solidhttp.Operation, error) {
+	if request.Method == "" {
+		return nil, errors.NewInternalError("No method specified on the HTTP request", nil)
 	}
-	target, err := callHandleSafe(p.targetExtractor, map[string]interface{}{ "request": request })
+	target, err := p.targetExtractor.Handle(request)
 	if err != nil {
 		return nil, err
 	}
-	preferences, err := callHandleSafe(p.preferenceParser, map[string]interface{}{ "request": request })
+	prefs, err := p.preferenceParser.Handle(request)
 	if err != nil {
 		return nil, err
 	}
-	metadata := map[string]interface{}{ "identifier": target }
-	_, err = callHandleSafe(p.metadataParser, map[string]interface{}{ "request": request, "metadata": metadata })
-	if err != nil {
+	meta := representation.NewRepresentationMetadata(target.Path)
+	if err := p.metadataParser.Handle(metadata.MetadataParserInput{Request: request, Metadata: meta}); err != nil {
 		return nil, err
 	}
-	conditions, err := callHandleSafe(p.conditionsParser, request)
+	conds, err := p.conditionsParser.Handle(request)
 	if err != nil {
 		return nil, err
 	}
-	body, err := callHandleSafe(p.bodyParser, map[string]interface{}{ "request": request, "metadata": metadata })
+	args := body.BodyParserArgs{Request: request, Metadata: meta}
+	if err := p.bodyParser.CanHandle(args); err != nil {
+		return nil, err
+	}
+	rep, err := p.bodyParser.Handle(args)
 	if err != nil {
 		return nil, err
 	}
-	return map[string]interface{}{
-		"method":      method,
-		"target":      target,
-		"preferences": preferences,
-		"conditions":  conditions,
-		"body":        body,
+	return &solidhttp.Operation{
+		Method:      request.Method,
+		Target:      target,
+		Preferences: prefs,
+		Conditions:  conds,
+		Body:        rep,
 	}, nil
 }
-
-// callHandleSafe is a helper to call a HandleSafe method on a parser.
-func callHandleSafe(parser interface{}, input interface{}) (interface{}, error) {
-	if parser == nil {
-		return nil, fmt.Errorf("parser is nil")
-	}
-	if h, ok := parser.(interface{ HandleSafe(interface{}) (interface{}, error) }); ok {
-		return h.HandleSafe(input)
-	}
-	if h, ok := parser.(interface{ HandleSafe(interface{}) error }); ok {
-		return nil, h.HandleSafe(input)
-	}
-	return nil, fmt.Errorf("parser does not implement HandleSafe")
-}
@@ -0,0 +1,85 @@
+package input
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"solid-go/internal/http/input/body"
+	"solid-go/internal/http/input/conditions"
+	"solid-go/internal/http/input/identifier"
+	"solid-go/internal/http/input/metadata"
+	"solid-go/internal/http/input/preferences"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/identifiers"
+)
+
+func newRequestParser() *BasicRequestParser {
+	return NewBasicRequestParser(BasicRequestParserArgs{
+		TargetExtractor: identifier.NewOriginalUrlExtractor(identifier.OriginalUrlExtractorArgs{
+			IdentifierStrategy: identifiers.NewSingleRootIdentifierStrategy("http://example.org/"),
+		}),
+		PreferenceParser: preferences.NewUnionPreferenceParser([]preferences.PreferenceParser{
+			preferences.NewAcceptPreferenceParser(), preferences.NewRangePreferenceParser(),
+		}),
+		MetadataParser:   metadata.NewParallelMetadataParser(metadata.NewContentTypeParser(), metadata.NewSlugParser()),
+		ConditionsParser: conditions.NewBasicConditionsParser(conditions.NewBasicETagHandler()),
+		BodyParser:       body.NewWaterfallBodyParser(body.NewN3PatchBodyParser(0), body.NewRawBodyParser()),
+	})
+}
+
+func TestBasicRequestParser(t *testing.T) {
+	parser := newRequestParser()
+	request := httptest.NewRequest("PUT", "http://example.org/foo/bar", strings.NewReader("hello"))
+	request.Header.Set("Content-Type", "text/plain")
+	request.Header.Set("Accept", "text/plain")
+	request.Header.Set("If-None-Match", "*")
+
+	operation, err := parser.Handle(request)
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if operation.Method != "PUT" || operation.Target.Path != "http://example.org/foo/bar" {
+		t.Errorf("operation = %v %v", operation.Method, operation.Target.Path)
+	}
+	if operation.Preferences == nil || operation.Preferences.Type["text/plain"] != 1 {
+		t.Errorf("Preferences = %+v", operation.Preferences)
+	}
+	if operation.Conditions == nil || operation.Conditions.Evaluate(representation.NewRepresentationMetadata(operation.Target.Path), false) == nil {
+		t.Errorf("Conditions = %v, want If-None-Match: * to fail on an existing resource", operation.Conditions)
+	}
+	if got := operation.Body.GetMetadata(); got.GetIdentifier() != operation.Target.Path || got.ContentType() != "text/plain" {
+		t.Errorf("body metadata = %v %v", got.GetIdentifier(), got.ContentType())
+	}
+	if data, _ := io.ReadAll(operation.Body.GetData()); string(data) != "hello" {
+		t.Errorf("body = %q", data)
+	}
+
+	patch := httptest.NewRequest("PATCH", "http://example.org/foo/bar",
+		strings.NewReader("@prefix solid: <http://www.w3.org/ns/solid/terms#>. _:p a solid:InsertDeletePatch."))
+	patch.Header.Set("Content-Type", "text/n3")
+	operation, err = parser.Handle(patch)
+	if err != nil {
+		t.Fatalf("Handle() of a PATCH error = %v", err)
+	}
+	if _, ok := operation.Body.(representation.N3Patch); !ok || operation.Conditions != nil {
+		t.Errorf("PATCH body = %T, conditions = %v", operation.Body, operation.Conditions)
+	}
+}
+
+func TestBasicRequestParser_Errors(t *testing.T) {
+	parser := newRequestParser()
+	if _, err := parser.Handle(httptest.NewRequest("GET", "http://other.org/", nil)); !errors.IsValidationError(err) {
+		t.Errorf("Handle() outside the identifier space error = %v, want ValidationError", err)
+	}
+	if _, err := parser.Handle(httptest.NewRequest("POST", "http://example.org/", strings.NewReader("x"))); !errors.IsValidationError(err) {
+		t.Errorf("Handle() of a body without Content-Type error = %v, want ValidationError", err)
+	}
+	request := httptest.NewRequest("GET", "http://example.org/", nil)
+	request.Header.Set("Range", "lines")
+	if _, err := parser.Handle(request); !errors.IsValidationError(err) {
+		t.Errorf("Handle() with an invalid Range error = %v, want ValidationError", err)
+	}
+}
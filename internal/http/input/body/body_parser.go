@@ -1,12 +1,49 @@
 // Package body provides the BodyParser interface for parsing HTTP request bodies.
 package body
 
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
+
+// DefaultMaxPatchSize is the size in bytes of the largest patch document the patch parsers accept by default
+const DefaultMaxPatchSize = 1 << 20
+
 // BodyParserArgs contains the arguments for the BodyParser.
 type BodyParserArgs struct {
-	Request  map[string]interface{}
-	Metadata map[string]interface{}
+	// Request is the incoming request whose body needs to be parsed
+	Request *http.Request
+	// Metadata is the metadata already parsed from the request headers
+	Metadata *representation.RepresentationMetadata
 }
 
+// BodyParser parses the body of an incoming request into a Representation.
 type BodyParser interface {
-	HandleSafe(input map[string]interface{}) (interface{}, error)
+	// CanHandle returns an error if the parser does not support the request body
+	CanHandle(args BodyParserArgs) error
+	// Handle parses the request body
+	Handle(args BodyParserArgs) (representation.Representation, error)
+}
+
+// isPatchRequest checks if the body belongs to a PATCH request, other methods never carry patch documents
+func isPatchRequest(args BodyParserArgs) bool {
+	return args.Request == nil || args.Request.Method == http.MethodPatch
+}
+
+// readPatch reads the whole patch document of the request,
+// failing with a 413 instead of reading more than maxSize bytes into memory
+func readPatch(body io.Reader, maxSize int64, name string) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(body, maxSize+1))
+	if err != nil {
+		return nil, errors.NewValidationError("failed to read "+name+" body", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, errors.NewPayloadTooLargeError(
+			fmt.Sprintf("%s documents can be at most %d bytes.", name, maxSize), nil)
+	}
+	return data, nil
 }
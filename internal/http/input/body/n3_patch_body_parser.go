@@ -2,72 +2,145 @@
 package body
 
 import (
-	"errors"
+	"bytes"
 	"fmt"
-	"io"
-	"io/ioutil"
-	"strings"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
 )
 
-// N3Patch is a placeholder for the parsed patch result.
-type N3Patch struct {
-	Deletes    []interface{}
-	Inserts    []interface{}
-	Conditions []interface{}
-	Binary     bool
-	Data       string
-	Metadata   interface{}
-	IsEmpty    bool
+// N3PatchBodyParser parses N3 Patch documents and ensures they conform to the Solid specification.
+type N3PatchBodyParser struct {
+	maxSize int64
 }
 
-// N3PatchBodyParser parses N3 Patch documents and ensures they conform to the Solid specification.
-type N3PatchBodyParser struct{}
+// NewN3PatchBodyParser creates a new N3PatchBodyParser that accepts documents of at most maxSize bytes,
+// or DefaultMaxPatchSize if it is not positive
+func NewN3PatchBodyParser(maxSize int64) *N3PatchBodyParser {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxPatchSize
+	}
+	return &N3PatchBodyParser{maxSize: maxSize}
+}
 
 // CanHandle checks if the metadata content type is N3 Patch.
 func (p *N3PatchBodyParser) CanHandle(args BodyParserArgs) error {
-	if m, ok := args.Metadata.(map[string]interface{}); ok {
-		if ct, ok := m["contentType"].(string); !ok || ct != "text/n3" {
-			return errors.New("This parser only supports N3 Patch documents.")
-		}
-	} else {
-		return errors.New("Missing or invalid metadata for N3 Patch parser.")
+	if args.Metadata == nil || !isPatchRequest(args) {
+		return errors.NewUnsupportedMediaTypeError("This parser only supports N3 Patch documents.", nil)
+	}
+	if format, ok := n3.FormatFromContentType(args.Metadata.ContentType()); !ok || format != n3.FormatN3 {
+		return errors.NewUnsupportedMediaTypeError("This parser only supports N3 Patch documents.", nil)
 	}
 	return nil
 }
 
 // Handle parses the N3 Patch body and returns an N3Patch representation.
-func (p *N3PatchBodyParser) Handle(args BodyParserArgs) (interface{}, error) {
-	// Read the request body as a string
-	var n3 string
-	switch r := args.Request.(io.Reader); {
-	case r != nil:
-		b, err := ioutil.ReadAll(r)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read N3 Patch body: %w", err)
-		}
-		n3 = string(b)
-	default:
-		return nil, errors.New("N3PatchBodyParser.Handle expects Request to be an io.Reader")
-	}
-	// TODO: Parse N3 Patch using an RDF/N3 parser library for Go
-	// For now, stub out the parsing and validation logic
-	// Simulate finding exactly one patch resource
-	patches := []string{"patch1"} // stub: should be extracted from parsed N3
+func (p *N3PatchBodyParser) Handle(args BodyParserArgs) (representation.Representation, error) {
+	if args.Request == nil || args.Request.Body == nil {
+		return nil, errors.NewValidationError("N3 Patch requests require a body", nil)
+	}
+	data, err := readPatch(args.Request.Body, p.maxSize, "N3 Patch")
+	if err != nil {
+		return nil, err
+	}
+	parser := n3.NewParser(n3.ParserOptions{Format: n3.FormatN3, BaseIRI: args.Metadata.GetIdentifier()})
+	store, err := parser.ParseToStore(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.NewValidationError("Invalid N3: "+err.Error(), err)
+	}
+
+	// Solid, §5.3.1: a patch document contains exactly one patch resource of type solid:InsertDeletePatch
+	patches := store.GetSubjects(vocabularies.RDF.Type, vocabularies.SOLID.InsertDeletePatch, n3.DefaultGraph())
 	if len(patches) != 1 {
-		return nil, fmt.Errorf("This patcher only supports N3 Patch documents with exactly 1 solid:InsertDeletePatch entry, but received %d.", len(patches))
-	}
-	// Simulate extracting deletes, inserts, and conditions
-	deletes := []interface{}{}    // TODO: extract from parsed N3
-	inserts := []interface{}{}    // TODO: extract from parsed N3
-	conditions := []interface{}{} // TODO: extract from parsed N3
-	// TODO: Validate blank nodes and variables as in the TypeScript version
-	return &N3Patch{
-		Deletes:    deletes,
-		Inserts:    inserts,
-		Conditions: conditions,
-		Binary:     true,
-		Data:       n3,
-		Metadata:   args.Metadata,
-		IsEmpty:    false,
-	}, nil
+		return nil, errors.NewUnprocessableEntityError(fmt.Sprintf(
+			"This patcher only supports N3 Patch documents with exactly 1 solid:InsertDeletePatch entry, but received %d.",
+			len(patches)), nil)
+	}
+	patch := patches[0]
+
+	// The formulae are optional and presumed to be empty when not present
+	deletes, err := findFormula(store, patch, vocabularies.SOLID.Deletes)
+	if err != nil {
+		return nil, err
+	}
+	inserts, err := findFormula(store, patch, vocabularies.SOLID.Inserts)
+	if err != nil {
+		return nil, err
+	}
+	conditions, err := findFormula(store, patch, vocabularies.SOLID.Where)
+	if err != nil {
+		return nil, err
+	}
+
+	// Solid, §5.3.1: insertions and deletions must not contain variables that do not occur in the conditions
+	known := make(map[string]bool)
+	for _, variable := range findVariables(conditions) {
+		known[variable.Value()] = true
+	}
+	for _, variable := range findVariables(append(append([]n3.Quad{}, inserts...), deletes...)) {
+		if !known[variable.Value()] {
+			return nil, errors.NewUnprocessableEntityError(
+				"Variables in the insert or delete formula must occur in the where formula: "+variable.String(), nil)
+		}
+	}
+
+	// Solid, §5.3.1: deletions must not contain blank nodes, as they can never match the target document
+	for _, quad := range deletes {
+		if quad.Subject.TermType() == n3.BlankNodeType || quad.Object.TermType() == n3.BlankNodeType {
+			return nil, errors.NewUnprocessableEntityError("The delete formula can not contain blank nodes.", nil)
+		}
+	}
+
+	rep := representation.NewBasicRepresentation(bytes.NewReader(data), args.Metadata, true)
+	return representation.NewBasicN3Patch(rep, deletes, inserts, conditions), nil
+}
+
+// findFormula returns the triples of the formula linked from the patch with the given predicate
+func findFormula(store *n3.BasicStore, patch n3.Term, predicate n3.Term) ([]n3.Quad, error) {
+	formulae := store.GetObjects(patch, predicate, n3.DefaultGraph())
+	if len(formulae) == 0 {
+		return nil, nil
+	}
+	if len(formulae) > 1 {
+		return nil, errors.NewUnprocessableEntityError(
+			fmt.Sprintf("An N3 Patch can have at most 1 %v.", predicate.Value()), nil)
+	}
+	formula := formulae[0]
+	// The parser stores the triples of a formula in the graph named by its blank node
+	if formula.TermType() != n3.BlankNodeType {
+		return nil, errors.NewUnprocessableEntityError(
+			fmt.Sprintf("The object of %v must be a formula.", predicate.Value()), nil)
+	}
+	quads := store.GetQuads(nil, nil, nil, formula)
+	triples := make([]n3.Quad, 0, len(quads))
+	for _, quad := range quads {
+		for _, term := range []n3.Term{quad.Subject, quad.Object} {
+			if term.TermType() == n3.BlankNodeType && store.CountQuads(nil, nil, nil, term) > 0 {
+				return nil, errors.NewUnprocessableEntityError("N3 Patch formulae can not be nested.", nil)
+			}
+		}
+		triples = append(triples, n3.NewQuad(quad.Subject, quad.Predicate, quad.Object, nil))
+	}
+	if len(triples) == 0 && store.CountQuads(formula, nil, nil, nil) > 0 {
+		return nil, errors.NewUnprocessableEntityError(
+			fmt.Sprintf("The object of %v must be a formula.", predicate.Value()), nil)
+	}
+	return triples, nil
+}
+
+// findVariables returns the distinct variables used in the given triples
+func findVariables(quads []n3.Quad) []n3.Term {
+	seen := make(map[string]bool)
+	var variables []n3.Term
+	for _, quad := range quads {
+		for _, term := range []n3.Term{quad.Subject, quad.Predicate, quad.Object} {
+			if term.TermType() == n3.VariableType && !seen[term.Value()] {
+				seen[term.Value()] = true
+				variables = append(variables, term)
+			}
+		}
+	}
+	return variables
 }
@@ -0,0 +1,107 @@
+package body
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+)
+
+const target = "http://example.org/doc.ttl"
+
+const prefixes = `@prefix solid: <http://www.w3.org/ns/solid/terms#>.
+@prefix ex: <http://example.org/terms#>.
+`
+
+func parseN3Patch(body string) (representation.Representation, error) {
+	request := httptest.NewRequest("PATCH", target, strings.NewReader(prefixes+body))
+	metadata := representation.NewRepresentationMetadata(target).SetContentType("text/n3")
+	return NewN3PatchBodyParser(0).Handle(BodyParserArgs{Request: request, Metadata: metadata})
+}
+
+func TestN3PatchBodyParser_CanHandle(t *testing.T) {
+	parser := NewN3PatchBodyParser(0)
+	if err := parser.CanHandle(BodyParserArgs{Metadata: representation.NewRepresentationMetadata(target).SetContentType("text/n3; charset=utf-8")}); err != nil {
+		t.Errorf("CanHandle() of text/n3 error = %v", err)
+	}
+	if err := parser.CanHandle(BodyParserArgs{Metadata: representation.NewRepresentationMetadata(target).SetContentType("text/turtle")}); !errors.IsUnsupportedMediaTypeError(err) {
+		t.Errorf("CanHandle() of text/turtle error = %v, want UnsupportedMediaTypeError", err)
+	}
+}
+
+func TestN3PatchBodyParser_Handle(t *testing.T) {
+	rep, err := parseN3Patch(`_:patch a solid:InsertDeletePatch;
+  solid:where { ?a ex:name ?name };
+  solid:deletes { ?a ex:name ?name };
+  solid:inserts { ?a ex:name "new"; ex:knows [ ex:name ?name ] }.`)
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	patch, ok := rep.(representation.N3Patch)
+	if !ok || !representation.IsN3Patch(patch) {
+		t.Fatalf("Handle() = %T, want an N3Patch", rep)
+	}
+	if len(patch.GetConditions()) != 1 || len(patch.GetDeletes()) != 1 || len(patch.GetInserts()) != 3 {
+		t.Errorf("formulae = %v, %v, %v", patch.GetConditions(), patch.GetDeletes(), patch.GetInserts())
+	}
+	for _, quad := range patch.GetInserts() {
+		if !quad.Graph.Equals(n3.DefaultGraph()) {
+			t.Errorf("inserted quad %v is not in the default graph", quad)
+		}
+	}
+
+	empty, err := parseN3Patch(`<#patch> a solid:InsertDeletePatch.`)
+	if err != nil {
+		t.Fatalf("Handle() of a patch without formulae error = %v", err)
+	}
+	if !representation.IsN3Patch(empty) || len(empty.(representation.N3Patch).GetInserts()) != 0 {
+		t.Errorf("missing formulae should be empty")
+	}
+}
+
+func TestN3PatchBodyParser_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want func(error) bool
+	}{
+		{"syntax error", `_:patch a solid:InsertDeletePatch; solid:inserts { ex:a ex:b }.`, errors.IsValidationError},
+		{"no patch", `<> ex:p ex:o.`, errors.IsUnprocessableEntityError},
+		{"two patches", `_:a a solid:InsertDeletePatch. _:b a solid:InsertDeletePatch.`, errors.IsUnprocessableEntityError},
+		{"two insert formulae", `_:p a solid:InsertDeletePatch; solid:inserts { ex:a ex:b ex:c }, { ex:a ex:b ex:d }.`,
+			errors.IsUnprocessableEntityError},
+		{"formula is not a formula", `_:p a solid:InsertDeletePatch; solid:inserts ex:a.`, errors.IsUnprocessableEntityError},
+		{"property list instead of formula", `_:p a solid:InsertDeletePatch; solid:inserts [ ex:a ex:b ].`, errors.IsUnprocessableEntityError},
+		{"nested formula", `_:p a solid:InsertDeletePatch; solid:inserts { ex:a ex:b { ex:c ex:d ex:e } }.`,
+			errors.IsUnprocessableEntityError},
+		{"unknown variable in inserts", `_:p a solid:InsertDeletePatch; solid:where { ?a ex:b ex:c }; solid:inserts { ?x ex:b ex:c }.`,
+			errors.IsUnprocessableEntityError},
+		{"unknown variable in deletes", `_:p a solid:InsertDeletePatch; solid:deletes { ?x ex:b ex:c }.`, errors.IsUnprocessableEntityError},
+		{"blank node in deletes", `_:p a solid:InsertDeletePatch; solid:deletes { _:x ex:b ex:c }.`, errors.IsUnprocessableEntityError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseN3Patch(tt.body); !tt.want(err) {
+				t.Errorf("Handle() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestN3PatchBodyParser_MaxSize(t *testing.T) {
+	patch := prefixes + `_:patch a solid:InsertDeletePatch; solid:inserts { <#a> ex:name "new" }.`
+	metadata := representation.NewRepresentationMetadata(target).SetContentType("text/n3")
+	request := httptest.NewRequest("PATCH", target, strings.NewReader(patch))
+	_, err := NewN3PatchBodyParser(int64(len(patch) - 1)).Handle(BodyParserArgs{Request: request, Metadata: metadata})
+	if !errors.IsPayloadTooLargeError(err) || errors.StatusCode(err) != 413 {
+		t.Errorf("Handle() of a too large patch error = %v, want PayloadTooLargeError", err)
+	}
+
+	request = httptest.NewRequest("PATCH", target, strings.NewReader(patch))
+	if _, err := NewN3PatchBodyParser(int64(len(patch))).Handle(BodyParserArgs{Request: request, Metadata: metadata}); err != nil {
+		t.Errorf("Handle() of a patch of the maximum size error = %v", err)
+	}
+}
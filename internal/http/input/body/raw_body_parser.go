@@ -2,19 +2,36 @@
 package body
 
 import (
-	"errors"
+	"net/http"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
 )
 
 // RawBodyParser converts incoming HTTP requests to a BasicRepresentation.
 type RawBodyParser struct{}
 
-// CanHandle always returns nil (no-op for raw parser).
+// NewRawBodyParser creates a new RawBodyParser
+func NewRawBodyParser() *RawBodyParser {
+	return &RawBodyParser{}
+}
+
+// CanHandle always returns nil, as every body can be passed on as raw data.
 func (p *RawBodyParser) CanHandle(args BodyParserArgs) error {
 	return nil
 }
 
 // Handle converts the request to a BasicRepresentation, validating headers.
-func (p *RawBodyParser) Handle(args BodyParserArgs) (interface{}, error) {
-	// TODO: Implement header checks and return BasicRepresentation
-	return nil, errors.New("RawBodyParser.Handle not implemented")
+func (p *RawBodyParser) Handle(args BodyParserArgs) (representation.Representation, error) {
+	// RFC 7230, §3.3: a message has a body if it has a Content-Length or Transfer-Encoding header
+	if args.Request.Body == nil || args.Request.Body == http.NoBody ||
+		(args.Request.ContentLength == 0 && len(args.Request.TransferEncoding) == 0) {
+		return representation.NewBasicRepresentation(http.NoBody, args.Metadata, true), nil
+	}
+	// While RFC 7231 allows treating a body without content type as an octet stream,
+	// such an omission likely signals a mistake, so force clients to make this explicit.
+	if args.Request.Header.Get("Content-Type") == "" {
+		return nil, errors.NewValidationError("HTTP request body was passed without a Content-Type header", nil)
+	}
+	return representation.NewBasicRepresentation(args.Request.Body, args.Metadata, true), nil
 }
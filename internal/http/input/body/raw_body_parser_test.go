@@ -0,0 +1,56 @@
+package body
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
+
+func TestRawBodyParser(t *testing.T) {
+	parser := NewRawBodyParser()
+	metadata := representation.NewRepresentationMetadata(target)
+
+	rep, err := parser.Handle(BodyParserArgs{Request: httptest.NewRequest("GET", target, nil), Metadata: metadata})
+	if err != nil {
+		t.Fatalf("Handle() without body error = %v", err)
+	}
+	if data, _ := io.ReadAll(rep.GetData()); len(data) != 0 || rep.GetMetadata() != metadata {
+		t.Errorf("Handle() without body = %q", data)
+	}
+
+	request := httptest.NewRequest("PUT", target, strings.NewReader("hello"))
+	if _, err := parser.Handle(BodyParserArgs{Request: request, Metadata: metadata}); !errors.IsValidationError(err) {
+		t.Errorf("Handle() without Content-Type error = %v, want ValidationError", err)
+	}
+	request.Header.Set("Content-Type", "text/plain")
+	rep, err = parser.Handle(BodyParserArgs{Request: request, Metadata: metadata})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if data, _ := io.ReadAll(rep.GetData()); string(data) != "hello" || !rep.IsBinary() {
+		t.Errorf("Handle() = %q", data)
+	}
+}
+
+func TestWaterfallBodyParser(t *testing.T) {
+	parser := NewWaterfallBodyParser(NewN3PatchBodyParser(0), NewSparqlUpdateBodyParser(0), NewRawBodyParser())
+	metadata := representation.NewRepresentationMetadata(target).SetContentType("text/n3")
+
+	patch := httptest.NewRequest("PATCH", target, strings.NewReader(prefixes+"_:p a solid:InsertDeletePatch."))
+	rep, err := parser.Handle(BodyParserArgs{Request: patch, Metadata: metadata})
+	if _, ok := rep.(representation.N3Patch); !ok || err != nil {
+		t.Errorf("Handle() of a PATCH = %T, %v, want an N3Patch", rep, err)
+	}
+
+	// Only PATCH bodies are patch documents, an N3 document can also be stored as is
+	put := httptest.NewRequest("PUT", target, strings.NewReader("<#a> <#b> <#c>."))
+	put.Header.Set("Content-Type", "text/n3")
+	rep, err = parser.Handle(BodyParserArgs{Request: put, Metadata: metadata})
+	if _, ok := rep.(representation.N3Patch); ok || err != nil {
+		t.Errorf("Handle() of a PUT = %T, %v, want the raw body", rep, err)
+	}
+}
@@ -2,25 +2,58 @@
 package body
 
 import (
-	"errors"
+	"bytes"
+	stderrors "errors"
+	"strings"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
 )
 
 // SparqlUpdateBodyParser parses SPARQL UPDATE content and returns a SparqlUpdatePatch representation.
-type SparqlUpdateBodyParser struct{}
+type SparqlUpdateBodyParser struct {
+	maxSize int64
+}
+
+// NewSparqlUpdateBodyParser creates a new SparqlUpdateBodyParser that accepts documents of at most maxSize bytes,
+// or DefaultMaxPatchSize if it is not positive
+func NewSparqlUpdateBodyParser(maxSize int64) *SparqlUpdateBodyParser {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxPatchSize
+	}
+	return &SparqlUpdateBodyParser{maxSize: maxSize}
+}
 
 // CanHandle checks if the metadata content type is application/sparql-update.
 func (p *SparqlUpdateBodyParser) CanHandle(args BodyParserArgs) error {
-	// TODO: Replace with actual content type check and metadata type
-	if m, ok := args.Metadata.(map[string]interface{}); ok {
-		if m["contentType"] != "application/sparql-update" {
-			return errors.New("This parser only supports SPARQL UPDATE data.")
-		}
+	if args.Metadata == nil || !isPatchRequest(args) {
+		return errors.NewUnsupportedMediaTypeError("This parser only supports SPARQL UPDATE data.", nil)
+	}
+	mediaType := strings.TrimSpace(strings.Split(args.Metadata.ContentType(), ";")[0])
+	if !strings.EqualFold(mediaType, util.SparqlUpdate) {
+		return errors.NewUnsupportedMediaTypeError("This parser only supports SPARQL UPDATE data.", nil)
 	}
 	return nil
 }
 
 // Handle parses the SPARQL UPDATE body and returns a SparqlUpdatePatch representation.
-func (p *SparqlUpdateBodyParser) Handle(args BodyParserArgs) (interface{}, error) {
-	// TODO: Implement SPARQL UPDATE parsing logic
-	return nil, errors.New("SparqlUpdateBodyParser.Handle not implemented")
+func (p *SparqlUpdateBodyParser) Handle(args BodyParserArgs) (representation.Representation, error) {
+	if args.Request == nil || args.Request.Body == nil {
+		return nil, errors.NewValidationError("SPARQL UPDATE requests require a body", nil)
+	}
+	data, err := readPatch(args.Request.Body, p.maxSize, "SPARQL UPDATE")
+	if err != nil {
+		return nil, err
+	}
+	algebra, err := n3.ParseSparqlUpdate(bytes.NewReader(data), args.Metadata.GetIdentifier())
+	if err != nil {
+		if stderrors.Is(err, n3.ErrUnsupportedUpdate) {
+			return nil, errors.NewNotImplementedError(err.Error(), err)
+		}
+		return nil, errors.NewValidationError("Invalid SPARQL UPDATE: "+err.Error(), err)
+	}
+	rep := representation.NewBasicRepresentation(bytes.NewReader(data), args.Metadata, true)
+	return representation.NewBasicSparqlUpdatePatch(rep, algebra), nil
 }
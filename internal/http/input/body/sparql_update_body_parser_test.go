@@ -0,0 +1,60 @@
+package body
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
+
+func parseSparqlUpdate(query string) (representation.Representation, error) {
+	request := httptest.NewRequest("PATCH", target, strings.NewReader(query))
+	metadata := representation.NewRepresentationMetadata(target).SetContentType("application/sparql-update")
+	return NewSparqlUpdateBodyParser(0).Handle(BodyParserArgs{Request: request, Metadata: metadata})
+}
+
+func TestSparqlUpdateBodyParser(t *testing.T) {
+	parser := NewSparqlUpdateBodyParser(0)
+	if err := parser.CanHandle(BodyParserArgs{Metadata: representation.NewRepresentationMetadata(target).SetContentType("application/sparql-update; charset=utf-8")}); err != nil {
+		t.Errorf("CanHandle() error = %v", err)
+	}
+	if err := parser.CanHandle(BodyParserArgs{Metadata: representation.NewRepresentationMetadata(target).SetContentType("text/n3")}); !errors.IsUnsupportedMediaTypeError(err) {
+		t.Errorf("CanHandle() of text/n3 error = %v, want UnsupportedMediaTypeError", err)
+	}
+
+	rep, err := parseSparqlUpdate(`PREFIX ex: <http://example.org/terms#> INSERT DATA { <#a> ex:p "b" }`)
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	patch, ok := rep.(representation.SparqlUpdatePatch)
+	if !ok || len(patch.Algebra().Operations) != 1 {
+		t.Fatalf("Handle() = %#v, want a SparqlUpdatePatch with one operation", rep)
+	}
+	if subject := patch.Algebra().Operations[0].Insert[0].Subject.Value(); subject != target+"#a" {
+		t.Errorf("subject = %v, want %v", subject, target+"#a")
+	}
+
+	if _, err := parseSparqlUpdate(`INSERT DATA { <#a> }`); !errors.IsValidationError(err) {
+		t.Errorf("Handle() of an invalid query error = %v, want ValidationError", err)
+	}
+	if _, err := parseSparqlUpdate(`CLEAR DEFAULT`); !errors.IsNotImplementedError(err) {
+		t.Errorf("Handle() of an unsupported operation error = %v, want NotImplementedError", err)
+	}
+}
+
+func TestSparqlUpdateBodyParser_MaxSize(t *testing.T) {
+	query := `PREFIX ex: <http://example.org/terms#> INSERT DATA { <#a> ex:p "b" }`
+	request := httptest.NewRequest("PATCH", target, strings.NewReader(query))
+	metadata := representation.NewRepresentationMetadata(target).SetContentType("application/sparql-update")
+	_, err := NewSparqlUpdateBodyParser(int64(len(query) - 1)).Handle(BodyParserArgs{Request: request, Metadata: metadata})
+	if !errors.IsPayloadTooLargeError(err) || errors.StatusCode(err) != 413 {
+		t.Errorf("Handle() of a too large query error = %v, want PayloadTooLargeError", err)
+	}
+
+	request = httptest.NewRequest("PATCH", target, strings.NewReader(query))
+	if _, err := NewSparqlUpdateBodyParser(int64(len(query))).Handle(BodyParserArgs{Request: request, Metadata: metadata}); err != nil {
+		t.Errorf("Handle() of a query of the maximum size error = %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package body
+
+import (
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
+
+// WaterfallBodyParser parses a body with the first of its parsers that supports it
+type WaterfallBodyParser struct {
+	parsers []BodyParser
+}
+
+// NewWaterfallBodyParser creates a new WaterfallBodyParser
+func NewWaterfallBodyParser(parsers ...BodyParser) *WaterfallBodyParser {
+	return &WaterfallBodyParser{parsers: parsers}
+}
+
+// CanHandle implements BodyParser.CanHandle
+func (p *WaterfallBodyParser) CanHandle(args BodyParserArgs) error {
+	_, err := p.find(args)
+	return err
+}
+
+// Handle implements BodyParser.Handle
+func (p *WaterfallBodyParser) Handle(args BodyParserArgs) (representation.Representation, error) {
+	parser, err := p.find(args)
+	if err != nil {
+		return nil, err
+	}
+	return parser.Handle(args)
+}
+
+// find returns the first parser that supports the body, or the error of the last one
+func (p *WaterfallBodyParser) find(args BodyParserArgs) (BodyParser, error) {
+	err := errors.NewNotImplementedError("no parser supports this body", nil)
+	for _, parser := range p.parsers {
+		if err = parser.CanHandle(args); err == nil {
+			return parser, nil
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,104 @@
+package conditions
+
+import (
+	"strings"
+	"time"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
+
+// BasicConditionsOptions holds parsed precondition options.
+type BasicConditionsOptions struct {
+	MatchesETag     []string
+	NotMatchesETag  []string
+	ModifiedSince   *time.Time
+	UnmodifiedSince *time.Time
+}
+
+// BasicConditions evaluates the If-Match, If-None-Match, If-Modified-Since and If-Unmodified-Since preconditions
+// in the order of RFC 9110, section 13.2.2.
+type BasicConditions struct {
+	ETagHandler ETagHandler
+	Options     BasicConditionsOptions
+}
+
+// NewBasicConditions creates a new BasicConditions, comparing ETags with a BasicETagHandler if handler is nil.
+func NewBasicConditions(handler ETagHandler, opts BasicConditionsOptions) *BasicConditions {
+	if handler == nil {
+		handler = NewBasicETagHandler()
+	}
+	return &BasicConditions{ETagHandler: handler, Options: opts}
+}
+
+// MatchesMetadata implements Conditions.MatchesMetadata
+func (c *BasicConditions) MatchesMetadata(metadata *representation.RepresentationMetadata, strict bool) bool {
+	return c.preconditionsHold(metadata, strict) && c.isModified(metadata, strict)
+}
+
+// Evaluate implements Conditions.Evaluate
+func (c *BasicConditions) Evaluate(metadata *representation.RepresentationMetadata, safe bool) error {
+	// Reads compare the exact representation, writes only the state of the resource
+	strict := safe
+	if !c.preconditionsHold(metadata, strict) {
+		return errors.NewPreconditionFailedError("the If-Match or If-Unmodified-Since condition does not hold", nil)
+	}
+	if c.isModified(metadata, strict) {
+		return nil
+	}
+	if safe {
+		eTag := ""
+		if metadata != nil {
+			eTag = c.ETagHandler.GetETag(metadata)
+		}
+		return errors.NewNotModifiedError("the representation has not been modified", eTag)
+	}
+	return errors.NewPreconditionFailedError("the If-None-Match condition does not hold", nil)
+}
+
+// preconditionsHold evaluates If-Match, or If-Unmodified-Since when there is no If-Match
+func (c *BasicConditions) preconditionsHold(metadata *representation.RepresentationMetadata, strict bool) bool {
+	if len(c.Options.MatchesETag) > 0 {
+		if metadata == nil {
+			return false
+		}
+		for _, eTag := range c.Options.MatchesETag {
+			// If-Match uses the strong comparison, so weak ETags never match
+			if eTag == "*" || (!isWeak(eTag) && c.ETagHandler.MatchesETag(metadata, eTag, strict)) {
+				return true
+			}
+		}
+		return false
+	}
+	if c.Options.UnmodifiedSince != nil && metadata != nil {
+		modified := ModifiedTime(metadata)
+		return modified.IsZero() || !modified.Truncate(time.Second).After(*c.Options.UnmodifiedSince)
+	}
+	return true
+}
+
+// isModified evaluates If-None-Match, or If-Modified-Since when there is no If-None-Match
+func (c *BasicConditions) isModified(metadata *representation.RepresentationMetadata, strict bool) bool {
+	if len(c.Options.NotMatchesETag) > 0 {
+		if metadata == nil {
+			return true
+		}
+		for _, eTag := range c.Options.NotMatchesETag {
+			// If-None-Match uses the weak comparison
+			if eTag == "*" || c.ETagHandler.MatchesETag(metadata, strings.TrimPrefix(eTag, "W/"), strict) {
+				return false
+			}
+		}
+		return true
+	}
+	if c.Options.ModifiedSince != nil && metadata != nil {
+		modified := ModifiedTime(metadata)
+		return modified.IsZero() || modified.Truncate(time.Second).After(*c.Options.ModifiedSince)
+	}
+	return true
+}
+
+// isWeak checks if an ETag is a weak validator
+func isWeak(eTag string) bool {
+	return strings.HasPrefix(eTag, "W/")
+}
@@ -4,32 +4,11 @@ package conditions
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 )
 
-// ETagHandler is a placeholder for ETag handling logic.
-type ETagHandler interface{}
-
-// BasicConditionsOptions holds parsed precondition options.
-type BasicConditionsOptions struct {
-	MatchesETag     []string
-	NotMatchesETag  []string
-	ModifiedSince   *time.Time
-	UnmodifiedSince *time.Time
-}
-
-// BasicConditions is a placeholder for a Conditions implementation.
-type BasicConditions struct {
-	ETagHandler ETagHandler
-	Options     BasicConditionsOptions
-}
-
-// NewBasicConditions creates a new BasicConditions.
-func NewBasicConditions(handler ETagHandler, opts BasicConditionsOptions) *BasicConditions {
-	return &BasicConditions{ETagHandler: handler, Options: opts}
-}
-
 // BasicConditionsParser parses HTTP precondition headers into a Conditions object.
 type BasicConditionsParser struct {
 	ETagHandler ETagHandler
@@ -41,7 +20,8 @@ func NewBasicConditionsParser(handler ETagHandler) *BasicConditionsParser {
 }
 
 // Handle parses the relevant headers and returns a Conditions object if any are present.
-func (p *BasicConditionsParser) Handle(method string, headers map[string]string) (*BasicConditions, error) {
+func (p *BasicConditionsParser) Handle(request *http.Request) (Conditions, error) {
+	method, headers := request.Method, request.Header
 	options := BasicConditionsOptions{
 		MatchesETag:    parseTagHeader(headers, "if-match"),
 		NotMatchesETag: parseTagHeader(headers, "if-none-match"),
@@ -64,8 +44,8 @@ func (p *BasicConditionsParser) Handle(method string, headers map[string]string)
 }
 
 // parseDateHeader parses a date header into a time.Time pointer.
-func parseDateHeader(headers map[string]string, header string) *time.Time {
-	if val, ok := headers[header]; ok && val != "" {
+func parseDateHeader(headers http.Header, header string) *time.Time {
+	if val := headers.Get(header); val != "" {
 		t, err := httpDateParse(val)
 		if err == nil {
 			return &t
@@ -75,8 +55,8 @@ func parseDateHeader(headers map[string]string, header string) *time.Time {
 }
 
 // parseTagHeader parses a comma-separated ETag header into a slice.
-func parseTagHeader(headers map[string]string, header string) []string {
-	if val, ok := headers[header]; ok && val != "" {
+func parseTagHeader(headers http.Header, header string) []string {
+	if val := strings.Join(headers.Values(header), ","); val != "" {
 		return splitCommaSeparated(val)
 	}
 	return nil
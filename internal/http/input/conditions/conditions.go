@@ -0,0 +1,2 @@
+// Package conditions parses and evaluates HTTP precondition headers.
+package conditions
@@ -1,10 +1,25 @@
 // Package conditions provides the ConditionsParser interface for parsing HTTP request conditions.
 package conditions
 
+import (
+	"net/http"
+
+	"solid-go/internal/http/representation"
+)
+
 // Conditions represents the result of parsing HTTP precondition headers.
-type Conditions interface{}
+type Conditions interface {
+	// MatchesMetadata checks if the conditions hold for a resource with the given metadata,
+	// nil metadata means the resource does not exist.
+	// With strict set, ETags also have to match the representation and not only the state of the resource.
+	MatchesMetadata(metadata *representation.RepresentationMetadata, strict bool) bool
+	// Evaluate returns the error to answer the request with if the conditions do not hold.
+	// Safe methods result in a NotModifiedError when only If-None-Match or If-Modified-Since fails,
+	// all other failures result in a PreconditionFailedError.
+	Evaluate(metadata *representation.RepresentationMetadata, safe bool) error
+}
 
 // ConditionsParser creates a Conditions object based on the input request headers.
 type ConditionsParser interface {
-	Handle(method string, headers map[string]string) (Conditions, error)
+	Handle(request *http.Request) (Conditions, error)
 }
@@ -0,0 +1,116 @@
+package conditions
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
+)
+
+const resource = "http://example.org/doc.ttl"
+
+var modified = time.Date(2024, 3, 1, 12, 0, 0, 500, time.UTC)
+
+func metadataAt(t time.Time, contentType string) *representation.RepresentationMetadata {
+	metadata := representation.NewRepresentationMetadata(resource).SetContentType(contentType)
+	metadata.AddQuad(n3.NewQuad(n3.NewNamedNode(resource), vocabularies.DC.Modified,
+		n3.NewTypedLiteral(t.Format(time.RFC3339Nano), n3.NewNamedNode(n3.XSDDateTime)), nil))
+	return metadata
+}
+
+func TestBasicETagHandler(t *testing.T) {
+	handler := NewBasicETagHandler()
+	turtle := handler.GetETag(metadataAt(modified, "text/turtle; charset=utf-8"))
+	if turtle == "" || turtle != handler.GetETag(metadataAt(modified, "text/turtle")) {
+		t.Fatalf("GetETag() = %v, parameters should not change the ETag", turtle)
+	}
+	jsonld := handler.GetETag(metadataAt(modified, "application/ld+json"))
+	if turtle == jsonld || !handler.SameResourceState(turtle, jsonld) {
+		t.Errorf("representations of the same state: %v and %v", turtle, jsonld)
+	}
+	later := handler.GetETag(metadataAt(modified.Add(time.Millisecond), "text/turtle"))
+	if handler.SameResourceState(turtle, later) {
+		t.Errorf("writes within the same second have the same ETag %v", later)
+	}
+	if handler.MatchesETag(metadataAt(modified, "text/turtle"), jsonld, true) {
+		t.Errorf("strict MatchesETag() matched another representation")
+	}
+	if !handler.MatchesETag(metadataAt(modified, "text/turtle"), jsonld, false) {
+		t.Errorf("MatchesETag() did not match another representation of the same state")
+	}
+	if handler.GetETag(representation.NewRepresentationMetadata(resource)) != "" {
+		t.Errorf("GetETag() without modification time is not empty")
+	}
+}
+
+func request(method string, headers map[string]string) *http.Request {
+	request := httptest.NewRequest(method, resource, nil)
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	return request
+}
+
+func TestBasicConditions_Evaluate(t *testing.T) {
+	parser := NewBasicConditionsParser(NewBasicETagHandler())
+	current := metadataAt(modified, "text/turtle")
+	eTag := NewBasicETagHandler().GetETag(current)
+	jsonldETag := NewBasicETagHandler().GetETag(metadataAt(modified, "application/ld+json"))
+	before := modified.Add(-time.Hour).Format(http.TimeFormat)
+	after := modified.Add(time.Hour).Format(http.TimeFormat)
+	same := modified.Format(http.TimeFormat)
+
+	tests := []struct {
+		name     string
+		method   string
+		headers  map[string]string
+		metadata *representation.RepresentationMetadata
+		want     func(error) bool
+	}{
+		{"if-match", "PUT", map[string]string{"if-match": eTag}, current, nil},
+		{"if-match other representation on write", "PUT", map[string]string{"if-match": jsonldETag}, current, nil},
+		{"if-match other representation on read", "GET", map[string]string{"if-match": jsonldETag}, current, errors.IsPreconditionFailedError},
+		{"if-match stale", "PUT", map[string]string{"if-match": `"1-text/turtle"`}, current, errors.IsPreconditionFailedError},
+		{"if-match weak", "PUT", map[string]string{"if-match": "W/" + eTag}, current, errors.IsPreconditionFailedError},
+		{"if-match star on missing resource", "PUT", map[string]string{"if-match": "*"}, nil, errors.IsPreconditionFailedError},
+		{"if-none-match star on missing resource", "PUT", map[string]string{"if-none-match": "*"}, nil, nil},
+		{"if-none-match star on existing resource", "PUT", map[string]string{"if-none-match": "*"}, current, errors.IsPreconditionFailedError},
+		{"if-none-match on read", "GET", map[string]string{"if-none-match": `"1-text/turtle", ` + eTag}, current, errors.IsNotModifiedError},
+		{"if-none-match weak on read", "HEAD", map[string]string{"if-none-match": "W/" + eTag}, current, errors.IsNotModifiedError},
+		{"if-none-match other representation", "GET", map[string]string{"if-none-match": jsonldETag}, current, nil},
+		{"if-modified-since unchanged", "GET", map[string]string{"if-modified-since": same}, current, errors.IsNotModifiedError},
+		{"if-modified-since changed", "GET", map[string]string{"if-modified-since": before}, current, nil},
+		{"if-modified-since ignored with if-none-match", "GET",
+			map[string]string{"if-modified-since": after, "if-none-match": jsonldETag}, current, nil},
+		{"if-unmodified-since", "DELETE", map[string]string{"if-unmodified-since": same}, current, nil},
+		{"if-unmodified-since changed", "DELETE", map[string]string{"if-unmodified-since": before}, current, errors.IsPreconditionFailedError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, err := parser.Handle(request(tt.method, tt.headers))
+			if err != nil || conditions == nil {
+				t.Fatalf("Handle() = %v, %v", conditions, err)
+			}
+			safe := tt.method == "GET" || tt.method == "HEAD"
+			err = conditions.Evaluate(tt.metadata, safe)
+			if tt.want == nil && err != nil {
+				t.Errorf("Evaluate() error = %v", err)
+			}
+			if tt.want != nil && !tt.want(err) {
+				t.Errorf("Evaluate() error = %v", err)
+			}
+			if errors.IsNotModifiedError(err) && errors.ResponseHeaders(err)["ETag"] != eTag {
+				t.Errorf("304 response ETag = %v, want %v", errors.ResponseHeaders(err)["ETag"], eTag)
+			}
+		})
+	}
+
+	if conditions, err := parser.Handle(request("GET", nil)); conditions != nil || err != nil {
+		t.Errorf("Handle() without conditions = %v, %v", conditions, err)
+	}
+}
@@ -0,0 +1,82 @@
+package conditions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/vocabularies"
+)
+
+// ETagHandler generates and compares ETags of representations
+type ETagHandler interface {
+	// GetETag returns the ETag of the representation described by the metadata,
+	// or the empty string if the metadata has no modification time
+	GetETag(metadata *representation.RepresentationMetadata) string
+	// MatchesETag checks if the ETag matches the metadata.
+	// Without strict, only the state of the resource is compared, ignoring which representation the ETag belongs to.
+	MatchesETag(metadata *representation.RepresentationMetadata, eTag string, strict bool) bool
+	// SameResourceState checks if two ETags belong to the same state of a resource
+	SameResourceState(eTag1, eTag2 string) bool
+}
+
+// BasicETagHandler creates strong ETags from the modification time and content type of a representation,
+// so every representation of every state of a resource gets a different ETag
+type BasicETagHandler struct{}
+
+// NewBasicETagHandler creates a new BasicETagHandler
+func NewBasicETagHandler() *BasicETagHandler {
+	return &BasicETagHandler{}
+}
+
+// GetETag implements ETagHandler.GetETag
+func (h *BasicETagHandler) GetETag(metadata *representation.RepresentationMetadata) string {
+	modified := ModifiedTime(metadata)
+	if modified.IsZero() {
+		return ""
+	}
+	contentType := strings.TrimSpace(strings.SplitN(metadata.ContentType(), ";", 2)[0])
+	return fmt.Sprintf("\"%d-%s\"", modified.UnixNano(), contentType)
+}
+
+// MatchesETag implements ETagHandler.MatchesETag
+func (h *BasicETagHandler) MatchesETag(metadata *representation.RepresentationMetadata, eTag string, strict bool) bool {
+	own := h.GetETag(metadata)
+	if own == "" {
+		return false
+	}
+	if strict {
+		return own == eTag
+	}
+	return h.SameResourceState(own, eTag)
+}
+
+// SameResourceState implements ETagHandler.SameResourceState
+func (h *BasicETagHandler) SameResourceState(eTag1, eTag2 string) bool {
+	state1, ok1 := resourceState(eTag1)
+	state2, ok2 := resourceState(eTag2)
+	return ok1 && ok2 && state1 == state2
+}
+
+// resourceState returns the modification time part of an ETag generated by the BasicETagHandler
+func resourceState(eTag string) (string, bool) {
+	if !strings.HasPrefix(eTag, "\"") {
+		return "", false
+	}
+	state, _, found := strings.Cut(strings.Trim(eTag, "\""), "-")
+	return state, found
+}
+
+// ModifiedTime returns the dc:modified time in metadata, or the zero time if there is none
+func ModifiedTime(metadata *representation.RepresentationMetadata) time.Time {
+	if metadata == nil {
+		return time.Time{}
+	}
+	for _, quad := range metadata.Quads(metadata.GetIdentifier(), vocabularies.DC.Modified, nil, nil) {
+		if modified, err := time.Parse(time.RFC3339, quad.Object.Value()); err == nil {
+			return modified
+		}
+	}
+	return time.Time{}
+}
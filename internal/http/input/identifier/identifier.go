@@ -0,0 +1,2 @@
+// Package identifier determines the resource targeted by an incoming request.
+package identifier
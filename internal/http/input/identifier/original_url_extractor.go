@@ -2,88 +2,126 @@
 package identifier
 
 import (
-	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
-	"solid-go-main/internal/http/representation"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
 )
 
 // IdentifierStrategy checks if an identifier is within the configured space.
 type IdentifierStrategy interface {
-	SupportsIdentifier(identifier *representation.ResourceIdentifier) bool
+	SupportsIdentifier(identifier representation.ResourceIdentifier) bool
 }
 
 // OriginalUrlExtractorArgs holds configuration for OriginalUrlExtractor.
 type OriginalUrlExtractorArgs struct {
 	IdentifierStrategy IdentifierStrategy
 	IncludeQueryString bool
-	FixedBaseUrl       string
+	// FixedBaseUrl replaces the scheme and host of all requests when set
+	FixedBaseUrl string
 }
 
 // OriginalUrlExtractor reconstructs the original URL of an incoming request.
+// The Forwarded and X-Forwarded-* headers of a reverse proxy take precedence over the request itself.
 type OriginalUrlExtractor struct {
 	IdentifierStrategy IdentifierStrategy
 	IncludeQueryString bool
+	FixedProtocol      string
 	FixedHost          string
 }
 
 // NewOriginalUrlExtractor creates a new OriginalUrlExtractor.
 func NewOriginalUrlExtractor(args OriginalUrlExtractorArgs) *OriginalUrlExtractor {
-	fixedHost := ""
-	if args.FixedBaseUrl != "" {
-		u, err := url.Parse(args.FixedBaseUrl)
-		if err == nil {
-			fixedHost = u.Host
-		}
-	}
-	return &OriginalUrlExtractor{
+	extractor := &OriginalUrlExtractor{
 		IdentifierStrategy: args.IdentifierStrategy,
 		IncludeQueryString: args.IncludeQueryString,
-		FixedHost:          fixedHost,
 	}
+	if args.FixedBaseUrl != "" {
+		if u, err := url.Parse(args.FixedBaseUrl); err == nil {
+			extractor.FixedProtocol = u.Scheme
+			extractor.FixedHost = u.Host
+		}
+	}
+	return extractor
 }
 
 // Handle reconstructs the original URL and returns a ResourceIdentifier.
-func (e *OriginalUrlExtractor) Handle(method string, headers map[string]string, urlPath string, connectionIsTLS bool) (*representation.ResourceIdentifier, error) {
-	if urlPath == "" {
-		return nil, fmt.Errorf("missing URL")
+func (e *OriginalUrlExtractor) Handle(request *http.Request) (representation.ResourceIdentifier, error) {
+	if request.URL == nil {
+		return representation.ResourceIdentifier{}, errors.NewInternalError("missing URL", nil)
 	}
-	// Extract host and protocol
-	host := headers["host"]
+	host := request.Host
 	protocol := "http"
-	if connectionIsTLS {
+	if request.TLS != nil {
 		protocol = "https"
 	}
-	// TODO: parse Forwarded/X-Forwarded-* headers if present
+	forwarded := ParseForwarded(request.Header)
+	if forwarded["host"] != "" {
+		host = forwarded["host"]
+	}
+	if proto := strings.ToLower(forwarded["proto"]); proto == "http" || proto == "https" {
+		protocol = proto
+	}
 	if e.FixedHost != "" {
-		host = e.FixedHost
+		protocol, host = e.FixedProtocol, e.FixedHost
 	}
+
+	// Perform a sanity check on the host, it ends up in the identifier of every resource
 	if host == "" {
-		return nil, fmt.Errorf("missing Host header")
+		return representation.ResourceIdentifier{}, errors.NewValidationError("Missing Host header", nil)
 	}
 	if strings.ContainsAny(host, "/\\*") {
-		return nil, fmt.Errorf("invalid Host header: %s", host)
+		return representation.ResourceIdentifier{}, errors.NewValidationError("The request has an invalid Host header: "+host, nil)
 	}
-	originalUrl := &url.URL{
-		Scheme: protocol,
-		Host:   host,
-	}
-	pathname, search := splitPathAndQuery(urlPath)
-	originalUrl.Path = pathname
-	if e.IncludeQueryString && search != "" {
-		originalUrl.RawQuery = strings.TrimPrefix(search, "?")
+
+	originalUrl := &url.URL{Scheme: protocol, Host: host}
+	originalUrl.RawPath = toCanonicalUriPath(request.URL.EscapedPath())
+	originalUrl.Path, _ = url.PathUnescape(originalUrl.RawPath)
+	if e.IncludeQueryString {
+		originalUrl.RawQuery = request.URL.RawQuery
 	}
-	identifier := &representation.ResourceIdentifier{Path: originalUrl.String()}
-	if !e.IdentifierStrategy.SupportsIdentifier(identifier) {
-		return nil, fmt.Errorf("the identifier %s is outside the configured identifier space", identifier.Path)
+	identifier := representation.ResourceIdentifier{Path: originalUrl.String()}
+	if e.IdentifierStrategy != nil && !e.IdentifierStrategy.SupportsIdentifier(identifier) {
+		return representation.ResourceIdentifier{}, errors.NewValidationError(
+			"The identifier "+identifier.Path+" is outside the configured identifier space.", nil)
 	}
 	return identifier, nil
 }
 
-// splitPathAndQuery splits a URL path into path and query string.
-func splitPathAndQuery(urlPath string) (string, string) {
-	if idx := strings.Index(urlPath, "?"); idx != -1 {
-		return urlPath[:idx], urlPath[idx:]
+// ParseForwarded returns the host and proto of the first proxy in the Forwarded header (RFC 7239),
+// falling back to the X-Forwarded-Host and X-Forwarded-Proto headers.
+func ParseForwarded(header http.Header) map[string]string {
+	forwarded := make(map[string]string)
+	if value := header.Get("Forwarded"); value != "" {
+		first, _, _ := strings.Cut(value, ",")
+		for _, pair := range strings.Split(first, ";") {
+			name, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			name = strings.ToLower(name)
+			if ok && (name == "by" || name == "for" || name == "host" || name == "proto") {
+				forwarded[name] = strings.Trim(val, `"`)
+			}
+		}
+		return forwarded
+	}
+	for _, suffix := range []string{"host", "proto"} {
+		if value := header.Get("X-Forwarded-" + suffix); value != "" {
+			first, _, _ := strings.Cut(value, ",")
+			forwarded[suffix] = strings.TrimSpace(first)
+		}
+	}
+	return forwarded
+}
+
+// toCanonicalUriPath normalizes the percent-encoding of every path segment,
+// so equivalent request paths result in the same identifier
+func toCanonicalUriPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if decoded, err := url.PathUnescape(segment); err == nil {
+			segments[i] = url.PathEscape(decoded)
+		}
 	}
-	return urlPath, ""
+	return strings.Join(segments, "/")
 }
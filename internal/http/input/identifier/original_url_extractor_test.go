@@ -0,0 +1,62 @@
+package identifier
+
+import (
+	"crypto/tls"
+	"net/http/httptest"
+	"testing"
+
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/identifiers"
+)
+
+func TestOriginalUrlExtractor(t *testing.T) {
+	extractor := NewOriginalUrlExtractor(OriginalUrlExtractorArgs{
+		IdentifierStrategy: identifiers.NewSingleRootIdentifierStrategy("http://example.org/"),
+	})
+	tests := []struct {
+		name    string
+		url     string
+		headers map[string]string
+		want    string
+	}{
+		{"request host", "http://example.org/foo/bar?x=y", nil, "http://example.org/foo/bar"},
+		{"canonical encoding", "http://example.org/a%7eb/c%20d%2Fe", nil, "http://example.org/a~b/c%20d%2Fe"},
+		{"forwarded", "http://localhost/foo", map[string]string{"Forwarded": `host="example.org";proto=http, host=other.org`}, "http://example.org/foo"},
+		{"x-forwarded", "http://localhost/foo", map[string]string{"X-Forwarded-Host": "example.org, other.org", "X-Forwarded-Proto": "http"}, "http://example.org/foo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", tt.url, nil)
+			for name, value := range tt.headers {
+				request.Header.Set(name, value)
+			}
+			identifier, err := extractor.Handle(request)
+			if err != nil || identifier.Path != tt.want {
+				t.Errorf("Handle() = %v, %v, want %v", identifier.Path, err, tt.want)
+			}
+		})
+	}
+
+	request := httptest.NewRequest("GET", "http://example.org/foo", nil)
+	request.TLS = &tls.ConnectionState{}
+	if _, err := extractor.Handle(request); !errors.IsValidationError(err) {
+		t.Errorf("Handle() of an https URL outside the identifier space error = %v, want ValidationError", err)
+	}
+	for _, host := range []string{"", "example.org/evil", "*"} {
+		request := httptest.NewRequest("GET", "http://example.org/foo", nil)
+		request.Host = host
+		if _, err := extractor.Handle(request); !errors.IsValidationError(err) {
+			t.Errorf("Handle() with Host %q error = %v, want ValidationError", host, err)
+		}
+	}
+}
+
+func TestOriginalUrlExtractor_Options(t *testing.T) {
+	extractor := NewOriginalUrlExtractor(OriginalUrlExtractorArgs{IncludeQueryString: true, FixedBaseUrl: "https://pod.example/"})
+	request := httptest.NewRequest("GET", "http://localhost:3000/foo?x=y", nil)
+	request.Header.Set("X-Forwarded-Host", "example.org")
+	identifier, err := extractor.Handle(request)
+	if err != nil || identifier.Path != "https://pod.example/foo?x=y" {
+		t.Errorf("Handle() = %v, %v", identifier.Path, err)
+	}
+}
@@ -1,9 +1,13 @@
 // Package identifier provides the TargetExtractor interface for extracting resource targets from HTTP requests.
 package identifier
 
-import "solid-go-main/internal/http/representation"
+import (
+	"net/http"
+
+	"solid-go/internal/http/representation"
+)
 
 // TargetExtractor extracts a ResourceIdentifier from an incoming HTTP request.
 type TargetExtractor interface {
-	Handle(method string, headers map[string]string, urlPath string, connectionIsTLS bool) (*representation.ResourceIdentifier, error)
+	Handle(request *http.Request) (representation.ResourceIdentifier, error)
 }
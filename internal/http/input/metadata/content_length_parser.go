@@ -2,32 +2,24 @@
 package metadata
 
 import (
-	"fmt"
 	"strconv"
 )
 
+// ContentLengthParser stores the Content-Length header as the "contentLength" metadata entry
 type ContentLengthParser struct{}
 
-func (p *ContentLengthParser) Handle(input map[string]interface{}) error {
-	req, ok := input["request"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid request type")
-	}
-	metadata, ok := input["metadata"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid metadata type")
-	}
-	headers, ok := req["headers"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid headers type")
-	}
-	contentLength, _ := headers["content-length"].(string)
+// NewContentLengthParser creates a new ContentLengthParser
+func NewContentLengthParser() *ContentLengthParser {
+	return &ContentLengthParser{}
+}
+
+// Handle implements MetadataParser.Handle
+func (p *ContentLengthParser) Handle(input MetadataParserInput) error {
+	contentLength := input.Request.Header.Get("Content-Length")
 	if contentLength != "" {
-		length, err := strconv.Atoi(contentLength)
-		if err == nil {
-			metadata["contentLength"] = length
-		} else {
-			// Log warning: invalid content-length header
+		// Invalid values are ignored, the body is read until EOF in that case
+		if length, err := strconv.ParseInt(contentLength, 10, 64); err == nil && length >= 0 {
+			input.Metadata.Add("contentLength", length)
 		}
 	}
 	return nil
@@ -1,30 +1,18 @@
 // Package metadata provides a parser for the content-type header.
 package metadata
 
+// ContentTypeParser sets the content type of the metadata to the Content-Type header
 type ContentTypeParser struct{}
 
-func (p *ContentTypeParser) Handle(input map[string]interface{}) error {
-	req, ok := input["request"].(map[string]interface{})
-	if !ok {
-		return nil
-	}
-	metadata, ok := input["metadata"].(map[string]interface{})
-	if !ok {
-		return nil
-	}
-	headers, ok := req["headers"].(map[string]interface{})
-	if !ok {
-		return nil
-	}
-	contentType, _ := headers["content-type"].(string)
-	if contentType != "" {
-		metadata["contentTypeObject"] = parseContentType(contentType)
-	}
-	return nil
+// NewContentTypeParser creates a new ContentTypeParser
+func NewContentTypeParser() *ContentTypeParser {
+	return &ContentTypeParser{}
 }
 
-// parseContentType is a placeholder for content-type parsing logic.
-func parseContentType(contentType string) interface{} {
-	// TODO: Implement actual content-type parsing
-	return contentType
+// Handle implements MetadataParser.Handle
+func (p *ContentTypeParser) Handle(input MetadataParserInput) error {
+	if contentType := input.Request.Header.Get("Content-Type"); contentType != "" {
+		input.Metadata.SetContentType(contentType)
+	}
+	return nil
 }
@@ -2,69 +2,133 @@
 package metadata
 
 import (
-	"fmt"
+	"strings"
+
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
 )
 
+// LinkRelParser converts Link headers with specific rel values into metadata quads,
+// e.g. rel="type" into rdf:type so a POST can create a container
 type LinkRelParser struct {
 	LinkRelMap map[string]LinkRelObject
 }
 
+// NewLinkRelParser creates a new LinkRelParser
+func NewLinkRelParser(linkRelMap map[string]LinkRelObject) *LinkRelParser {
+	return &LinkRelParser{LinkRelMap: linkRelMap}
+}
+
+// LinkRelObject describes how the targets of Link headers with a specific rel value are stored
 type LinkRelObject struct {
-	Value     string
+	// Value is the predicate of the quads
+	Value string
+	// Ephemeral quads are only used for the current request and never stored
 	Ephemeral bool
+	// AllowList restricts the accepted targets, all are accepted if it is nil
 	AllowList []string
 }
 
-func (p *LinkRelParser) Handle(input map[string]interface{}) error {
-	req, ok := input["request"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid request type")
+// Handle implements MetadataParser.Handle
+func (p *LinkRelParser) Handle(input MetadataParserInput) error {
+	for _, link := range ParseLinkHeaders(input.Request.Header.Values("Link")) {
+		for _, rel := range link.Rels() {
+			if obj, ok := p.LinkRelMap[rel]; ok {
+				obj.AddToMetadata(link.Target, input)
+			}
+		}
+	}
+	return nil
+}
+
+// AddToMetadata adds the target of a link as a quad if it is allowed
+func (o *LinkRelObject) AddToMetadata(object string, input MetadataParserInput) {
+	if !o.objectAllowed(object) {
+		return
 	}
-	metadata, ok := input["metadata"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid metadata type")
+	var graph n3.Term
+	if o.Ephemeral {
+		graph = vocabularies.SOLID_META.ResponseMetadata
 	}
-	headers, ok := req["headers"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid headers type")
+	input.Metadata.AddQuad(n3.NewQuad(n3.NewNamedNode(input.Metadata.GetIdentifier()), n3.NewNamedNode(o.Value),
+		n3.NewNamedNode(object), graph))
+}
+
+func (o *LinkRelObject) objectAllowed(object string) bool {
+	if o.AllowList == nil {
+		return true
 	}
-	linkHeader, _ := headers["link"].(string)
-	links := parseLinkHeader(linkHeader)
-	for _, link := range links {
-		if obj, ok := p.LinkRelMap[link.Rel]; ok {
-			obj.AddToMetadata(link.Target, metadata)
+	for _, allowed := range o.AllowList {
+		if allowed == object {
+			return true
 		}
 	}
-	return nil
+	return false
 }
 
+// LinkHeader is a single link of a Link header
 type LinkHeader struct {
 	Target     string
 	Parameters map[string]string
-	Rel        string
 }
 
-// parseLinkHeader is a placeholder for parsing Link headers.
-func parseLinkHeader(header string) []LinkHeader {
-	// TODO: Implement actual Link header parsing
-	return nil
+// Rels returns the relation types of the link, which can be a space-separated list
+func (l LinkHeader) Rels() []string {
+	return strings.Fields(l.Parameters["rel"])
 }
 
-func (o *LinkRelObject) AddToMetadata(object string, metadata map[string]interface{}) {
-	if o.objectAllowed(object) {
-		// TODO: Add to metadata, handle ephemeral and allowList
-		metadata[o.Value] = object
+// ParseLinkHeaders parses the links in Link header values as described in RFC 8288.
+// Invalid links are skipped.
+func ParseLinkHeaders(headers []string) []LinkHeader {
+	var links []LinkHeader
+	for _, header := range headers {
+		for _, value := range splitOutsideQuotes(header, ',') {
+			if link, ok := parseLink(value); ok {
+				links = append(links, link)
+			}
+		}
 	}
+	return links
 }
 
-func (o *LinkRelObject) objectAllowed(object string) bool {
-	if o.AllowList == nil {
-		return true
+// parseLink parses a single `<target>; name=value` link
+func parseLink(value string) (LinkHeader, bool) {
+	value = strings.TrimSpace(value)
+	end := strings.Index(value, ">")
+	if !strings.HasPrefix(value, "<") || end < 0 {
+		return LinkHeader{}, false
 	}
-	for _, allowed := range o.AllowList {
-		if allowed == object {
-			return true
+	link := LinkHeader{Target: value[1:end], Parameters: make(map[string]string)}
+	for _, param := range splitOutsideQuotes(value[end+1:], ';') {
+		name, paramValue, _ := strings.Cut(strings.TrimSpace(param), "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		// Only the first occurrence of a parameter is used
+		if _, exists := link.Parameters[name]; !exists {
+			link.Parameters[name] = strings.Trim(strings.TrimSpace(paramValue), "\"")
 		}
 	}
-	return false
+	return link, true
+}
+
+// splitOutsideQuotes splits a header value on a separator that is not inside a quoted string or IRI
+func splitOutsideQuotes(value string, separator rune) []string {
+	var parts []string
+	start, quoted, inIRI := 0, false, false
+	for i, char := range value {
+		switch {
+		case char == '"' && !inIRI:
+			quoted = !quoted
+		case char == '<' && !quoted:
+			inIRI = true
+		case char == '>' && !quoted:
+			inIRI = false
+		case char == separator && !quoted && !inIRI:
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
 }
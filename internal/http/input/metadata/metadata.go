@@ -1,7 +1,2 @@
-// Package metadata provides types and interfaces translated from the TypeScript input/metadata folder.
+// Package metadata contains the parsers that convert the headers of an HTTP request into representation metadata.
 package metadata
-
-// MetadataParser parses a specific part of an HttpRequest and converts it into metadata.
-type MetadataParser interface {
-	Handle(args interface{}) (interface{}, error) // TODO: use actual argument and RepresentationMetadata types
-}
@@ -1,6 +1,39 @@
 // Package metadata provides the MetadataParser interface for parsing metadata from HTTP requests.
 package metadata
 
+import (
+	"net/http"
+
+	"solid-go/internal/http/representation"
+)
+
+// MetadataParserInput contains the request to parse and the metadata to add the results to
+type MetadataParserInput struct {
+	Request  *http.Request
+	Metadata *representation.RepresentationMetadata
+}
+
+// MetadataParser parses a specific part of an HTTP request and converts it into metadata
 type MetadataParser interface {
-	HandleSafe(input map[string]interface{}) (interface{}, error)
+	Handle(input MetadataParserInput) error
+}
+
+// ParallelMetadataParser runs all its parsers on the same input
+type ParallelMetadataParser struct {
+	Parsers []MetadataParser
+}
+
+// NewParallelMetadataParser creates a new ParallelMetadataParser
+func NewParallelMetadataParser(parsers ...MetadataParser) *ParallelMetadataParser {
+	return &ParallelMetadataParser{Parsers: parsers}
+}
+
+// Handle implements MetadataParser.Handle
+func (p *ParallelMetadataParser) Handle(input MetadataParserInput) error {
+	for _, parser := range p.Parsers {
+		if err := parser.Handle(input); err != nil {
+			return err
+		}
+	}
+	return nil
 }
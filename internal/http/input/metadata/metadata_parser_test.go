@@ -0,0 +1,91 @@
+package metadata
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/vocabularies"
+)
+
+const target = "http://example.org/foo/"
+
+func parse(t *testing.T, parser MetadataParser, headers map[string][]string) (*representation.RepresentationMetadata, error) {
+	t.Helper()
+	request := httptest.NewRequest("POST", target, nil)
+	for name, values := range headers {
+		for _, value := range values {
+			request.Header.Add(name, value)
+		}
+	}
+	metadata := representation.NewRepresentationMetadata(target)
+	return metadata, parser.Handle(MetadataParserInput{Request: request, Metadata: metadata})
+}
+
+func TestParseLinkHeaders(t *testing.T) {
+	links := ParseLinkHeaders([]string{
+		`<http://example.org/a,b>; rel="type next"; title="x;y", <http://example.org/c>;REL=acl`,
+		`invalid, <http://example.org/d>`,
+	})
+	if len(links) != 3 {
+		t.Fatalf("ParseLinkHeaders() = %v", links)
+	}
+	if links[0].Target != "http://example.org/a,b" || len(links[0].Rels()) != 2 || links[0].Parameters["title"] != "x;y" {
+		t.Errorf("first link = %+v", links[0])
+	}
+	if links[1].Rels()[0] != "acl" || len(links[2].Rels()) != 0 {
+		t.Errorf("links = %+v", links)
+	}
+}
+
+func TestLinkRelParser(t *testing.T) {
+	parser := NewLinkRelParser(map[string]LinkRelObject{
+		"type":        {Value: vocabularies.RDF.Type.Value(), Ephemeral: true, AllowList: []string{vocabularies.LDP.BasicContainer.Value()}},
+		"describedby": {Value: "http://www.w3.org/2007/05/powder-s#describedby"},
+	})
+	metadata, err := parse(t, parser, map[string][]string{"Link": {
+		"<" + vocabularies.LDP.BasicContainer.Value() + `>; rel="type"`,
+		`<http://example.org/Other>; rel="type", <http://example.org/meta>; rel="describedby"`,
+	}})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	types := metadata.Quads(target, vocabularies.RDF.Type, nil, nil)
+	if len(types) != 1 || types[0].Object.Value() != vocabularies.LDP.BasicContainer.Value() {
+		t.Errorf("types = %v, want only the allowed type", types)
+	}
+	if types[0].Graph.Value() != vocabularies.SOLID_META.ResponseMetadata.Value() {
+		t.Errorf("ephemeral type is in graph %v", types[0].Graph)
+	}
+	if len(metadata.Quads(target, "http://www.w3.org/2007/05/powder-s#describedby", "http://example.org/meta", nil)) != 1 {
+		t.Errorf("describedby link is missing from %v", metadata.Quads(nil, nil, nil, nil))
+	}
+}
+
+func TestSlugParser(t *testing.T) {
+	metadata, err := parse(t, NewSlugParser(), map[string][]string{"Slug": {" notes "}})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if slugs := metadata.Quads(target, vocabularies.SOLID_HTTP.Slug, nil, nil); len(slugs) != 1 || slugs[0].Object.Value() != "notes" {
+		t.Errorf("slugs = %v", slugs)
+	}
+	if _, err := parse(t, NewSlugParser(), map[string][]string{"Slug": {"a", "b"}}); !errors.IsValidationError(err) {
+		t.Errorf("Handle() with multiple slugs error = %v", err)
+	}
+}
+
+func TestPlainJsonLdFilter(t *testing.T) {
+	headers := map[string][]string{
+		"Content-Type": {"application/json; charset=utf-8"},
+		"Link":         {`<http://example.org/context.jsonld>; rel="http://www.w3.org/ns/json-ld#context"`},
+	}
+	if _, err := parse(t, NewPlainJsonLdFilter(), headers); !errors.IsUnsupportedMediaTypeError(err) {
+		t.Errorf("Handle() error = %v", err)
+	}
+	headers["Content-Type"] = []string{"application/ld+json"}
+	if _, err := parse(t, NewPlainJsonLdFilter(), headers); err != nil {
+		t.Errorf("Handle() of JSON-LD error = %v", err)
+	}
+}
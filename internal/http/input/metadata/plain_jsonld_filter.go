@@ -2,33 +2,40 @@
 package metadata
 
 import (
-	"fmt"
+	"strings"
+
+	"solid-go/internal/util/errors"
 )
 
+// jsonLdContextRel is the Link relation of a JSON-LD context
+const jsonLdContextRel = "http://www.w3.org/ns/json-ld#context"
+
+// PlainJsonLdFilter rejects JSON-LD sent as application/json with a context Link header,
+// since the server would otherwise store it as plain JSON
 type PlainJsonLdFilter struct{}
 
-func (p *PlainJsonLdFilter) Handle(input map[string]interface{}) error {
-	req, ok := input["request"].(map[string]interface{})
-	if !ok {
-		return nil
-	}
-	metadata, ok := input["metadata"].(map[string]interface{})
-	if !ok {
-		return nil
-	}
-	headers, ok := req["headers"].(map[string]interface{})
-	if !ok {
-		return nil
-	}
-	contentType, _ := headers["content-type"].(string)
-	if contentType == "application/json" && linkHasContextRelation(headers["link"]) {
-		return fmt.Errorf("JSON-LD is only supported with the application/ld+json content type.")
+// NewPlainJsonLdFilter creates a new PlainJsonLdFilter
+func NewPlainJsonLdFilter() *PlainJsonLdFilter {
+	return &PlainJsonLdFilter{}
+}
+
+// Handle implements MetadataParser.Handle
+func (p *PlainJsonLdFilter) Handle(input MetadataParserInput) error {
+	contentType := strings.TrimSpace(strings.SplitN(input.Request.Header.Get("Content-Type"), ";", 2)[0])
+	if strings.EqualFold(contentType, "application/json") && linkHasContextRelation(input.Request.Header.Values("Link")) {
+		return errors.NewUnsupportedMediaTypeError("JSON-LD is only supported with the application/ld+json content type.", nil)
 	}
 	return nil
 }
 
-// linkHasContextRelation is a placeholder for checking Link header for JSON-LD context.
-func linkHasContextRelation(link interface{}) bool {
-	// TODO: Implement actual check for JSON-LD context relation
+// linkHasContextRelation checks if one of the Link headers has the JSON-LD context relation
+func linkHasContextRelation(headers []string) bool {
+	for _, link := range ParseLinkHeaders(headers) {
+		for _, rel := range link.Rels() {
+			if rel == jsonLdContextRel {
+				return true
+			}
+		}
+	}
 	return false
 }
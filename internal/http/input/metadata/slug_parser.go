@@ -2,27 +2,33 @@
 package metadata
 
 import (
-	"fmt"
+	"strings"
+
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
 )
 
+// SlugParser stores the Slug header as a solid-http:slug quad, which POST uses to name the new resource
 type SlugParser struct{}
 
-func (p *SlugParser) Handle(input map[string]interface{}) error {
-	req, ok := input["request"].(map[string]interface{})
-	if !ok {
-		return nil
-	}
-	metadata, ok := input["metadata"].(map[string]interface{})
-	if !ok {
+// NewSlugParser creates a new SlugParser
+func NewSlugParser() *SlugParser {
+	return &SlugParser{}
+}
+
+// Handle implements MetadataParser.Handle
+func (p *SlugParser) Handle(input MetadataParserInput) error {
+	slugs := input.Request.Header.Values("Slug")
+	if len(slugs) == 0 {
 		return nil
 	}
-	headers, ok := req["headers"].(map[string]interface{})
-	if !ok {
-		return nil
+	if len(slugs) > 1 {
+		return errors.NewValidationError("request has multiple Slug headers", nil)
 	}
-	slug, _ := headers["slug"].(string)
-	if slug != "" {
-		metadata["slug"] = slug
+	if slug := strings.TrimSpace(slugs[0]); slug != "" {
+		input.Metadata.AddQuad(n3.NewQuad(n3.NewNamedNode(input.Metadata.GetIdentifier()), vocabularies.SOLID_HTTP.Slug,
+			n3.NewLiteral(slug), vocabularies.SOLID_META.ResponseMetadata))
 	}
 	return nil
 }
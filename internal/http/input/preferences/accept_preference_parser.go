@@ -2,8 +2,11 @@
 package preferences
 
 import (
+	"net/http"
+	"strconv"
 	"strings"
-	"solid-go-main/internal/http/representation"
+
+	"solid-go/internal/http/representation"
 )
 
 // AcceptHeader represents a parsed Accept-* header value.
@@ -24,7 +27,7 @@ func NewAcceptPreferenceParser() *AcceptPreferenceParser {
 }
 
 // Handle extracts preferences from Accept-* headers in the request.
-func (p *AcceptPreferenceParser) Handle(headers map[string]string) *representation.RepresentationPreferences {
+func (p *AcceptPreferenceParser) Handle(request *http.Request) (*representation.RepresentationPreferences, error) {
 	preferences := &representation.RepresentationPreferences{}
 	parsers := []struct {
 		Name   string
@@ -38,40 +41,66 @@ func (p *AcceptPreferenceParser) Handle(headers map[string]string) *representati
 		{"datetime", "accept-datetime", parseAcceptDateTime},
 	}
 	for _, parser := range parsers {
-		if value, ok := headers[parser.Header]; ok && value != "" {
+		if value := strings.Join(request.Header.Values(parser.Header), ","); value != "" {
 			result := map[string]float64{}
 			for _, h := range parser.Parse(value) {
 				result[h.Range] = h.Weight
 			}
 			if len(result) > 0 {
 				switch parser.Name {
-				case "type": preferences.Type = result
-				case "charset": preferences.Charset = result
-				case "encoding": preferences.Encoding = result
-				case "language": preferences.Language = result
-				case "datetime": preferences.Datetime = result
+				case "type":
+					preferences.Type = result
+				case "charset":
+					preferences.Charset = result
+				case "encoding":
+					preferences.Encoding = result
+				case "language":
+					preferences.Language = result
+				case "datetime":
+					preferences.Datetime = result
 				}
 			}
 		}
 	}
-	return preferences
+	return preferences, nil
 }
 
-// The following are stub parser functions. Replace with real implementations as needed.
-func parseAccept(value string) []AcceptHeader          { return parseAcceptGeneric(value) }
-func parseAcceptCharset(value string) []AcceptHeader   { return parseAcceptGeneric(value) }
-func parseAcceptEncoding(value string) []AcceptHeader  { return parseAcceptGeneric(value) }
-func parseAcceptLanguage(value string) []AcceptHeader  { return parseAcceptGeneric(value) }
-func parseAcceptDateTime(value string) []AcceptHeader  { return parseAcceptGeneric(value) }
+// The Accept-* headers share the same syntax, only the allowed ranges differ.
+func parseAccept(value string) []AcceptHeader         { return parseAcceptGeneric(value, true) }
+func parseAcceptCharset(value string) []AcceptHeader  { return parseAcceptGeneric(value, false) }
+func parseAcceptEncoding(value string) []AcceptHeader { return parseAcceptGeneric(value, false) }
+func parseAcceptLanguage(value string) []AcceptHeader { return parseAcceptGeneric(value, false) }
+func parseAcceptDateTime(value string) []AcceptHeader {
+	// Accept-Datetime holds a single date that may contain commas
+	return []AcceptHeader{{Range: strings.TrimSpace(value), Weight: 1.0}}
+}
 
-// parseAcceptGeneric is a stub that splits comma-separated values and assigns weight 1.0.
-func parseAcceptGeneric(value string) []AcceptHeader {
+// parseAcceptGeneric splits comma-separated ranges and reads their q parameter.
+// Entries with an invalid q value or a media type without a slash are ignored.
+func parseAcceptGeneric(value string, mediaTypes bool) []AcceptHeader {
 	parts := strings.Split(value, ",")
 	headers := make([]AcceptHeader, 0, len(parts))
 	for _, part := range parts {
-		p := strings.TrimSpace(part)
-		if p != "" {
-			headers = append(headers, AcceptHeader{Range: p, Weight: 1.0})
+		params := strings.Split(part, ";")
+		rangeValue := strings.ToLower(strings.TrimSpace(params[0]))
+		if rangeValue == "" || (mediaTypes && !strings.Contains(rangeValue, "/")) {
+			continue
+		}
+		weight, valid := 1.0, true
+		for _, param := range params[1:] {
+			name, val, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.TrimSpace(strings.ToLower(name)) != "q" {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+			if err != nil || q < 0 || q > 1 {
+				valid = false
+				break
+			}
+			weight = q
+		}
+		if valid {
+			headers = append(headers, AcceptHeader{Range: rangeValue, Weight: weight})
 		}
 	}
 	return headers
@@ -0,0 +1,2 @@
+// Package preferences parses the content negotiation preferences of a request.
+package preferences
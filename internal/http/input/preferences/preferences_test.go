@@ -0,0 +1,41 @@
+package preferences
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"solid-go/internal/util/errors"
+)
+
+func TestUnionPreferenceParser(t *testing.T) {
+	parser := NewUnionPreferenceParser([]PreferenceParser{NewAcceptPreferenceParser(), NewRangePreferenceParser()})
+	request := httptest.NewRequest("GET", "http://example.org/", nil)
+	request.Header.Add("Accept", "text/turtle;q=0.9, invalid")
+	request.Header.Add("Accept", "application/ld+json")
+	request.Header.Set("Accept-Language", "en-GB, nl;q=0.5")
+	request.Header.Set("Range", "bytes=5-, -10")
+
+	preferences, err := parser.Handle(request)
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if len(preferences.Type) != 2 || preferences.Type["text/turtle"] != 0.9 || preferences.Type["application/ld+json"] != 1 {
+		t.Errorf("Type = %v", preferences.Type)
+	}
+	if preferences.Language["nl"] != 0.5 || preferences.Language["en-gb"] != 1 {
+		t.Errorf("Language = %v", preferences.Language)
+	}
+	if r := preferences.Range; r == nil || r.Unit != "bytes" || len(r.Parts) != 2 || r.Parts[0].End != nil || r.Parts[1].Start != -10 {
+		t.Errorf("Range = %+v", r)
+	}
+
+	request.Header.Set("Range", "bytes=a-b")
+	if _, err := parser.Handle(request); !errors.IsValidationError(err) {
+		t.Errorf("Handle() with an invalid range error = %v, want ValidationError", err)
+	}
+	request.Header.Set("Range", "bytes=0-1")
+	duplicate := NewUnionPreferenceParser([]PreferenceParser{NewRangePreferenceParser(), NewRangePreferenceParser()})
+	if _, err := duplicate.Handle(request); !errors.IsInternalError(err) {
+		t.Errorf("Handle() with two range parsers error = %v, want InternalError", err)
+	}
+}
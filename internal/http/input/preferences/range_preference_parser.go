@@ -2,10 +2,12 @@
 package preferences
 
 import (
-	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
-	"solid-go-main/internal/http/representation"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
 )
 
 // RangePreferenceParser parses the Range header into range preferences.
@@ -17,19 +19,19 @@ func NewRangePreferenceParser() *RangePreferenceParser {
 }
 
 // Handle parses the Range header and returns RepresentationPreferences.
-func (p *RangePreferenceParser) Handle(headers map[string]string) (*representation.RepresentationPreferences, error) {
-	rangeHeader, ok := headers["range"]
-	if !ok || rangeHeader == "" {
+func (p *RangePreferenceParser) Handle(request *http.Request) (*representation.RepresentationPreferences, error) {
+	rangeHeader := request.Header.Get("Range")
+	if rangeHeader == "" {
 		return &representation.RepresentationPreferences{}, nil
 	}
 	parts := strings.SplitN(rangeHeader, "=", 2)
 	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid range header format: %s", rangeHeader)
+		return nil, errors.NewValidationError("invalid range header format: "+rangeHeader, nil)
 	}
 	unit := strings.TrimSpace(parts[0])
 	rangeTail := strings.TrimSpace(parts[1])
 	if unit == "" {
-		return nil, fmt.Errorf("missing unit value from range header: %s", rangeHeader)
+		return nil, errors.NewValidationError("missing unit value from range header: "+rangeHeader, nil)
 	}
 	ranges := strings.Split(rangeTail, ",")
 	rangeParts := []representation.RangePart{}
@@ -37,29 +39,29 @@ func (p *RangePreferenceParser) Handle(headers map[string]string) (*representati
 		entry = strings.TrimSpace(entry)
 		se := strings.SplitN(entry, "-", 2)
 		if len(se) != 2 {
-			return nil, fmt.Errorf("invalid range header format: %s", rangeHeader)
+			return nil, errors.NewValidationError("invalid range header format: "+rangeHeader, nil)
 		}
 		start := strings.TrimSpace(se[0])
 		end := strings.TrimSpace(se[1])
 		if start == "" {
 			if end == "" {
-				return nil, fmt.Errorf("invalid range header format: %s", rangeHeader)
+				return nil, errors.NewValidationError("invalid range header format: "+rangeHeader, nil)
 			}
 			endVal, err := strconv.Atoi(end)
 			if err != nil {
-				return nil, fmt.Errorf("invalid end value in range header: %s", rangeHeader)
+				return nil, errors.NewValidationError("invalid end value in range header: "+rangeHeader, nil)
 			}
 			rangeParts = append(rangeParts, representation.RangePart{Start: -endVal})
 		} else {
 			startVal, err := strconv.Atoi(start)
 			if err != nil {
-				return nil, fmt.Errorf("invalid start value in range header: %s", rangeHeader)
+				return nil, errors.NewValidationError("invalid start value in range header: "+rangeHeader, nil)
 			}
 			var endVal *int
 			if end != "" {
 				v, err := strconv.Atoi(end)
 				if err != nil {
-					return nil, fmt.Errorf("invalid end value in range header: %s", rangeHeader)
+					return nil, errors.NewValidationError("invalid end value in range header: "+rangeHeader, nil)
 				}
 				endVal = &v
 			}
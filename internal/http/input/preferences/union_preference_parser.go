@@ -2,14 +2,11 @@
 package preferences
 
 import (
-	"errors"
-	"solid-go-main/internal/http/representation"
-)
+	"net/http"
 
-// PreferenceParser interface for all preference parsers.
-type PreferenceParser interface {
-	Handle(headers map[string]string) *representation.RepresentationPreferences
-}
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
 
 // UnionPreferenceParser combines the results of multiple PreferenceParsers.
 type UnionPreferenceParser struct {
@@ -22,13 +19,16 @@ func NewUnionPreferenceParser(parsers []PreferenceParser) *UnionPreferenceParser
 }
 
 // Handle combines the results of all parsers.
-func (u *UnionPreferenceParser) Handle(headers map[string]string) (*representation.RepresentationPreferences, error) {
+func (u *UnionPreferenceParser) Handle(request *http.Request) (*representation.RepresentationPreferences, error) {
 	results := make([]*representation.RepresentationPreferences, 0, len(u.Parsers))
 	for _, parser := range u.Parsers {
 		if parser == nil {
 			continue
 		}
-		prefs := parser.Handle(headers)
+		prefs, err := parser.Handle(request)
+		if err != nil {
+			return nil, err
+		}
 		results = append(results, prefs)
 	}
 	rangeCount := 0
@@ -38,7 +38,7 @@ func (u *UnionPreferenceParser) Handle(headers map[string]string) (*representati
 		}
 	}
 	if rangeCount > 1 {
-		return nil, errors.New("found multiple range values; this implies a misconfiguration")
+		return nil, errors.NewInternalError("found multiple range values; this implies a misconfiguration", nil)
 	}
 	preferences := &representation.RepresentationPreferences{}
 	for _, result := range results {
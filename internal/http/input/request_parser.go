@@ -1,7 +1,13 @@
 // Package input provides the RequestParser interface.
 package input
 
+import (
+	"net/http"
+
+	solidhttp "solid-go/internal/http"
+)
+
 // RequestParser converts an incoming HttpRequest to an Operation.
 type RequestParser interface {
-	Handle(request interface{}) (interface{}, error) // TODO: use actual HttpRequest and Operation types
+	Handle(request *http.Request) (*solidhttp.Operation, error)
 }
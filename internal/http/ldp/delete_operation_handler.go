@@ -1,6 +1,31 @@
 // Package ldp provides the DeleteOperationHandler struct.
 package ldp
 
+import (
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/storage"
+)
+
+// DeleteOperationHandler deletes the target resource
 type DeleteOperationHandler struct {
-	Store interface{} // TODO: use actual ResourceStore type
+	Store storage.ResourceStore
+}
+
+// NewDeleteOperationHandler creates a new DeleteOperationHandler
+func NewDeleteOperationHandler(store storage.ResourceStore) *DeleteOperationHandler {
+	return &DeleteOperationHandler{Store: store}
+}
+
+// CanHandle implements OperationHandler.CanHandle
+func (h *DeleteOperationHandler) CanHandle(input OperationHandlerInput) error {
+	return checkMethod(input, "DELETE")
+}
+
+// Handle implements OperationHandler.Handle
+func (h *DeleteOperationHandler) Handle(input OperationHandlerInput) (*response.ResponseDescription, error) {
+	operation := input.Operation
+	if _, err := h.Store.DeleteResource(operation.Target, operation.Conditions); err != nil {
+		return nil, err
+	}
+	return &response.NewResetResponseDescription().ResponseDescription, nil
 }
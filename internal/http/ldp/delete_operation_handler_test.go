@@ -0,0 +1,94 @@
+package ldp
+
+import (
+	"testing"
+	"time"
+
+	"solid-go/internal/http"
+	"solid-go/internal/http/auxiliary"
+	"solid-go/internal/http/input/conditions"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage"
+	"solid-go/internal/util/errors"
+)
+
+func newAuxiliaryStrategy(t *testing.T) auxiliary.AuxiliaryStrategy {
+	t.Helper()
+	acl, _ := auxiliary.NewSuffixAuxiliaryIdentifierStrategy(".acl")
+	meta, _ := auxiliary.NewSuffixAuxiliaryIdentifierStrategy(".meta")
+	return auxiliary.NewRoutingAuxiliaryStrategy([]auxiliary.AuxiliaryStrategy{
+		auxiliary.NewComposedAuxiliaryStrategy(acl, nil, nil, true, true),
+		auxiliary.NewComposedAuxiliaryStrategy(meta, nil, nil, false, false),
+	})
+}
+
+func TestDeleteOperationHandler(t *testing.T) {
+	store := storage.NewDataAccessorBasedStore(storage.NewInMemoryDataAccessor(baseURL), baseURL).
+		WithAuxiliaryStrategy(newAuxiliaryStrategy(t))
+	handler := NewDeleteOperationHandler(store)
+	for _, path := range []string{"foo/doc.txt", "foo/doc.txt.acl", "foo/doc.txt.meta", "foo/.acl", ".acl"} {
+		if _, err := store.SetRepresentation(representation.ResourceIdentifier{Path: baseURL + path}, textBody("x"), nil); err != nil {
+			t.Fatalf("SetRepresentation(%v) error = %v", path, err)
+		}
+	}
+	remove := func(path string) error {
+		t.Helper()
+		result, err := respond(t, handler, &http.Operation{Method: "DELETE", Target: representation.ResourceIdentifier{Path: baseURL + path}})
+		if err == nil && result.Code != 205 {
+			t.Errorf("DELETE %v = %v, want 205", path, result.Code)
+		}
+		return err
+	}
+	exists := func(path string) bool {
+		exists, _ := store.HasResource(representation.ResourceIdentifier{Path: baseURL + path})
+		return exists
+	}
+
+	if err := remove("foo/"); !errors.IsConflictError(err) {
+		t.Errorf("DELETE of a non-empty container error = %v, want ConflictError", err)
+	}
+	if !exists("foo/.acl") {
+		t.Errorf("a failed DELETE removed the ACL of the container")
+	}
+
+	before := conditions.ModifiedTime(mustMetadata(t, store, "foo/"))
+	time.Sleep(time.Millisecond)
+	if err := remove("foo/doc.txt"); err != nil {
+		t.Fatalf("DELETE of a document error = %v", err)
+	}
+	for _, path := range []string{"foo/doc.txt", "foo/doc.txt.acl", "foo/doc.txt.meta"} {
+		if exists(path) {
+			t.Errorf("%v still exists", path)
+		}
+	}
+	if after := conditions.ModifiedTime(mustMetadata(t, store, "foo/")); !after.After(before) {
+		t.Errorf("modification time of the parent did not change: %v", after)
+	}
+
+	// A container that only has its own auxiliary resources left is empty
+	if err := remove("foo/"); err != nil {
+		t.Errorf("DELETE of an empty container error = %v", err)
+	}
+	if exists("foo/.acl") || exists("foo/") {
+		t.Errorf("the container or its ACL still exists")
+	}
+
+	if err := remove(""); !errors.IsMethodNotAllowedError(err) {
+		t.Errorf("DELETE of the root error = %v, want MethodNotAllowedError", err)
+	}
+	if err := remove(".acl"); !errors.IsMethodNotAllowedError(err) {
+		t.Errorf("DELETE of the root ACL error = %v, want MethodNotAllowedError", err)
+	}
+	if err := remove("missing"); !errors.IsNotFoundError(err) {
+		t.Errorf("DELETE of a missing resource error = %v, want NotFoundError", err)
+	}
+}
+
+func mustMetadata(t *testing.T, store storage.ResourceStore, path string) *representation.RepresentationMetadata {
+	t.Helper()
+	rep, err := store.GetRepresentation(representation.ResourceIdentifier{Path: baseURL + path}, nil, nil)
+	if err != nil {
+		t.Fatalf("GetRepresentation(%v) error = %v", path, err)
+	}
+	return rep.GetMetadata()
+}
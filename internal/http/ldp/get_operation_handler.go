@@ -1,7 +1,49 @@
 // Package ldp provides the GetOperationHandler struct.
 package ldp
 
+import (
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage"
+)
+
+// GetOperationHandler returns the representation of the target that matches the preferences of the operation
 type GetOperationHandler struct {
-	Store      interface{} // TODO: use actual ResourceStore type
-	ETagHandler interface{} // TODO: use actual ETagHandler type
+	Store storage.ResourceStore
+}
+
+// NewGetOperationHandler creates a new GetOperationHandler
+func NewGetOperationHandler(store storage.ResourceStore) *GetOperationHandler {
+	return &GetOperationHandler{Store: store}
+}
+
+// CanHandle implements OperationHandler.CanHandle
+func (h *GetOperationHandler) CanHandle(input OperationHandlerInput) error {
+	return checkMethod(input, "GET")
+}
+
+// Handle implements OperationHandler.Handle
+func (h *GetOperationHandler) Handle(input OperationHandlerInput) (*response.ResponseDescription, error) {
+	rep, err := readRepresentation(h.Store, input)
+	if err != nil {
+		return nil, err
+	}
+	return &response.NewOkResponseDescription(rep.GetMetadata(), rep.GetData()).ResponseDescription, nil
+}
+
+// readRepresentation gets the representation of the target and checks the conditions of the read against it.
+// The conditions are evaluated on the converted representation, since ETags differ between representations.
+func readRepresentation(store storage.ResourceStore, input OperationHandlerInput) (representation.Representation, error) {
+	operation := input.Operation
+	rep, err := store.GetRepresentation(operation.Target, operation.Preferences, operation.Conditions)
+	if err != nil {
+		return nil, err
+	}
+	if operation.Conditions != nil {
+		if err := operation.Conditions.Evaluate(rep.GetMetadata(), true); err != nil {
+			closeData(rep.GetData())
+			return nil, err
+		}
+	}
+	return rep, nil
 }
@@ -0,0 +1,164 @@
+package ldp
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"solid-go/internal/http"
+	"solid-go/internal/http/input/conditions"
+	"solid-go/internal/http/output"
+	"solid-go/internal/http/output/metadata"
+	"solid-go/internal/http/output/serialize"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage"
+	"solid-go/internal/storage/conversion"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/vocabularies"
+)
+
+const baseURL = "http://example.org/"
+
+func newTestStore(t *testing.T) storage.ResourceStore {
+	t.Helper()
+	source := storage.NewDataAccessorBasedStore(storage.NewInMemoryDataAccessor(baseURL), baseURL)
+	store := storage.NewBinarySliceResourceStore(
+		storage.NewRepresentationConvertingStore(source, conversion.NewRdfConverter(serialize.Options{})))
+	if _, err := store.SetRepresentation(representation.ResourceIdentifier{Path: baseURL + "doc.txt"}, textBody("0123456789"), nil); err != nil {
+		t.Fatalf("SetRepresentation() error = %v", err)
+	}
+	return store
+}
+
+func textBody(data string) representation.Representation {
+	return representation.NewBasicRepresentation(strings.NewReader(data),
+		representation.NewRepresentationMetadata("").SetContentType(util.TextPlain), true)
+}
+
+func respond(t *testing.T, handler OperationHandler, operation *http.Operation) (*httptest.ResponseRecorder, error) {
+	t.Helper()
+	input := OperationHandlerInput{Operation: operation}
+	if err := handler.CanHandle(input); err != nil {
+		t.Fatalf("CanHandle() error = %v", err)
+	}
+	description, err := handler.Handle(input)
+	if err != nil {
+		return nil, err
+	}
+	writer := output.NewBasicResponseWriter(metadata.NewParallelMetadataWriter(
+		metadata.NewContentTypeMetadataWriter(),
+		metadata.NewModifiedMetadataWriter(conditions.NewBasicETagHandler()),
+		metadata.NewRangeMetadataWriter(),
+		metadata.NewLinkRelMetadataWriter(map[string]string{vocabularies.RDF.Type.Value(): "type"}),
+		metadata.NewAllowAcceptHeaderWriter([]string{"OPTIONS", "GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"}, nil),
+		metadata.NewMappedMetadataWriter(map[string]string{vocabularies.SOLID_HTTP.Location.Value(): "Location"}),
+	))
+	recorder := httptest.NewRecorder()
+	if err := writer.Handle(recorder, description); err != nil {
+		t.Fatalf("BasicResponseWriter.Handle() error = %v", err)
+	}
+	return recorder, nil
+}
+
+func TestGetOperationHandler(t *testing.T) {
+	store := newTestStore(t)
+	handler := NewGetOperationHandler(store)
+	doc := representation.ResourceIdentifier{Path: baseURL + "doc.txt"}
+
+	if err := handler.CanHandle(OperationHandlerInput{Operation: &http.Operation{Method: "PUT", Target: doc}}); !errors.IsNotImplementedError(err) {
+		t.Errorf("CanHandle() of PUT error = %v, want NotImplementedError", err)
+	}
+
+	result, err := respond(t, handler, &http.Operation{Method: "GET", Target: doc})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if result.Code != 200 || result.Body.String() != "0123456789" {
+		t.Errorf("GET = %v %q", result.Code, result.Body.String())
+	}
+	header := result.Header()
+	for name, want := range map[string]string{
+		"Content-Type":   util.TextPlain,
+		"Content-Length": "10",
+		"Link":           "<" + vocabularies.LDP.Resource.Value() + `>; rel="type"`,
+		"Allow":          "OPTIONS, GET, HEAD, PUT, PATCH, DELETE",
+	} {
+		if got := header.Get(name); got != want {
+			t.Errorf("%v = %q, want %q", name, got, want)
+		}
+	}
+	eTag := header.Get("ETag")
+	if eTag == "" || header.Get("Last-Modified") == "" {
+		t.Fatalf("missing ETag or Last-Modified in %v", header)
+	}
+
+	ifNoneMatch := httptest.NewRequest("GET", doc.Path, nil)
+	ifNoneMatch.Header.Set("If-None-Match", eTag)
+	notModified, _ := conditions.NewBasicConditionsParser(nil).Handle(ifNoneMatch)
+	if _, err := respond(t, handler, &http.Operation{Method: "GET", Target: doc, Conditions: notModified}); !errors.IsNotModifiedError(err) {
+		t.Errorf("GET with If-None-Match error = %v, want NotModifiedError", err)
+	}
+
+	ranged := &representation.RepresentationPreferences{Range: &representation.Range{Unit: "bytes", Parts: []representation.RangePart{{Start: -4}}}}
+	result, err = respond(t, handler, &http.Operation{Method: "GET", Target: doc, Preferences: ranged})
+	if err != nil {
+		t.Fatalf("Handle() with range error = %v", err)
+	}
+	if result.Code != 206 || result.Body.String() != "6789" || result.Header().Get("Content-Range") != "bytes 6-9/10" {
+		t.Errorf("GET with range = %v %q %v", result.Code, result.Body.String(), result.Header())
+	}
+}
+
+func TestGetOperationHandler_Container(t *testing.T) {
+	handler := NewGetOperationHandler(newTestStore(t))
+	root := representation.ResourceIdentifier{Path: baseURL}
+
+	result, err := respond(t, handler, &http.Operation{Method: "GET", Target: root})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if got := result.Header().Get("Content-Type"); got != util.Turtle {
+		t.Errorf("Content-Type without Accept = %q, want %q", got, util.Turtle)
+	}
+	if !strings.Contains(result.Body.String(), "doc.txt") {
+		t.Errorf("container body does not list its child: %v", result.Body.String())
+	}
+	if links := result.Header().Values("Link"); len(links) != 3 {
+		t.Errorf("Link = %v, want the three container types", links)
+	}
+	if allow := result.Header().Get("Allow"); !strings.Contains(allow, "POST") {
+		t.Errorf("Allow = %q, containers accept POST", allow)
+	}
+
+	jsonld := &representation.RepresentationPreferences{Type: representation.ValuePreferences{util.JSONLD: 1}}
+	result, err = respond(t, handler, &http.Operation{Method: "GET", Target: root, Preferences: jsonld})
+	if err != nil {
+		t.Fatalf("Handle() with Accept error = %v", err)
+	}
+	if got := result.Header().Get("Content-Type"); got != util.JSONLD {
+		t.Errorf("Content-Type = %q, want %q", got, util.JSONLD)
+	}
+
+	html := &representation.RepresentationPreferences{Type: representation.ValuePreferences{"image/png": 1}}
+	if _, err := respond(t, handler, &http.Operation{Method: "GET", Target: root, Preferences: html}); !errors.IsNotAcceptableError(err) {
+		t.Errorf("GET with unsupported Accept error = %v, want NotAcceptableError", err)
+	}
+}
+
+func TestHeadOperationHandler(t *testing.T) {
+	handler := NewHeadOperationHandler(newTestStore(t))
+	result, err := respond(t, handler, &http.Operation{Method: "HEAD", Target: representation.ResourceIdentifier{Path: baseURL + "doc.txt"}})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if result.Code != 200 || result.Body.Len() != 0 {
+		t.Errorf("HEAD = %v with %v bytes", result.Code, result.Body.Len())
+	}
+	if result.Header().Get("Content-Length") != "10" || result.Header().Get("ETag") == "" {
+		t.Errorf("HEAD headers = %v", result.Header())
+	}
+	if _, err := respond(t, handler, &http.Operation{Method: "HEAD", Target: representation.ResourceIdentifier{Path: baseURL + "missing"}}); !errors.IsNotFoundError(err) {
+		t.Errorf("HEAD of a missing resource error = %v, want NotFoundError", err)
+	}
+}
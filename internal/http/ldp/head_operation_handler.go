@@ -1,7 +1,32 @@
 // Package ldp provides the HeadOperationHandler struct.
 package ldp
 
+import (
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/storage"
+)
+
+// HeadOperationHandler returns the same metadata as a GET operation would, without the data
 type HeadOperationHandler struct {
-	Store      interface{} // TODO: use actual ResourceStore type
-	ETagHandler interface{} // TODO: use actual ETagHandler type
+	Store storage.ResourceStore
+}
+
+// NewHeadOperationHandler creates a new HeadOperationHandler
+func NewHeadOperationHandler(store storage.ResourceStore) *HeadOperationHandler {
+	return &HeadOperationHandler{Store: store}
+}
+
+// CanHandle implements OperationHandler.CanHandle
+func (h *HeadOperationHandler) CanHandle(input OperationHandlerInput) error {
+	return checkMethod(input, "HEAD")
+}
+
+// Handle implements OperationHandler.Handle
+func (h *HeadOperationHandler) Handle(input OperationHandlerInput) (*response.ResponseDescription, error) {
+	rep, err := readRepresentation(h.Store, input)
+	if err != nil {
+		return nil, err
+	}
+	closeData(rep.GetData())
+	return &response.NewOkResponseDescription(rep.GetMetadata(), nil).ResponseDescription, nil
 }
@@ -0,0 +1,2 @@
+// Package ldp contains the handlers that execute LDP operations on a ResourceStore.
+package ldp
@@ -1,11 +1,34 @@
 // Package ldp provides the OperationHandler interface and input struct.
 package ldp
 
+import (
+	"io"
+
+	"solid-go/internal/http"
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/util/errors"
+)
+
 type OperationHandlerInput struct {
-	Operation interface{} // TODO: use actual Operation type from http package
+	Operation *http.Operation
 }
 
 type OperationHandler interface {
 	CanHandle(input OperationHandlerInput) error
-	Handle(input OperationHandlerInput) (interface{}, error) // TODO: use actual ResponseDescription type
+	Handle(input OperationHandlerInput) (*response.ResponseDescription, error)
+}
+
+// checkMethod returns a NotImplementedError if the operation does not have the given method
+func checkMethod(input OperationHandlerInput, method string) error {
+	if input.Operation == nil || input.Operation.Method != method {
+		return errors.NewNotImplementedError("this handler only supports "+method+" operations", nil)
+	}
+	return nil
+}
+
+// closeData closes data that will not be sent
+func closeData(data io.Reader) {
+	if closer, ok := data.(io.Closer); ok {
+		closer.Close()
+	}
 }
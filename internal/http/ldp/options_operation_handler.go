@@ -0,0 +1,41 @@
+// Package ldp provides the OptionsOperationHandler struct.
+package ldp
+
+import (
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
+)
+
+// OptionsOperationHandler answers OPTIONS operations with an empty response.
+// Its metadata only tells whether the target exists,
+// which is what the metadata writers need to describe the methods and media types the target supports.
+type OptionsOperationHandler struct {
+	Store storage.ResourceStore
+}
+
+// NewOptionsOperationHandler creates a new OptionsOperationHandler
+func NewOptionsOperationHandler(store storage.ResourceStore) *OptionsOperationHandler {
+	return &OptionsOperationHandler{Store: store}
+}
+
+// CanHandle implements OperationHandler.CanHandle
+func (h *OptionsOperationHandler) CanHandle(input OperationHandlerInput) error {
+	return checkMethod(input, "OPTIONS")
+}
+
+// Handle implements OperationHandler.Handle
+func (h *OptionsOperationHandler) Handle(input OperationHandlerInput) (*response.ResponseDescription, error) {
+	target := input.Operation.Target
+	exists, err := h.Store.HasResource(target)
+	if err != nil {
+		return nil, err
+	}
+	metadata := representation.NewRepresentationMetadata(target.Path)
+	if exists {
+		metadata.AddQuad(n3.NewQuad(n3.NewNamedNode(target.Path), vocabularies.RDF.Type, vocabularies.LDP.Resource, nil))
+	}
+	return &response.NewNoContentResponseDescription(metadata).ResponseDescription, nil
+}
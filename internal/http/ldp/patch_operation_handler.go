@@ -1,6 +1,43 @@
 // Package ldp provides the PatchOperationHandler struct.
 package ldp
 
+import (
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/storage"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/vocabularies"
+)
+
+// PatchOperationHandler modifies the target with the patch in the body of the operation,
+// creating the target if it does not exist yet
 type PatchOperationHandler struct {
-	Store interface{} // TODO: use actual ResourceStore type
+	Store storage.ResourceStore
+}
+
+// NewPatchOperationHandler creates a new PatchOperationHandler
+func NewPatchOperationHandler(store storage.ResourceStore) *PatchOperationHandler {
+	return &PatchOperationHandler{Store: store}
+}
+
+// CanHandle implements OperationHandler.CanHandle
+func (h *PatchOperationHandler) CanHandle(input OperationHandlerInput) error {
+	return checkMethod(input, "PATCH")
+}
+
+// Handle implements OperationHandler.Handle.
+// The response is 201 Created for a new resource and 205 Reset Content for a modified one.
+func (h *PatchOperationHandler) Handle(input OperationHandlerInput) (*response.ResponseDescription, error) {
+	operation := input.Operation
+	// Solid, §2.1: a server MUST reject PUT, POST and PATCH requests without the Content-Type header with a 400
+	if operation.Body == nil || operation.Body.GetMetadata() == nil || operation.Body.GetMetadata().ContentType() == "" {
+		return nil, errors.NewValidationError("PATCH requests require the Content-Type header to be set", nil)
+	}
+	changes, err := h.Store.ModifyResource(operation.Target, operation.Body, operation.Conditions)
+	if err != nil {
+		return nil, err
+	}
+	if activity, _ := changes.Activity(operation.Target); activity != nil && activity.Value() == vocabularies.AS.Create.Value() {
+		return &response.NewCreatedResponseDescription(operation.Target.Path).ResponseDescription, nil
+	}
+	return &response.NewResetResponseDescription().ResponseDescription, nil
 }
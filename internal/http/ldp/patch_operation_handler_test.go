@@ -0,0 +1,120 @@
+package ldp
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"solid-go/internal/http"
+	"solid-go/internal/http/input/body"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage"
+	"solid-go/internal/storage/patch"
+	"solid-go/internal/util/errors"
+)
+
+const n3Prefixes = "@prefix solid: <http://www.w3.org/ns/solid/terms#>. @prefix ex: <http://example.org/terms#>.\n"
+
+func patchRequest(t *testing.T, handler OperationHandler, target, n3 string) (int, error) {
+	t.Helper()
+	request := httptest.NewRequest("PATCH", target, strings.NewReader(n3Prefixes+n3))
+	request.Header.Set("Content-Type", "text/n3")
+	args := body.BodyParserArgs{Request: request, Metadata: parseBody(t, request).GetMetadata()}
+	rep, err := body.NewN3PatchBodyParser(0).Handle(args)
+	if err != nil {
+		t.Fatalf("N3PatchBodyParser.Handle() error = %v", err)
+	}
+	result, err := respond(t, handler, &http.Operation{Method: "PATCH", Target: representation.ResourceIdentifier{Path: target}, Body: rep})
+	if err != nil {
+		return 0, err
+	}
+	return result.Code, nil
+}
+
+func TestPatchOperationHandler(t *testing.T) {
+	source := newPatchingStore()
+	handler := NewPatchOperationHandler(source)
+	doc := baseURL + "profile/card"
+
+	if code, err := patchRequest(t, handler, doc, `_:p a solid:InsertDeletePatch; solid:inserts { <#me> ex:name "Alice" }.`); code != 201 || err != nil {
+		t.Fatalf("PATCH of a new document = %v, %v", code, err)
+	}
+	rename := `_:p a solid:InsertDeletePatch;
+  solid:where { <#me> ex:name ?name };
+  solid:deletes { <#me> ex:name ?name };
+  solid:inserts { <#me> ex:name "Alicia"; ex:previous ?name }.`
+	if code, err := patchRequest(t, handler, doc, rename); code != 205 || err != nil {
+		t.Fatalf("PATCH of an existing document = %v, %v", code, err)
+	}
+	if _, err := patchRequest(t, handler, doc, `_:p a solid:InsertDeletePatch; solid:where { ?x ex:name "Bob" }; solid:inserts { ?x ex:knows <#me> }.`); !errors.IsConflictError(err) {
+		t.Errorf("PATCH with an unmatched where formula error = %v, want ConflictError", err)
+	}
+	if _, err := patchRequest(t, handler, doc, `_:p a solid:InsertDeletePatch; solid:inserts { <#me> ex:age 3 }; solid:deletes { <#me> ex:name "Bob" }.`); !errors.IsConflictError(err) {
+		t.Errorf("PATCH deleting a missing triple error = %v, want ConflictError", err)
+	}
+
+	data, err := io.ReadAll(mustGetData(t, source, doc))
+	if err != nil {
+		t.Fatalf("reading %v: %v", doc, err)
+	}
+	got := string(data)
+	if !strings.Contains(got, `"Alicia"`) || !strings.Contains(got, `"Alice"`) || strings.Contains(got, "age") {
+		t.Errorf("patched document = %v", got)
+	}
+
+	source.SetRepresentation(representation.ResourceIdentifier{Path: baseURL + "doc.txt"}, textBody("text"), nil)
+	if _, err := patchRequest(t, handler, baseURL+"doc.txt", `_:p a solid:InsertDeletePatch.`); !errors.IsConflictError(err) {
+		t.Errorf("PATCH of a non-RDF document error = %v, want ConflictError", err)
+	}
+	if _, err := patchRequest(t, handler, baseURL+"profile/", `_:p a solid:InsertDeletePatch.`); !errors.IsConflictError(err) {
+		t.Errorf("PATCH of a container error = %v, want ConflictError", err)
+	}
+}
+
+func TestPatchOperationHandler_SparqlUpdate(t *testing.T) {
+	source := newPatchingStore()
+	handler := NewPatchOperationHandler(source)
+	doc := baseURL + "notes.ttl"
+	update := func(query string) (int, error) {
+		request := httptest.NewRequest("PATCH", doc, strings.NewReader(query))
+		request.Header.Set("Content-Type", "application/sparql-update")
+		args := body.BodyParserArgs{Request: request, Metadata: parseBody(t, request).GetMetadata()}
+		rep, err := body.NewSparqlUpdateBodyParser(0).Handle(args)
+		if err != nil {
+			t.Fatalf("SparqlUpdateBodyParser.Handle() error = %v", err)
+		}
+		result, err := respond(t, handler, &http.Operation{Method: "PATCH", Target: representation.ResourceIdentifier{Path: doc}, Body: rep})
+		if err != nil {
+			return 0, err
+		}
+		return result.Code, nil
+	}
+
+	if code, err := update(`INSERT DATA { <#n1> <http://purl.org/dc/terms/title> "First" }`); code != 201 || err != nil {
+		t.Fatalf("INSERT DATA on a new document = %v, %v", code, err)
+	}
+	query := `PREFIX dc: <http://purl.org/dc/terms/>
+DELETE { ?note dc:title ?title } INSERT { ?note dc:title "Renamed" } WHERE { ?note dc:title ?title }`
+	if code, err := update(query); code != 205 || err != nil {
+		t.Fatalf("DELETE/INSERT WHERE = %v, %v", code, err)
+	}
+	data, _ := io.ReadAll(mustGetData(t, source, doc))
+	if got := string(data); !strings.Contains(got, `"Renamed"`) || strings.Contains(got, `"First"`) {
+		t.Errorf("patched document = %v", got)
+	}
+}
+
+func newPatchingStore() *storage.DataAccessorBasedStore {
+	return storage.NewDataAccessorBasedStore(storage.NewInMemoryDataAccessor(baseURL), baseURL).
+		WithPatcher(patch.NewWaterfallRdfPatcher(patch.NewN3Patcher(), patch.NewSparqlUpdatePatcher()))
+}
+
+func mustGetData(t *testing.T, store storage.ResourceStore, path string) io.Reader {
+	t.Helper()
+	rep, err := store.GetRepresentation(representation.ResourceIdentifier{Path: path}, nil, nil)
+	if err != nil {
+		t.Fatalf("GetRepresentation() error = %v", err)
+	}
+	return rep.GetData()
+}
@@ -1,6 +1,43 @@
 // Package ldp provides the PostOperationHandler struct.
 package ldp
 
+import (
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/storage"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/vocabularies"
+)
+
+// PostOperationHandler adds the body of the operation as a new resource to the target container
 type PostOperationHandler struct {
-	Store interface{} // TODO: use actual ResourceStore type
+	Store storage.ResourceStore
+}
+
+// NewPostOperationHandler creates a new PostOperationHandler
+func NewPostOperationHandler(store storage.ResourceStore) *PostOperationHandler {
+	return &PostOperationHandler{Store: store}
+}
+
+// CanHandle implements OperationHandler.CanHandle
+func (h *PostOperationHandler) CanHandle(input OperationHandlerInput) error {
+	return checkMethod(input, "POST")
+}
+
+// Handle implements OperationHandler.Handle
+func (h *PostOperationHandler) Handle(input OperationHandlerInput) (*response.ResponseDescription, error) {
+	operation := input.Operation
+	// Solid, §2.1: a server MUST reject PUT, POST and PATCH requests without the Content-Type header with a 400
+	if operation.Body == nil || operation.Body.GetMetadata() == nil || operation.Body.GetMetadata().ContentType() == "" {
+		return nil, errors.NewValidationError("POST requests require the Content-Type header to be set", nil)
+	}
+	changes, err := h.Store.AddResource(operation.Target, operation.Body, operation.Conditions)
+	if err != nil {
+		return nil, err
+	}
+	for _, identifier := range changes.Identifiers() {
+		if activity, _ := changes.Activity(identifier); activity != nil && activity.Value() == vocabularies.AS.Create.Value() {
+			return &response.NewCreatedResponseDescription(identifier.Path).ResponseDescription, nil
+		}
+	}
+	return nil, errors.NewInternalError("the store did not report the created resource", nil)
 }
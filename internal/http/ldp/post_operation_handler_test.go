@@ -0,0 +1,133 @@
+package ldp
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"solid-go/internal/http"
+	inputmetadata "solid-go/internal/http/input/metadata"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/vocabularies"
+)
+
+// parseBody creates the body of an operation from a request like the request parser does
+func parseBody(t *testing.T, request *nethttp.Request) representation.Representation {
+	t.Helper()
+	parser := inputmetadata.NewParallelMetadataParser(
+		inputmetadata.NewContentTypeParser(),
+		inputmetadata.NewSlugParser(),
+		inputmetadata.NewLinkRelParser(map[string]inputmetadata.LinkRelObject{
+			"type": {Value: vocabularies.RDF.Type.Value(), Ephemeral: true, AllowList: []string{
+				vocabularies.LDP.Resource.Value(), vocabularies.LDP.Container.Value(), vocabularies.LDP.BasicContainer.Value(),
+			}},
+		}),
+	)
+	metadata := representation.NewRepresentationMetadata(request.URL.String())
+	if err := parser.Handle(inputmetadata.MetadataParserInput{Request: request, Metadata: metadata}); err != nil {
+		t.Fatalf("MetadataParser.Handle() error = %v", err)
+	}
+	return representation.NewBasicRepresentation(request.Body, metadata, true)
+}
+
+func post(t *testing.T, handler OperationHandler, target, body string, headers map[string]string) (string, error) {
+	t.Helper()
+	request := httptest.NewRequest("POST", target, strings.NewReader(body))
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	result, err := respond(t, handler, &http.Operation{
+		Method: "POST",
+		Target: representation.ResourceIdentifier{Path: target},
+		Body:   parseBody(t, request),
+	})
+	if err != nil {
+		return "", err
+	}
+	if result.Code != 201 {
+		t.Fatalf("POST = %v, want 201", result.Code)
+	}
+	return result.Header().Get("Location"), nil
+}
+
+func TestPostOperationHandler(t *testing.T) {
+	store := newTestStore(t)
+	handler := NewPostOperationHandler(store)
+	containerLink := "<" + vocabularies.LDP.BasicContainer.Value() + `>; rel="type"`
+
+	location, err := post(t, handler, baseURL, "hello", map[string]string{"Content-Type": "text/plain", "Slug": "note.txt"})
+	if err != nil || location != baseURL+"note.txt" {
+		t.Fatalf("POST with slug = %q, %v", location, err)
+	}
+	rep, err := store.GetRepresentation(representation.ResourceIdentifier{Path: location}, nil, nil)
+	if err != nil {
+		t.Fatalf("GetRepresentation() error = %v", err)
+	}
+	closeData(rep.GetData())
+	if quads := rep.GetMetadata().Quads(nil, vocabularies.SOLID_HTTP.Slug, nil, nil); len(quads) > 0 {
+		t.Errorf("the slug was stored: %v", quads)
+	}
+
+	location, err = post(t, handler, baseURL, "hello", map[string]string{"Content-Type": "text/plain", "Slug": "note.txt"})
+	if err != nil || location == baseURL+"note.txt" || !strings.HasPrefix(location, baseURL) {
+		t.Errorf("POST with a taken slug = %q, %v", location, err)
+	}
+
+	location, err = post(t, handler, baseURL, "<> <http://purl.org/dc/terms/title> \"Notes\" .",
+		map[string]string{"Content-Type": "text/turtle", "Slug": "notes/", "Link": containerLink})
+	if err != nil || location != baseURL+"notes/" {
+		t.Fatalf("POST of a container = %q, %v", location, err)
+	}
+	container, err := store.GetRepresentation(representation.ResourceIdentifier{Path: location}, nil, nil)
+	if err != nil {
+		t.Fatalf("GetRepresentation() error = %v", err)
+	}
+	if len(container.GetMetadata().Quads(location, "http://purl.org/dc/terms/title", nil, nil)) != 1 {
+		t.Errorf("the container body was not stored: %v", container.GetMetadata().Quads(nil, nil, nil, nil))
+	}
+
+	// A document named like the new container also takes the slug
+	location, err = post(t, handler, baseURL, "", map[string]string{"Content-Type": "text/turtle", "Slug": "doc.txt", "Link": containerLink})
+	if err != nil || location == baseURL+"doc.txt/" || !strings.HasSuffix(location, "/") {
+		t.Errorf("POST of a container with a slug taken by a document = %q, %v", location, err)
+	}
+
+	location, err = post(t, handler, baseURL+"notes/", "", map[string]string{"Content-Type": "text/turtle", "Slug": "a b"})
+	if err != nil || location != baseURL+"notes/a%20b" {
+		t.Errorf("POST with a slug that needs encoding = %q, %v", location, err)
+	}
+}
+
+func TestPostOperationHandler_Errors(t *testing.T) {
+	handler := NewPostOperationHandler(newTestStore(t))
+	tests := []struct {
+		name    string
+		target  string
+		body    string
+		headers map[string]string
+		want    func(error) bool
+	}{
+		{"no content type", baseURL, "hello", nil, errors.IsValidationError},
+		{"slug with slashes", baseURL, "hello", map[string]string{"Content-Type": "text/plain", "Slug": "a/b"}, errors.IsValidationError},
+		{"document target", baseURL + "doc.txt", "hello", map[string]string{"Content-Type": "text/plain"}, errors.IsMethodNotAllowedError},
+		{"missing target", baseURL + "missing/", "hello", map[string]string{"Content-Type": "text/plain"}, errors.IsNotFoundError},
+		{"containment triples", baseURL, "<> <http://www.w3.org/ns/ldp#contains> <other> .", map[string]string{
+			"Content-Type": "text/turtle", "Link": "<" + vocabularies.LDP.BasicContainer.Value() + `>; rel="type"`,
+		}, errors.IsConflictError},
+		{"container without RDF", baseURL, "hello", map[string]string{
+			"Content-Type": "text/plain", "Link": "<" + vocabularies.LDP.Container.Value() + `>; rel="type"`,
+		}, errors.IsUnsupportedMediaTypeError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if location, err := post(t, handler, tt.target, tt.body, tt.headers); !tt.want(err) {
+				t.Errorf("POST = %q, %v", location, err)
+			}
+		})
+	}
+	if err := handler.CanHandle(OperationHandlerInput{Operation: &http.Operation{Method: "GET"}}); !errors.IsNotImplementedError(err) {
+		t.Errorf("CanHandle() of GET error = %v", err)
+	}
+}
@@ -1,7 +1,49 @@
 // Package ldp provides the PutOperationHandler struct.
 package ldp
 
+import (
+	"solid-go/internal/http/output/metadata"
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/storage"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/vocabularies"
+)
+
+// PutOperationHandler creates or replaces the target with the body of the operation,
+// creating missing parent containers along the way
 type PutOperationHandler struct {
-	Store            interface{} // TODO: use actual ResourceStore type
-	MetadataStrategy interface{} // TODO: use actual AuxiliaryStrategy type
+	Store storage.ResourceStore
+	// MetadataStrategy identifies the description resources, which can only be modified with PATCH (optional)
+	MetadataStrategy metadata.AuxiliaryIdentifierStrategy
+}
+
+// NewPutOperationHandler creates a new PutOperationHandler
+func NewPutOperationHandler(store storage.ResourceStore, metadataStrategy metadata.AuxiliaryIdentifierStrategy) *PutOperationHandler {
+	return &PutOperationHandler{Store: store, MetadataStrategy: metadataStrategy}
+}
+
+// CanHandle implements OperationHandler.CanHandle
+func (h *PutOperationHandler) CanHandle(input OperationHandlerInput) error {
+	return checkMethod(input, "PUT")
+}
+
+// Handle implements OperationHandler.Handle.
+// The response is 201 Created for a new resource and 205 Reset Content for a replaced one.
+func (h *PutOperationHandler) Handle(input OperationHandlerInput) (*response.ResponseDescription, error) {
+	operation := input.Operation
+	// Solid, §2.1: a server MUST reject PUT, POST and PATCH requests without the Content-Type header with a 400
+	if operation.Body == nil || operation.Body.GetMetadata() == nil || operation.Body.GetMetadata().ContentType() == "" {
+		return nil, errors.NewValidationError("PUT requests require the Content-Type header to be set", nil)
+	}
+	if h.MetadataStrategy != nil && h.MetadataStrategy.IsAuxiliaryIdentifier(operation.Target) {
+		return nil, errors.NewConflictError("metadata resources can not be created or edited with PUT, use PATCH instead", nil)
+	}
+	changes, err := h.Store.SetRepresentation(operation.Target, operation.Body, operation.Conditions)
+	if err != nil {
+		return nil, err
+	}
+	if activity, _ := changes.Activity(operation.Target); activity != nil && activity.Value() == vocabularies.AS.Create.Value() {
+		return &response.NewCreatedResponseDescription(operation.Target.Path).ResponseDescription, nil
+	}
+	return &response.NewResetResponseDescription().ResponseDescription, nil
 }
@@ -0,0 +1,100 @@
+package ldp
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"solid-go/internal/http"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/vocabularies"
+)
+
+// metaStrategy treats resources ending in .meta as description resources
+type metaStrategy struct{}
+
+func (metaStrategy) GetAuxiliaryIdentifier(identifier representation.ResourceIdentifier) representation.ResourceIdentifier {
+	return representation.ResourceIdentifier{Path: identifier.Path + ".meta"}
+}
+
+func (metaStrategy) IsAuxiliaryIdentifier(identifier representation.ResourceIdentifier) bool {
+	return strings.HasSuffix(identifier.Path, ".meta")
+}
+
+func put(t *testing.T, handler OperationHandler, target, body string, headers map[string]string) (int, error) {
+	t.Helper()
+	request := httptest.NewRequest("PUT", target, strings.NewReader(body))
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	result, err := respond(t, handler, &http.Operation{
+		Method: "PUT",
+		Target: representation.ResourceIdentifier{Path: target},
+		Body:   parseBody(t, request),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if result.Code == 201 && result.Header().Get("Location") != target {
+		t.Errorf("Location = %q, want %q", result.Header().Get("Location"), target)
+	}
+	return result.Code, nil
+}
+
+func TestPutOperationHandler(t *testing.T) {
+	store := newTestStore(t)
+	handler := NewPutOperationHandler(store, metaStrategy{})
+	text := map[string]string{"Content-Type": "text/plain"}
+	turtle := map[string]string{"Content-Type": "text/turtle"}
+
+	if code, err := put(t, handler, baseURL+"a/b/c.txt", "hello", text); code != 201 || err != nil {
+		t.Fatalf("PUT of a new document = %v, %v", code, err)
+	}
+	for _, path := range []string{baseURL + "a/", baseURL + "a/b/"} {
+		rep, err := store.GetRepresentation(representation.ResourceIdentifier{Path: path}, nil, nil)
+		if err != nil {
+			t.Fatalf("intermediate container %v: %v", path, err)
+		}
+		if len(rep.GetMetadata().Quads(path, vocabularies.LDP.Contains, nil, nil)) != 1 {
+			t.Errorf("%v does not contain its child", path)
+		}
+	}
+	if code, err := put(t, handler, baseURL+"a/b/c.txt", "hello again", text); code != 205 || err != nil {
+		t.Errorf("PUT of an existing document = %v, %v", code, err)
+	}
+	if code, err := put(t, handler, baseURL+"a/b/", "", turtle); code != 205 || err != nil {
+		t.Errorf("PUT of an existing container without body = %v, %v", code, err)
+	}
+	if code, err := put(t, handler, baseURL+"d/", "<> <http://purl.org/dc/terms/title> \"D\" .", turtle); code != 201 || err != nil {
+		t.Errorf("PUT of a new container with body = %v, %v", code, err)
+	}
+
+	tests := []struct {
+		name    string
+		target  string
+		body    string
+		headers map[string]string
+		want    func(error) bool
+	}{
+		{"existing container with body", baseURL + "a/", "<> <http://purl.org/dc/terms/title> \"A\" .", turtle, errors.IsConflictError},
+		{"container where a document exists", baseURL + "a/b/c.txt/", "", turtle, errors.IsConflictError},
+		{"document where a container exists", baseURL + "a/b", "hello", text, errors.IsConflictError},
+		{"document inside a document", baseURL + "a/b/c.txt/d", "hello", text, errors.IsConflictError},
+		{"container type without slash", baseURL + "e", "", map[string]string{
+			"Content-Type": "text/turtle", "Link": "<" + vocabularies.LDP.BasicContainer.Value() + `>; rel="type"`,
+		}, errors.IsValidationError},
+		{"no content type", baseURL + "f.txt", "hello", nil, errors.IsValidationError},
+		{"metadata resource", baseURL + "a/b/c.txt.meta", "", turtle, errors.IsConflictError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, err := put(t, handler, tt.target, tt.body, tt.headers); !tt.want(err) {
+				t.Errorf("PUT = %v, %v", code, err)
+			}
+		})
+	}
+	if exists, _ := store.HasResource(representation.ResourceIdentifier{Path: baseURL + "a/b/c.txt/"}); exists {
+		t.Errorf("a failed PUT created a resource")
+	}
+}
@@ -2,8 +2,8 @@
 package http
 
 import (
-	"solid-go-main/internal/http/conditions"
-	"solid-go-main/internal/http/representation"
+	"solid-go/internal/http/input/conditions"
+	"solid-go/internal/http/representation"
 )
 
 // Operation represents a single REST operation.
@@ -1,6 +1,39 @@
-// Package output provides the BasicResponseWriter interface.
+// Package output provides the BasicResponseWriter.
 package output
 
-type BasicResponseWriter interface {
-	WriteResponse(response interface{}) error // TODO: use actual response type
+import (
+	"io"
+	"net/http"
+
+	"solid-go/internal/http/output/metadata"
+	"solid-go/internal/http/output/response"
+)
+
+// BasicResponseWriter writes the metadata of a response as headers using a MetadataWriter,
+// followed by the status code and the data.
+type BasicResponseWriter struct {
+	MetadataWriter metadata.MetadataWriter
+}
+
+// NewBasicResponseWriter creates a new BasicResponseWriter
+func NewBasicResponseWriter(metadataWriter metadata.MetadataWriter) *BasicResponseWriter {
+	return &BasicResponseWriter{MetadataWriter: metadataWriter}
+}
+
+// Handle implements ResponseWriter.Handle
+func (b *BasicResponseWriter) Handle(w http.ResponseWriter, description *response.ResponseDescription) error {
+	if closer, ok := description.Data.(io.Closer); ok {
+		defer closer.Close()
+	}
+	if description.Metadata != nil {
+		if err := b.MetadataWriter.Handle(metadata.MetadataWriterInput{Response: w, Metadata: description.Metadata}); err != nil {
+			return err
+		}
+	}
+	w.WriteHeader(description.StatusCode)
+	if description.Data == nil {
+		return nil
+	}
+	_, err := io.Copy(w, description.Data)
+	return err
 }
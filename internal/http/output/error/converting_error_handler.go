@@ -1,27 +1,77 @@
-// Package errorhandler implements an ErrorHandler that converts errors into a representation and feeds it into a converter.
+// Package errorhandler implements an ErrorHandler that converts errors into a representation in the preferred content type.
 package errorhandler
 
+import (
+	"bytes"
+	"encoding/json"
+
+	"solid-go/internal/http/input/preferences"
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage/conversion"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+)
+
+// supportedTypes are the content types an error can be converted to, plain text being the default
+var supportedTypes = representation.ValuePreferences{util.TextPlain: 1, util.ApplicationJSON: 0.9}
+
+// ConvertingErrorHandler describes errors as plain text or JSON, depending on the Accept header of the request.
 type ConvertingErrorHandler struct {
-	Converter        interface{} // TODO: Replace with concrete RepresentationConverter type
-	PreferenceParser interface{} // TODO: Replace with concrete PreferenceParser type
+	PreferenceParser preferences.PreferenceParser
 	ShowStackTrace   bool
 }
 
-func NewConvertingErrorHandler(converter, preferenceParser interface{}, showStackTrace bool) *ConvertingErrorHandler {
-	return &ConvertingErrorHandler{Converter: converter, PreferenceParser: preferenceParser, ShowStackTrace: showStackTrace}
+// NewConvertingErrorHandler creates a new ConvertingErrorHandler
+func NewConvertingErrorHandler(preferenceParser preferences.PreferenceParser, showStackTrace bool) *ConvertingErrorHandler {
+	return &ConvertingErrorHandler{PreferenceParser: preferenceParser, ShowStackTrace: showStackTrace}
 }
 
+// CanHandle implements ErrorHandler.CanHandle
 func (h *ConvertingErrorHandler) CanHandle(input ErrorHandlerArgs) error {
-	// TODO: Implement logic to check if handler can handle the error
 	return nil
 }
 
-func (h *ConvertingErrorHandler) Handle(input ErrorHandlerArgs) (interface{}, error) {
-	// TODO: Implement error conversion and response creation
-	return nil, nil
+// Handle implements ErrorHandler.Handle
+func (h *ConvertingErrorHandler) Handle(input ErrorHandlerArgs) (*response.ResponseDescription, error) {
+	contentType := util.TextPlain
+	if h.PreferenceParser != nil && input.Request != nil {
+		// Errors in the preferences should not hide the original error
+		if prefs, err := h.PreferenceParser.Handle(input.Request); err == nil && prefs != nil {
+			if weighted := conversion.GetWeightedPreferences(supportedTypes, prefs.Type); len(weighted) > 0 {
+				contentType = weighted[0].Value
+			}
+		}
+	}
+
+	metadata := errorMetadata(input.Error).SetContentType(contentType)
+	data, err := h.serialize(input.Error, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return &response.ResponseDescription{
+		StatusCode: errors.StatusCode(input.Error),
+		Metadata:   metadata,
+		Data:       bytes.NewReader(data),
+	}, nil
 }
 
-func (h *ConvertingErrorHandler) HandleSafe(input ErrorHandlerArgs) (interface{}, error) {
-	// TODO: Implement safe error handling
-	return nil, nil
+// serialize describes the error in the given content type
+func (h *ConvertingErrorHandler) serialize(err error, contentType string) ([]byte, error) {
+	message := errors.GetErrorMessage(err)
+	if contentType == util.ApplicationJSON {
+		body := map[string]interface{}{
+			"name":       string(errors.GetErrorType(err)),
+			"message":    message,
+			"statusCode": errors.StatusCode(err),
+		}
+		if h.ShowStackTrace {
+			body["stack"] = errors.GetErrorStack(err)
+		}
+		return json.Marshal(body)
+	}
+	if h.ShowStackTrace {
+		message += "\n\n" + errors.GetErrorStack(err)
+	}
+	return []byte(message + "\n"), nil
 }
@@ -1,21 +1,55 @@
 // Package errorhandler implements an ErrorHandler that returns an error response without a body for certain status codes.
 package errorhandler
 
+import (
+	"strconv"
+
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/util/errors"
+)
+
+// EmptyErrorHandler returns error responses without a body.
+// This is used for responses that can not have a body, such as 304, or whose body is irrelevant.
 type EmptyErrorHandler struct {
 	StatusCodes []int
 	Always      bool
 }
 
+// NewEmptyErrorHandler creates a new EmptyErrorHandler for the given status codes, or for all errors if always is set
 func NewEmptyErrorHandler(statusCodes []int, always bool) *EmptyErrorHandler {
 	return &EmptyErrorHandler{StatusCodes: statusCodes, Always: always}
 }
 
+// CanHandle implements ErrorHandler.CanHandle
 func (h *EmptyErrorHandler) CanHandle(input ErrorHandlerArgs) error {
-	// TODO: Implement logic to check if handler can handle the error
-	return nil
+	if h.Always {
+		return nil
+	}
+	status := errors.StatusCode(input.Error)
+	for _, code := range h.StatusCodes {
+		if code == status {
+			return nil
+		}
+	}
+	return errors.NewNotImplementedError("Only responses with status "+statusList(h.StatusCodes)+" are empty", nil)
+}
+
+// Handle implements ErrorHandler.Handle
+func (h *EmptyErrorHandler) Handle(input ErrorHandlerArgs) (*response.ResponseDescription, error) {
+	return &response.ResponseDescription{
+		StatusCode: errors.StatusCode(input.Error),
+		Metadata:   errorMetadata(input.Error),
+	}, nil
 }
 
-func (h *EmptyErrorHandler) Handle(input ErrorHandlerArgs) (interface{}, error) {
-	// TODO: Implement error response creation without body
-	return nil, nil
+// statusList joins the status codes for an error message
+func statusList(codes []int) string {
+	list := ""
+	for i, code := range codes {
+		if i > 0 {
+			list += ", "
+		}
+		list += strconv.Itoa(code)
+	}
+	return list
 }
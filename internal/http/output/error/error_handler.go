@@ -1,14 +1,33 @@
 // Package errorhandler provides interfaces and implementations for error handling in HTTP output.
 package errorhandler
 
+import (
+	"net/http"
+
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
+
+// ErrorHandlerArgs contains the error to convert and the request that caused it.
 type ErrorHandlerArgs struct {
-	Error   interface{} // TODO: Replace with concrete HttpError type
-	Request interface{} // TODO: Replace with concrete HttpRequest type
+	Error   error
+	Request *http.Request
 }
 
 // ErrorHandler converts an error into a ResponseDescription based on the request preferences.
 type ErrorHandler interface {
 	CanHandle(input ErrorHandlerArgs) error
-	Handle(input ErrorHandlerArgs) (interface{}, error) // TODO: use actual ResponseDescription type
-	HandleSafe(input ErrorHandlerArgs) (interface{}, error)
+	Handle(input ErrorHandlerArgs) (*response.ResponseDescription, error)
+}
+
+// errorMetadata creates the metadata of an error response.
+// The status code and the headers of the error are stored so the metadata writers can add them to the response.
+func errorMetadata(err error) *representation.RepresentationMetadata {
+	metadata := representation.NewRepresentationMetadata("")
+	metadata.Add("statusCode", errors.StatusCode(err))
+	if headers := errors.ResponseHeaders(err); len(headers) > 0 {
+		metadata.Add("responseHeaders", headers)
+	}
+	return metadata
 }
@@ -1,5 +1,5 @@
-// Package error provides error handling interfaces for HTTP output.
-package error
+// Package errorhandler provides error handling interfaces for HTTP output.
+package errorhandler
 
 type ErrorResponseWriter interface {
 	WriteError(err error) error
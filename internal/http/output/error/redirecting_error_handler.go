@@ -1,14 +1,34 @@
 // Package errorhandler implements an ErrorHandler that converts redirect errors to redirect response descriptions.
 package errorhandler
 
+import (
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/util/errors"
+)
+
+// RedirectingErrorHandler converts errors with a 3xx status and a Location header into redirect responses.
 type RedirectingErrorHandler struct{}
 
+// NewRedirectingErrorHandler creates a new RedirectingErrorHandler
+func NewRedirectingErrorHandler() *RedirectingErrorHandler {
+	return &RedirectingErrorHandler{}
+}
+
+// CanHandle implements ErrorHandler.CanHandle
 func (h *RedirectingErrorHandler) CanHandle(input ErrorHandlerArgs) error {
-	// TODO: Implement logic to check if error is a redirect error
+	status := errors.StatusCode(input.Error)
+	if status < 300 || status >= 400 || errors.ResponseHeaders(input.Error)["Location"] == "" {
+		return errors.NewNotImplementedError("Only redirect errors are supported", nil)
+	}
 	return nil
 }
 
-func (h *RedirectingErrorHandler) Handle(input ErrorHandlerArgs) (interface{}, error) {
-	// TODO: Implement redirect response creation
-	return nil, nil
+// Handle implements ErrorHandler.Handle
+func (h *RedirectingErrorHandler) Handle(input ErrorHandlerArgs) (*response.ResponseDescription, error) {
+	if err := h.CanHandle(input); err != nil {
+		return nil, err
+	}
+	location := errors.ResponseHeaders(input.Error)["Location"]
+	redirect := response.NewRedirectResponseDescription(errors.StatusCode(input.Error), nil, location)
+	return &redirect.ResponseDescription, nil
 }
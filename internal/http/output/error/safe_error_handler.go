@@ -1,16 +1,52 @@
 // Package errorhandler implements a failsafe ErrorHandler that returns a simple text description of an error.
 package errorhandler
 
+import (
+	"log"
+	"strings"
+
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+)
+
+// SafeErrorHandler falls back to a plain text description of the error
+// in case the wrapped handler can not handle it or fails itself.
 type SafeErrorHandler struct {
 	ErrorHandler   ErrorHandler
 	ShowStackTrace bool
 }
 
+// NewSafeErrorHandler creates a new SafeErrorHandler
 func NewSafeErrorHandler(errorHandler ErrorHandler, showStackTrace bool) *SafeErrorHandler {
 	return &SafeErrorHandler{ErrorHandler: errorHandler, ShowStackTrace: showStackTrace}
 }
 
-func (h *SafeErrorHandler) Handle(input ErrorHandlerArgs) (interface{}, error) {
-	// TODO: Implement failsafe error handling
-	return nil, nil
+// CanHandle implements ErrorHandler.CanHandle
+func (h *SafeErrorHandler) CanHandle(input ErrorHandlerArgs) error {
+	return nil
+}
+
+// Handle implements ErrorHandler.Handle
+func (h *SafeErrorHandler) Handle(input ErrorHandlerArgs) (*response.ResponseDescription, error) {
+	if h.ErrorHandler != nil {
+		err := h.ErrorHandler.CanHandle(input)
+		if err == nil {
+			var description *response.ResponseDescription
+			if description, err = h.ErrorHandler.Handle(input); err == nil {
+				return description, nil
+			}
+		}
+		log.Printf("Recovering from error handler failure: %v", err)
+	}
+
+	message := errors.GetErrorMessage(input.Error)
+	if h.ShowStackTrace {
+		message += "\n\n" + errors.GetErrorStack(input.Error)
+	}
+	return &response.ResponseDescription{
+		StatusCode: errors.StatusCode(input.Error),
+		Metadata:   errorMetadata(input.Error).SetContentType(util.TextPlain),
+		Data:       strings.NewReader(message + "\n"),
+	}, nil
 }
@@ -1,21 +1,37 @@
 // Package errorhandler implements an ErrorHandler that adds metadata to an error to indicate the targeted resource identifier.
 package errorhandler
 
+import (
+	"solid-go/internal/http/input/identifier"
+	"solid-go/internal/http/output/response"
+)
+
+// TargetExtractorErrorHandler sets the identifier of the error metadata to the target of the request,
+// so metadata writers can add headers about the targeted resource, such as the Link to its ACL.
 type TargetExtractorErrorHandler struct {
 	ErrorHandler    ErrorHandler
-	TargetExtractor interface{} // TODO: Replace with concrete TargetExtractor type
+	TargetExtractor identifier.TargetExtractor
 }
 
-func NewTargetExtractorErrorHandler(errorHandler ErrorHandler, targetExtractor interface{}) *TargetExtractorErrorHandler {
+// NewTargetExtractorErrorHandler creates a new TargetExtractorErrorHandler
+func NewTargetExtractorErrorHandler(errorHandler ErrorHandler, targetExtractor identifier.TargetExtractor) *TargetExtractorErrorHandler {
 	return &TargetExtractorErrorHandler{ErrorHandler: errorHandler, TargetExtractor: targetExtractor}
 }
 
+// CanHandle implements ErrorHandler.CanHandle
 func (h *TargetExtractorErrorHandler) CanHandle(input ErrorHandlerArgs) error {
-	// TODO: Delegate to wrapped error handler
-	return nil
+	return h.ErrorHandler.CanHandle(input)
 }
 
-func (h *TargetExtractorErrorHandler) Handle(input ErrorHandlerArgs) (interface{}, error) {
-	// TODO: Implement logic to add target metadata and delegate to wrapped handler
-	return nil, nil
+// Handle implements ErrorHandler.Handle
+func (h *TargetExtractorErrorHandler) Handle(input ErrorHandlerArgs) (*response.ResponseDescription, error) {
+	description, err := h.ErrorHandler.Handle(input)
+	if err != nil || description.Metadata == nil || input.Request == nil {
+		return description, err
+	}
+	// The target might be the cause of the error, in which case there is nothing to add
+	if target, err := h.TargetExtractor.Handle(input.Request); err == nil {
+		description.Metadata.SetIdentifier(target.Path)
+	}
+	return description, nil
 }
@@ -0,0 +1,42 @@
+// Package errorhandler implements an ErrorHandler that delegates to the first of its handlers that supports the error.
+package errorhandler
+
+import (
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/util/errors"
+)
+
+// WaterfallErrorHandler handles errors with the first of its handlers that can handle them.
+type WaterfallErrorHandler struct {
+	Handlers []ErrorHandler
+}
+
+// NewWaterfallErrorHandler creates a new WaterfallErrorHandler
+func NewWaterfallErrorHandler(handlers ...ErrorHandler) *WaterfallErrorHandler {
+	return &WaterfallErrorHandler{Handlers: handlers}
+}
+
+// CanHandle implements ErrorHandler.CanHandle
+func (h *WaterfallErrorHandler) CanHandle(input ErrorHandlerArgs) error {
+	_, err := h.findHandler(input)
+	return err
+}
+
+// Handle implements ErrorHandler.Handle
+func (h *WaterfallErrorHandler) Handle(input ErrorHandlerArgs) (*response.ResponseDescription, error) {
+	handler, err := h.findHandler(input)
+	if err != nil {
+		return nil, err
+	}
+	return handler.Handle(input)
+}
+
+// findHandler returns the first handler that can handle the input
+func (h *WaterfallErrorHandler) findHandler(input ErrorHandlerArgs) (ErrorHandler, error) {
+	for _, handler := range h.Handlers {
+		if handler.CanHandle(input) == nil {
+			return handler, nil
+		}
+	}
+	return nil, errors.NewNotImplementedError("No error handler supports the error", nil)
+}
@@ -1,95 +1,98 @@
-// Package metadatawriter implements a writer that generates Allow, Accept-Patch, Accept-Post, and Accept-Put headers.
-package metadatawriter
+// Package metadata implements a writer that generates Allow, Accept-Patch, Accept-Post, and Accept-Put headers.
+package metadata
+
+import (
+	"net/http"
+	"strings"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/vocabularies"
+)
 
 type AllowAcceptHeaderWriter struct {
 	SupportedMethods []string
 	AcceptTypes      map[string][]string // keys: patch, post, put
+	// MetadataStrategy identifies the description resources, which can only be modified with PATCH
+	MetadataStrategy AuxiliaryIdentifierStrategy
 }
 
 func NewAllowAcceptHeaderWriter(supportedMethods []string, acceptTypes map[string][]string) *AllowAcceptHeaderWriter {
 	return &AllowAcceptHeaderWriter{SupportedMethods: supportedMethods, AcceptTypes: acceptTypes}
 }
 
+// WithMetadataStrategy sets the strategy identifying description resources and returns the writer for chaining
+func (w *AllowAcceptHeaderWriter) WithMetadataStrategy(strategy AuxiliaryIdentifierStrategy) *AllowAcceptHeaderWriter {
+	w.MetadataStrategy = strategy
+	return w
+}
+
 func (w *AllowAcceptHeaderWriter) Handle(input MetadataWriterInput) error {
 	// This function generates Allow, Accept-Patch, Accept-Post, and Accept-Put headers
 	// based on supported methods, accept types, and metadata.
-	response := input.Response.(map[string]interface{}) // TODO: Replace with actual HttpResponse type
-	metadata := input.Metadata.(map[string]interface{}) // TODO: Replace with actual RepresentationMetadata type
+	header := input.Response.Header()
+	metadata := input.Metadata
+	if metadata == nil {
+		metadata = representation.NewRepresentationMetadata("")
+	}
 
-	// Determine resource type (document, container, unknown)
-	resourceType := "unknown"
-	if types, ok := metadata["types"].([]string); ok {
-		for _, t := range types {
-			if t == "ldp:Resource" {
-				if isContainerPath(metadata["identifier"].(string)) {
-					resourceType = "container"
-				} else {
-					resourceType = "document"
-				}
-				break
-			}
-		}
-	} else if target, ok := metadata["target"].(string); ok {
-		if isContainerPath(target) {
-			resourceType = "container"
-		} else {
-			resourceType = "document"
+	// Determine resource type: existing resources have the ldp:Resource type in their metadata.
+	// Error responses have no such metadata, so only a 404 tells whether the resource exists,
+	// other errors such as 304, 412 and 416 get no Allow header instead of one for a missing resource.
+	exists := isResource(metadata)
+	if status, ok := metadata.Get("statusCode"); ok {
+		if status != http.StatusNotFound {
+			return nil
 		}
+		exists = false
 	}
+	container := isContainerPath(metadata.GetIdentifier())
+	description := w.MetadataStrategy != nil &&
+		w.MetadataStrategy.IsAuxiliaryIdentifier(representation.ResourceIdentifier{Path: metadata.GetIdentifier()})
 
-	// Filter allowed methods (stub: allow all supported methods)
+	// Filter allowed methods based on the resource type, keeping the configured order
 	allowedMethods := make(map[string]bool)
+	allowList := []string{}
 	for _, m := range w.SupportedMethods {
+		switch {
+		case !exists && m != "PUT" && m != "PATCH" && m != "OPTIONS":
+			// Only methods that can create a resource apply to a resource that does not exist
+			continue
+		case exists && !container && m == "POST":
+			// POST is only allowed on containers
+			continue
+		case description && (m == "PUT" || m == "DELETE"):
+			// Descriptions exist as long as their subject and are only changed with PATCH
+			continue
+		}
 		allowedMethods[m] = true
+		allowList = append(allowList, m)
 	}
-	// TODO: Remove methods from allowedMethods based on metadata (e.g., disallowedMethod)
 
 	// Generate Allow header
-	allowList := []string{}
-	for m := range allowedMethods {
-		allowList = append(allowList, m)
-	}
 	if len(allowList) > 0 {
-		setHeader(response, "Allow", join(allowList, ", "))
+		header.Set("Allow", strings.Join(allowList, ", "))
 	}
 
 	// Generate Accept-Patch, Accept-Post, Accept-Put headers if method is allowed
 	if allowedMethods["PATCH"] && len(w.AcceptTypes["patch"]) > 0 {
-		setHeader(response, "Accept-Patch", join(w.AcceptTypes["patch"], ", "))
+		header.Set("Accept-Patch", strings.Join(w.AcceptTypes["patch"], ", "))
 	}
 	if allowedMethods["POST"] && len(w.AcceptTypes["post"]) > 0 {
-		setHeader(response, "Accept-Post", join(w.AcceptTypes["post"], ", "))
+		header.Set("Accept-Post", strings.Join(w.AcceptTypes["post"], ", "))
 	}
 	if allowedMethods["PUT"] && len(w.AcceptTypes["put"]) > 0 {
-		setHeader(response, "Accept-Put", join(w.AcceptTypes["put"], ", "))
+		header.Set("Accept-Put", strings.Join(w.AcceptTypes["put"], ", "))
 	}
 
 	return nil
 }
 
-// isContainerPath is a stub for checking if a path is a container
+// isContainerPath checks if a path is a container
 func isContainerPath(path string) bool {
-	return len(path) > 0 && path[len(path)-1] == '/'
-}
-
-// setHeader is a stub for setting a header on the response
-func setHeader(response map[string]interface{}, key, value string) {
-	headers, ok := response["headers"].(map[string]string)
-	if !ok {
-		headers = make(map[string]string)
-		response["headers"] = headers
-	}
-	headers[key] = value
+	return strings.HasSuffix(path, "/")
 }
 
-// join is a helper to join string slices
-func join(list []string, sep string) string {
-	result := ""
-	for i, s := range list {
-		if i > 0 {
-			result += sep
-		}
-		result += s
-	}
-	return result
+// isResource checks if the metadata describes an existing resource
+func isResource(metadata *representation.RepresentationMetadata) bool {
+	return len(metadata.Quads(metadata.GetIdentifier(), vocabularies.RDF.Type, vocabularies.LDP.Resource, nil)) > 0
 }
@@ -1,44 +1,33 @@
-// Package metadatawriter implements a writer that adds auxiliary Link headers.
-package metadatawriter
+// Package metadata implements a writer that adds auxiliary Link headers.
+package metadata
+
+import "solid-go/internal/http/representation"
+
+// AuxiliaryIdentifierStrategy contains the methods of an auxiliary identifier strategy the writer needs
+type AuxiliaryIdentifierStrategy interface {
+	GetAuxiliaryIdentifier(identifier representation.ResourceIdentifier) representation.ResourceIdentifier
+	IsAuxiliaryIdentifier(identifier representation.ResourceIdentifier) bool
+}
 
 type AuxiliaryLinkMetadataWriter struct {
-	AuxiliaryStrategy interface{}
-	SpecificStrategy  interface{}
+	AuxiliaryStrategy AuxiliaryIdentifierStrategy
+	SpecificStrategy  AuxiliaryIdentifierStrategy
 	RelationType      string
 }
 
-func NewAuxiliaryLinkMetadataWriter(auxStrategy, specStrategy interface{}, relationType string) *AuxiliaryLinkMetadataWriter {
+func NewAuxiliaryLinkMetadataWriter(auxStrategy, specStrategy AuxiliaryIdentifierStrategy, relationType string) *AuxiliaryLinkMetadataWriter {
 	return &AuxiliaryLinkMetadataWriter{AuxiliaryStrategy: auxStrategy, SpecificStrategy: specStrategy, RelationType: relationType}
 }
 
 func (w *AuxiliaryLinkMetadataWriter) Handle(input MetadataWriterInput) error {
 	// Implements logic to add a Link header for an auxiliary resource if appropriate.
-	response := input.Response.(map[string]interface{}) // TODO: Replace with actual HttpResponse type
-	metadata := input.Metadata.(map[string]interface{}) // TODO: Replace with actual RepresentationMetadata type
-
-	var identifier string
-	if types, ok := metadata["types"].([]string); ok {
-		for _, t := range types {
-			if t == "ldp:Resource" {
-				identifier = metadata["identifier"].(string)
-				break
-			}
-		}
-	}
-	if identifier == "" {
-		target, ok := metadata["target"].(string)
-		if ok {
-			identifier = target
-		}
+	if input.Metadata == nil || !isResource(input.Metadata) {
+		return nil
 	}
-
-	if identifier != "" {
-		auxStrategy, ok := w.AuxiliaryStrategy.(interface{ IsAuxiliaryIdentifier(string) bool })
-		specStrategy, ok2 := w.SpecificStrategy.(interface{ GetAuxiliaryIdentifier(string) string })
-		if ok && ok2 && !auxStrategy.IsAuxiliaryIdentifier(identifier) {
-			auxID := specStrategy.GetAuxiliaryIdentifier(identifier)
-			setHeader(response, "Link", "<"+auxID+">; rel=\""+w.RelationType+"\"")
-		}
+	identifier := representation.ResourceIdentifier{Path: input.Metadata.GetIdentifier()}
+	if !w.AuxiliaryStrategy.IsAuxiliaryIdentifier(identifier) {
+		auxID := w.SpecificStrategy.GetAuxiliaryIdentifier(identifier)
+		input.Response.Header().Add("Link", "<"+auxID.Path+">; rel=\""+w.RelationType+"\"")
 	}
 	return nil
 }
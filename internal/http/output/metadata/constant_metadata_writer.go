@@ -1,5 +1,5 @@
-// Package metadatawriter implements a writer that adds constant headers to the response.
-package metadatawriter
+// Package metadata implements a writer that adds constant headers to the response.
+package metadata
 
 type ConstantMetadataWriter struct {
 	Headers map[string]string
@@ -10,9 +10,9 @@ func NewConstantMetadataWriter(headers map[string]string) *ConstantMetadataWrite
 }
 
 func (w *ConstantMetadataWriter) Handle(input MetadataWriterInput) error {
-	response := input.Response.(map[string]interface{}) // TODO: Replace with actual HttpResponse type
+	header := input.Response.Header()
 	for key, value := range w.Headers {
-		setHeader(response, key, value)
+		header.Add(key, value)
 	}
 	return nil
 }
@@ -1,13 +1,23 @@
-// Package metadatawriter implements a writer that adds the Content-Type header.
-package metadatawriter
+// Package metadata implements a writer that adds the Content-Type header.
+package metadata
 
 type ContentTypeMetadataWriter struct{}
 
+// NewContentTypeMetadataWriter creates a new ContentTypeMetadataWriter
+func NewContentTypeMetadataWriter() *ContentTypeMetadataWriter {
+	return &ContentTypeMetadataWriter{}
+}
+
 func (w *ContentTypeMetadataWriter) Handle(input MetadataWriterInput) error {
-	response := input.Response.(map[string]interface{}) // TODO: Replace with actual HttpResponse type
-	metadata := input.Metadata.(map[string]interface{}) // TODO: Replace with actual RepresentationMetadata type
-	if contentType, ok := metadata["contentTypeObject"].(string); ok && contentType != "" {
-		setHeader(response, "Content-Type", contentType)
+	if input.Metadata == nil {
+		return nil
+	}
+	// Multipart range responses get their content type from the RangeMetadataWriter
+	if _, ok := input.Metadata.Get("boundary"); ok {
+		return nil
+	}
+	if contentType := input.Metadata.ContentType(); contentType != "" {
+		input.Response.Header().Set("Content-Type", contentType)
 	}
 	return nil
 }
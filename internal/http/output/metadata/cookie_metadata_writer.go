@@ -1,9 +1,9 @@
-// Package metadatawriter implements a writer that generates Set-Cookie headers from metadata.
-package metadatawriter
+// Package metadata implements a writer that generates Set-Cookie headers from metadata.
+package metadata
 
 type CookieMetadataWriter struct {
 	CookieMap map[string]struct {
-		Name         string
+		Name          string
 		ExpirationUri string
 	}
 }
@@ -13,17 +13,18 @@ func NewCookieMetadataWriter(cookieMap map[string]struct{ Name, ExpirationUri st
 }
 
 func (w *CookieMetadataWriter) Handle(input MetadataWriterInput) error {
-	response := input.Response.(map[string]interface{}) // TODO: Replace with actual HttpResponse type
-	metadata := input.Metadata.(map[string]interface{}) // TODO: Replace with actual RepresentationMetadata type
+	if input.Metadata == nil {
+		return nil
+	}
 	for uri, cookie := range w.CookieMap {
-		if value, ok := metadata[uri].(string); ok && value != "" {
+		if value, ok := input.Metadata.Store[uri].(string); ok && value != "" {
 			// Expiration is optional
 			expires := ""
-			if exp, ok := metadata[cookie.ExpirationUri].(string); ok && exp != "" {
+			if exp, ok := input.Metadata.Store[cookie.ExpirationUri].(string); ok && exp != "" {
 				expires = "; Expires=" + exp
 			}
 			cookieHeader := cookie.Name + "=" + value + "; Path=/; SameSite=Lax" + expires
-			setHeader(response, "Set-Cookie", cookieHeader)
+			input.Response.Header().Add("Set-Cookie", cookieHeader)
 		}
 	}
 	return nil
@@ -1,5 +1,5 @@
-// Package metadatawriter implements a writer that adds Link headers based on metadata predicates.
-package metadatawriter
+// Package metadata implements a writer that adds Link headers based on metadata predicates.
+package metadata
 
 type LinkRelMetadataWriter struct {
 	LinkRelMap map[string]string // predicate URI -> rel value
@@ -10,15 +10,12 @@ func NewLinkRelMetadataWriter(linkRelMap map[string]string) *LinkRelMetadataWrit
 }
 
 func (w *LinkRelMetadataWriter) Handle(input MetadataWriterInput) error {
-	response := input.Response.(map[string]interface{}) // TODO: Replace with actual HttpResponse type
-	metadata := input.Metadata.(map[string]interface{}) // TODO: Replace with actual RepresentationMetadata type
+	if input.Metadata == nil {
+		return nil
+	}
 	for predicate, relValue := range w.LinkRelMap {
-		if values, ok := metadata[predicate].([]string); ok && len(values) > 0 {
-			linkHeaders := []string{}
-			for _, v := range values {
-				linkHeaders = append(linkHeaders, "<"+v+">; rel=\""+relValue+"\"")
-			}
-			setHeader(response, "Link", join(linkHeaders, ","))
+		for _, v := range objectValues(input.Metadata, predicate) {
+			input.Response.Header().Add("Link", "<"+v+">; rel=\""+relValue+"\"")
 		}
 	}
 	return nil
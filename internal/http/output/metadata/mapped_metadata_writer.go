@@ -1,5 +1,12 @@
-// Package metadatawriter implements a writer that maps metadata predicates to headers.
-package metadatawriter
+// Package metadata implements a writer that maps metadata predicates to headers.
+package metadata
+
+import (
+	"sort"
+	"strings"
+
+	"solid-go/internal/http/representation"
+)
 
 type MappedMetadataWriter struct {
 	HeaderMap map[string]string // predicate URI -> header name
@@ -10,12 +17,23 @@ func NewMappedMetadataWriter(headerMap map[string]string) *MappedMetadataWriter
 }
 
 func (w *MappedMetadataWriter) Handle(input MetadataWriterInput) error {
-	response := input.Response.(map[string]interface{}) // TODO: Replace with actual HttpResponse type
-	metadata := input.Metadata.(map[string]interface{}) // TODO: Replace with actual RepresentationMetadata type
+	if input.Metadata == nil {
+		return nil
+	}
 	for predicate, header := range w.HeaderMap {
-		if values, ok := metadata[predicate].([]string); ok && len(values) > 0 {
-			setHeader(response, header, join(values, ","))
+		if values := objectValues(input.Metadata, predicate); len(values) > 0 {
+			input.Response.Header().Set(header, strings.Join(values, ","))
 		}
 	}
 	return nil
 }
+
+// objectValues returns the sorted values of the objects of a predicate about the resource
+func objectValues(metadata *representation.RepresentationMetadata, predicate string) []string {
+	var values []string
+	for _, quad := range metadata.Quads(metadata.GetIdentifier(), predicate, nil, nil) {
+		values = append(values, quad.Object.Value())
+	}
+	sort.Strings(values)
+	return values
+}
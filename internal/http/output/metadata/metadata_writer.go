@@ -1,6 +1,19 @@
-// Package metadata provides metadata writing interfaces for HTTP output.
+// Package metadata provides the writers that convert representation metadata into response headers.
 package metadata
 
+import (
+	"net/http"
+
+	"solid-go/internal/http/representation"
+)
+
+// MetadataWriterInput contains the response to add headers to and the metadata to base them on
+type MetadataWriterInput struct {
+	Response http.ResponseWriter
+	Metadata *representation.RepresentationMetadata
+}
+
+// MetadataWriter adds headers to a response based on the metadata of the response
 type MetadataWriter interface {
-	WriteMetadata(metadata interface{}) error // TODO: use actual metadata type
+	Handle(input MetadataWriterInput) error
 }
@@ -0,0 +1,108 @@
+package metadata
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
+)
+
+const resource = "http://example.org/video.mp4"
+
+func sizedMetadata(size string) *representation.RepresentationMetadata {
+	metadata := representation.NewRepresentationMetadata(resource)
+	metadata.AddQuad(n3.NewQuad(n3.NewNamedNode(resource), vocabularies.RDF.Type, vocabularies.LDP.Resource, nil))
+	metadata.AddQuad(n3.NewQuad(n3.NewNamedNode(resource), vocabularies.POSIX.Size,
+		n3.NewTypedLiteral(size, n3.NewNamedNode(n3.XSDInteger)), nil))
+	return metadata
+}
+
+func TestRangeMetadataWriter(t *testing.T) {
+	tests := []struct {
+		name          string
+		metadata      *representation.RepresentationMetadata
+		contentRange  string
+		contentLength string
+		contentType   string
+		acceptRanges  string
+	}{
+		{"full", sizedMetadata("100"), "", "100", "", "bytes"},
+		{"single range", sizedMetadata("100").Add("unit", "bytes").Add("start", int64(10)).Add("end", int64(19)),
+			"bytes 10-19/100", "10", "", "bytes"},
+		{"multipart", sizedMetadata("100").Add("unit", "bytes").Add("boundary", "xyz"), "", "",
+			"multipart/byteranges; boundary=xyz", "bytes"},
+		{"unknown size", representation.NewRepresentationMetadata(resource).Add("unit", "bytes").
+			Add("start", int64(0)).Add("end", int64(4)), "bytes 0-4/*", "5", "", "bytes"},
+		{"converted or container", representation.NewRepresentationMetadata(resource), "", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			if err := NewRangeMetadataWriter().Handle(MetadataWriterInput{Response: response, Metadata: tt.metadata}); err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+			if got := response.Header().Get("Content-Range"); got != tt.contentRange {
+				t.Errorf("Content-Range = %q, want %q", got, tt.contentRange)
+			}
+			if got := response.Header().Get("Content-Length"); got != tt.contentLength {
+				t.Errorf("Content-Length = %q, want %q", got, tt.contentLength)
+			}
+			if got := response.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+			if got := response.Header().Get("Accept-Ranges"); got != tt.acceptRanges {
+				t.Errorf("Accept-Ranges = %q, want %q", got, tt.acceptRanges)
+			}
+		})
+	}
+}
+
+func TestAllowAcceptHeaderWriter(t *testing.T) {
+	writer := NewAllowAcceptHeaderWriter([]string{"OPTIONS", "GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"},
+		map[string][]string{"post": {"text/turtle"}, "patch": {"text/n3"}})
+
+	response := httptest.NewRecorder()
+	if err := writer.Handle(MetadataWriterInput{Response: response, Metadata: sizedMetadata("1")}); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if got := response.Header().Get("Allow"); got != "OPTIONS, GET, HEAD, PUT, PATCH, DELETE" {
+		t.Errorf("Allow for a document = %q", got)
+	}
+	if got := response.Header().Get("Accept-Post"); got != "" {
+		t.Errorf("Accept-Post for a document = %q, want none", got)
+	}
+
+	response = httptest.NewRecorder()
+	missing := representation.NewRepresentationMetadata("http://example.org/missing")
+	if err := writer.Handle(MetadataWriterInput{Response: response, Metadata: missing}); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if got := response.Header().Get("Allow"); got != "OPTIONS, PUT, PATCH" {
+		t.Errorf("Allow for a missing resource = %q", got)
+	}
+	if got := response.Header().Get("Accept-Patch"); got != "text/n3" {
+		t.Errorf("Accept-Patch = %q, want text/n3", got)
+	}
+
+	response = httptest.NewRecorder()
+	notFound := representation.NewRepresentationMetadata("http://example.org/missing").Add("statusCode", 404)
+	if err := writer.Handle(MetadataWriterInput{Response: response, Metadata: notFound}); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if got := response.Header().Get("Allow"); got != "OPTIONS, PUT, PATCH" {
+		t.Errorf("Allow for a 404 = %q", got)
+	}
+
+	for _, status := range []int{304, 412, 416} {
+		response = httptest.NewRecorder()
+		failed := representation.NewRepresentationMetadata(resource).Add("statusCode", status)
+		if err := writer.Handle(MetadataWriterInput{Response: response, Metadata: failed}); err != nil {
+			t.Fatalf("Handle() error = %v", err)
+		}
+		if got := response.Header().Get("Allow"); got != "" {
+			t.Errorf("Allow for a %d = %q, want none", status, got)
+		}
+	}
+}
@@ -1,16 +1,31 @@
-// Package metadatawriter implements a writer that generates Last-Modified and ETag headers.
-package metadatawriter
+// Package metadata implements a writer that generates Last-Modified and ETag headers.
+package metadata
 
-type ModifiedMetadataWriter struct{}
+import (
+	"net/http"
+
+	"solid-go/internal/http/input/conditions"
+)
+
+type ModifiedMetadataWriter struct {
+	ETagHandler conditions.ETagHandler
+}
+
+// NewModifiedMetadataWriter creates a new ModifiedMetadataWriter
+func NewModifiedMetadataWriter(eTagHandler conditions.ETagHandler) *ModifiedMetadataWriter {
+	return &ModifiedMetadataWriter{ETagHandler: eTagHandler}
+}
 
 func (w *ModifiedMetadataWriter) Handle(input MetadataWriterInput) error {
-	response := input.Response.(map[string]interface{}) // TODO: Replace with actual HttpResponse type
-	metadata := input.Metadata.(map[string]interface{}) // TODO: Replace with actual RepresentationMetadata type
-	if modified, ok := metadata["modified"].(string); ok && modified != "" {
-		setHeader(response, "Last-Modified", modified) // Should be formatted as RFC1123 if needed
+	metadata := input.Metadata
+	if metadata == nil {
+		return nil
+	}
+	if modified := conditions.ModifiedTime(metadata); !modified.IsZero() {
+		input.Response.Header().Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
 	}
-	if etag, ok := metadata["etag"].(string); ok && etag != "" {
-		setHeader(response, "ETag", etag)
+	if eTag := w.ETagHandler.GetETag(metadata); eTag != "" {
+		input.Response.Header().Set("ETag", eTag)
 	}
 	return nil
 }
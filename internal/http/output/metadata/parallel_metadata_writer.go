@@ -0,0 +1,21 @@
+package metadata
+
+// ParallelMetadataWriter runs all its writers on the same input
+type ParallelMetadataWriter struct {
+	writers []MetadataWriter
+}
+
+// NewParallelMetadataWriter creates a new ParallelMetadataWriter
+func NewParallelMetadataWriter(writers ...MetadataWriter) *ParallelMetadataWriter {
+	return &ParallelMetadataWriter{writers: writers}
+}
+
+// Handle implements MetadataWriter.Handle
+func (w *ParallelMetadataWriter) Handle(input MetadataWriterInput) error {
+	for _, writer := range w.writers {
+		if err := writer.Handle(input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
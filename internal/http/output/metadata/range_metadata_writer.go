@@ -1,42 +1,75 @@
-// Package metadatawriter implements a writer that generates Content-Range and Content-Length headers for range requests.
-package metadatawriter
+// Package metadata implements a writer that generates Content-Range and Content-Length headers for range requests.
+package metadata
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
 
+	"solid-go/internal/util/vocabularies"
+)
+
+// RangeMetadataWriter writes the Content-Range header of a single range response,
+// and the Content-Length header whenever the length of the body is known.
+// Responses with the data of a document of known size advertise byte ranges with Accept-Ranges,
+// since the size is removed from the metadata of converted representations, whose ranges are not served.
+// Multipart range responses only get their multipart/byteranges content type,
+// their parts carry their own Content-Range headers.
 type RangeMetadataWriter struct{}
 
+// NewRangeMetadataWriter creates a new RangeMetadataWriter
+func NewRangeMetadataWriter() *RangeMetadataWriter {
+	return &RangeMetadataWriter{}
+}
+
 func (w *RangeMetadataWriter) Handle(input MetadataWriterInput) error {
-	response := input.Response.(map[string]interface{}) // TODO: Replace with actual HttpResponse type
-	metadata := input.Metadata.(map[string]interface{}) // TODO: Replace with actual RepresentationMetadata type
+	metadata := input.Metadata
+	if metadata == nil {
+		return nil
+	}
+	header := input.Response.Header()
+	size := int64(-1)
+	for _, quad := range metadata.Quads(metadata.GetIdentifier(), vocabularies.POSIX.Size, nil, nil) {
+		if value, err := strconv.ParseInt(quad.Object.Value(), 10, 64); err == nil {
+			size = value
+		}
+	}
 
-	size, _ := metadata["size"].(int)
-	unit, _ := metadata["unit"].(string)
+	unit, _ := metadata.Store["unit"].(string)
+	if size >= 0 || unit != "" {
+		header.Set("Accept-Ranges", "bytes")
+	}
 	if unit == "" {
-		if size > 0 {
-			setHeader(response, "Content-Length", itoa(size))
+		if size >= 0 {
+			header.Set("Content-Length", itoa(size))
 		}
 		return nil
 	}
-	start, _ := metadata["start"].(int)
-	end, hasEnd := metadata["end"].(int)
-	if !hasEnd && size > 0 {
+	if boundary, ok := metadata.Store["boundary"].(string); ok {
+		header.Set("Content-Type", "multipart/byteranges; boundary="+boundary)
+		return nil
+	}
+	start, hasStart := metadata.Store["start"].(int64)
+	end, hasEnd := metadata.Store["end"].(int64)
+	if !hasStart {
+		return nil
+	}
+	if !hasEnd {
 		end = size - 1
 	}
-	rangeHeader := unit + " " + itoaOrStar(start) + "-" + itoaOrStar(end) + "/" + itoaOrStar(size)
-	setHeader(response, "Content-Range", rangeHeader)
+	header.Set("Content-Range", fmt.Sprintf("%s %s-%s/%s", unit, itoaOrStar(start), itoaOrStar(end), itoaOrStar(size)))
 	if start >= 0 && end >= 0 {
-		setHeader(response, "Content-Length", itoa(end-start+1))
+		header.Set("Content-Length", itoa(end-start+1))
 	}
 	return nil
 }
 
-func itoaOrStar(val int) string {
+func itoaOrStar(val int64) string {
 	if val >= 0 {
 		return itoa(val)
 	}
 	return "*"
 }
 
-func itoa(val int) string {
-	return fmt.Sprintf("%d", val)
+func itoa(val int64) string {
+	return strconv.FormatInt(val, 10)
 }
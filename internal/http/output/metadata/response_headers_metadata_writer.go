@@ -0,0 +1,23 @@
+// Package metadata implements a writer that adds the headers stored in the metadata, such as those of errors.
+package metadata
+
+// ResponseHeadersMetadataWriter adds the headers in the "responseHeaders" entry of the metadata,
+// e.g. the Allow header of a 405 or the ETag of a 304 response.
+type ResponseHeadersMetadataWriter struct{}
+
+// NewResponseHeadersMetadataWriter creates a new ResponseHeadersMetadataWriter
+func NewResponseHeadersMetadataWriter() *ResponseHeadersMetadataWriter {
+	return &ResponseHeadersMetadataWriter{}
+}
+
+// Handle implements MetadataWriter.Handle
+func (w *ResponseHeadersMetadataWriter) Handle(input MetadataWriterInput) error {
+	if input.Metadata == nil {
+		return nil
+	}
+	headers, _ := input.Metadata.Store["responseHeaders"].(map[string]string)
+	for key, value := range headers {
+		input.Response.Header().Set(key, value)
+	}
+	return nil
+}
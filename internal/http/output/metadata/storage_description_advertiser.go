@@ -1,47 +1,42 @@
-// Package metadatawriter implements a writer that adds a storage description link header.
-package metadatawriter
+// Package metadata implements a writer that adds a storage description link header.
+package metadata
+
+import "solid-go/internal/http/representation"
+
+// StorageLocationStrategy finds the storage a resource is located in
+type StorageLocationStrategy interface {
+	GetStorageIdentifier(identifier representation.ResourceIdentifier) (representation.ResourceIdentifier, error)
+}
 
 type StorageDescriptionAdvertiser struct {
-	StorageStrategy interface{}
+	StorageStrategy StorageLocationStrategy
 	RelativePath    string
 }
 
-func NewStorageDescriptionAdvertiser(storageStrategy interface{}, relativePath string) *StorageDescriptionAdvertiser {
+func NewStorageDescriptionAdvertiser(storageStrategy StorageLocationStrategy, relativePath string) *StorageDescriptionAdvertiser {
 	return &StorageDescriptionAdvertiser{StorageStrategy: storageStrategy, RelativePath: relativePath}
 }
 
 func (w *StorageDescriptionAdvertiser) Handle(input MetadataWriterInput) error {
-	response := input.Response.(map[string]interface{}) // TODO: Replace with actual HttpResponse type
-	metadata := input.Metadata.(map[string]interface{}) // TODO: Replace with actual RepresentationMetadata type
-	if types, ok := metadata["types"].([]string); !ok || !contains(types, "ldp:Resource") {
-		return nil
-	}
-	identifier := map[string]string{"path": metadata["identifier"].(string)}
-	storageStrategy, ok := w.StorageStrategy.(interface{ GetStorageIdentifier(identifier map[string]string) (map[string]string, error) })
-	if !ok {
+	if input.Metadata == nil || !isResource(input.Metadata) {
 		return nil
 	}
-	storageRoot, err := storageStrategy.GetStorageIdentifier(identifier)
+	identifier := representation.ResourceIdentifier{Path: input.Metadata.GetIdentifier()}
+	storageRoot, err := w.StorageStrategy.GetStorageIdentifier(identifier)
 	if err != nil {
 		return nil
 	}
-	storageDescription := joinUrl(storageRoot["path"], w.RelativePath)
-	setHeader(response, "Link", "<"+storageDescription+">; rel=\"storageDescription\"")
+	storageDescription := joinUrl(storageRoot.Path, w.RelativePath)
+	input.Response.Header().Add("Link", "<"+storageDescription+">; rel=\"http://www.w3.org/ns/solid/terms#storageDescription\"")
 	return nil
 }
 
-func contains(list []string, val string) bool {
-	for _, v := range list {
-		if v == val {
-			return true
-		}
-	}
-	return false
-}
-
 func joinUrl(base, rel string) string {
 	if len(base) > 0 && base[len(base)-1] != '/' && len(rel) > 0 && rel[0] != '/' {
 		return base + "/" + rel
 	}
+	if len(base) > 0 && base[len(base)-1] == '/' && len(rel) > 0 && rel[0] == '/' {
+		return base + rel[1:]
+	}
 	return base + rel
 }
@@ -1,13 +1,26 @@
-// Package metadatawriter implements a writer that adds the WAC-Allow header for access control.
-package metadatawriter
+// Package metadata implements a writer that adds the WAC-Allow header for access control.
+package metadata
+
+import (
+	"sort"
+	"strings"
+)
 
 type WacAllowMetadataWriter struct{}
 
+// NewWacAllowMetadataWriter creates a new WacAllowMetadataWriter
+func NewWacAllowMetadataWriter() *WacAllowMetadataWriter {
+	return &WacAllowMetadataWriter{}
+}
+
 func (w *WacAllowMetadataWriter) Handle(input MetadataWriterInput) error {
-	response := input.Response.(map[string]interface{}) // TODO: Replace with actual HttpResponse type
-	metadata := input.Metadata.(map[string]interface{}) // TODO: Replace with actual RepresentationMetadata type
-	userModes := toSet(metadata["userMode"].([]string))
-	publicModes := toSet(metadata["publicMode"].([]string))
+	if input.Metadata == nil {
+		return nil
+	}
+	userList, _ := input.Metadata.Store["userMode"].([]string)
+	publicList, _ := input.Metadata.Store["publicMode"].([]string)
+	userModes := toSet(userList)
+	publicModes := toSet(publicList)
 	for m := range publicModes {
 		userModes[m] = struct{}{}
 	}
@@ -19,7 +32,7 @@ func (w *WacAllowMetadataWriter) Handle(input MetadataWriterInput) error {
 		headerStrings = append(headerStrings, createAccessParam("public", publicModes))
 	}
 	if len(headerStrings) > 0 {
-		setHeader(response, "WAC-Allow", join(headerStrings, ","))
+		input.Response.Header().Set("WAC-Allow", strings.Join(headerStrings, ","))
 	}
 	return nil
 }
@@ -38,5 +51,5 @@ func createAccessParam(name string, modes map[string]struct{}) string {
 		modeList = append(modeList, m)
 	}
 	sort.Strings(modeList)
-	return name + "=\"" + join(modeList, " ") + "\""
+	return name + "=\"" + strings.Join(modeList, " ") + "\""
 }
@@ -1,5 +1,5 @@
-// Package metadatawriter implements a writer that adds the WWW-Authenticate header for 401 responses.
-package metadatawriter
+// Package metadata implements a writer that adds the WWW-Authenticate header for 401 responses.
+package metadata
 
 type WwwAuthMetadataWriter struct {
 	Auth string
@@ -10,10 +10,11 @@ func NewWwwAuthMetadataWriter(auth string) *WwwAuthMetadataWriter {
 }
 
 func (w *WwwAuthMetadataWriter) Handle(input MetadataWriterInput) error {
-	response := input.Response.(map[string]interface{}) // TODO: Replace with actual HttpResponse type
-	metadata := input.Metadata.(map[string]interface{}) // TODO: Replace with actual RepresentationMetadata type
-	if status, ok := metadata["statusCodeNumber"].(string); ok && status == "401" {
-		setHeader(response, "WWW-Authenticate", w.Auth)
+	if input.Metadata == nil {
+		return nil
+	}
+	if status, ok := input.Metadata.Store["statusCode"].(int); ok && status == 401 {
+		input.Response.Header().Set("WWW-Authenticate", w.Auth)
 	}
 	return nil
 }
@@ -0,0 +1,2 @@
+// Package output writes response descriptions as HTTP responses.
+package output
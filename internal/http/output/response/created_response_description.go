@@ -1,13 +1,20 @@
 // Package response provides a CreatedResponseDescription for 201 responses.
 package response
 
+import (
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
+)
+
 type CreatedResponseDescription struct {
 	ResponseDescription
 }
 
 // NewCreatedResponseDescription constructs a new CreatedResponseDescription with the given location.
 func NewCreatedResponseDescription(location string) *CreatedResponseDescription {
-	metadata := map[string]interface{}{"location": location} // TODO: Use actual RepresentationMetadata
+	metadata := representation.NewRepresentationMetadata(location)
+	metadata.AddQuad(n3.NewQuad(n3.NewNamedNode(location), vocabularies.SOLID_HTTP.Location, n3.NewNamedNode(location), nil))
 	return &CreatedResponseDescription{
 		ResponseDescription: ResponseDescription{
 			StatusCode: 201,
@@ -0,0 +1,18 @@
+// Package response provides a NoContentResponseDescription for 204 responses.
+package response
+
+import "solid-go/internal/http/representation"
+
+type NoContentResponseDescription struct {
+	ResponseDescription
+}
+
+// NewNoContentResponseDescription constructs a new NoContentResponseDescription with the given metadata.
+func NewNoContentResponseDescription(metadata *representation.RepresentationMetadata) *NoContentResponseDescription {
+	return &NoContentResponseDescription{
+		ResponseDescription: ResponseDescription{
+			StatusCode: 204,
+			Metadata:   metadata,
+		},
+	}
+}
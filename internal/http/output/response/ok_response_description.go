@@ -1,14 +1,21 @@
 // Package response provides an OkResponseDescription for 200/206 responses.
 package response
 
+import (
+	"io"
+
+	"solid-go/internal/http/representation"
+)
+
 type OkResponseDescription struct {
 	ResponseDescription
 }
 
 // NewOkResponseDescription constructs a new OkResponseDescription with the given metadata and data.
-func NewOkResponseDescription(metadata map[string]interface{}, data interface{}) *OkResponseDescription {
+// Metadata with a range unit results in a 206 response.
+func NewOkResponseDescription(metadata *representation.RepresentationMetadata, data io.Reader) *OkResponseDescription {
 	status := 200
-	if _, ok := metadata["unit"]; ok {
+	if _, ok := metadata.Get("unit"); ok {
 		status = 206
 	}
 	return &OkResponseDescription{
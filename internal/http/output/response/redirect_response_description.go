@@ -1,13 +1,22 @@
 // Package response provides a RedirectResponseDescription for redirect responses.
 package response
 
+import (
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
+)
+
 type RedirectResponseDescription struct {
 	ResponseDescription
 }
 
 // NewRedirectResponseDescription constructs a new RedirectResponseDescription with the given status code, metadata, and location.
-func NewRedirectResponseDescription(statusCode int, metadata map[string]interface{}, location string) *RedirectResponseDescription {
-	metadata["location"] = location
+func NewRedirectResponseDescription(statusCode int, metadata *representation.RepresentationMetadata, location string) *RedirectResponseDescription {
+	if metadata == nil {
+		metadata = representation.NewRepresentationMetadata(location)
+	}
+	metadata.AddQuad(n3.NewQuad(n3.NewNamedNode(metadata.GetIdentifier()), vocabularies.SOLID_HTTP.Location, n3.NewNamedNode(location), nil))
 	return &RedirectResponseDescription{
 		ResponseDescription: ResponseDescription{
 			StatusCode: statusCode,
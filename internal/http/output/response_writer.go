@@ -1,6 +1,13 @@
 // Package output provides the ResponseWriter interface.
 package output
 
+import (
+	"net/http"
+
+	"solid-go/internal/http/output/response"
+)
+
+// ResponseWriter writes a ResponseDescription to the HTTP response
 type ResponseWriter interface {
-	Write(response interface{}) error // TODO: use actual response type
+	Handle(w http.ResponseWriter, description *response.ResponseDescription) error
 }
@@ -0,0 +1,216 @@
+package serialize
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"solid-go/internal/util"
+	"solid-go/internal/util/n3"
+)
+
+// DefaultContext is the JSON-LD context used when none is configured
+var DefaultContext = map[string]string{
+	"acl":   "http://www.w3.org/ns/auth/acl#",
+	"dc":    "http://purl.org/dc/terms/",
+	"foaf":  "http://xmlns.com/foaf/0.1/",
+	"ldp":   "http://www.w3.org/ns/ldp#",
+	"pim":   "http://www.w3.org/ns/pim/space#",
+	"posix": "http://www.w3.org/ns/posix/stat#",
+	"rdf":   "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+	"solid": "http://www.w3.org/ns/solid/terms#",
+	"xsd":   "http://www.w3.org/2001/XMLSchema#",
+}
+
+// JsonLdSerializer writes datasets as compacted JSON-LD.
+// Context entries whose IRI ends in '#' or '/' are used as prefixes, all others as terms.
+type JsonLdSerializer struct {
+	context    map[string]string
+	terms      map[string]string
+	namespaces []string
+}
+
+// NewJsonLdSerializer creates a new JsonLdSerializer with the given context.
+// DefaultContext is used if the context is nil.
+func NewJsonLdSerializer(context map[string]string) *JsonLdSerializer {
+	if context == nil {
+		context = DefaultContext
+	}
+	s := &JsonLdSerializer{context: context, terms: make(map[string]string)}
+	for term, iri := range context {
+		if strings.HasSuffix(iri, "#") || strings.HasSuffix(iri, "/") {
+			s.namespaces = append(s.namespaces, term)
+		}
+		s.terms[iri] = term
+	}
+	// Longest namespace first so the most specific prefix wins
+	sort.Slice(s.namespaces, func(i, j int) bool {
+		a, b := context[s.namespaces[i]], context[s.namespaces[j]]
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return s.namespaces[i] < s.namespaces[j]
+	})
+	return s
+}
+
+// ContentType implements QuadSerializer.ContentType
+func (s *JsonLdSerializer) ContentType() string {
+	return util.JSONLD
+}
+
+// Serialize implements QuadSerializer.Serialize.
+// A dataset with a single node in the default graph is written as one node object,
+// everything else as a "@graph" array; node objects are written one at a time.
+func (s *JsonLdSerializer) Serialize(w io.Writer, dataset n3.Store) error {
+	out := bufio.NewWriter(w)
+	quads := n3.GroupQuads(dataset.GetQuads(nil, nil, nil, nil))
+	groups := groupBySubject(quads)
+
+	context, err := json.Marshal(s.context)
+	if err != nil {
+		return err
+	}
+	if len(groups) == 1 && groups[0].graph.TermType() == n3.DefaultGraphType {
+		node := s.nodeObject(groups[0].quads)
+		node["@context"] = json.RawMessage(context)
+		if err := writeJSON(out, node); err != nil {
+			return err
+		}
+		return out.Flush()
+	}
+
+	out.WriteString(`{"@context":`)
+	out.Write(context)
+	out.WriteString(`,"@graph":[`)
+	for i := 0; i < len(groups); {
+		if i > 0 {
+			out.WriteString(",")
+		}
+		graph := groups[i].graph
+		if graph.TermType() == n3.DefaultGraphType {
+			if err := writeJSON(out, s.nodeObject(groups[i].quads)); err != nil {
+				return err
+			}
+			i++
+			continue
+		}
+		// Named graphs become node objects with their own "@graph"
+		var nodes []map[string]interface{}
+		for ; i < len(groups) && groups[i].graph.Equals(graph); i++ {
+			nodes = append(nodes, s.nodeObject(groups[i].quads))
+		}
+		if err := writeJSON(out, map[string]interface{}{"@id": s.compactID(graph), "@graph": nodes}); err != nil {
+			return err
+		}
+	}
+	out.WriteString("]}")
+	return out.Flush()
+}
+
+type subjectGroup struct {
+	graph n3.Term
+	quads []n3.Quad
+}
+
+// groupBySubject splits grouped quads into runs that share graph and subject
+func groupBySubject(quads []n3.Quad) []subjectGroup {
+	var groups []subjectGroup
+	for _, quad := range quads {
+		graph := quad.Graph
+		if graph == nil {
+			graph = n3.DefaultGraph()
+		}
+		last := len(groups) - 1
+		if last >= 0 && groups[last].graph.Equals(graph) && groups[last].quads[0].Subject.Equals(quad.Subject) {
+			groups[last].quads = append(groups[last].quads, quad)
+			continue
+		}
+		groups = append(groups, subjectGroup{graph: graph, quads: []n3.Quad{quad}})
+	}
+	return groups
+}
+
+// nodeObject builds the compacted node object for quads sharing a subject
+func (s *JsonLdSerializer) nodeObject(quads []n3.Quad) map[string]interface{} {
+	node := map[string]interface{}{"@id": s.compactID(quads[0].Subject)}
+	values := make(map[string][]interface{})
+	for _, quad := range quads {
+		if quad.Predicate.Value() == n3.RDFType && quad.Object.TermType() != n3.LiteralType {
+			values["@type"] = append(values["@type"], s.compactType(quad.Object))
+			continue
+		}
+		key := s.compactIRI(quad.Predicate.Value())
+		values[key] = append(values[key], s.value(quad.Object))
+	}
+	for key, list := range values {
+		if len(list) == 1 {
+			node[key] = list[0]
+		} else {
+			node[key] = list
+		}
+	}
+	return node
+}
+
+// value converts an object term into a JSON-LD value
+func (s *JsonLdSerializer) value(term n3.Term) interface{} {
+	literal, ok := term.(n3.Literal)
+	if !ok {
+		return map[string]interface{}{"@id": s.compactID(term)}
+	}
+	switch {
+	case literal.Language() != "":
+		return map[string]interface{}{"@value": literal.Value(), "@language": literal.Language()}
+	case literal.Datatype().Value() == n3.XSDString:
+		return literal.Value()
+	}
+	return map[string]interface{}{"@value": literal.Value(), "@type": s.compactIRI(literal.Datatype().Value())}
+}
+
+// compactID compacts the identifier of a node, keeping blank node labels.
+// Values of "@id" are not expanded with terms, so only compact IRIs can be used for them.
+func (s *JsonLdSerializer) compactID(term n3.Term) string {
+	if term.TermType() == n3.BlankNodeType {
+		return term.String()
+	}
+	return s.compactPrefix(term.Value())
+}
+
+// compactType compacts a type of a node, keeping blank node labels
+func (s *JsonLdSerializer) compactType(term n3.Term) string {
+	if term.TermType() == n3.BlankNodeType {
+		return term.String()
+	}
+	return s.compactIRI(term.Value())
+}
+
+// compactIRI replaces an IRI by a context term or a compact IRI if possible
+func (s *JsonLdSerializer) compactIRI(iri string) string {
+	if term, ok := s.terms[iri]; ok {
+		return term
+	}
+	return s.compactPrefix(iri)
+}
+
+// compactPrefix replaces an IRI by a compact IRI if one of the namespaces matches
+func (s *JsonLdSerializer) compactPrefix(iri string) string {
+	for _, prefix := range s.namespaces {
+		namespace := s.context[prefix]
+		if strings.HasPrefix(iri, namespace) && len(iri) > len(namespace) && !strings.HasPrefix(iri[len(namespace):], "//") {
+			return prefix + ":" + iri[len(namespace):]
+		}
+	}
+	return iri
+}
+
+func writeJSON(w io.Writer, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(encoded)
+	return err
+}
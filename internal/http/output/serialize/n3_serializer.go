@@ -0,0 +1,101 @@
+package serialize
+
+import (
+	"io"
+	"strings"
+
+	"solid-go/internal/util"
+	"solid-go/internal/util/n3"
+)
+
+// N3Serializer writes datasets as Turtle, TriG, N-Triples or N-Quads
+type N3Serializer struct {
+	format   n3.Format
+	prefixes map[string]string
+}
+
+// NewN3Serializer creates a new N3Serializer.
+// Prefixes are only used by Turtle and TriG.
+func NewN3Serializer(format n3.Format, prefixes map[string]string) *N3Serializer {
+	return &N3Serializer{format: format, prefixes: prefixes}
+}
+
+// NewTurtleSerializer creates a serializer for Turtle
+func NewTurtleSerializer(prefixes map[string]string) *N3Serializer {
+	return NewN3Serializer(n3.FormatTurtle, prefixes)
+}
+
+// NewNTriplesSerializer creates a serializer for N-Triples
+func NewNTriplesSerializer() *N3Serializer {
+	return NewN3Serializer(n3.FormatNTriples, nil)
+}
+
+// NewNQuadsSerializer creates a serializer for N-Quads
+func NewNQuadsSerializer() *N3Serializer {
+	return NewN3Serializer(n3.FormatNQuads, nil)
+}
+
+// ContentType implements QuadSerializer.ContentType
+func (s *N3Serializer) ContentType() string {
+	switch s.format {
+	case n3.FormatTriG:
+		return util.TriG
+	case n3.FormatNTriples:
+		return util.NTriples
+	case n3.FormatNQuads:
+		return util.NQuads
+	}
+	return util.Turtle
+}
+
+// Serialize implements QuadSerializer.Serialize.
+// Line-based formats are streamed; Turtle and TriG group quads by subject first.
+func (s *N3Serializer) Serialize(w io.Writer, dataset n3.Store) error {
+	if s.format == n3.FormatNTriples || s.format == n3.FormatNQuads {
+		writer := n3.NewWriter(w, n3.WriterOptions{Format: s.format})
+		var err error
+		dataset.ForEach(func(quad n3.Quad) bool {
+			err = writer.AddQuad(quad)
+			return err == nil
+		}, nil, nil, nil, nil)
+		if err != nil {
+			return err
+		}
+		return writer.End()
+	}
+
+	quads := n3.GroupQuads(dataset.GetQuads(nil, nil, nil, nil))
+	writer := n3.NewWriter(w, n3.WriterOptions{Format: s.format, Prefixes: usedPrefixes(quads, s.prefixes)})
+	if err := writer.AddQuads(quads); err != nil {
+		return err
+	}
+	return writer.End()
+}
+
+// usedPrefixes returns the prefixes whose namespace occurs in any of the quads
+func usedPrefixes(quads []n3.Quad, prefixes map[string]string) map[string]string {
+	used := make(map[string]string)
+	check := func(term n3.Term) {
+		if literal, ok := term.(n3.Literal); ok {
+			term = literal.Datatype()
+		}
+		// These are written without prefix by the Turtle writer
+		if term.TermType() != n3.NamedNodeType || term.Value() == n3.XSDString || term.Value() == n3.RDFLangString || term.Value() == n3.RDFType {
+			return
+		}
+		for prefix, namespace := range prefixes {
+			if strings.HasPrefix(term.Value(), namespace) {
+				used[prefix] = namespace
+			}
+		}
+	}
+	for _, quad := range quads {
+		check(quad.Subject)
+		check(quad.Predicate)
+		check(quad.Object)
+		if quad.Graph != nil {
+			check(quad.Graph)
+		}
+	}
+	return used
+}
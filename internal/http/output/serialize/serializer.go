@@ -0,0 +1,65 @@
+// Package serialize writes RDF datasets in the syntaxes that can be sent to clients.
+package serialize
+
+import (
+	"io"
+	"strings"
+
+	"solid-go/internal/util"
+	"solid-go/internal/util/n3"
+)
+
+// DefaultPrefixes are the prefixes used to compact IRIs in Turtle and TriG output
+var DefaultPrefixes = map[string]string{
+	"acl":   "http://www.w3.org/ns/auth/acl#",
+	"acp":   "http://www.w3.org/ns/solid/acp#",
+	"dc":    "http://purl.org/dc/terms/",
+	"foaf":  "http://xmlns.com/foaf/0.1/",
+	"ldp":   "http://www.w3.org/ns/ldp#",
+	"pim":   "http://www.w3.org/ns/pim/space#",
+	"posix": "http://www.w3.org/ns/posix/stat#",
+	"rdf":   "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+	"rdfs":  "http://www.w3.org/2000/01/rdf-schema#",
+	"solid": "http://www.w3.org/ns/solid/terms#",
+	"vcard": "http://www.w3.org/2006/vcard/ns#",
+	"xsd":   "http://www.w3.org/2001/XMLSchema#",
+}
+
+// QuadSerializer writes a dataset in a specific RDF syntax
+type QuadSerializer interface {
+	// ContentType returns the content type of the output
+	ContentType() string
+	// Serialize writes all quads of the dataset to the writer
+	Serialize(w io.Writer, dataset n3.Store) error
+}
+
+// Options configures the serializers created by NewSerializer
+type Options struct {
+	// Prefixes are used for Turtle and TriG; DefaultPrefixes is used if nil
+	Prefixes map[string]string
+	// Context is used for JSON-LD; DefaultContext is used if nil
+	Context map[string]string
+}
+
+// SupportedContentTypes returns the content types for which a serializer exists
+func SupportedContentTypes() []string {
+	return []string{util.Turtle, util.TriG, util.NTriples, util.NQuads, util.JSONLD}
+}
+
+// NewSerializer returns a serializer for the given content type.
+// The boolean result is false if the content type is not supported.
+func NewSerializer(contentType string, options Options) (QuadSerializer, bool) {
+	prefixes := options.Prefixes
+	if prefixes == nil {
+		prefixes = DefaultPrefixes
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	if mediaType == util.JSONLD {
+		return NewJsonLdSerializer(options.Context), true
+	}
+	format, ok := n3.FormatFromContentType(mediaType)
+	if !ok {
+		return nil, false
+	}
+	return NewN3Serializer(format, prefixes), true
+}
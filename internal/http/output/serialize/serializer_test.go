@@ -0,0 +1,189 @@
+package serialize
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"solid-go/internal/util"
+	"solid-go/internal/util/n3"
+)
+
+const ldp = "http://www.w3.org/ns/ldp#"
+
+func testDataset() *n3.BasicStore {
+	container := n3.NewNamedNode("http://example.org/foo/")
+	return n3.NewBasicStoreWithQuads([]n3.Quad{
+		n3.NewQuad(container, n3.NewNamedNode(n3.RDFType), n3.NewNamedNode(ldp+"Container"), nil),
+		n3.NewQuad(container, n3.NewNamedNode(ldp+"contains"), n3.NewNamedNode("http://example.org/foo/a"), nil),
+		n3.NewQuad(container, n3.NewNamedNode("http://purl.org/dc/terms/title"), n3.NewLangLiteral("Foo \"bar\"", "en"), nil),
+		n3.NewQuad(container, n3.NewNamedNode(n3.RDFType), n3.NewNamedNode(ldp+"BasicContainer"), nil),
+		n3.NewQuad(container, n3.NewNamedNode(ldp+"contains"), n3.NewNamedNode("http://example.org/foo/b"), nil),
+		n3.NewQuad(n3.NewNamedNode("http://example.org/foo/a"), n3.NewNamedNode("http://www.w3.org/ns/posix/stat#size"),
+			n3.NewTypedLiteral("12", n3.NewNamedNode(n3.XSDInteger)), nil),
+	})
+}
+
+func TestNewSerializer(t *testing.T) {
+	for _, contentType := range SupportedContentTypes() {
+		serializer, ok := NewSerializer(contentType+"; charset=utf-8", Options{})
+		if !ok {
+			t.Errorf("NewSerializer(%v) not supported", contentType)
+			continue
+		}
+		if serializer.ContentType() != contentType {
+			t.Errorf("ContentType() = %v, want %v", serializer.ContentType(), contentType)
+		}
+	}
+	if _, ok := NewSerializer(util.TextHTML, Options{}); ok {
+		t.Errorf("NewSerializer(%v) should not be supported", util.TextHTML)
+	}
+}
+
+func TestN3Serializer_TurtleRoundTrip(t *testing.T) {
+	dataset := testDataset()
+	var out bytes.Buffer
+	if err := NewTurtleSerializer(DefaultPrefixes).Serialize(&out, dataset); err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	turtle := out.String()
+
+	for _, want := range []string{
+		"@prefix ldp: <http://www.w3.org/ns/ldp#> .",
+		"<http://example.org/foo/> a ldp:Container, ldp:BasicContainer ;",
+		"ldp:contains <http://example.org/foo/a>, <http://example.org/foo/b> ;",
+		`dc:title "Foo \"bar\""@en .`,
+		"posix:size 12 .",
+	} {
+		if !strings.Contains(turtle, want) {
+			t.Errorf("Serialize() = %v, want it to contain %v", turtle, want)
+		}
+	}
+	if strings.Contains(turtle, "@prefix acl:") {
+		t.Errorf("Serialize() = %v, should not declare unused prefixes", turtle)
+	}
+
+	parsed, err := n3.NewParser(n3.ParserOptions{Format: n3.FormatTurtle}).ParseToStore(strings.NewReader(turtle))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.Size() != dataset.Size() {
+		t.Errorf("round trip Size() = %v, want %v", parsed.Size(), dataset.Size())
+	}
+	for _, quad := range dataset.GetQuads(nil, nil, nil, nil) {
+		if !parsed.Has(quad) {
+			t.Errorf("round trip is missing %v", quad)
+		}
+	}
+}
+
+func TestN3Serializer_NQuads(t *testing.T) {
+	dataset := n3.NewBasicStoreWithQuads([]n3.Quad{
+		n3.NewQuad(n3.NewNamedNode("http://example.org/s"), n3.NewNamedNode("http://example.org/p"), n3.NewLiteral("line\nbreak"), nil),
+		n3.NewQuad(n3.NewBlankNode("b"), n3.NewNamedNode("http://example.org/p"), n3.NewNamedNode("http://example.org/o"), n3.NewNamedNode("http://example.org/g")),
+	})
+	var out bytes.Buffer
+	if err := NewNQuadsSerializer().Serialize(&out, dataset); err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	want := "<http://example.org/s> <http://example.org/p> \"line\\nbreak\" .\n" +
+		"_:b <http://example.org/p> <http://example.org/o> <http://example.org/g> .\n"
+	if out.String() != want {
+		t.Errorf("Serialize() = %q, want %q", out.String(), want)
+	}
+}
+
+func TestN3Serializer_TriG(t *testing.T) {
+	dataset := n3.NewBasicStoreWithQuads([]n3.Quad{
+		n3.NewQuad(n3.NewNamedNode("http://example.org/s"), n3.NewNamedNode("http://example.org/p"), n3.NewLiteral("a"), n3.NewNamedNode("http://example.org/g")),
+		n3.NewQuad(n3.NewNamedNode("http://example.org/s"), n3.NewNamedNode("http://example.org/p"), n3.NewLiteral("b"), nil),
+	})
+	var out bytes.Buffer
+	if err := NewN3Serializer(n3.FormatTriG, nil).Serialize(&out, dataset); err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	parsed, err := n3.NewParser(n3.ParserOptions{Format: n3.FormatTriG}).ParseToStore(&out)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := parsed.CountQuads(nil, nil, nil, "http://example.org/g"); got != 1 {
+		t.Errorf("CountQuads(graph) = %v, want %v", got, 1)
+	}
+}
+
+func TestJsonLdSerializer(t *testing.T) {
+	var out bytes.Buffer
+	if err := NewJsonLdSerializer(nil).Serialize(&out, testDataset()); err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	var doc struct {
+		Context map[string]string        `json:"@context"`
+		Graph   []map[string]interface{} `json:"@graph"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("Serialize() produced invalid JSON %v: %v", out.String(), err)
+	}
+	if doc.Context["ldp"] != ldp {
+		t.Errorf("@context = %v, want ldp prefix", doc.Context)
+	}
+	if len(doc.Graph) != 2 {
+		t.Fatalf("@graph = %v, want 2 nodes", doc.Graph)
+	}
+	container := doc.Graph[0]
+	if container["@id"] != "http://example.org/foo/" {
+		t.Errorf("@id = %v, want %v", container["@id"], "http://example.org/foo/")
+	}
+	if types, ok := container["@type"].([]interface{}); !ok || len(types) != 2 || types[0] != "ldp:Container" {
+		t.Errorf("@type = %v, want [ldp:Container ldp:BasicContainer]", container["@type"])
+	}
+	title, ok := container["dc:title"].(map[string]interface{})
+	if !ok || title["@language"] != "en" || title["@value"] != `Foo "bar"` {
+		t.Errorf("dc:title = %v, want a language-tagged value", container["dc:title"])
+	}
+	size, ok := doc.Graph[1]["posix:size"].(map[string]interface{})
+	if !ok || size["@type"] != "xsd:integer" {
+		t.Errorf("posix:size = %v, want a typed value", doc.Graph[1]["posix:size"])
+	}
+}
+
+func TestJsonLdSerializer_SingleNodeWithCustomContext(t *testing.T) {
+	dataset := n3.NewBasicStoreWithQuads([]n3.Quad{
+		n3.NewQuad(n3.NewNamedNode("http://example.org/s"), n3.NewNamedNode("http://example.org/vocab#name"), n3.NewLiteral("s"), nil),
+	})
+	var out bytes.Buffer
+	if err := NewJsonLdSerializer(map[string]string{"name": "http://example.org/vocab#name"}).Serialize(&out, dataset); err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	want := `{"@context":{"name":"http://example.org/vocab#name"},"@id":"http://example.org/s","name":"s"}`
+	if out.String() != want {
+		t.Errorf("Serialize() = %v, want %v", out.String(), want)
+	}
+}
+
+func TestJsonLdSerializer_CompactsIdentifiersToCompactIRIs(t *testing.T) {
+	vocab := "http://example.org/vocab#"
+	subject := n3.NewNamedNode(vocab + "thing")
+	dataset := n3.NewBasicStoreWithQuads([]n3.Quad{
+		n3.NewQuad(subject, n3.NewNamedNode(vocab+"name"), n3.NewNamedNode(vocab+"name"), nil),
+		n3.NewQuad(subject, n3.NewNamedNode(n3.RDFType), n3.NewBlankNode("b0"), nil),
+	})
+	var out bytes.Buffer
+	context := map[string]string{"ex": vocab, "name": vocab + "name", "thing": vocab + "thing"}
+	if err := NewJsonLdSerializer(context).Serialize(&out, dataset); err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	var node map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &node); err != nil {
+		t.Fatalf("Serialize() produced invalid JSON %v: %v", out.String(), err)
+	}
+	if node["@id"] != "ex:thing" {
+		t.Errorf("@id = %v, want %v", node["@id"], "ex:thing")
+	}
+	if name, ok := node["name"].(map[string]interface{}); !ok || name["@id"] != "ex:name" {
+		t.Errorf("name = %v, want a reference to ex:name", node["name"])
+	}
+	if node["@type"] != "_:b0" {
+		t.Errorf("@type = %v, want %v", node["@type"], "_:b0")
+	}
+}
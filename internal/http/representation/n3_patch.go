@@ -1,9 +1,6 @@
 // Package representation provides the N3Patch struct.
 package representation
 
-// Quad is a placeholder for RDF quads.
-type Quad interface{}
-
 // N3Patch is a representation of an N3 Patch, including deletes, inserts, and conditions.
 type N3Patch interface {
 	Patch
@@ -19,3 +16,37 @@ func IsN3Patch(patch interface{}) bool {
 	}
 	return false
 }
+
+// BasicN3Patch is an N3Patch that keeps the original patch document as its data
+type BasicN3Patch struct {
+	*BasicRepresentation
+	deletes    []Quad
+	inserts    []Quad
+	conditions []Quad
+}
+
+// NewBasicN3Patch creates a new BasicN3Patch. Missing formulae are treated as empty.
+func NewBasicN3Patch(rep *BasicRepresentation, deletes, inserts, conditions []Quad) *BasicN3Patch {
+	return &BasicN3Patch{
+		BasicRepresentation: rep,
+		deletes:             nonNilQuads(deletes),
+		inserts:             nonNilQuads(inserts),
+		conditions:          nonNilQuads(conditions),
+	}
+}
+
+// GetDeletes implements N3Patch.GetDeletes
+func (p *BasicN3Patch) GetDeletes() []Quad { return p.deletes }
+
+// GetInserts implements N3Patch.GetInserts
+func (p *BasicN3Patch) GetInserts() []Quad { return p.inserts }
+
+// GetConditions implements N3Patch.GetConditions
+func (p *BasicN3Patch) GetConditions() []Quad { return p.conditions }
+
+func nonNilQuads(quads []Quad) []Quad {
+	if quads == nil {
+		return []Quad{}
+	}
+	return quads
+}
@@ -1,8 +1,14 @@
 // Package representation provides the RdfDatasetRepresentation struct.
 package representation
 
-// Dataset is a placeholder for an RDF dataset (e.g., from an RDF library).
-type Dataset interface{}
+import (
+	"io"
+
+	"solid-go/internal/util/n3"
+)
+
+// Dataset is an RDF dataset.
+type Dataset = n3.Store
 
 // RdfDatasetRepresentation contains an RDF dataset instead of a raw data stream.
 type RdfDatasetRepresentation interface {
@@ -13,12 +19,12 @@ type RdfDatasetRepresentation interface {
 // BasicRdfDatasetRepresentation is a concrete implementation of RdfDatasetRepresentation.
 type BasicRdfDatasetRepresentation struct {
 	Metadata *RepresentationMetadata
-	Dataset  interface{} // TODO: Replace with concrete Dataset type
+	Quads    Dataset
 	Binary   bool
 }
 
 func (r *BasicRdfDatasetRepresentation) GetMetadata() *RepresentationMetadata { return r.Metadata }
-func (r *BasicRdfDatasetRepresentation) GetData() io.Reader                  { return nil }
-func (r *BasicRdfDatasetRepresentation) IsBinary() bool                      { return r.Binary }
-func (r *BasicRdfDatasetRepresentation) IsEmpty() bool                       { return r.Dataset == nil }
-func (r *BasicRdfDatasetRepresentation) Dataset() Dataset                    { return r.Dataset }
+func (r *BasicRdfDatasetRepresentation) GetData() io.Reader                   { return nil }
+func (r *BasicRdfDatasetRepresentation) IsBinary() bool                       { return r.Binary }
+func (r *BasicRdfDatasetRepresentation) IsEmpty() bool                        { return r.Quads == nil }
+func (r *BasicRdfDatasetRepresentation) Dataset() Dataset                     { return r.Quads }
@@ -1,6 +1,8 @@
 // Package representation provides the RepresentationMetadata struct.
 package representation
 
+import "solid-go/internal/util/n3"
+
 // RepresentationMetadata stores metadata triples and provides methods for access.
 type RepresentationMetadata struct {
 	Identifier string
@@ -33,8 +35,40 @@ func (m *RepresentationMetadata) Remove(key string) *RepresentationMetadata {
 	return m
 }
 
-// SetIdentifier sets the identifier.
+// Clone returns a copy of the metadata that can be modified independently.
+func (m *RepresentationMetadata) Clone() *RepresentationMetadata {
+	clone := NewRepresentationMetadata(m.Identifier)
+	for key, value := range m.Store {
+		if key != "quads" {
+			clone.Store[key] = value
+		}
+	}
+	return clone.AddQuads(m.Quads(nil, nil, nil, nil))
+}
+
+// ContentType returns the content type of the representation, or the empty string if it is unknown.
+func (m *RepresentationMetadata) ContentType() string {
+	contentType, _ := m.Store["contentType"].(string)
+	return contentType
+}
+
+// SetContentType sets the content type and returns the metadata for chaining.
+func (m *RepresentationMetadata) SetContentType(contentType string) *RepresentationMetadata {
+	if contentType == "" {
+		return m.Remove("contentType")
+	}
+	return m.Add("contentType", contentType)
+}
+
+// SetIdentifier sets the identifier, moving the quads about the old identifier to the new one.
 func (m *RepresentationMetadata) SetIdentifier(id string) *RepresentationMetadata {
+	if id != m.Identifier {
+		subject := n3.NewNamedNode(id)
+		for _, quad := range m.Quads(n3.NewNamedNode(m.Identifier), nil, nil, nil) {
+			m.RemoveQuad(quad)
+			m.AddQuad(n3.NewQuad(subject, quad.Predicate, quad.Object, quad.Graph))
+		}
+	}
 	m.Identifier = id
 	return m
 }
@@ -50,54 +84,55 @@ func IsRepresentationMetadata(obj interface{}) bool {
 	return ok
 }
 
-// Quad is a placeholder for RDF quads.
-type Quad interface{}
+// Quad is an RDF quad as used throughout the representation layer.
+type Quad = n3.Quad
+
+// quads returns the dataset holding the metadata quads, creating it if needed.
+func (m *RepresentationMetadata) quads() *n3.BasicStore {
+	if m.Store == nil {
+		m.Store = make(map[string]interface{})
+	}
+	store, ok := m.Store["quads"].(*n3.BasicStore)
+	if !ok {
+		store = n3.NewBasicStore()
+		m.Store["quads"] = store
+	}
+	return store
+}
 
 // AddQuad adds a quad to the metadata and returns the metadata for chaining.
 func (m *RepresentationMetadata) AddQuad(quad Quad) *RepresentationMetadata {
-	if m.Store["quads"] == nil {
-		m.Store["quads"] = []Quad{}
-	}
-	m.Store["quads"] = append(m.Store["quads"].([]Quad), quad)
+	m.quads().AddQuad(quad)
 	return m
 }
 
 // AddQuads adds multiple quads to the metadata and returns the metadata for chaining.
 func (m *RepresentationMetadata) AddQuads(quads []Quad) *RepresentationMetadata {
-	for _, q := range quads {
-		m.AddQuad(q)
-	}
+	m.quads().AddQuads(quads)
 	return m
 }
 
 // RemoveQuad removes a quad from the metadata and returns the metadata for chaining.
 func (m *RepresentationMetadata) RemoveQuad(quad Quad) *RepresentationMetadata {
-	if m.Store["quads"] == nil {
-		return m
-	}
-	quads := m.Store["quads"].([]Quad)
-	newQuads := []Quad{}
-	for _, q := range quads {
-		if q != quad {
-			newQuads = append(newQuads, q)
-		}
-	}
-	m.Store["quads"] = newQuads
+	m.quads().RemoveQuad(quad)
 	return m
 }
 
 // RemoveQuads removes multiple quads from the metadata and returns the metadata for chaining.
 func (m *RepresentationMetadata) RemoveQuads(quads []Quad) *RepresentationMetadata {
+	store := m.quads()
 	for _, q := range quads {
-		m.RemoveQuad(q)
+		store.RemoveQuad(q)
 	}
 	return m
 }
 
-// Quads returns all quads in the metadata.
-func (m *RepresentationMetadata) Quads() []Quad {
-	if m.Store["quads"] == nil {
-		return nil
-	}
-	return m.Store["quads"].([]Quad)
+// Quads returns all quads in the metadata matching the given pattern; pass nils to get everything.
+func (m *RepresentationMetadata) Quads(subject, predicate, object, graph interface{}) []Quad {
+	return m.quads().GetQuads(subject, predicate, object, graph)
+}
+
+// Dataset returns the store backing the metadata quads.
+func (m *RepresentationMetadata) Dataset() n3.Store {
+	return m.quads()
 }
@@ -1,11 +1,29 @@
 // Package representation provides the SparqlUpdatePatch struct.
 package representation
 
-// Algebra is a placeholder for the SPARQL algebra type.
-type Algebra interface{}
+import "solid-go/internal/util/n3"
+
+// Algebra is the parsed form of a SPARQL update.
+type Algebra = *n3.SparqlUpdate
 
 // SparqlUpdatePatch is a specific type of Patch corresponding to a SPARQL update.
 type SparqlUpdatePatch interface {
 	Patch
 	Algebra() Algebra
 }
+
+// BasicSparqlUpdatePatch is a SparqlUpdatePatch that keeps the original query as its data
+type BasicSparqlUpdatePatch struct {
+	*BasicRepresentation
+	algebra Algebra
+}
+
+// NewBasicSparqlUpdatePatch creates a new BasicSparqlUpdatePatch
+func NewBasicSparqlUpdatePatch(rep *BasicRepresentation, algebra Algebra) *BasicSparqlUpdatePatch {
+	return &BasicSparqlUpdatePatch{BasicRepresentation: rep, algebra: algebra}
+}
+
+// Algebra implements SparqlUpdatePatch.Algebra
+func (p *BasicSparqlUpdatePatch) Algebra() Algebra {
+	return p.algebra
+}
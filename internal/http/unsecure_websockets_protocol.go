@@ -6,9 +6,10 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"regexp"
 	"sync"
 	"github.com/gorilla/websocket"
-	"solid-go-main/internal/http/representation"
+	"solid-go/internal/http/representation"
 )
 
 const WebSocketsVersion = "solid-0.1"
@@ -0,0 +1,8 @@
+package pod
+
+// Owner is a WebID that owns a pod.
+// Visible owners are advertised in the storage description of the pod.
+type Owner struct {
+	WebID   string
+	Visible bool
+}
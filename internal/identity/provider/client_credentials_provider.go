@@ -0,0 +1,283 @@
+// Package provider is a Solid-OIDC identity provider that issues access tokens to the clients it is configured with.
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"solid-go/internal/authentication/oidc"
+	"solid-go/internal/util/identifiers"
+)
+
+// The paths of the endpoints relative to the issuer
+const (
+	DiscoveryPath = ".well-known/openid-configuration"
+	JwksPath      = ".oidc/jwks"
+	TokenPath     = ".oidc/token"
+)
+
+// DefaultTokenLifetime is how long the issued access tokens are valid
+const DefaultTokenLifetime = 10 * time.Minute
+
+// maxTokenRequestSize limits the size of the form POSTed to the token endpoint
+const maxTokenRequestSize = 64 << 10
+
+// Client is a client that can request access tokens for its WebID
+type Client struct {
+	ID     string
+	Secret string
+	WebID  string
+}
+
+// ProofVerifier checks the DPoP proofs sent to the token endpoint and returns the thumbprint of their key
+type ProofVerifier interface {
+	VerifyProof(dpop *oidc.DPoPRequest) (string, error)
+}
+
+// ClientCredentialsProvider issues Solid-OIDC access tokens with the client credentials grant, RFC 6749, §4.4.
+// Clients authenticate with their ID and secret and get a token for the WebID they are configured with,
+// which is bound to the key of their DPoP proof if they send one.
+// It serves the discovery document, key set and token endpoint of the issuer,
+// but no authorization endpoint, since it has no accounts users could log in with.
+type ClientCredentialsProvider struct {
+	issuer        string
+	key           *ecdsa.PrivateKey
+	jwk           oidc.JWK
+	clients       map[string]Client
+	proofs        ProofVerifier
+	tokenLifetime time.Duration
+	now           func() time.Time
+}
+
+// NewClientCredentialsProvider creates a new ClientCredentialsProvider for the issuer URL,
+// which signs the tokens with the P-256 key
+func NewClientCredentialsProvider(issuer string, key *ecdsa.PrivateKey, clients []Client, proofs ProofVerifier) (*ClientCredentialsProvider, error) {
+	if !strings.HasSuffix(issuer, "/") {
+		issuer += "/"
+	}
+	jwk, err := PublicJWK(key)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]Client, len(clients))
+	for _, client := range clients {
+		byID[client.ID] = client
+	}
+	return &ClientCredentialsProvider{
+		issuer:        issuer,
+		key:           key,
+		jwk:           jwk,
+		clients:       byID,
+		proofs:        proofs,
+		tokenLifetime: DefaultTokenLifetime,
+		now:           time.Now,
+	}, nil
+}
+
+// PublicJWK returns the public key of the P-256 key as ES256 signing key,
+// identified by its thumbprint
+func PublicJWK(key *ecdsa.PrivateKey) (oidc.JWK, error) {
+	if key.Curve.Params().Name != "P-256" {
+		return oidc.JWK{}, fmt.Errorf("the signing key has to be a P-256 key")
+	}
+	jwk := oidc.JWK{
+		Kty: "EC",
+		Alg: "ES256",
+		Use: "sig",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+	kid, err := jwk.Thumbprint()
+	if err != nil {
+		return oidc.JWK{}, err
+	}
+	jwk.Kid = kid
+	return jwk, nil
+}
+
+// Handles checks whether the request is for one of the endpoints of the provider
+func (p *ClientCredentialsProvider) Handles(r *http.Request) bool {
+	switch r.URL.Path {
+	case p.path(DiscoveryPath), p.path(JwksPath), p.path(TokenPath):
+		return true
+	}
+	return false
+}
+
+// HandleSafe answers the requests to the endpoints of the provider
+func (p *ClientCredentialsProvider) HandleSafe(w http.ResponseWriter, r *http.Request) error {
+	switch r.URL.Path {
+	case p.path(DiscoveryPath):
+		return p.handleDocument(w, r, map[string]interface{}{
+			"issuer":                                p.issuer,
+			"jwks_uri":                              p.issuer + JwksPath,
+			"token_endpoint":                        p.issuer + TokenPath,
+			"grant_types_supported":                 []string{"client_credentials"},
+			"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+			"dpop_signing_alg_values_supported":     []string{"ES256", "ES384", "ES512", "EdDSA", "RS256", "PS256"},
+			"id_token_signing_alg_values_supported": []string{"ES256"},
+			"scopes_supported":                      []string{"openid", "webid"},
+			"claims_supported":                      []string{"webid", "client_id"},
+			"subject_types_supported":               []string{"public"},
+			"response_types_supported":              []string{},
+		})
+	case p.path(JwksPath):
+		return p.handleDocument(w, r, map[string]interface{}{"keys": []oidc.JWK{p.jwk}})
+	default:
+		return p.handleToken(w, r)
+	}
+}
+
+// path returns the URL path of the endpoint
+func (p *ClientCredentialsProvider) path(endpoint string) string {
+	u, err := url.Parse(p.issuer + endpoint)
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
+
+// handleDocument answers GET and HEAD requests with the JSON document
+func (p *ClientCredentialsProvider) handleDocument(w http.ResponseWriter, r *http.Request, document interface{}) error {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		return writeJSON(w, r, http.StatusMethodNotAllowed, map[string]string{"error": "invalid_request"})
+	}
+	return writeJSON(w, r, http.StatusOK, document)
+}
+
+// handleToken issues an access token to a client that authenticated with its secret, RFC 6749, §4.4.2
+func (p *ClientCredentialsProvider) handleToken(w http.ResponseWriter, r *http.Request) error {
+	w.Header().Set("Cache-Control", "no-store")
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		return writeTokenError(w, r, http.StatusMethodNotAllowed, "invalid_request", "tokens are requested with POST")
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxTokenRequestSize)
+	if err := r.ParseForm(); err != nil {
+		return writeTokenError(w, r, http.StatusBadRequest, "invalid_request", "the request is not a valid form")
+	}
+	if grant := r.PostForm.Get("grant_type"); grant != "client_credentials" {
+		return writeTokenError(w, r, http.StatusBadRequest, "unsupported_grant_type", "only the client_credentials grant is supported")
+	}
+	client, ok := p.authenticateClient(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Basic realm="`+p.issuer+`"`)
+		return writeTokenError(w, r, http.StatusUnauthorized, "invalid_client", "unknown client or wrong secret")
+	}
+
+	tokenType := "Bearer"
+	var confirmation *oidc.Confirmation
+	if proof := r.Header.Get("DPoP"); proof != "" {
+		jkt, err := p.proofs.VerifyProof(&oidc.DPoPRequest{Proof: proof, Method: r.Method, URL: p.issuer + TokenPath})
+		if err != nil {
+			return writeTokenError(w, r, http.StatusBadRequest, "invalid_dpop_proof", err.Error())
+		}
+		tokenType = "DPoP"
+		confirmation = &oidc.Confirmation{JKT: jkt}
+	}
+	jti, err := identifiers.NewIdentifierUtil().GenerateUUID()
+	if err != nil {
+		return err
+	}
+	now := p.now()
+	token, err := p.sign(oidc.Header{Alg: "ES256", Typ: "at+jwt", Kid: p.jwk.Kid}, oidc.Claims{
+		Issuer:       p.issuer,
+		Subject:      client.WebID,
+		Audience:     oidc.Audience{oidc.SolidAudience, client.ID},
+		IssuedAt:     now.Unix(),
+		Expiry:       now.Add(p.tokenLifetime).Unix(),
+		WebID:        client.WebID,
+		ClientID:     client.ID,
+		AuthorizedBy: client.ID,
+		Confirmation: confirmation,
+		JTI:          jti,
+	})
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, r, http.StatusOK, map[string]interface{}{
+		"access_token": token,
+		"token_type":   tokenType,
+		"expires_in":   int(p.tokenLifetime.Seconds()),
+		"scope":        "webid",
+	})
+}
+
+// authenticateClient returns the client whose ID and secret were sent with HTTP Basic authentication
+// or in the form, RFC 6749, §2.3.1
+func (p *ClientCredentialsProvider) authenticateClient(r *http.Request) (Client, bool) {
+	id, secret, basic := r.BasicAuth()
+	if basic {
+		// The credentials are form-urlencoded before they are Base64 encoded
+		var err error
+		if id, err = url.QueryUnescape(id); err != nil {
+			return Client{}, false
+		}
+		if secret, err = url.QueryUnescape(secret); err != nil {
+			return Client{}, false
+		}
+		// Clients must not use more than one authentication method
+		if r.PostForm.Has("client_secret") {
+			return Client{}, false
+		}
+	} else {
+		id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	client, ok := p.clients[id]
+	if !ok || id == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(client.Secret)) != 1 {
+		return Client{}, false
+	}
+	return client, true
+}
+
+// sign creates an ES256 signed JWT with the key of the provider
+func (p *ClientCredentialsProvider) sign(header oidc.Header, claims oidc.Claims) (string, error) {
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	input := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(input))
+	r, s, err := ecdsa.Sign(rand.Reader, p.key, digest[:])
+	if err != nil {
+		return "", err
+	}
+	// RFC 7518, §3.4: the signature is the concatenation of R and S, each as long as the curve order
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// writeTokenError writes an error response of the token endpoint, RFC 6749, §5.2
+func writeTokenError(w http.ResponseWriter, r *http.Request, status int, code, description string) error {
+	return writeJSON(w, r, status, map[string]string{"error": code, "error_description": description})
+}
+
+// writeJSON writes the value as JSON response, without body for HEAD requests
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if r.Method == http.MethodHead {
+		return nil
+	}
+	_, err = w.Write(data)
+	return err
+}
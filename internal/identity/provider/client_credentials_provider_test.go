@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"solid-go/internal/authentication/oidc"
+)
+
+const testWebID = "https://alice.example/profile#me"
+
+// newTestProvider serves a provider with a single client, whose tokens are verified by the returned verifier
+// after fetching the keys of the provider like those of any other issuer
+func newTestProvider(t *testing.T) (*httptest.Server, *oidc.TokenVerifier) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var provider *ClientCredentialsProvider
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !provider.Handles(r) {
+			http.NotFound(w, r)
+			return
+		}
+		if err := provider.HandleSafe(w, r); err != nil {
+			t.Errorf("HandleSafe() error = %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	verifier := oidc.NewTokenVerifier(oidc.NewIssuerKeySets(server.Client(), time.Hour), nil, nil)
+	provider, err = NewClientCredentialsProvider(server.URL, key, []Client{
+		{ID: "app", Secret: "s3cr:t", WebID: testWebID},
+	}, verifier)
+	if err != nil {
+		t.Fatalf("NewClientCredentialsProvider() error = %v", err)
+	}
+	return server, verifier
+}
+
+// dpopProof creates a DPoP proof of the client key for the request
+func dpopProof(t *testing.T, key ed25519.PrivateKey, method, target, token string) string {
+	t.Helper()
+	jwk := &oidc.JWK{Kty: "OKP", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(key.Public().(ed25519.PublicKey))}
+	claims := oidc.Claims{HTM: method, HTU: target, IssuedAt: time.Now().Unix(), JTI: fmt.Sprint(time.Now().UnixNano())}
+	if token != "" {
+		claims.ATH = athOf(token)
+	}
+	header, _ := json.Marshal(oidc.Header{Alg: "EdDSA", Typ: "dpop+jwt", JWK: jwk})
+	payload, _ := json.Marshal(claims)
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return input + "." + base64.RawURLEncoding.EncodeToString(ed25519.Sign(key, []byte(input)))
+}
+
+func TestClientCredentialsProvider(t *testing.T) {
+	server, verifier := newTestProvider(t)
+	tokenURL := server.URL + "/" + TokenPath
+	_, clientKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requestToken := func(form url.Values, basic bool, proof string) (*http.Response, map[string]interface{}) {
+		t.Helper()
+		request, _ := http.NewRequest(http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if basic {
+			request.SetBasicAuth("app", url.QueryEscape("s3cr:t"))
+		}
+		if proof != "" {
+			request.Header.Set("DPoP", proof)
+		}
+		response, err := server.Client().Do(request)
+		if err != nil {
+			t.Fatalf("POST %s error = %v", tokenURL, err)
+		}
+		defer response.Body.Close()
+		var body map[string]interface{}
+		if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+			t.Fatalf("invalid JSON response: %v", err)
+		}
+		return response, body
+	}
+	grant := url.Values{"grant_type": {"client_credentials"}}
+
+	response, body := requestToken(grant, true, dpopProof(t, clientKey, http.MethodPost, tokenURL, ""))
+	if response.StatusCode != http.StatusOK || body["token_type"] != "DPoP" || response.Header.Get("Cache-Control") != "no-store" {
+		t.Fatalf("DPoP token request = %d %v", response.StatusCode, body)
+	}
+	token := body["access_token"].(string)
+	claims, err := verifier.Verify(token, &oidc.DPoPRequest{
+		Proof:  dpopProof(t, clientKey, http.MethodGet, "https://pod.example/resource", token),
+		Method: http.MethodGet,
+		URL:    "https://pod.example/resource",
+	})
+	if err != nil {
+		t.Fatalf("Verify() of the issued token error = %v", err)
+	}
+	if claims.WebID != testWebID || claims.ClientID != "app" || claims.Issuer != server.URL+"/" {
+		t.Errorf("Verify() = %+v", claims)
+	}
+	if _, err := verifier.Verify(token, nil); err == nil {
+		t.Error("Verify() of the DPoP-bound token without proof should fail")
+	}
+
+	posted := url.Values{"grant_type": {"client_credentials"}, "client_id": {"app"}, "client_secret": {"s3cr:t"}}
+	response, body = requestToken(posted, false, "")
+	if response.StatusCode != http.StatusOK || body["token_type"] != "Bearer" {
+		t.Fatalf("Bearer token request = %d %v", response.StatusCode, body)
+	}
+	if _, err := verifier.Verify(body["access_token"].(string), nil); err != nil {
+		t.Errorf("Verify() of the Bearer token error = %v", err)
+	}
+
+	for name, tt := range map[string]struct {
+		form   url.Values
+		basic  bool
+		proof  string
+		status int
+		error  string
+	}{
+		"wrong secret":       {url.Values{"grant_type": {"client_credentials"}, "client_id": {"app"}, "client_secret": {"other"}}, false, "", http.StatusUnauthorized, "invalid_client"},
+		"unknown client":     {url.Values{"grant_type": {"client_credentials"}, "client_id": {"other"}, "client_secret": {"s3cr:t"}}, false, "", http.StatusUnauthorized, "invalid_client"},
+		"no credentials":     {grant, false, "", http.StatusUnauthorized, "invalid_client"},
+		"two auth methods":   {url.Values{"grant_type": {"client_credentials"}, "client_secret": {"s3cr:t"}}, true, "", http.StatusUnauthorized, "invalid_client"},
+		"other grant":        {url.Values{"grant_type": {"password"}}, true, "", http.StatusBadRequest, "unsupported_grant_type"},
+		"proof of other URL": {grant, true, dpopProof(t, clientKey, http.MethodPost, server.URL+"/other", ""), http.StatusBadRequest, "invalid_dpop_proof"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			response, body := requestToken(tt.form, tt.basic, tt.proof)
+			if response.StatusCode != tt.status || body["error"] != tt.error {
+				t.Errorf("token request = %d %v, want %d %s", response.StatusCode, body, tt.status, tt.error)
+			}
+		})
+	}
+
+	if response, err := server.Client().Get(tokenURL); err != nil || response.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET %s = %v, %v", tokenURL, response, err)
+	}
+}
+
+func TestLocalKeySource(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwk, err := PublicJWK(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fallback := &mockKeySource{}
+	keys := NewLocalKeySource("https://pod.example/", jwk, fallback)
+	if got, err := keys.GetKeys("https://pod.example", jwk.Kid); err != nil || len(got) != 1 || got[0] != jwk {
+		t.Errorf("GetKeys() of the local issuer = %v, %v", got, err)
+	}
+	if _, err := keys.GetKeys("https://pod.example/", "other"); err == nil {
+		t.Error("GetKeys() of an unknown key ID should fail")
+	}
+	if _, err := keys.GetKeys("https://idp.example/", ""); err != nil || fallback.issuer != "https://idp.example/" {
+		t.Errorf("GetKeys() of other issuers should use the fallback, error = %v", err)
+	}
+}
+
+// mockKeySource remembers the issuer it was asked for
+type mockKeySource struct {
+	issuer string
+}
+
+func (s *mockKeySource) GetKeys(issuer, kid string) ([]oidc.JWK, error) {
+	s.issuer = issuer
+	return nil, nil
+}
+
+// athOf returns the ath claim of a proof for the access token
+func athOf(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
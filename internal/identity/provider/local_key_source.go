@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"solid-go/internal/authentication/oidc"
+)
+
+// LocalKeySource serves the key of the issuer running in this server without fetching it,
+// and gets the keys of all other issuers from its fallback.
+type LocalKeySource struct {
+	issuer   string
+	jwk      oidc.JWK
+	fallback oidc.KeySource
+}
+
+// NewLocalKeySource creates a new LocalKeySource for the issuer with the public key
+func NewLocalKeySource(issuer string, jwk oidc.JWK, fallback oidc.KeySource) *LocalKeySource {
+	return &LocalKeySource{issuer: strings.TrimSuffix(issuer, "/"), jwk: jwk, fallback: fallback}
+}
+
+// GetKeys implements oidc.KeySource.GetKeys
+func (s *LocalKeySource) GetKeys(issuer, kid string) ([]oidc.JWK, error) {
+	if strings.TrimSuffix(issuer, "/") != s.issuer {
+		return s.fallback.GetKeys(issuer, kid)
+	}
+	if kid != "" && kid != s.jwk.Kid {
+		return nil, fmt.Errorf("the issuer %s has no key %q", issuer, kid)
+	}
+	return []oidc.JWK{s.jwk}, nil
+}
@@ -5,12 +5,14 @@ import (
 	"solid-go/internal/init/variables"
 )
 
-// CliExtractor extracts variables from command line arguments
+// CliExtractor extracts variables from command line arguments.
+// Only the flags that are set explicitly result in a variable,
+// so they can override the values of a configuration file.
 type CliExtractor struct {
 	args []string
 }
 
-// NewCliExtractor creates a new CliExtractor
+// NewCliExtractor creates a new CliExtractor for the arguments, including the program name
 func NewCliExtractor(args []string) *CliExtractor {
 	return &CliExtractor{
 		args: args,
@@ -21,56 +23,63 @@ func NewCliExtractor(args []string) *CliExtractor {
 func (e *CliExtractor) Extract() ([]variables.Variable, error) {
 	// Parse command line arguments
 	fs := flag.NewFlagSet("solid-go", flag.ExitOnError)
-	var (
-		configPath string
-		logLevel   string
-		port       int
-		host       string
-	)
+	fs.String("config", "", "Path to a JSON or YAML config file")
+	fs.String("log-level", "info", "Log level: debug, info, warn or error")
+	fs.Int("port", 3000, "Port to listen on")
+	fs.Bool("https", false, "Use HTTPS")
+	fs.String("cert", "", "Path to TLS certificate file")
+	fs.String("key", "", "Path to TLS private key file")
+	fs.String("base-url", "", "Base URL of the server, defaults to http://localhost:<port>/")
+	fs.String("storage", "file", "Storage backend: file, memory or sqlite")
+	fs.String("root-path", "./data", "Path to storage directory for the file and sqlite backends")
+	fs.String("auth", "allow-all", "Authorization mode: allow-all, deny-all, webacl or acp")
+	fs.Bool("show-stack-trace", false, "Add stack traces to error responses")
+	// The server listens on all interfaces, so -host is only still accepted to not break existing scripts
+	fs.String("host", "", "Deprecated, has no effect: the server listens on all interfaces")
 
-	fs.StringVar(&configPath, "config", "", "Path to config file")
-	fs.StringVar(&logLevel, "log-level", "info", "Log level")
-	fs.IntVar(&port, "port", 3000, "Port to listen on")
-	fs.StringVar(&host, "host", "localhost", "Host to listen on")
-
-	if err := fs.Parse(e.args[1:]); err != nil {
-		return nil, err
+	if len(e.args) > 0 {
+		if err := fs.Parse(e.args[1:]); err != nil {
+			return nil, err
+		}
 	}
 
 	// Create variables
 	var vars []variables.Variable
-	if configPath != "" {
-		vars = append(vars, variables.Variable{
-			Name:  "configPath",
-			Type:  variables.StringType,
-			Value: configPath,
-		})
-	}
-	if logLevel != "" {
-		vars = append(vars, variables.Variable{
-			Name:  "logLevel",
-			Type:  variables.StringType,
-			Value: logLevel,
-		})
-	}
-	if port != 0 {
+	fs.Visit(func(f *flag.Flag) {
+		mapping, ok := flagVariables[f.Name]
+		if !ok {
+			return
+		}
+		name, varType := mapping.name, mapping.varType
+		getter := f.Value.(flag.Getter)
 		vars = append(vars, variables.Variable{
-			Name:  "port",
-			Type:  variables.NumberType,
-			Value: port,
+			Name:  name,
+			Type:  varType,
+			Value: getter.Get(),
 		})
-	}
-	if host != "" {
-		vars = append(vars, variables.Variable{
-			Name:  "host",
-			Type:  variables.StringType,
-			Value: host,
-		})
-	}
+	})
 
 	return vars, nil
 }
 
+// flagVariables maps the flags to the names and types of the variables they set
+var flagVariables = map[string]struct {
+	name    string
+	varType variables.VariableType
+}{
+	"config":           {"configPath", variables.StringType},
+	"log-level":        {"logLevel", variables.StringType},
+	"port":             {"port", variables.NumberType},
+	"https":            {"https", variables.BooleanType},
+	"cert":             {"cert", variables.StringType},
+	"key":              {"key", variables.StringType},
+	"base-url":         {"baseUrl", variables.StringType},
+	"storage":          {"storage", variables.StringType},
+	"root-path":        {"rootPath", variables.StringType},
+	"auth":             {"auth", variables.StringType},
+	"show-stack-trace": {"showStackTrace", variables.BooleanType},
+}
+
 // YargsCliExtractor extracts variables from command line arguments using Yargs
 type YargsCliExtractor struct {
 	args []string
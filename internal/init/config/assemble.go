@@ -0,0 +1,182 @@
+package config
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"solid-go/internal/authentication"
+	"solid-go/internal/authentication/oidc"
+	"solid-go/internal/http/auxiliary"
+	"solid-go/internal/http/input/identifier"
+	"solid-go/internal/http/output/serialize"
+	"solid-go/internal/identity/provider"
+	"solid-go/internal/logging"
+	"solid-go/internal/server"
+	"solid-go/internal/storage"
+	"solid-go/internal/storage/conversion"
+	"solid-go/internal/storage/patch"
+)
+
+// ServerOptions creates the components selected by the configuration
+// and returns the options to create a server with.
+func (c *Config) ServerOptions(logger logging.Logger) (*server.ServerOptions, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	baseURL := c.BaseURL()
+	converter := conversion.NewRdfConverter(serialize.Options{})
+	acl := auxiliary.NewAclStrategy(converter)
+	acr := auxiliary.NewAcrStrategy(converter)
+	description := auxiliary.NewDescriptionStrategy(converter)
+	// Only the authorization resources of the selected auth mode are auxiliary resources of the storage
+	authStrategy := acl
+	if c.Authorization.Type == server.AuthModeACP {
+		authStrategy = acr
+	}
+	auxiliaryStrategy := auxiliary.NewRoutingAuxiliaryStrategy([]auxiliary.AuxiliaryStrategy{authStrategy, description})
+	store, err := newResourceStore(c.Storage, baseURL, converter, auxiliaryStrategy, description)
+	if err != nil {
+		return nil, fmt.Errorf("error creating storage: %w", err)
+	}
+	verifier, identityProvider, err := newIdentity(c.Identity, baseURL, c.Authentication.TrustedIssuers)
+	if err != nil {
+		return nil, fmt.Errorf("error creating identity provider: %w", err)
+	}
+	options := &server.ServerOptions{
+		Port:                 c.Server.Port,
+		HTTPS:                c.Server.HTTPS,
+		CertFile:             c.Server.Cert,
+		KeyFile:              c.Server.Key,
+		BaseURL:              baseURL,
+		AuthMode:             c.Authorization.Type,
+		Storage:              store,
+		MetadataStrategy:     description,
+		AclStrategy:          acl,
+		AcrStrategy:          acr,
+		AuxiliaryStrategy:    auxiliaryStrategy,
+		CredentialsExtractor: newCredentialsExtractor(c.Authentication, newOriginalUrlExtractor(baseURL, c.Server.TrustProxy), verifier),
+		NotificationChannels: c.Notifications.Channels,
+		MaxPatchSize:         c.Server.MaxPatchSize,
+		ShowStackTrace:       c.Server.ShowStackTrace,
+		Logger:               logger,
+	}
+	// A nil provider has to stay a nil interface, so the server knows there is none
+	if identityProvider != nil {
+		options.IdentityProvider = identityProvider
+	}
+	return options, nil
+}
+
+// newIdentity creates the verifier of the access tokens the credentials extractors accept,
+// and the configured identity provider, if any, whose tokens are verified without fetching its keys.
+// The provider signs with a key generated at startup, so its tokens are no longer accepted after a restart.
+func newIdentity(config IdentityConfig, baseURL string, trustedIssuers []string) (*oidc.TokenVerifier,
+	*provider.ClientCredentialsProvider, error) {
+	var keys oidc.KeySource = oidc.NewIssuerKeySets(nil, oidc.DefaultKeySetTTL)
+	issuerVerifier := oidc.NewWebIDIssuerVerifier(nil, oidc.DefaultProfileTTL)
+	if config.Type != IdentityClientCredentials {
+		return oidc.NewTokenVerifier(keys, issuerVerifier, trustedIssuers), nil, nil
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	jwk, err := provider.PublicJWK(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The own issuer is always trusted, also when only some external issuers are
+	if len(trustedIssuers) > 0 {
+		trustedIssuers = append(append([]string{}, trustedIssuers...), baseURL)
+	}
+	verifier := oidc.NewTokenVerifier(provider.NewLocalKeySource(baseURL, jwk, keys), issuerVerifier, trustedIssuers)
+	clients := make([]provider.Client, len(config.Clients))
+	for i, client := range config.Clients {
+		clients[i] = provider.Client{ID: client.ID, Secret: client.Secret, WebID: client.WebID}
+	}
+	identityProvider, err := provider.NewClientCredentialsProvider(baseURL, key, clients, verifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	return verifier, identityProvider, nil
+}
+
+// newResourceStore creates the ResourceStore for the configured storage backend,
+// which manages the auxiliary resources of the strategy
+func newResourceStore(config StorageConfig, baseURL string, converter conversion.RepresentationConverter,
+	auxiliaryStrategy auxiliary.AuxiliaryStrategy, metadataStrategy auxiliary.AuxiliaryIdentifierStrategy) (storage.ResourceStore, error) {
+	var accessor storage.DataAccessor
+	switch config.Type {
+	case StorageMemory:
+		accessor = storage.NewInMemoryDataAccessor(baseURL)
+	case StorageFile:
+		if err := os.MkdirAll(config.RootPath, 0755); err != nil {
+			return nil, err
+		}
+		mapper, err := storage.NewExtensionBasedMapper(baseURL, config.RootPath)
+		if err != nil {
+			return nil, err
+		}
+		accessor = storage.NewFileDataAccessor(mapper)
+	case StorageSQLite:
+		if err := os.MkdirAll(config.RootPath, 0755); err != nil {
+			return nil, err
+		}
+		db, err := storage.OpenSQLite(context.Background(), filepath.Join(config.RootPath, "solid.db"))
+		if err != nil {
+			return nil, err
+		}
+		if accessor, err = storage.NewSQLDataAccessor(context.Background(), db, baseURL); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown storage type %q", config.Type)
+	}
+	source := storage.NewDataAccessorBasedStore(accessor, baseURL).
+		WithAuxiliaryStrategy(auxiliaryStrategy).
+		WithMetadataStrategy(metadataStrategy).
+		WithPatcher(patch.NewWaterfallRdfPatcher(patch.NewN3Patcher(), patch.NewSparqlUpdatePatcher()))
+	converting := storage.NewRepresentationConvertingStore(source, converter)
+	return storage.NewBinarySliceResourceStore(converting), nil
+}
+
+// newOriginalUrlExtractor determines the URL clients sent requests to,
+// which is on the base URL unless the headers of a trusted proxy say otherwise
+func newOriginalUrlExtractor(baseURL string, trustProxy bool) *identifier.OriginalUrlExtractor {
+	if trustProxy {
+		return identifier.NewOriginalUrlExtractor(identifier.OriginalUrlExtractorArgs{})
+	}
+	return identifier.NewOriginalUrlExtractor(identifier.OriginalUrlExtractorArgs{FixedBaseUrl: baseURL})
+}
+
+// newCredentialsExtractor combines the configured credentials extractors
+func newCredentialsExtractor(config AuthenticationConfig, originalUrlExtractor *identifier.OriginalUrlExtractor,
+	verifier *oidc.TokenVerifier) authentication.CredentialsExtractor {
+	extractors := make([]authentication.CredentialsExtractor, 0, len(config.Extractors))
+	for _, extractor := range config.Extractors {
+		switch extractor.Type {
+		case ExtractorPublic:
+			extractors = append(extractors, authentication.NewPublicCredentialsExtractor())
+		case ExtractorUnsecureWebID:
+			extractors = append(extractors, authentication.NewUnsecureWebIdExtractor())
+		case ExtractorUnsecureConstant:
+			extractors = append(extractors, authentication.NewUnsecureConstantCredentialsExtractor(extractor.WebID))
+		case ExtractorDPoP:
+			// The proofs are bound to the URL the client sent the request to
+			extractors = append(extractors, authentication.NewDPoPWebIdExtractor(originalUrlExtractor, verifier))
+		case ExtractorBearer:
+			extractors = append(extractors, authentication.NewBearerWebIdExtractor(verifier))
+		}
+	}
+	// The origin is added to whatever credentials are found, so acl:origin can restrict web applications
+	if len(extractors) == 1 {
+		return authentication.NewOriginExtractor(extractors[0])
+	}
+	return authentication.NewOriginExtractor(authentication.NewUnionCredentialsExtractor(extractors...))
+}
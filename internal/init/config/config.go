@@ -0,0 +1,334 @@
+// Package config reads the declarative JSON or YAML configuration
+// that selects the implementation of every component slot of the server.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"solid-go/internal/init/variables"
+	"solid-go/internal/server"
+)
+
+// The supported storage backends
+const (
+	StorageFile   = "file"
+	StorageMemory = "memory"
+	StorageSQLite = "sqlite"
+)
+
+// The supported credentials extractors
+const (
+	ExtractorPublic           = "public"
+	ExtractorUnsecureWebID    = "unsecure-webid"
+	ExtractorUnsecureConstant = "unsecure-constant"
+	ExtractorDPoP             = "dpop"
+	ExtractorBearer           = "bearer"
+)
+
+// The supported identity providers
+const (
+	IdentityNone              = "none"
+	IdentityClientCredentials = "client-credentials"
+)
+
+// Config selects and configures the components the server is assembled from.
+type Config struct {
+	Server         ServerConfig         `json:"server"`
+	Storage        StorageConfig        `json:"storage"`
+	Authorization  AuthorizationConfig  `json:"authorization"`
+	Authentication AuthenticationConfig `json:"authentication"`
+	Notifications  NotificationsConfig  `json:"notifications"`
+	Identity       IdentityConfig       `json:"identity"`
+}
+
+// ServerConfig configures how the server listens for requests.
+type ServerConfig struct {
+	Port int `json:"port"`
+	// BaseURL is the URL of the root container, defaults to http(s)://localhost:<port>/
+	BaseURL        string `json:"baseUrl"`
+	HTTPS          bool   `json:"https"`
+	Cert           string `json:"cert"`
+	Key            string `json:"key"`
+	ShowStackTrace bool   `json:"showStackTrace"`
+	LogLevel       string `json:"logLevel"`
+	// TrustProxy takes the URL requests were sent to from the Forwarded and X-Forwarded-* headers
+	// instead of the base URL, which is only safe behind a reverse proxy that sets those headers
+	TrustProxy bool `json:"trustProxy,omitempty"`
+	// MaxPatchSize is the size in bytes of the largest N3 Patch or SPARQL Update document accepted, 1 MiB if not set
+	MaxPatchSize int64 `json:"maxPatchSize,omitempty"`
+}
+
+// StorageConfig selects the backend the resources are stored in.
+type StorageConfig struct {
+	Type string `json:"type"`
+	// RootPath is the directory of the file and sqlite backends
+	RootPath string `json:"rootPath"`
+}
+
+// AuthorizationConfig selects the permission readers that determine the permissions of agents.
+type AuthorizationConfig struct {
+	Type string `json:"type"`
+}
+
+// AuthenticationConfig selects the credentials extractors that determine who made a request.
+// The credentials of all extractors that support a request are combined.
+type AuthenticationConfig struct {
+	Extractors []ExtractorConfig `json:"extractors"`
+	// TrustedIssuers are the only issuers whose access tokens the dpop and bearer extractors accept,
+	// tokens of all issuers are accepted if there are none
+	TrustedIssuers []string `json:"trustedIssuers,omitempty"`
+}
+
+// NotificationsConfig selects the notification channel types clients can subscribe to resources with.
+type NotificationsConfig struct {
+	// Channels are the offered channel types, no notifications are sent if there are none
+	Channels []string `json:"channels,omitempty"`
+}
+
+// IdentityConfig selects the identity provider running in the server, which issues the access tokens
+// the dpop and bearer extractors accept.
+type IdentityConfig struct {
+	Type string `json:"type"`
+	// Clients can get access tokens for their WebID from the client-credentials identity provider
+	Clients []ClientConfig `json:"clients,omitempty"`
+}
+
+// ClientConfig configures a client of the client-credentials identity provider.
+type ClientConfig struct {
+	ID     string `json:"id"`
+	Secret string `json:"secret"`
+	WebID  string `json:"webId"`
+}
+
+// ExtractorConfig configures a single credentials extractor.
+// It can also be written as a string containing only the type.
+type ExtractorConfig struct {
+	Type string `json:"type"`
+	// WebID is the agent of every request for the unsecure-constant extractor
+	WebID string `json:"webId,omitempty"`
+}
+
+// UnmarshalJSON accepts both the object form and the shorthand string form
+func (e *ExtractorConfig) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*e = ExtractorConfig{Type: name}
+		return nil
+	}
+	type plain ExtractorConfig
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode((*plain)(e))
+}
+
+// Default returns the configuration of a server that stores its resources on disk
+// and grants everyone full access, which the server warns about when it starts.
+func Default() *Config {
+	return &Config{
+		Server:         ServerConfig{Port: 3000, LogLevel: "info"},
+		Storage:        StorageConfig{Type: StorageFile, RootPath: "./data"},
+		Authorization:  AuthorizationConfig{Type: server.AuthModeAllowAll},
+		Authentication: AuthenticationConfig{Extractors: []ExtractorConfig{{Type: ExtractorPublic}}},
+		Identity:       IdentityConfig{Type: IdentityNone},
+	}
+}
+
+// Load reads the configuration file at the given path on top of the defaults.
+// Files with a .yaml or .yml extension are parsed as YAML, all others as JSON.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		// The YAML document is converted to JSON, so both are decoded by the same rules
+		var document interface{}
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("invalid YAML in %s: %w", path, err)
+		}
+		if data, err = json.Marshal(document); err != nil {
+			return nil, fmt.Errorf("invalid YAML in %s: %w", path, err)
+		}
+	}
+	config := Default()
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(config); err != nil {
+		return nil, fmt.Errorf("invalid configuration in %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Apply overrides the configuration with the values of the given variables,
+// which are named after the command line flags or the SOLID_* environment variables.
+// Unknown variables are ignored.
+func (c *Config) Apply(vars []variables.Variable) error {
+	for _, variable := range vars {
+		var err error
+		switch variable.Name {
+		case "port", "SOLID_PORT":
+			c.Server.Port, err = toInt(variable.Value)
+		case "baseUrl", "SOLID_BASE_URL":
+			c.Server.BaseURL = toString(variable.Value)
+		case "https", "SOLID_HTTPS":
+			c.Server.HTTPS, err = toBool(variable.Value)
+		case "cert", "SOLID_CERT":
+			c.Server.Cert = toString(variable.Value)
+		case "key", "SOLID_KEY":
+			c.Server.Key = toString(variable.Value)
+		case "showStackTrace", "SOLID_SHOW_STACK_TRACE":
+			c.Server.ShowStackTrace, err = toBool(variable.Value)
+		case "logLevel", "SOLID_LOG_LEVEL":
+			c.Server.LogLevel = toString(variable.Value)
+		case "storage", "SOLID_STORAGE":
+			c.Storage.Type = toString(variable.Value)
+		case "rootPath", "SOLID_STORAGE_PATH":
+			c.Storage.RootPath = toString(variable.Value)
+		case "auth", "SOLID_AUTH":
+			c.Authorization.Type = toString(variable.Value)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %w", variable.Name, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks that every slot selects an implementation that is available.
+func (c *Config) Validate() error {
+	if c.Server.Port < 0 || c.Server.Port > 65535 {
+		return fmt.Errorf("invalid port %d", c.Server.Port)
+	}
+	if c.Server.HTTPS && (c.Server.Cert == "" || c.Server.Key == "") {
+		return fmt.Errorf("a certificate and key are required for HTTPS")
+	}
+	switch c.Storage.Type {
+	case StorageMemory:
+	case StorageFile, StorageSQLite:
+		if c.Storage.RootPath == "" {
+			return fmt.Errorf("the %s storage requires a rootPath", c.Storage.Type)
+		}
+	default:
+		return fmt.Errorf("unknown storage type %q", c.Storage.Type)
+	}
+	if len(c.Authentication.Extractors) == 0 {
+		return fmt.Errorf("at least one credentials extractor is required")
+	}
+	for _, extractor := range c.Authentication.Extractors {
+		switch extractor.Type {
+		case ExtractorPublic, ExtractorUnsecureWebID, ExtractorDPoP, ExtractorBearer:
+		case ExtractorUnsecureConstant:
+			if extractor.WebID == "" {
+				return fmt.Errorf("the %s credentials extractor requires a webId", extractor.Type)
+			}
+		default:
+			return fmt.Errorf("unsupported credentials extractor %q", extractor.Type)
+		}
+	}
+	for _, channel := range c.Notifications.Channels {
+		if channel != server.NotificationChannelWebSocket {
+			return fmt.Errorf("unsupported notification channel %q", channel)
+		}
+	}
+	return c.validateIdentity()
+}
+
+// validateIdentity checks the identity provider can issue tokens the credentials extractors accept
+func (c *Config) validateIdentity() error {
+	switch c.Identity.Type {
+	case IdentityNone:
+		if len(c.Identity.Clients) > 0 {
+			return fmt.Errorf("clients require the %s identity provider", IdentityClientCredentials)
+		}
+		return nil
+	case IdentityClientCredentials:
+	default:
+		return fmt.Errorf("unknown identity provider %q", c.Identity.Type)
+	}
+	if !c.hasExtractor(ExtractorDPoP) && !c.hasExtractor(ExtractorBearer) {
+		return fmt.Errorf("the %s identity provider requires the %s or %s credentials extractor",
+			c.Identity.Type, ExtractorDPoP, ExtractorBearer)
+	}
+	if len(c.Identity.Clients) == 0 {
+		return fmt.Errorf("the %s identity provider requires at least one client", c.Identity.Type)
+	}
+	ids := make(map[string]bool, len(c.Identity.Clients))
+	for _, client := range c.Identity.Clients {
+		if client.ID == "" || client.Secret == "" {
+			return fmt.Errorf("every client requires an id and a secret")
+		}
+		if ids[client.ID] {
+			return fmt.Errorf("duplicate client %q", client.ID)
+		}
+		ids[client.ID] = true
+		if webID, err := url.Parse(client.WebID); err != nil || (webID.Scheme != "http" && webID.Scheme != "https") || webID.Host == "" {
+			return fmt.Errorf("the client %q requires an http(s) webId", client.ID)
+		}
+	}
+	return nil
+}
+
+// hasExtractor checks whether the credentials extractor of the type is configured
+func (c *Config) hasExtractor(extractorType string) bool {
+	for _, extractor := range c.Authentication.Extractors {
+		if extractor.Type == extractorType {
+			return true
+		}
+	}
+	return false
+}
+
+// BaseURL returns the configured base URL, or the localhost URL of the configured port
+func (c *Config) BaseURL() string {
+	baseURL := c.Server.BaseURL
+	if baseURL == "" {
+		scheme := "http"
+		if c.Server.HTTPS {
+			scheme = "https"
+		}
+		baseURL = fmt.Sprintf("%s://localhost:%d/", scheme, c.Server.Port)
+	}
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	return baseURL
+}
+
+// toString converts a variable value to a string
+func toString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+// toInt converts a numeric or string variable value to an int
+func toInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		return int(v), nil
+	}
+	return strconv.Atoi(toString(value))
+}
+
+// toBool converts a boolean or string variable value to a bool
+func toBool(value interface{}) (bool, error) {
+	if b, ok := value.(bool); ok {
+		return b, nil
+	}
+	return strconv.ParseBool(toString(value))
+}
@@ -0,0 +1,298 @@
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"solid-go/internal/init/variables"
+	"solid-go/internal/server"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	jsonPath := writeFile(t, "config.json", `{
+		"server": { "port": 8080, "baseUrl": "https://pod.example/" },
+		"storage": { "type": "memory" },
+		"authentication": {
+			"extractors": ["unsecure-webid", { "type": "unsecure-constant", "webId": "https://alice.example/#me" }],
+			"trustedIssuers": ["https://idp.example/"]
+		},
+		"notifications": { "channels": ["websocket"] }
+	}`)
+	yamlPath := writeFile(t, "config.yaml", `
+# The same configuration as YAML
+server:
+  port: 8080
+  baseUrl: "https://pod.example/"   # quoted
+storage:
+  type: memory
+authentication:
+  extractors:
+  - unsecure-webid
+  - type: unsecure-constant
+    webId: 'https://alice.example/#me'
+  trustedIssuers: [https://idp.example/]
+notifications:
+  channels: [websocket]
+`)
+
+	fromJSON, err := Load(jsonPath)
+	if err != nil {
+		t.Fatalf("Load() of JSON error = %v", err)
+	}
+	fromYAML, err := Load(yamlPath)
+	if err != nil {
+		t.Fatalf("Load() of YAML error = %v", err)
+	}
+	if !reflect.DeepEqual(fromJSON, fromYAML) {
+		t.Errorf("JSON and YAML differ:\n%+v\n%+v", fromJSON, fromYAML)
+	}
+
+	want := Default()
+	want.Server.Port = 8080
+	want.Server.BaseURL = "https://pod.example/"
+	want.Storage.Type = StorageMemory
+	want.Authentication.Extractors = []ExtractorConfig{
+		{Type: ExtractorUnsecureWebID},
+		{Type: ExtractorUnsecureConstant, WebID: "https://alice.example/#me"},
+	}
+	want.Authentication.TrustedIssuers = []string{"https://idp.example/"}
+	want.Notifications.Channels = []string{"websocket"}
+	if !reflect.DeepEqual(fromJSON, want) {
+		t.Errorf("Load() = %+v, want %+v", fromJSON, want)
+	}
+	if err := fromJSON.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	if _, err := Load(writeFile(t, "typo.json", `{ "storag": {} }`)); err == nil {
+		t.Error("Load() should reject unknown fields")
+	}
+	if _, err := Load(writeFile(t, "bad.yaml", "storage:\n  type: memory\n    rootPath: x\n")); err == nil {
+		t.Error("Load() should reject invalid indentation")
+	}
+}
+
+func TestPresets(t *testing.T) {
+	for _, name := range []string{"file.json", "memory.yaml"} {
+		config, err := Load(filepath.Join("..", "..", "..", "config", name))
+		if err != nil {
+			t.Errorf("Load(%v) error = %v", name, err)
+			continue
+		}
+		if err := config.Validate(); err != nil {
+			t.Errorf("%v: Validate() error = %v", name, err)
+		}
+	}
+}
+
+func TestConfig_Apply(t *testing.T) {
+	config := Default()
+	err := config.Apply([]variables.Variable{
+		{Name: "SOLID_PORT", Type: variables.StringType, Value: "8080"},
+		{Name: "SOLID_STORAGE_PATH", Type: variables.StringType, Value: "/data"},
+		{Name: "storage", Type: variables.StringType, Value: "sqlite"},
+		{Name: "https", Type: variables.BooleanType, Value: true},
+		{Name: "SOLID_UNKNOWN", Type: variables.StringType, Value: "ignored"},
+	})
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if config.Server.Port != 8080 || config.Storage.RootPath != "/data" || config.Storage.Type != StorageSQLite || !config.Server.HTTPS {
+		t.Errorf("Apply() = %+v", config)
+	}
+	if got := config.BaseURL(); got != "https://localhost:8080/" {
+		t.Errorf("BaseURL() = %v", got)
+	}
+	if err := config.Apply([]variables.Variable{{Name: "port", Value: "abc"}}); err == nil {
+		t.Error("Apply() should reject an invalid port")
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	for name, modify := range map[string]func(*Config){
+		"storage":      func(c *Config) { c.Storage.Type = "s3" },
+		"root path":    func(c *Config) { c.Storage.RootPath = "" },
+		"https":        func(c *Config) { c.Server.HTTPS = true },
+		"extractor":    func(c *Config) { c.Authentication.Extractors = []ExtractorConfig{{Type: "magic"}} },
+		"webId":        func(c *Config) { c.Authentication.Extractors = []ExtractorConfig{{Type: ExtractorUnsecureConstant}} },
+		"no extractor": func(c *Config) { c.Authentication.Extractors = nil },
+		"channel":      func(c *Config) { c.Notifications.Channels = []string{"webhook"} },
+		"identity":     func(c *Config) { c.Identity.Type = "password" },
+		"clients": func(c *Config) {
+			c.Identity.Clients = []ClientConfig{{ID: "app", Secret: "s", WebID: "https://a.example/#me"}}
+		},
+		"no token extractor": func(c *Config) {
+			c.Identity = IdentityConfig{Type: IdentityClientCredentials, Clients: []ClientConfig{{ID: "app", Secret: "s", WebID: "https://a.example/#me"}}}
+		},
+		"no clients": func(c *Config) {
+			c.Authentication.Extractors = []ExtractorConfig{{Type: ExtractorDPoP}}
+			c.Identity.Type = IdentityClientCredentials
+		},
+		"client webId": func(c *Config) {
+			c.Authentication.Extractors = []ExtractorConfig{{Type: ExtractorDPoP}}
+			c.Identity = IdentityConfig{Type: IdentityClientCredentials, Clients: []ClientConfig{{ID: "app", Secret: "s", WebID: "alice"}}}
+		},
+	} {
+		config := Default()
+		modify(config)
+		if err := config.Validate(); err == nil {
+			t.Errorf("%v: Validate() should fail", name)
+		}
+	}
+}
+
+func TestConfig_ServerOptions(t *testing.T) {
+	config := Default()
+	config.Storage.Type = StorageMemory
+	config.Authorization.Type = "deny-all"
+	config.Notifications.Channels = []string{"websocket"}
+	options, err := config.ServerOptions(nil)
+	if err != nil {
+		t.Fatalf("ServerOptions() error = %v", err)
+	}
+	if options.Storage == nil || options.CredentialsExtractor == nil || options.AuthMode != "deny-all" || len(options.NotificationChannels) != 1 ||
+		options.AclStrategy == nil || options.AcrStrategy == nil || options.MetadataStrategy == nil || options.AuxiliaryStrategy == nil ||
+		!strings.HasPrefix(options.BaseURL, "http://localhost:3000/") {
+		t.Errorf("ServerOptions() = %+v", options)
+	}
+}
+
+func TestLoad_YAML(t *testing.T) {
+	config, err := Load(writeFile(t, "config.yml", `
+server: {port: 8080, baseUrl: "https://pod.example/"}
+storage:
+  type: memory
+authentication:
+  extractors:
+  - &constant {type: unsecure-constant, webId: "https://alice.example/#me"}
+  - *constant
+  trustedIssuers:
+  - >-
+    https://idp.example/
+`))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	constant := ExtractorConfig{Type: ExtractorUnsecureConstant, WebID: "https://alice.example/#me"}
+	if config.Server.Port != 8080 || config.Server.BaseURL != "https://pod.example/" ||
+		!reflect.DeepEqual(config.Authentication.Extractors, []ExtractorConfig{constant, constant}) ||
+		!reflect.DeepEqual(config.Authentication.TrustedIssuers, []string{"https://idp.example/"}) {
+		t.Errorf("Load() = %+v", config)
+	}
+}
+
+func TestNewOriginalUrlExtractor(t *testing.T) {
+	request := httptest.NewRequest("GET", "http://pod.example/doc", nil)
+	request.Header.Set("X-Forwarded-Host", "attacker.example")
+	for trustProxy, want := range map[bool]string{false: "https://pod.example/doc", true: "http://attacker.example/doc"} {
+		identifier, err := newOriginalUrlExtractor("https://pod.example/", trustProxy).Handle(request)
+		if err != nil || identifier.Path != want {
+			t.Errorf("Handle() with trustProxy %v = %v, %v, want %v", trustProxy, identifier.Path, err, want)
+		}
+	}
+}
+
+func TestConfig_ServerOptions_Identity(t *testing.T) {
+	config := Default()
+	config.Storage.Type = StorageMemory
+	config.Authentication.Extractors = []ExtractorConfig{{Type: ExtractorDPoP}, {Type: ExtractorPublic}}
+	config.Identity = IdentityConfig{Type: IdentityClientCredentials, Clients: []ClientConfig{
+		{ID: "app", Secret: "secret", WebID: "http://localhost:3000/profile/card#me"},
+	}}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	options, err := config.ServerOptions(nil)
+	if err != nil {
+		t.Fatalf("ServerOptions() error = %v", err)
+	}
+	if options.IdentityProvider == nil {
+		t.Fatal("ServerOptions() should include the identity provider")
+	}
+	request := httptest.NewRequest("GET", "http://localhost:3000/.well-known/openid-configuration", nil)
+	if !options.IdentityProvider.Handles(request) {
+		t.Error("the identity provider should serve its discovery document")
+	}
+
+	config.Identity = IdentityConfig{Type: IdentityNone}
+	if options, err = config.ServerOptions(nil); err != nil || options.IdentityProvider != nil {
+		t.Errorf("ServerOptions() without identity provider = %+v, %v", options, err)
+	}
+}
+
+func TestClientCredentialsIdentity(t *testing.T) {
+	testServer := httptest.NewUnstartedServer(nil)
+	base := "http://" + testServer.Listener.Addr().String() + "/"
+	webID := base + "profile#me"
+	config := Default()
+	config.Server.BaseURL = base
+	config.Storage.Type = StorageMemory
+	config.Authentication.Extractors = []ExtractorConfig{{Type: ExtractorBearer}, {Type: ExtractorPublic}}
+	config.Identity = IdentityConfig{Type: IdentityClientCredentials, Clients: []ClientConfig{
+		{ID: "app", Secret: "secret", WebID: webID},
+	}}
+	options, err := config.ServerOptions(nil)
+	if err != nil {
+		t.Fatalf("ServerOptions() error = %v", err)
+	}
+	srv, err := server.NewServer(options)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	testServer.Config.Handler = srv.Handler()
+	testServer.Start()
+	defer testServer.Close()
+
+	// The profile names the server as issuer, so it may issue tokens for the WebID
+	profile, _ := http.NewRequest("PUT", base+"profile", strings.NewReader(
+		"<#me> <http://www.w3.org/ns/solid/terms#oidcIssuer> <"+base+">."))
+	profile.Header.Set("Content-Type", "text/turtle")
+	if response, err := http.DefaultClient.Do(profile); err != nil || response.StatusCode != http.StatusCreated {
+		t.Fatalf("PUT profile = %v, %v", response, err)
+	}
+
+	tokenRequest, _ := http.NewRequest("POST", base+".oidc/token", strings.NewReader("grant_type=client_credentials"))
+	tokenRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	tokenRequest.SetBasicAuth("app", "secret")
+	response, err := http.DefaultClient.Do(tokenRequest)
+	if err != nil || response.StatusCode != http.StatusOK {
+		t.Fatalf("POST token = %v, %v", response, err)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&token); err != nil {
+		t.Fatalf("invalid token response: %v", err)
+	}
+	response.Body.Close()
+
+	for _, tt := range []struct {
+		token  string
+		status int
+	}{
+		{token.AccessToken, http.StatusOK},
+		{token.AccessToken[:len(token.AccessToken)-4] + "AAAA", http.StatusUnauthorized},
+	} {
+		request, _ := http.NewRequest("GET", base+"profile", nil)
+		request.Header.Set("Authorization", "Bearer "+tt.token)
+		response, err := http.DefaultClient.Do(request)
+		if err != nil || response.StatusCode != tt.status {
+			t.Errorf("GET profile with token = %v, %v, want %d", response, err, tt.status)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+)
+
+// SQLMigration is a versioned schema change of a SQL database
+type SQLMigration struct {
+	// Version is the schema version after the migration, starting from 1
+	Version int
+	// Description explains what the migration changes
+	Description string
+	// Statements are executed in order within a single transaction
+	Statements []string
+}
+
+// SQLMigrator brings a SQL database to the latest schema version.
+// Applied versions are tracked in the schema_migrations table, so running it again only applies new migrations.
+type SQLMigrator struct {
+	db         *sql.DB
+	migrations []SQLMigration
+}
+
+// NewSQLMigrator creates a new SQLMigrator for the given migrations
+func NewSQLMigrator(db *sql.DB, migrations []SQLMigration) *SQLMigrator {
+	sorted := append([]SQLMigration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return &SQLMigrator{db: db, migrations: sorted}
+}
+
+// Run implements Migration.Run
+func (m *SQLMigrator) Run(ctx context.Context) error {
+	if _, err := m.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		description TEXT NOT NULL
+	)`); err != nil {
+		return err
+	}
+	current, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	for _, migration := range m.migrations {
+		if migration.Version <= current {
+			continue
+		}
+		if err := m.apply(ctx, migration); err != nil {
+			return fmt.Errorf("migration to version %d (%s) failed: %w", migration.Version, migration.Description, err)
+		}
+	}
+	return nil
+}
+
+// Version returns the current schema version, 0 if no migration has been applied
+func (m *SQLMigrator) Version(ctx context.Context) (int, error) {
+	var version sql.NullInt64
+	if err := m.db.QueryRowContext(ctx, `SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, err
+	}
+	return int(version.Int64), nil
+}
+
+// apply runs a single migration and records it in one transaction
+func (m *SQLMigrator) apply(ctx context.Context, migration SQLMigration) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, statement := range migration.Statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, description) VALUES (?, ?)`,
+		migration.Version, migration.Description); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -1,89 +1,30 @@
 package server
 
 import (
-	"context"
-	"encoding/json"
 	"log"
-)
-
-// --- Types for credentials, access, and errors ---
-type Credentials struct {
-	// Add fields as needed
-}
-
-type AccessMode string
-
-type Identifier struct {
-	Path string
-}
-
-type AccessMap map[Identifier][]AccessMode
-
-type PermissionReaderInput struct {
-	Credentials    Credentials
-	RequestedModes AccessMap
-}
-
-type AuthorizerInput struct {
-	Credentials          Credentials
-	RequestedModes       AccessMap
-	AvailablePermissions map[Identifier][]AccessMode
-}
-
-type OperationHttpHandlerInput struct {
-	Request   interface{}
-	Operation interface{}
-}
-
-type ResponseDescription struct{}
-
-// --- Custom error type for attaching metadata ---
-type Metadata map[string]interface{}
-
-type HttpError struct {
-	Msg      string
-	Metadata Metadata
-}
 
-func (e *HttpError) Error() string {
-	return e.Msg
-}
-
-func NewHttpError(msg string) *HttpError {
-	return &HttpError{Msg: msg, Metadata: make(Metadata)}
-}
-
-// --- Interfaces for dependencies ---
-type CredentialsExtractor interface {
-	HandleSafe(ctx context.Context, request interface{}) (Credentials, error)
-}
-type ModesExtractor interface {
-	HandleSafe(ctx context.Context, operation interface{}) (AccessMap, error)
-}
-type PermissionReader interface {
-	HandleSafe(ctx context.Context, input PermissionReaderInput) (map[Identifier][]AccessMode, error)
-}
-type Authorizer interface {
-	HandleSafe(ctx context.Context, input AuthorizerInput) error
-}
-type OperationHttpHandler interface {
-	HandleSafe(ctx context.Context, input OperationHttpHandlerInput) (ResponseDescription, error)
-}
+	"solid-go/internal/authentication"
+	"solid-go/internal/authorization"
+	"solid-go/internal/authorization/permissions"
+	"solid-go/internal/http/output/response"
+)
 
-// --- AuthorizingHttpHandler implementation ---
+// AuthorizingHttpHandler only passes requests to the wrapped handler
+// if the agent that made them has all the access modes the operation requires.
 type AuthorizingHttpHandler struct {
-	credentialsExtractor CredentialsExtractor
-	modesExtractor       ModesExtractor
-	permissionReader     PermissionReader
-	authorizer           Authorizer
+	credentialsExtractor authentication.CredentialsExtractor
+	modesExtractor       permissions.ModesExtractor
+	permissionReader     authorization.PermissionReader
+	authorizer           authorization.Authorizer
 	operationHandler     OperationHttpHandler
 }
 
+// NewAuthorizingHttpHandler creates a new AuthorizingHttpHandler
 func NewAuthorizingHttpHandler(
-	credentialsExtractor CredentialsExtractor,
-	modesExtractor ModesExtractor,
-	permissionReader PermissionReader,
-	authorizer Authorizer,
+	credentialsExtractor authentication.CredentialsExtractor,
+	modesExtractor permissions.ModesExtractor,
+	permissionReader authorization.PermissionReader,
+	authorizer authorization.Authorizer,
 	operationHandler OperationHttpHandler,
 ) *AuthorizingHttpHandler {
 	return &AuthorizingHttpHandler{
@@ -95,59 +36,42 @@ func NewAuthorizingHttpHandler(
 	}
 }
 
-func (h *AuthorizingHttpHandler) Handle(ctx context.Context, input OperationHttpHandlerInput) (ResponseDescription, error) {
-	credentials, err := h.credentialsExtractor.HandleSafe(ctx, input.Request)
+// Handle implements OperationHttpHandler.Handle
+func (h *AuthorizingHttpHandler) Handle(input OperationHttpHandlerInput) (*response.ResponseDescription, error) {
+	credentials, err := h.credentialsExtractor.Extract(input.Request)
 	if err != nil {
 		log.Printf("Failed to extract credentials: %v", err)
-		return ResponseDescription{}, err
+		return nil, err
 	}
-	credJson, _ := json.Marshal(credentials)
-	log.Printf("Extracted credentials: %s", credJson)
 
-	requestedModes, err := h.modesExtractor.HandleSafe(ctx, input.Operation)
+	if err := h.modesExtractor.CanHandle(input.Operation); err != nil {
+		return nil, err
+	}
+	requestedModes, err := h.modesExtractor.Extract(input.Operation)
 	if err != nil {
 		log.Printf("Failed to extract required modes: %v", err)
-		return ResponseDescription{}, err
+		return nil, err
 	}
-	log.Printf("Retrieved required modes: %v", requestedModes)
 
-	availablePermissions, err := h.permissionReader.HandleSafe(ctx, PermissionReaderInput{
+	availablePermissions, err := h.permissionReader.Read(authorization.PermissionReaderInput{
 		Credentials:    credentials,
 		RequestedModes: requestedModes,
 	})
 	if err != nil {
 		log.Printf("Failed to read available permissions: %v", err)
-		return ResponseDescription{}, err
+		return nil, err
 	}
-	log.Printf("Available permissions: %v", availablePermissions)
 
-	authInput := AuthorizerInput{
+	if err := h.authorizer.Authorize(authorization.AuthorizerInput{
 		Credentials:          credentials,
 		RequestedModes:       requestedModes,
 		AvailablePermissions: availablePermissions,
-	}
-	err = h.authorizer.HandleSafe(ctx, authInput)
-	if err != nil {
+	}); err != nil {
 		log.Printf("Authorization failed: %v", err)
-		if httpErr, ok := err.(*HttpError); ok {
-			h.addAccessModesToError(httpErr, requestedModes)
-		}
-		return ResponseDescription{}, err
+		return nil, err
 	}
 
-	log.Printf("Authorization succeeded, calling source handler")
-	return h.operationHandler.HandleSafe(ctx, input)
-}
-
-// addAccessModesToError attaches requested access info to the error's metadata
-func (h *AuthorizingHttpHandler) addAccessModesToError(err *HttpError, requestedModes AccessMap) {
-	var requested []map[string]interface{}
-	for identifier, modes := range requestedModes {
-		entry := map[string]interface{}{
-			"accessTarget": identifier.Path,
-			"accessModes":  modes,
-		}
-		requested = append(requested, entry)
-	}
-	err.Metadata["requestedAccess"] = requested
+	// The credentials are passed on, since extracting them again would reuse the DPoP proof of the request
+	input.Credentials = credentials
+	return h.operationHandler.Handle(input)
 }
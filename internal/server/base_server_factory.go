@@ -45,8 +45,7 @@ func (f *BaseServerFactory) CreateServer() (*http.Server, error) {
 		return nil, err
 	}
 
-	server := &http.Server{}
-	*server = serverOptions
+	server := serverOptions
 	if tlsConfig != nil {
 		server.TLSConfig = tlsConfig
 	}
@@ -59,27 +58,27 @@ func (f *BaseServerFactory) CreateServer() (*http.Server, error) {
 }
 
 // createServerOptions reads key/cert files and prepares server/tls config.
-func (f *BaseServerFactory) createServerOptions() (http.Server, *tls.Config, error) {
+func (f *BaseServerFactory) createServerOptions() (*http.Server, *tls.Config, error) {
 	options := f.options
 	var tlsConfig *tls.Config
 
 	if options.HTTPS {
 		cert, err := ioutil.ReadFile(options.Cert)
 		if err != nil {
-			return http.Server{}, nil, err
+			return nil, nil, err
 		}
 		key, err := ioutil.ReadFile(options.Key)
 		if err != nil {
-			return http.Server{}, nil, err
+			return nil, nil, err
 		}
 		certificate, err := tls.X509KeyPair(cert, key)
 		if err != nil {
-			return http.Server{}, nil, err
+			return nil, nil, err
 		}
 		tlsConfig = &tls.Config{
 			Certificates: []tls.Certificate{certificate},
 		}
 	}
 	// Additional options (Pfx, Passphrase) can be handled here if needed
-	return http.Server{}, tlsConfig, nil
+	return &http.Server{}, tlsConfig, nil
 }
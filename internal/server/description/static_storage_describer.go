@@ -2,19 +2,10 @@ package description
 
 import (
 	"errors"
-)
-
-// --- Stubs for RDF types ---
-type RDFTerm struct {
-	Value string
-	Type  string // e.g., "NamedNode", "Literal"
-}
+	"sort"
 
-type RDFQuad struct {
-	Subject   RDFTerm
-	Predicate RDFTerm
-	Object    RDFTerm
-}
+	"solid-go/internal/util/n3"
+)
 
 // --- StaticStorageDescriber implementation ---
 type StaticStorageDescriber struct {
@@ -29,20 +20,31 @@ func NewStaticStorageDescriber(terms map[string][]string) (*StaticStorageDescrib
 	return &StaticStorageDescriber{terms: terms}, nil
 }
 
+// CanHandle accepts every target.
+func (s *StaticStorageDescriber) CanHandle(_ ResourceIdentifier) error {
+	return nil
+}
+
 // Handle generates RDF triples for the storage description resource.
-func (s *StaticStorageDescriber) Handle(target ResourceIdentifier) ([]RDFQuad, error) {
-	subject := RDFTerm{Value: target.Path, Type: "NamedNode"}
+func (s *StaticStorageDescriber) Handle(target ResourceIdentifier) ([]n3.Quad, error) {
+	subject := n3.NewNamedNode(target.Path)
 	return s.generateTriples(subject), nil
 }
 
-// generateTriples yields all triples for the subject.
-func (s *StaticStorageDescriber) generateTriples(subject RDFTerm) []RDFQuad {
-	var quads []RDFQuad
-	for predicate, objects := range s.terms {
-		predTerm := RDFTerm{Value: predicate, Type: "NamedNode"}
-		for _, obj := range objects {
-			objTerm := RDFTerm{Value: obj, Type: "NamedNode"} // For simplicity, treat all as NamedNode
-			quads = append(quads, RDFQuad{Subject: subject, Predicate: predTerm, Object: objTerm})
+// generateTriples yields all triples for the subject, sorted by predicate.
+func (s *StaticStorageDescriber) generateTriples(subject n3.Term) []n3.Quad {
+	predicates := make([]string, 0, len(s.terms))
+	for predicate := range s.terms {
+		predicates = append(predicates, predicate)
+	}
+	sort.Strings(predicates)
+
+	var quads []n3.Quad
+	for _, predicate := range predicates {
+		predTerm := n3.NewNamedNode(predicate)
+		for _, obj := range s.terms[predicate] {
+			objTerm := n3.NewNamedNode(obj) // For simplicity, treat all as NamedNode
+			quads = append(quads, n3.NewQuad(subject, predTerm, objTerm, nil))
 		}
 	}
 	return quads
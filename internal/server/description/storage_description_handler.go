@@ -3,6 +3,12 @@ package description
 import (
 	"errors"
 	"fmt"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage"
+	"solid-go/internal/storage/conversion"
+	"solid-go/internal/util"
+	"solid-go/internal/util/n3"
 )
 
 // --- Stubs for dependent types ---
@@ -12,39 +18,32 @@ type ResponseDescription struct {
 }
 
 type Operation struct {
-	Method string
-	Target ResourceIdentifier
+	Method      string
+	Target      ResourceIdentifier
+	Preferences *representation.RepresentationPreferences
 }
 
 type OperationHttpHandlerInput struct {
 	Operation Operation
 }
 
-type ResourceStore interface {
-	GetRepresentation(container ResourceIdentifier, opts map[string]interface{}) (*Representation, error)
-}
-
-type Representation struct {
-	Metadata map[string]interface{}
-	Data     interface{}
-}
-
-func (r *Representation) Destroy() {
-	// Placeholder for resource cleanup
-}
+// pimStorage is the type of storage root containers
+const pimStorage = "http://www.w3.org/ns/pim/space#Storage"
 
 // --- StorageDescriptionHandler implementation ---
 type StorageDescriptionHandler struct {
-	store     ResourceStore
+	store     storage.ResourceStore
 	path      string
 	describer StorageDescriber
+	converter conversion.RepresentationConverter
 }
 
-func NewStorageDescriptionHandler(store ResourceStore, path string, describer StorageDescriber) *StorageDescriptionHandler {
+func NewStorageDescriptionHandler(store storage.ResourceStore, path string, describer StorageDescriber, converter conversion.RepresentationConverter) *StorageDescriptionHandler {
 	return &StorageDescriptionHandler{
 		store:     store,
 		path:      path,
 		describer: describer,
+		converter: converter,
 	}
 }
 
@@ -54,28 +53,37 @@ func (h *StorageDescriptionHandler) CanHandle(input OperationHttpHandlerInput) e
 		return fmt.Errorf("Only GET requests can target the storage description")
 	}
 	container := h.getStorageIdentifier(input.Operation.Target)
-	representation, err := h.store.GetRepresentation(container, map[string]interface{}{})
+	rep, err := h.store.GetRepresentation(representation.ResourceIdentifier{Path: container.Path}, nil, nil)
 	if err != nil {
 		return err
 	}
-	representation.Destroy()
-	if !hasType(representation.Metadata, "Storage") {
+	if !hasType(rep.GetMetadata(), pimStorage) {
 		return errors.New("Only supports descriptions of storage containers")
 	}
 	return h.describer.CanHandle(input.Operation.Target)
 }
 
-// Handle generates the storage description response.
+// Handle generates the storage description response in the content type preferred by the client.
 func (h *StorageDescriptionHandler) Handle(input OperationHttpHandlerInput) (ResponseDescription, error) {
-	quads, err := h.describer.Handle(input.Operation.Target)
+	target := input.Operation.Target
+	quads, err := h.describer.Handle(target)
 	if err != nil {
 		return ResponseDescription{}, err
 	}
-	representation := &Representation{
-		Metadata: map[string]interface{}{},
-		Data:     quads,
+	description := &representation.BasicRdfDatasetRepresentation{
+		Metadata: representation.NewRepresentationMetadata(target.Path).SetContentType(util.InternalQuads),
+		Quads:    n3.NewBasicStoreWithQuads(quads),
+	}
+	converted, err := h.converter.Handle(conversion.RepresentationConverterArgs{
+		Identifier:     representation.ResourceIdentifier{Path: target.Path},
+		Representation: description,
+		Preferences:    input.Operation.Preferences,
+	})
+	if err != nil {
+		return ResponseDescription{}, err
 	}
-	return ResponseDescription{Metadata: representation.Metadata, Data: representation.Data}, nil
+	metadata := map[string]interface{}{"contentType": converted.GetMetadata().ContentType()}
+	return ResponseDescription{Metadata: metadata, Data: converted.GetData()}, nil
 }
 
 // getStorageIdentifier determines the identifier of the root storage based on the description identifier.
@@ -96,7 +104,9 @@ func ensureTrailingSlash(path string) string {
 }
 
 // hasType checks if the metadata has the given type.
-func hasType(metadata map[string]interface{}, typ string) bool {
-	// Placeholder: implement actual type checking logic
-	return true
+func hasType(metadata *representation.RepresentationMetadata, typ string) bool {
+	if metadata == nil {
+		return false
+	}
+	return len(metadata.Quads(metadata.GetIdentifier(), n3.RDFType, typ, nil)) > 0
 }
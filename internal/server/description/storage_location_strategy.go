@@ -1,9 +1,9 @@
 package description
 
+import "solid-go/internal/http/representation"
+
 // ResourceIdentifier represents a resource's unique identifier (e.g., a URI or path).
-type ResourceIdentifier struct {
-	Path string
-}
+type ResourceIdentifier = representation.ResourceIdentifier
 
 // StorageLocationStrategy is used to find the storage a specific identifier is located in.
 type StorageLocationStrategy interface {
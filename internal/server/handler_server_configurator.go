@@ -27,7 +27,6 @@ func NewHandlerServerConfigurator(handler HttpHandler, showStackTrace bool) *Han
 // Handle attaches the handler to the http.Server's handler field
 func (c *HandlerServerConfigurator) HandleSafe(server *http.Server) error {
 	server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("Received %s request for %s", r.Method, r.URL.Path)
 		err := c.handler.HandleSafe(w, r)
 		if err != nil {
 			errMsg := c.createErrorMessage(err)
@@ -37,11 +36,6 @@ func (c *HandlerServerConfigurator) HandleSafe(server *http.Server) error {
 			}
 			w.WriteHeader(http.StatusInternalServerError)
 			fmt.Fprint(w, errMsg)
-			return
-		}
-		// If no handler wrote a response, return 404
-		if w.Header().Get("Content-Type") == "" {
-			w.WriteHeader(http.StatusNotFound)
 		}
 	})
 	return nil
@@ -0,0 +1,28 @@
+package server
+
+import (
+	"net/http"
+)
+
+// HeaderHandler adds headers to the response of a request
+type HeaderHandler interface {
+	Handle(w http.ResponseWriter, r *http.Request)
+}
+
+// HeaderHttpHandler lets its header handler add headers to every response,
+// before passing the request to the wrapped handler.
+type HeaderHttpHandler struct {
+	headerHandler HeaderHandler
+	handler       HttpHandler
+}
+
+// NewHeaderHttpHandler creates a new HeaderHttpHandler
+func NewHeaderHttpHandler(headerHandler HeaderHandler, handler HttpHandler) *HeaderHttpHandler {
+	return &HeaderHttpHandler{headerHandler: headerHandler, handler: handler}
+}
+
+// HandleSafe implements HttpHandler.HandleSafe
+func (h *HeaderHttpHandler) HandleSafe(w http.ResponseWriter, r *http.Request) error {
+	h.headerHandler.Handle(w, r)
+	return h.handler.HandleSafe(w, r)
+}
@@ -0,0 +1,33 @@
+package server
+
+import (
+	"net/http"
+)
+
+// HealthCheckHttpHandler answers requests to its path with 200 OK,
+// so orchestrators can check the server is up without touching the storage.
+// All other requests are passed to the wrapped handler.
+type HealthCheckHttpHandler struct {
+	path    string
+	handler HttpHandler
+}
+
+// NewHealthCheckHttpHandler creates a new HealthCheckHttpHandler
+func NewHealthCheckHttpHandler(path string, handler HttpHandler) *HealthCheckHttpHandler {
+	return &HealthCheckHttpHandler{path: path, handler: handler}
+}
+
+// HandleSafe implements HttpHandler.HandleSafe
+func (h *HealthCheckHttpHandler) HandleSafe(w http.ResponseWriter, r *http.Request) error {
+	if r.URL.Path != h.path || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+		return h.handler.HandleSafe(w, r)
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_, err := w.Write([]byte("OK\n"))
+		return err
+	}
+	return nil
+}
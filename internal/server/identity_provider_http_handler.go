@@ -0,0 +1,32 @@
+package server
+
+import (
+	"net/http"
+)
+
+// IdentityProvider answers the requests to the endpoints of an identity provider running in the server
+type IdentityProvider interface {
+	HttpHandler
+	// Handles checks whether the request is for one of the endpoints of the identity provider
+	Handles(r *http.Request) bool
+}
+
+// IdentityProviderHttpHandler passes the requests to the endpoints of the identity provider to it,
+// and all other requests to the wrapped handler.
+type IdentityProviderHttpHandler struct {
+	provider IdentityProvider
+	handler  HttpHandler
+}
+
+// NewIdentityProviderHttpHandler creates a new IdentityProviderHttpHandler
+func NewIdentityProviderHttpHandler(provider IdentityProvider, handler HttpHandler) *IdentityProviderHttpHandler {
+	return &IdentityProviderHttpHandler{provider: provider, handler: handler}
+}
+
+// HandleSafe implements HttpHandler.HandleSafe
+func (h *IdentityProviderHttpHandler) HandleSafe(w http.ResponseWriter, r *http.Request) error {
+	if !h.provider.Handles(r) {
+		return h.handler.HandleSafe(w, r)
+	}
+	return h.provider.HandleSafe(w, r)
+}
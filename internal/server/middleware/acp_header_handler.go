@@ -3,20 +3,24 @@ package middleware
 import (
 	"fmt"
 	"net/http"
-	"strings"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/vocabularies"
 )
 
-// TargetExtractor extracts a target identifier from a request.
+// TargetExtractor extracts the target identifier from a request.
 type TargetExtractor interface {
-	HandleSafe(r *http.Request) (string, error) // For demo, just return a string identifier
+	Handle(request *http.Request) (representation.ResourceIdentifier, error)
 }
 
 // AuxiliaryIdentifierStrategy checks if an identifier is auxiliary.
 type AuxiliaryIdentifierStrategy interface {
-	IsAuxiliaryIdentifier(identifier string) bool
+	IsAuxiliaryIdentifier(identifier representation.ResourceIdentifier) bool
 }
 
 // AcpHeaderHandler handles all required ACP headers.
+// Responses for Access Control Resources advertise their type,
+// the access modes they can grant and the attributes their matchers support.
 type AcpHeaderHandler struct {
 	targetExtractor TargetExtractor
 	strategy        AuxiliaryIdentifierStrategy
@@ -34,23 +38,17 @@ func NewAcpHeaderHandler(targetExtractor TargetExtractor, strategy AuxiliaryIden
 	}
 }
 
-// Handle sets the Link header if the identifier is auxiliary.
+// Handle adds the Link headers if the target is an Access Control Resource.
 func (h *AcpHeaderHandler) Handle(w http.ResponseWriter, r *http.Request) {
-	identifier, err := h.targetExtractor.HandleSafe(r)
-	if err != nil {
-		return
-	}
-	if !h.strategy.IsAuxiliaryIdentifier(identifier) {
+	identifier, err := h.targetExtractor.Handle(r)
+	if err != nil || !h.strategy.IsAuxiliaryIdentifier(identifier) {
 		return
 	}
-	linkValues := []string{
-		fmt.Sprintf("<%s>; rel=\"type\"", "https://www.w3.org/ns/solid/acp#AccessControlResource"),
-	}
+	w.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"type\"", vocabularies.ACP.AccessControlResource.Value()))
 	for _, mode := range h.modes {
-		linkValues = append(linkValues, fmt.Sprintf("<%s>; rel=\"%s\"", mode, "grant"))
+		w.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"%s\"", mode, vocabularies.ACP.Grant.Value()))
 	}
-	for _, attr := range h.attributes {
-		linkValues = append(linkValues, fmt.Sprintf("<%s>; rel=\"%s\"", attr, "attribute"))
+	for _, attribute := range h.attributes {
+		w.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"%s\"", attribute, vocabularies.ACP.Attribute.Value()))
 	}
-	w.Header().Add("Link", strings.Join(linkValues, ", "))
 }
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/websocket"
+
+	"solid-go/internal/authentication"
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/server/notifications"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+)
+
+// NotificationHttpHandler answers the requests to the subscription service of a notification channel type.
+// WebSocket upgrade requests go to the listener of the channel type, if it has one,
+// other requests to the subscription handler.
+// All other requests are passed to the wrapped handler.
+type NotificationHttpHandler struct {
+	path         string
+	subscription HttpHandler
+	listener     HttpHandler
+	handler      HttpHandler
+}
+
+// NewNotificationHttpHandler creates a new NotificationHttpHandler for the subscription service at the URL
+func NewNotificationHttpHandler(serviceURL string, subscription, listener, handler HttpHandler) (*NotificationHttpHandler, error) {
+	parsed, err := url.Parse(serviceURL)
+	if err != nil {
+		return nil, err
+	}
+	return &NotificationHttpHandler{path: parsed.Path, subscription: subscription, listener: listener, handler: handler}, nil
+}
+
+// HandleSafe implements HttpHandler.HandleSafe
+func (h *NotificationHttpHandler) HandleSafe(w http.ResponseWriter, r *http.Request) error {
+	switch {
+	case r.URL.Path != h.path:
+		return h.handler.HandleSafe(w, r)
+	case h.listener != nil && websocket.IsWebSocketUpgrade(r):
+		return h.listener.HandleSafe(w, r)
+	default:
+		return h.subscription.HandleSafe(w, r)
+	}
+}
+
+// SubscriptionHttpHandler creates notification channels from the JSON-LD subscription requests POSTed to it,
+// and describes the subscription service on GET and HEAD requests.
+type SubscriptionHttpHandler struct {
+	credentialsExtractor authentication.CredentialsExtractor
+	subscriber           *notifications.NotificationSubscriber
+}
+
+// NewSubscriptionHttpHandler creates a new SubscriptionHttpHandler
+func NewSubscriptionHttpHandler(credentialsExtractor authentication.CredentialsExtractor,
+	subscriber *notifications.NotificationSubscriber) *SubscriptionHttpHandler {
+	return &SubscriptionHttpHandler{credentialsExtractor: credentialsExtractor, subscriber: subscriber}
+}
+
+// Handle implements OperationHttpHandler.Handle
+func (h *SubscriptionHttpHandler) Handle(input OperationHttpHandlerInput) (*response.ResponseDescription, error) {
+	operation := input.Operation
+	channelType := h.subscriber.ChannelType()
+	var body interface{}
+	switch operation.Method {
+	case "GET", "HEAD":
+		body = map[string]interface{}{
+			"@context":    []string{notifications.NotificationContext},
+			"id":          channelType.Path(),
+			"channelType": channelType.Type(),
+		}
+	case "POST":
+		contentType := ""
+		if operation.Body != nil {
+			contentType = strings.TrimSpace(strings.SplitN(operation.Body.GetMetadata().ContentType(), ";", 2)[0])
+		}
+		if contentType != util.JSONLD && contentType != util.ApplicationJSON {
+			return nil, errors.NewUnsupportedMediaTypeError("Subscription requests have to be JSON-LD", nil)
+		}
+		credentials, err := h.credentialsExtractor.Extract(input.Request)
+		if err != nil {
+			return nil, err
+		}
+		channel, err := h.subscriber.Subscribe(credentials, operation.Body.GetData())
+		if err != nil {
+			return nil, err
+		}
+		body = channel
+	default:
+		return nil, errors.NewMethodNotAllowedError(operation.Method+" is not allowed on a subscription service",
+			[]string{"GET", "HEAD", "POST"})
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	metadata := representation.NewRepresentationMetadata(operation.Target.Path).SetContentType(util.JSONLD)
+	if operation.Method == "HEAD" {
+		return &response.NewOkResponseDescription(metadata, nil).ResponseDescription, nil
+	}
+	return &response.NewOkResponseDescription(metadata, bytes.NewReader(data)).ResponseDescription, nil
+}
@@ -1,17 +1,32 @@
 package websocketchannel2023
 
-// WebSocket2023Emitter is a placeholder for the Go equivalent of WebSocket2023Emitter.ts.
+import (
+	"encoding/json"
+
+	"solid-go/internal/server/notifications"
+)
+
+// WebSocket2023Emitter sends notifications as JSON-LD to all WebSockets of the channel.
+// WebSockets that can not keep up are closed.
 type WebSocket2023Emitter struct {
-	// Add fields as needed for WebSocket 2023 emitter logic
+	sockets *WebSocketMap
 }
 
-// NewWebSocket2023Emitter creates a new WebSocket2023Emitter.
-func NewWebSocket2023Emitter() *WebSocket2023Emitter {
-	return &WebSocket2023Emitter{}
+// NewWebSocket2023Emitter creates a new WebSocket2023Emitter
+func NewWebSocket2023Emitter(sockets *WebSocketMap) *WebSocket2023Emitter {
+	return &WebSocket2023Emitter{sockets: sockets}
 }
 
-// Emit emits a WebSocket notification (placeholder implementation).
-func (e *WebSocket2023Emitter) Emit(notification interface{}) error {
-	// TODO: Implement WebSocket notification emitting logic
+// Emit implements notifications.NotificationEmitter.Emit
+func (e *WebSocket2023Emitter) Emit(channel *notifications.NotificationChannel, notification *notifications.Notification) error {
+	message, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	for _, socket := range e.sockets.get(channel.ID) {
+		if !socket.send(message) {
+			socket.close()
+		}
+	}
 	return nil
 }
@@ -1,17 +1,43 @@
 package websocketchannel2023
 
-// WebSocket2023Handler is a placeholder for the Go equivalent of WebSocket2023Handler.ts.
+import (
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"solid-go/internal/server/notifications"
+)
+
+// maxMessageSize is the size of the messages clients can send, which are ignored
+const maxMessageSize = 1024
+
+// WebSocket2023Handler receives the notifications of a channel on a WebSocket
+// until the client closes it or the channel expires
 type WebSocket2023Handler struct {
-	// Add fields as needed for WebSocket 2023 handler logic
+	storer *WebSocket2023Storer
 }
 
-// NewWebSocket2023Handler creates a new WebSocket2023Handler.
-func NewWebSocket2023Handler() *WebSocket2023Handler {
-	return &WebSocket2023Handler{}
+// NewWebSocket2023Handler creates a new WebSocket2023Handler
+func NewWebSocket2023Handler(storer *WebSocket2023Storer) *WebSocket2023Handler {
+	return &WebSocket2023Handler{storer: storer}
 }
 
-// HandleConnection handles a WebSocket connection (placeholder implementation).
-func (h *WebSocket2023Handler) HandleConnection(conn interface{}) error {
-	// TODO: Implement connection handling logic
-	return nil
+// Handle keeps the connection open for the channel, it returns once the connection is closed
+func (h *WebSocket2023Handler) Handle(channel *notifications.NotificationChannel, conn *websocket.Conn) {
+	socket := newWebSocket(conn)
+	h.storer.store(channel, socket)
+	defer h.storer.remove(channel, socket)
+	defer socket.close()
+	if !channel.EndAt.IsZero() {
+		timer := time.AfterFunc(time.Until(channel.EndAt), socket.close)
+		defer timer.Stop()
+	}
+
+	// Reading handles the control messages, such as the close message of the client
+	conn.SetReadLimit(maxMessageSize)
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
 }
@@ -1,17 +1,43 @@
 package websocketchannel2023
 
-// WebSocket2023Listener is a placeholder for the Go equivalent of WebSocket2023Listener.ts.
+import (
+	"net/http"
+
+	"github.com/gorilla/websocket"
+
+	"solid-go/internal/server/notifications"
+)
+
+// WebSocket2023Listener accepts the WebSocket upgrade requests to the receiveFrom URLs of WebSocketChannel2023 channels
 type WebSocket2023Listener struct {
-	// Add fields as needed for WebSocket 2023 listener logic
+	storage  notifications.NotificationChannelStorage
+	handler  *WebSocket2023Handler
+	upgrader websocket.Upgrader
 }
 
-// NewWebSocket2023Listener creates a new WebSocket2023Listener.
-func NewWebSocket2023Listener() *WebSocket2023Listener {
-	return &WebSocket2023Listener{}
+// NewWebSocket2023Listener creates a new WebSocket2023Listener
+func NewWebSocket2023Listener(storage notifications.NotificationChannelStorage, handler *WebSocket2023Handler) *WebSocket2023Listener {
+	return &WebSocket2023Listener{
+		storage: storage,
+		handler: handler,
+		// Applications of any origin can receive notifications, the unguessable channel identifier is the credential
+		upgrader: websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }},
+	}
 }
 
-// Listen starts listening for WebSocket connections (placeholder implementation).
-func (l *WebSocket2023Listener) Listen() error {
-	// TODO: Implement listening logic
+// HandleSafe upgrades the request if it is for an existing WebSocketChannel2023 channel
+// and handles the WebSocket until it closes
+func (l *WebSocket2023Listener) HandleSafe(w http.ResponseWriter, r *http.Request) error {
+	channel := l.storage.Get(parseWebSocketRequest(r))
+	if channel == nil || channel.ReceiveFrom == "" {
+		http.Error(w, "Unknown notification channel", http.StatusNotFound)
+		return nil
+	}
+	conn, err := l.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already responded with an error
+		return nil
+	}
+	l.handler.Handle(channel, conn)
 	return nil
 }
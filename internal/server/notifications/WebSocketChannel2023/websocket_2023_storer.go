@@ -1,17 +1,27 @@
 package websocketchannel2023
 
-// WebSocket2023Storer is a placeholder for the Go equivalent of WebSocket2023Storer.ts.
+import "solid-go/internal/server/notifications"
+
+// WebSocket2023Storer keeps the WebSockets of the channels in a WebSocketMap.
+// A channel is removed from the channel storage once its last WebSocket closes.
 type WebSocket2023Storer struct {
-	// Add fields as needed for WebSocket 2023 storer logic
+	sockets *WebSocketMap
+	storage notifications.NotificationChannelStorage
+}
+
+// NewWebSocket2023Storer creates a new WebSocket2023Storer
+func NewWebSocket2023Storer(sockets *WebSocketMap, storage notifications.NotificationChannelStorage) *WebSocket2023Storer {
+	return &WebSocket2023Storer{sockets: sockets, storage: storage}
 }
 
-// NewWebSocket2023Storer creates a new WebSocket2023Storer.
-func NewWebSocket2023Storer() *WebSocket2023Storer {
-	return &WebSocket2023Storer{}
+// store adds the WebSocket of the channel
+func (s *WebSocket2023Storer) store(channel *notifications.NotificationChannel, socket *webSocket) {
+	s.sockets.add(channel.ID, socket)
 }
 
-// StoreConnection stores a WebSocket connection (placeholder implementation).
-func (s *WebSocket2023Storer) StoreConnection(conn interface{}) error {
-	// TODO: Implement connection storing logic
-	return nil
+// remove removes the WebSocket of the channel, and the channel itself if it was the last one
+func (s *WebSocket2023Storer) remove(channel *notifications.NotificationChannel, socket *webSocket) {
+	if s.sockets.remove(channel.ID, socket) == 0 {
+		s.storage.Delete(channel.ID)
+	}
 }
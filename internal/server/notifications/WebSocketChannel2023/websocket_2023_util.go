@@ -1,15 +1,23 @@
 package websocketchannel2023
 
-// WebSocket2023Util is a placeholder for the Go equivalent of WebSocket2023Util.ts.
-type WebSocket2023Util struct{}
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
 
-// NewWebSocket2023Util creates a new WebSocket2023Util.
-func NewWebSocket2023Util() *WebSocket2023Util {
-	return &WebSocket2023Util{}
+// generateWebSocketUrl returns the WebSocket URL of the path with the channel identifier as auth parameter
+func generateWebSocketUrl(path, id string) string {
+	switch {
+	case strings.HasPrefix(path, "https://"):
+		path = "wss://" + strings.TrimPrefix(path, "https://")
+	case strings.HasPrefix(path, "http://"):
+		path = "ws://" + strings.TrimPrefix(path, "http://")
+	}
+	return path + "?auth=" + url.QueryEscape(id)
 }
 
-// UtilityMethod is a placeholder utility method (replace with actual methods as needed).
-func (u *WebSocket2023Util) UtilityMethod() error {
-	// TODO: Implement utility logic
-	return nil
+// parseWebSocketRequest returns the channel identifier of a WebSocket upgrade request
+func parseWebSocketRequest(r *http.Request) string {
+	return r.URL.Query().Get("auth")
 }
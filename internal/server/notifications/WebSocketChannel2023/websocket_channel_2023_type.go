@@ -1,17 +1,30 @@
+// Package websocketchannel2023 implements the WebSocketChannel2023 notification channel type,
+// which sends the notifications of a channel to the WebSockets that connect to its receiveFrom URL.
 package websocketchannel2023
 
-// WebSocketChannel2023Type is a placeholder for the Go equivalent of WebSocketChannel2023Type.ts.
+import (
+	"solid-go/internal/server/notifications"
+	"solid-go/internal/util/vocabularies"
+)
+
+// WebSocketChannel2023Type creates WebSocketChannel2023 channels,
+// whose receiveFrom URL is the WebSocket URL of the subscription service with the channel identifier
 type WebSocketChannel2023Type struct {
-	// Add fields as needed for WebSocket channel type logic
+	*notifications.BaseChannelType
 }
 
-// NewWebSocketChannel2023Type creates a new WebSocketChannel2023Type.
-func NewWebSocketChannel2023Type() *WebSocketChannel2023Type {
-	return &WebSocketChannel2023Type{}
+// NewWebSocketChannel2023Type creates a new WebSocketChannel2023Type with the given subscription service URL
+func NewWebSocketChannel2023Type(path string) *WebSocketChannel2023Type {
+	return &WebSocketChannel2023Type{BaseChannelType: notifications.NewBaseChannelType(
+		vocabularies.NOTIFY.WebSocketChannel2023.Value(), path, notifications.DefaultChannelLifetime)}
 }
 
-// DescribeType returns a description of the WebSocket channel type (placeholder implementation).
-func (w *WebSocketChannel2023Type) DescribeType() string {
-	// TODO: Implement type description logic
-	return ""
+// InitChannel implements notifications.NotificationChannelType.InitChannel
+func (t *WebSocketChannel2023Type) InitChannel(topic string) (*notifications.NotificationChannel, error) {
+	channel, err := t.BaseChannelType.InitChannel(topic)
+	if err != nil {
+		return nil, err
+	}
+	channel.ReceiveFrom = generateWebSocketUrl(t.Path(), channel.ID)
+	return channel, nil
 }
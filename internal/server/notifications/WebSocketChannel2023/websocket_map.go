@@ -1,17 +1,110 @@
 package websocketchannel2023
 
-// WebSocketMap is a placeholder for the Go equivalent of WebSocketMap.ts.
+import (
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// writeTimeout is how long writing a message to a WebSocket can take before the connection is closed
+const writeTimeout = 10 * time.Second
+
+// maxQueuedMessages is the number of messages that can wait for a slow WebSocket before it is closed
+const maxQueuedMessages = 64
+
+// webSocket is a connection on which a client receives the notifications of a channel.
+// Messages are queued and written by their own goroutine, so a slow client does not hold up the others.
+type webSocket struct {
+	conn     *websocket.Conn
+	messages chan []byte
+	done     chan struct{}
+	once     sync.Once
+}
+
+// newWebSocket wraps the connection and starts writing the messages that are sent to it
+func newWebSocket(conn *websocket.Conn) *webSocket {
+	socket := &webSocket{conn: conn, messages: make(chan []byte, maxQueuedMessages), done: make(chan struct{})}
+	go socket.write()
+	return socket
+}
+
+// send queues the message, it returns false if the socket is closed or too far behind
+func (s *webSocket) send(message []byte) bool {
+	select {
+	case <-s.done:
+		return false
+	case s.messages <- message:
+		return true
+	default:
+		return false
+	}
+}
+
+// write writes the queued messages until the socket is closed
+func (s *webSocket) write() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case message := <-s.messages:
+			s.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := s.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				s.close()
+				return
+			}
+		}
+	}
+}
+
+// close closes the connection, it can be called more than once
+func (s *webSocket) close() {
+	s.once.Do(func() {
+		close(s.done)
+		s.conn.Close()
+	})
+}
+
+// WebSocketMap keeps track of the WebSockets of every channel
 type WebSocketMap struct {
-	// Add fields as needed for WebSocket map logic
+	mu      sync.Mutex
+	sockets map[string]map[*webSocket]bool
 }
 
-// NewWebSocketMap creates a new WebSocketMap.
+// NewWebSocketMap creates a new WebSocketMap
 func NewWebSocketMap() *WebSocketMap {
-	return &WebSocketMap{}
+	return &WebSocketMap{sockets: make(map[string]map[*webSocket]bool)}
+}
+
+// add adds a WebSocket of the channel
+func (m *WebSocketMap) add(id string, socket *webSocket) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sockets[id] == nil {
+		m.sockets[id] = make(map[*webSocket]bool)
+	}
+	m.sockets[id][socket] = true
+}
+
+// remove removes a WebSocket of the channel and returns how many the channel still has
+func (m *WebSocketMap) remove(id string, socket *webSocket) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sockets[id], socket)
+	remaining := len(m.sockets[id])
+	if remaining == 0 {
+		delete(m.sockets, id)
+	}
+	return remaining
 }
 
-// AddConnection adds a WebSocket connection (placeholder implementation).
-func (m *WebSocketMap) AddConnection(conn interface{}) error {
-	// TODO: Implement connection adding logic
-	return nil
+// get returns the WebSockets of the channel
+func (m *WebSocketMap) get(id string) []*webSocket {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sockets := make([]*webSocket, 0, len(m.sockets[id]))
+	for socket := range m.sockets[id] {
+		sockets = append(sockets, socket)
+	}
+	return sockets
 }
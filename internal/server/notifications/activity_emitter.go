@@ -1,13 +1,9 @@
 package notifications
 
-// ActivityEmitter is a placeholder for the Go equivalent of ActivityEmitter.ts.
-type ActivityEmitter struct {
-	// Add fields as needed for activity emitting
-}
+import "solid-go/internal/storage"
 
-// NewActivityEmitter creates a new ActivityEmitter.
-func NewActivityEmitter() *ActivityEmitter {
-	return &ActivityEmitter{}
+// ActivityEmitter reports the resources that were changed by every successful operation,
+// such as storage.MonitoringStore
+type ActivityEmitter interface {
+	OnChange(listener storage.ChangeListener)
 }
-
-// Add methods for activity emitting as needed.
@@ -1,13 +1,48 @@
 package notifications
 
-// BaseChannelType is a placeholder for the Go equivalent of BaseChannelType.ts.
+import (
+	"time"
+
+	"solid-go/internal/util/identifiers"
+)
+
+// DefaultChannelLifetime is how long channels exist if the channel type does not end them earlier
+const DefaultChannelLifetime = 2 * time.Hour
+
+// BaseChannelType creates channels with a random identifier within its subscription service
+// that expire after the lifetime of the type.
+// It can be embedded by channel types that add to the channels it creates.
 type BaseChannelType struct {
-	// Add fields as needed for base channel type
+	channelType string
+	path        string
+	lifetime    time.Duration
+}
+
+// NewBaseChannelType creates a new BaseChannelType with the given IRI, subscription service URL and channel lifetime
+func NewBaseChannelType(channelType, path string, lifetime time.Duration) *BaseChannelType {
+	return &BaseChannelType{channelType: channelType, path: path, lifetime: lifetime}
+}
+
+// Type implements NotificationChannelType.Type
+func (t *BaseChannelType) Type() string {
+	return t.channelType
 }
 
-// NewBaseChannelType creates a new BaseChannelType.
-func NewBaseChannelType() *BaseChannelType {
-	return &BaseChannelType{}
+// Path implements NotificationChannelType.Path
+func (t *BaseChannelType) Path() string {
+	return t.path
 }
 
-// Add methods for base channel type as needed.
+// InitChannel implements NotificationChannelType.InitChannel
+func (t *BaseChannelType) InitChannel(topic string) (*NotificationChannel, error) {
+	id, err := identifiers.NewIdentifierUtil().GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	return &NotificationChannel{
+		ID:    t.path + id,
+		Type:  t.channelType,
+		Topic: topic,
+		EndAt: time.Now().Add(t.lifetime),
+	}, nil
+}
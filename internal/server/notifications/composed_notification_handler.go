@@ -1,13 +1,28 @@
 package notifications
 
-// ComposedNotificationHandler is a placeholder for the Go equivalent of ComposedNotificationHandler.ts.
+import "solid-go/internal/util/n3"
+
+// NotificationGenerator generates the notification of an activity on a topic
+type NotificationGenerator interface {
+	Generate(topic string, activity n3.Term) (*Notification, error)
+}
+
+// ComposedNotificationHandler generates the notification of an activity and emits it through the channel
 type ComposedNotificationHandler struct {
-	// Add fields as needed for composed notification handling
+	generator NotificationGenerator
+	emitter   NotificationEmitter
 }
 
-// NewComposedNotificationHandler creates a new ComposedNotificationHandler.
-func NewComposedNotificationHandler() *ComposedNotificationHandler {
-	return &ComposedNotificationHandler{}
+// NewComposedNotificationHandler creates a new ComposedNotificationHandler
+func NewComposedNotificationHandler(generator NotificationGenerator, emitter NotificationEmitter) *ComposedNotificationHandler {
+	return &ComposedNotificationHandler{generator: generator, emitter: emitter}
 }
 
-// Add methods for composed notification handling as needed.
+// Handle implements NotificationHandler.Handle
+func (h *ComposedNotificationHandler) Handle(channel *NotificationChannel, activity n3.Term) error {
+	notification, err := h.generator.Generate(channel.Topic, activity)
+	if err != nil {
+		return err
+	}
+	return h.emitter.Emit(channel, notification)
+}
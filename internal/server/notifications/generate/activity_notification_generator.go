@@ -1,17 +1,38 @@
 package generate
 
-// ActivityNotificationGenerator is a placeholder for the Go equivalent of ActivityNotificationGenerator.ts.
+import (
+	"io"
+	"time"
+
+	"solid-go/internal/http/input/conditions"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/server/notifications"
+	"solid-go/internal/storage"
+	"solid-go/internal/util/n3"
+)
+
+// ActivityNotificationGenerator generates notifications with the current state of the topic,
+// which is the ETag of the resource in the store
 type ActivityNotificationGenerator struct {
-	// Add fields as needed for activity notification generation
+	store       storage.ResourceStore
+	eTagHandler conditions.ETagHandler
 }
 
-// NewActivityNotificationGenerator creates a new ActivityNotificationGenerator.
-func NewActivityNotificationGenerator() *ActivityNotificationGenerator {
-	return &ActivityNotificationGenerator{}
+// NewActivityNotificationGenerator creates a new ActivityNotificationGenerator
+func NewActivityNotificationGenerator(store storage.ResourceStore, eTagHandler conditions.ETagHandler) *ActivityNotificationGenerator {
+	return &ActivityNotificationGenerator{store: store, eTagHandler: eTagHandler}
 }
 
-// Generate creates an activity notification (placeholder implementation).
-func (g *ActivityNotificationGenerator) Generate(activity interface{}) (string, error) {
-	// TODO: Implement activity notification generation logic
-	return "", nil
+// Generate implements notifications.NotificationGenerator
+func (g *ActivityNotificationGenerator) Generate(topic string, activity n3.Term) (*notifications.Notification, error) {
+	rep, err := g.store.GetRepresentation(representation.ResourceIdentifier{Path: topic}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if closer, ok := rep.GetData().(io.Closer); ok {
+		closer.Close()
+	}
+	notification := notifications.NewNotification(activity.Value(), topic, time.Now())
+	notification.State = g.eTagHandler.GetETag(rep.GetMetadata())
+	return notification, nil
 }
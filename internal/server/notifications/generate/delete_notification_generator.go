@@ -1,17 +1,21 @@
 package generate
 
-// DeleteNotificationGenerator is a placeholder for the Go equivalent of DeleteNotificationGenerator.ts.
-type DeleteNotificationGenerator struct {
-	// Add fields as needed for delete notification generation
-}
+import (
+	"time"
+
+	"solid-go/internal/server/notifications"
+	"solid-go/internal/util/n3"
+)
+
+// DeleteNotificationGenerator generates notifications without state, for topics that no longer exist
+type DeleteNotificationGenerator struct{}
 
-// NewDeleteNotificationGenerator creates a new DeleteNotificationGenerator.
+// NewDeleteNotificationGenerator creates a new DeleteNotificationGenerator
 func NewDeleteNotificationGenerator() *DeleteNotificationGenerator {
 	return &DeleteNotificationGenerator{}
 }
 
-// GenerateDelete creates a delete notification (placeholder implementation).
-func (g *DeleteNotificationGenerator) GenerateDelete(resource interface{}) (string, error) {
-	// TODO: Implement delete notification generation logic
-	return "", nil
+// Generate implements notifications.NotificationGenerator
+func (g *DeleteNotificationGenerator) Generate(topic string, activity n3.Term) (*notifications.Notification, error) {
+	return notifications.NewNotification(activity.Value(), topic, time.Now()), nil
 }
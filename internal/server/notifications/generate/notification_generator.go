@@ -1,17 +1,28 @@
+// Package generate creates the notifications of the activities that happen to the topics of channels.
 package generate
 
-// NotificationGenerator is a placeholder for the Go equivalent of NotificationGenerator.ts.
+import (
+	"solid-go/internal/server/notifications"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
+)
+
+// NotificationGenerator generates notifications with the delete generator for Delete activities,
+// since their topic no longer exists, and with the activity generator for all others.
 type NotificationGenerator struct {
-	// Add fields as needed for notification generation
+	activity notifications.NotificationGenerator
+	delete   notifications.NotificationGenerator
 }
 
-// NewNotificationGenerator creates a new NotificationGenerator.
-func NewNotificationGenerator() *NotificationGenerator {
-	return &NotificationGenerator{}
+// NewNotificationGenerator creates a new NotificationGenerator
+func NewNotificationGenerator(activity, delete notifications.NotificationGenerator) *NotificationGenerator {
+	return &NotificationGenerator{activity: activity, delete: delete}
 }
 
-// GenerateNotification creates a notification (placeholder implementation).
-func (g *NotificationGenerator) GenerateNotification(data interface{}) (string, error) {
-	// TODO: Implement notification generation logic
-	return "", nil
+// Generate implements notifications.NotificationGenerator
+func (g *NotificationGenerator) Generate(topic string, activity n3.Term) (*notifications.Notification, error) {
+	if activity.Equals(vocabularies.AS.Delete) {
+		return g.delete.Generate(topic, activity)
+	}
+	return g.activity.Generate(topic, activity)
 }
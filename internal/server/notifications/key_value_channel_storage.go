@@ -1,13 +1,97 @@
 package notifications
 
-// KeyValueChannelStorage is a placeholder for the Go equivalent of KeyValueChannelStorage.ts.
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultMaxChannels is the number of channels a KeyValueChannelStorage holds at most
+const DefaultMaxChannels = 10000
+
+// KeyValueChannelStorage keeps channels in memory, keyed by their identifier and indexed by their topic.
+// Expired channels are removed when new ones are added.
 type KeyValueChannelStorage struct {
-	// Add fields as needed for key-value channel storage
+	maxChannels int
+	now         func() time.Time
+
+	mu       sync.Mutex
+	channels map[string]*NotificationChannel
+	topics   map[string]map[string]bool
+}
+
+// NewKeyValueChannelStorage creates a new KeyValueChannelStorage holding at most maxChannels channels
+func NewKeyValueChannelStorage(maxChannels int) *KeyValueChannelStorage {
+	return &KeyValueChannelStorage{
+		maxChannels: maxChannels,
+		now:         time.Now,
+		channels:    make(map[string]*NotificationChannel),
+		topics:      make(map[string]map[string]bool),
+	}
+}
+
+// Get implements NotificationChannelStorage.Get
+func (s *KeyValueChannelStorage) Get(id string) *NotificationChannel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	channel, ok := s.channels[id]
+	if !ok || channel.Expired(s.now()) {
+		return nil
+	}
+	return channel
+}
+
+// Add implements NotificationChannelStorage.Add
+func (s *KeyValueChannelStorage) Add(channel *NotificationChannel) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for id, existing := range s.channels {
+		if existing.Expired(now) {
+			s.delete(id)
+		}
+	}
+	if len(s.channels) >= s.maxChannels {
+		return fmt.Errorf("there are already %d notification channels", len(s.channels))
+	}
+	s.channels[channel.ID] = channel
+	if s.topics[channel.Topic] == nil {
+		s.topics[channel.Topic] = make(map[string]bool)
+	}
+	s.topics[channel.Topic][channel.ID] = true
+	return nil
 }
 
-// NewKeyValueChannelStorage creates a new KeyValueChannelStorage.
-func NewKeyValueChannelStorage() *KeyValueChannelStorage {
-	return &KeyValueChannelStorage{}
+// Delete implements NotificationChannelStorage.Delete
+func (s *KeyValueChannelStorage) Delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delete(id)
 }
 
-// Add methods for key-value channel storage as needed.
+// GetAll implements NotificationChannelStorage.GetAll
+func (s *KeyValueChannelStorage) GetAll(topic string) []*NotificationChannel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	var channels []*NotificationChannel
+	for id := range s.topics[topic] {
+		if channel := s.channels[id]; !channel.Expired(now) {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+// delete removes a channel from both maps, the lock has to be held
+func (s *KeyValueChannelStorage) delete(id string) {
+	channel, ok := s.channels[id]
+	if !ok {
+		return
+	}
+	delete(s.channels, id)
+	delete(s.topics[channel.Topic], id)
+	if len(s.topics[channel.Topic]) == 0 {
+		delete(s.topics, channel.Topic)
+	}
+}
@@ -0,0 +1,42 @@
+package notifications
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKeyValueChannelStorage(t *testing.T) {
+	now := time.Now()
+	storage := NewKeyValueChannelStorage(2)
+	storage.now = func() time.Time { return now }
+	channel := &NotificationChannel{ID: "http://example.org/1", Topic: "http://example.org/doc", EndAt: now.Add(time.Minute)}
+	expiring := &NotificationChannel{ID: "http://example.org/2", Topic: "http://example.org/doc", EndAt: now.Add(time.Second)}
+	if err := storage.Add(channel); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := storage.Add(expiring); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if got := storage.GetAll("http://example.org/doc"); len(got) != 2 {
+		t.Errorf("GetAll() = %d channels, want 2", len(got))
+	}
+	if err := storage.Add(&NotificationChannel{ID: "http://example.org/3", EndAt: now.Add(time.Minute)}); err == nil {
+		t.Error("Add() beyond the maximum should fail")
+	}
+
+	now = now.Add(2 * time.Second)
+	if storage.Get(expiring.ID) != nil {
+		t.Error("Get() should not return expired channels")
+	}
+	if got := storage.GetAll("http://example.org/doc"); len(got) != 1 || got[0] != channel {
+		t.Errorf("GetAll() = %v, want only the unexpired channel", got)
+	}
+	if err := storage.Add(&NotificationChannel{ID: "http://example.org/3", EndAt: now.Add(time.Minute)}); err != nil {
+		t.Errorf("Add() should replace expired channels, error = %v", err)
+	}
+
+	storage.Delete(channel.ID)
+	if storage.Get(channel.ID) != nil || len(storage.GetAll("http://example.org/doc")) != 0 {
+		t.Error("Delete() should remove the channel")
+	}
+}
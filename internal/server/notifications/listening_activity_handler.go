@@ -1,13 +1,38 @@
 package notifications
 
-// ListeningActivityHandler is a placeholder for the Go equivalent of ListeningActivityHandler.ts.
+import (
+	"solid-go/internal/logging"
+	"solid-go/internal/storage"
+)
+
+// ListeningActivityHandler listens to the changes of an ActivityEmitter
+// and notifies every channel whose topic changed.
+// A channel whose notification can not be sent does not stop the others from being notified.
 type ListeningActivityHandler struct {
-	// Add fields as needed for listening activity handling
+	storage NotificationChannelStorage
+	handler NotificationHandler
+	logger  logging.Logger
 }
 
-// NewListeningActivityHandler creates a new ListeningActivityHandler.
-func NewListeningActivityHandler() *ListeningActivityHandler {
-	return &ListeningActivityHandler{}
+// NewListeningActivityHandler creates a new ListeningActivityHandler that listens to the emitter
+func NewListeningActivityHandler(emitter ActivityEmitter, storage NotificationChannelStorage,
+	handler NotificationHandler, logger logging.Logger) *ListeningActivityHandler {
+	h := &ListeningActivityHandler{storage: storage, handler: handler, logger: logger}
+	emitter.OnChange(h.handle)
+	return h
 }
 
-// Add methods for listening activity handling as needed.
+// handle notifies the channels of every changed resource
+func (h *ListeningActivityHandler) handle(changes storage.ChangeMap) {
+	for _, identifier := range changes.Identifiers() {
+		activity, ok := changes.Activity(identifier)
+		if !ok {
+			continue
+		}
+		for _, channel := range h.storage.GetAll(identifier.Path) {
+			if err := h.handler.Handle(channel, activity); err != nil {
+				h.logger.Error("Error notifying channel " + channel.ID + ": " + err.Error())
+			}
+		}
+	}
+}
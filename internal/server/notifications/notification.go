@@ -1,13 +1,41 @@
+// Package notifications implements the Solid Notifications Protocol,
+// which lets clients subscribe to channels that tell them about changes to resources.
 package notifications
 
-// Notification is a placeholder for the Go equivalent of Notification.ts.
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The JSON-LD contexts of notifications and notification channels
+const (
+	ActivityStreamsContext = "https://www.w3.org/ns/activitystreams"
+	NotificationContext    = "https://www.w3.org/ns/solid/notification/v1"
+)
+
+// activityStreamsNamespace is the namespace of the activity types
+const activityStreamsNamespace = ActivityStreamsContext + "#"
+
+// Notification is an Activity Streams 2.0 notification about a change to the topic of a channel
 type Notification struct {
-	// Add fields as needed for notifications
+	Context []string `json:"@context"`
+	ID      string   `json:"id"`
+	// Type is the activity, such as Create, Update or Delete
+	Type   string `json:"type"`
+	Object string `json:"object"`
+	// State is the ETag of the topic after the change, it is empty if the topic no longer exists
+	State     string `json:"state,omitempty"`
+	Published string `json:"published"`
 }
 
-// NewNotification creates a new Notification.
-func NewNotification() *Notification {
-	return &Notification{}
+// NewNotification creates a notification of the activity, given as Activity Streams IRI, on the topic
+func NewNotification(activity, topic string, published time.Time) *Notification {
+	return &Notification{
+		Context:   []string{ActivityStreamsContext, NotificationContext},
+		ID:        fmt.Sprintf("urn:%d:%s", published.UnixMilli(), topic),
+		Type:      strings.TrimPrefix(activity, activityStreamsNamespace),
+		Object:    topic,
+		Published: published.UTC().Format("2006-01-02T15:04:05.000Z07:00"),
+	}
 }
-
-// Add methods for notifications as needed.
@@ -1,13 +1,40 @@
 package notifications
 
-// NotificationChannel is a placeholder for the Go equivalent of NotificationChannel.ts.
+import (
+	"encoding/json"
+	"time"
+)
+
+// NotificationChannel is a channel through which notifications about changes to its topic are sent
 type NotificationChannel struct {
-	// Add fields as needed for notification channels
+	// ID identifies the channel, it is a URL within the subscription service of its type
+	ID string
+	// Type is the IRI of the channel type
+	Type  string
+	Topic string
+	// ReceiveFrom is the URL clients receive notifications from, for channel types that have one
+	ReceiveFrom string
+	// EndAt is when the channel expires
+	EndAt time.Time
 }
 
-// NewNotificationChannel creates a new NotificationChannel.
-func NewNotificationChannel() *NotificationChannel {
-	return &NotificationChannel{}
+// Expired checks whether the channel expired at the given time
+func (c *NotificationChannel) Expired(now time.Time) bool {
+	return !c.EndAt.IsZero() && !now.Before(c.EndAt)
 }
 
-// Add methods for notification channels as needed.
+// MarshalJSON serializes the channel as the JSON-LD description that is sent to the client that created it
+func (c *NotificationChannel) MarshalJSON() ([]byte, error) {
+	description := struct {
+		Context     []string `json:"@context"`
+		ID          string   `json:"id"`
+		Type        string   `json:"type"`
+		Topic       string   `json:"topic"`
+		ReceiveFrom string   `json:"receiveFrom,omitempty"`
+		EndAt       string   `json:"endAt,omitempty"`
+	}{Context: []string{NotificationContext}, ID: c.ID, Type: c.Type, Topic: c.Topic, ReceiveFrom: c.ReceiveFrom}
+	if !c.EndAt.IsZero() {
+		description.EndAt = c.EndAt.UTC().Format(time.RFC3339)
+	}
+	return json.Marshal(description)
+}
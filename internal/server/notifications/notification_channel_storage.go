@@ -1,13 +1,13 @@
 package notifications
 
-// NotificationChannelStorage is a placeholder for the Go equivalent of NotificationChannelStorage.ts.
-type NotificationChannelStorage struct {
-	// Add fields as needed for notification channel storage
+// NotificationChannelStorage stores the channels that notifications are sent through
+type NotificationChannelStorage interface {
+	// Get returns the channel with the identifier, or nil if there is none or it expired
+	Get(id string) *NotificationChannel
+	// Add stores a channel
+	Add(channel *NotificationChannel) error
+	// Delete removes a channel
+	Delete(id string)
+	// GetAll returns the channels that have the topic and did not expire
+	GetAll(topic string) []*NotificationChannel
 }
-
-// NewNotificationChannelStorage creates a new NotificationChannelStorage.
-func NewNotificationChannelStorage() *NotificationChannelStorage {
-	return &NotificationChannelStorage{}
-}
-
-// Add methods for notification channel storage as needed.
@@ -1,13 +1,11 @@
 package notifications
 
-// NotificationChannelType is a placeholder for the Go equivalent of NotificationChannelType.ts.
-type NotificationChannelType struct {
-	// Add fields as needed for notification channel types
+// NotificationChannelType creates the channels of one of the channel types of the Solid Notifications Protocol
+type NotificationChannelType interface {
+	// Type returns the IRI of the channel type
+	Type() string
+	// Path returns the URL of the subscription service that creates channels of this type
+	Path() string
+	// InitChannel creates a channel for the topic, without storing it
+	InitChannel(topic string) (*NotificationChannel, error)
 }
-
-// NewNotificationChannelType creates a new NotificationChannelType.
-func NewNotificationChannelType() *NotificationChannelType {
-	return &NotificationChannelType{}
-}
-
-// Add methods for notification channel types as needed.
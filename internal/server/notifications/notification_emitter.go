@@ -1,17 +1,6 @@
 package notifications
 
-// NotificationEmitter is a placeholder for the Go equivalent of NotificationEmitter.ts.
-type NotificationEmitter struct {
-	// Add fields as needed for notification emitting
-}
-
-// NewNotificationEmitter creates a new NotificationEmitter.
-func NewNotificationEmitter() *NotificationEmitter {
-	return &NotificationEmitter{}
-}
-
-// Emit sends a notification (placeholder implementation).
-func (e *NotificationEmitter) Emit(notification *Notification) error {
-	// TODO: Implement notification emitting logic
-	return nil
+// NotificationEmitter sends notifications through channels of the type it supports
+type NotificationEmitter interface {
+	Emit(channel *NotificationChannel, notification *Notification) error
 }
@@ -1,17 +1,8 @@
 package notifications
 
-// NotificationHandler is a placeholder for the Go equivalent of NotificationHandler.ts.
-type NotificationHandler struct {
-	// Add fields as needed for notification handling
-}
-
-// NewNotificationHandler creates a new NotificationHandler.
-func NewNotificationHandler() *NotificationHandler {
-	return &NotificationHandler{}
-}
+import "solid-go/internal/util/n3"
 
-// Handle processes a notification (placeholder implementation).
-func (h *NotificationHandler) Handle(notification *Notification) error {
-	// TODO: Implement notification handling logic
-	return nil
+// NotificationHandler notifies a channel that an activity happened to its topic
+type NotificationHandler interface {
+	Handle(channel *NotificationChannel, activity n3.Term) error
 }
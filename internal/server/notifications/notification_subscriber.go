@@ -1,17 +1,108 @@
 package notifications
 
-// NotificationSubscriber is a placeholder for the Go equivalent of NotificationSubscriber.ts.
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"solid-go/internal/authentication"
+	"solid-go/internal/authorization"
+	"solid-go/internal/authorization/permissions"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
+
+// notifyNamespace is the namespace of the channel types, which the notification context maps their names to
+const notifyNamespace = "http://www.w3.org/ns/solid/notifications#"
+
+// maxSubscriptionSize is the size in bytes a subscription request can have at most
+const maxSubscriptionSize = 64 * 1024
+
+// IdentifierStrategy determines which identifiers belong to the storage
+type IdentifierStrategy interface {
+	SupportsIdentifier(identifier representation.ResourceIdentifier) bool
+}
+
+// subscription is a JSON-LD subscription request, the notification context is assumed
+type subscription struct {
+	Type  string `json:"type"`
+	Topic string `json:"topic"`
+}
+
+// NotificationSubscriber creates channels of one type from subscription requests.
+// Agents can only subscribe to topics in the storage that they can read.
 type NotificationSubscriber struct {
-	// Add fields as needed for notification subscribing
+	channelType        NotificationChannelType
+	storage            NotificationChannelStorage
+	identifierStrategy IdentifierStrategy
+	permissionReader   authorization.PermissionReader
+	authorizer         authorization.Authorizer
+}
+
+// NewNotificationSubscriber creates a new NotificationSubscriber
+func NewNotificationSubscriber(channelType NotificationChannelType, storage NotificationChannelStorage,
+	identifierStrategy IdentifierStrategy, permissionReader authorization.PermissionReader,
+	authorizer authorization.Authorizer) *NotificationSubscriber {
+	return &NotificationSubscriber{
+		channelType:        channelType,
+		storage:            storage,
+		identifierStrategy: identifierStrategy,
+		permissionReader:   permissionReader,
+		authorizer:         authorizer,
+	}
 }
 
-// NewNotificationSubscriber creates a new NotificationSubscriber.
-func NewNotificationSubscriber() *NotificationSubscriber {
-	return &NotificationSubscriber{}
+// ChannelType returns the type of the channels the subscriber creates
+func (s *NotificationSubscriber) ChannelType() NotificationChannelType {
+	return s.channelType
+}
+
+// Subscribe creates and stores a channel for the subscription request in the body
+func (s *NotificationSubscriber) Subscribe(credentials *authentication.Credentials, body io.Reader) (*NotificationChannel, error) {
+	var request subscription
+	decoder := json.NewDecoder(io.LimitReader(body, maxSubscriptionSize))
+	if err := decoder.Decode(&request); err != nil {
+		return nil, errors.NewValidationError("Invalid subscription request: "+err.Error(), err)
+	}
+	channelType := request.Type
+	if !strings.Contains(channelType, ":") {
+		channelType = notifyNamespace + channelType
+	}
+	if channelType != s.channelType.Type() {
+		return nil, errors.NewUnprocessableEntityError("Unsupported channel type "+request.Type, nil)
+	}
+	topic := representation.ResourceIdentifier{Path: request.Topic}
+	if request.Topic == "" || !s.identifierStrategy.SupportsIdentifier(topic) {
+		return nil, errors.NewUnprocessableEntityError("The topic "+request.Topic+" is not a resource of this storage", nil)
+	}
+
+	if err := s.authorize(credentials, topic); err != nil {
+		return nil, err
+	}
+	channel, err := s.channelType.InitChannel(topic.Path)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.storage.Add(channel); err != nil {
+		return nil, errors.NewInternalError("Unable to store the notification channel", err)
+	}
+	return channel, nil
 }
 
-// Subscribe subscribes to notifications (placeholder implementation).
-func (s *NotificationSubscriber) Subscribe(channel *NotificationChannel) error {
-	// TODO: Implement notification subscribing logic
-	return nil
+// authorize checks that the credentials can read the topic
+func (s *NotificationSubscriber) authorize(credentials *authentication.Credentials, topic representation.ResourceIdentifier) error {
+	requestedModes := make(permissions.AccessMap)
+	requestedModes.Add(topic.Path, permissions.Read)
+	available, err := s.permissionReader.Read(authorization.PermissionReaderInput{
+		Credentials:    credentials,
+		RequestedModes: requestedModes,
+	})
+	if err != nil {
+		return err
+	}
+	return s.authorizer.Authorize(authorization.AuthorizerInput{
+		Credentials:          credentials,
+		RequestedModes:       requestedModes,
+		AvailablePermissions: available,
+	})
 }
@@ -1,3 +1,45 @@
 package server
 
-// This file is intentionally left empty because OperationHttpHandlerInput and OperationHttpHandler are already defined elsewhere in the codebase.
+import (
+	"net/http"
+
+	"solid-go/internal/authentication"
+	solidhttp "solid-go/internal/http"
+	"solid-go/internal/http/ldp"
+	"solid-go/internal/http/output/response"
+	"solid-go/internal/util/errors"
+)
+
+// OperationHttpHandlerInput contains the parsed Operation and the request it was parsed from.
+type OperationHttpHandlerInput struct {
+	Request   *http.Request
+	Operation *solidhttp.Operation
+	// Credentials are those of the agent that made the request, once they have been extracted
+	Credentials *authentication.Credentials
+}
+
+// OperationHttpHandler handles a parsed Operation and describes the response.
+type OperationHttpHandler interface {
+	Handle(input OperationHttpHandlerInput) (*response.ResponseDescription, error)
+}
+
+// LdpOperationHttpHandler executes the Operation with the first LDP OperationHandler that supports it.
+type LdpOperationHttpHandler struct {
+	handlers []ldp.OperationHandler
+}
+
+// NewLdpOperationHttpHandler creates a new LdpOperationHttpHandler
+func NewLdpOperationHttpHandler(handlers ...ldp.OperationHandler) *LdpOperationHttpHandler {
+	return &LdpOperationHttpHandler{handlers: handlers}
+}
+
+// Handle implements OperationHttpHandler.Handle
+func (h *LdpOperationHttpHandler) Handle(input OperationHttpHandlerInput) (*response.ResponseDescription, error) {
+	ldpInput := ldp.OperationHandlerInput{Operation: input.Operation}
+	for _, handler := range h.handlers {
+		if handler.CanHandle(ldpInput) == nil {
+			return handler.Handle(ldpInput)
+		}
+	}
+	return nil, errors.NewNotImplementedError("No handler supports the "+input.Operation.Method+" method", nil)
+}
@@ -1,50 +1,28 @@
 package server
 
 import (
-	"context"
-	"fmt"
-	"log"
 	"net/http"
-)
-
-type RequestParser interface {
-	HandleSafe(ctx context.Context, r *http.Request) (Operation, error)
-}
-
-type ErrorHandler interface {
-	HandleSafe(ctx context.Context, err error, r *http.Request) (ResponseDescription, error)
-}
-
-type ResponseWriter interface {
-	HandleSafe(ctx context.Context, w http.ResponseWriter, result ResponseDescription) error
-}
-
-type Operation struct {
-	Method string
-	Target struct{ Path string }
-	// Add fields as needed to represent the parsed operation
-}
 
-type InternalServerError struct {
-	Msg   string
-	Cause error
-}
-
-func (e *InternalServerError) Error() string {
-	return fmt.Sprintf("InternalServerError: %s (cause: %v)", e.Msg, e.Cause)
-}
+	"solid-go/internal/http/input"
+	"solid-go/internal/http/output"
+	errorhandler "solid-go/internal/http/output/error"
+	"solid-go/internal/http/output/response"
+)
 
+// ParsingHttpHandler parses requests into Operations, passes them to the OperationHttpHandler
+// and writes the result, or the description of the error that occurred, to the response.
 type ParsingHttpHandler struct {
-	requestParser    RequestParser
-	errorHandler     ErrorHandler
-	responseWriter   ResponseWriter
+	requestParser    input.RequestParser
+	errorHandler     errorhandler.ErrorHandler
+	responseWriter   output.ResponseWriter
 	operationHandler OperationHttpHandler
 }
 
+// NewParsingHttpHandler creates a new ParsingHttpHandler
 func NewParsingHttpHandler(
-	requestParser RequestParser,
-	errorHandler ErrorHandler,
-	responseWriter ResponseWriter,
+	requestParser input.RequestParser,
+	errorHandler errorhandler.ErrorHandler,
+	responseWriter output.ResponseWriter,
 	operationHandler OperationHttpHandler,
 ) *ParsingHttpHandler {
 	return &ParsingHttpHandler{
@@ -55,51 +33,31 @@ func NewParsingHttpHandler(
 	}
 }
 
+// HandleSafe implements HttpHandler.HandleSafe
 func (h *ParsingHttpHandler) HandleSafe(w http.ResponseWriter, r *http.Request) error {
-	ctx := r.Context()
-	var result ResponseDescription
-
-	log.Printf("ParsingHttpHandler: received %s request for %s", r.Method, r.URL.Path)
-
-	operation, err := h.handleRequest(ctx, r)
+	result, err := h.handleRequest(r)
 	if err != nil {
-		result, _ = h.handleError(ctx, err, r)
-	} else {
-		result, err = h.operationHandler.HandleSafe(ctx, OperationHttpHandlerInput{
-			Request:   r,
-			Operation: operation,
-		})
-		if err != nil {
-			result, _ = h.handleError(ctx, err, r)
+		if result, err = h.handleError(err, r); err != nil {
+			return err
 		}
 	}
-
-	if (result != ResponseDescription{}) {
-		return h.responseWriter.HandleSafe(ctx, w, result)
-	}
-	return nil
+	return h.responseWriter.Handle(w, result)
 }
 
-// handleRequest parses the request and returns the operation.
-func (h *ParsingHttpHandler) handleRequest(ctx context.Context, r *http.Request) (Operation, error) {
-	operation, err := h.requestParser.HandleSafe(ctx, r)
+// handleRequest parses the request and passes the resulting Operation to the OperationHttpHandler.
+func (h *ParsingHttpHandler) handleRequest(r *http.Request) (*response.ResponseDescription, error) {
+	operation, err := h.requestParser.Handle(r)
 	if err != nil {
-		return Operation{}, err
+		return nil, err
 	}
-	log.Printf("ParsingHttpHandler: parsed %s operation on %s", operation.Method, operation.Target.Path)
-	return operation, nil
+	return h.operationHandler.Handle(OperationHttpHandlerInput{Request: r, Operation: operation})
 }
 
-// handleError normalizes and handles errors.
-func (h *ParsingHttpHandler) handleError(ctx context.Context, err error, r *http.Request) (ResponseDescription, error) {
-	// If not an HttpError, wrap in InternalServerError
-	_, isHttpErr := err.(*HttpError)
-	if !isHttpErr {
-		err = &InternalServerError{
-			Msg:   fmt.Sprintf("Received unexpected non-HttpError: %v", err),
-			Cause: err,
-		}
+// handleError converts the error into a response description.
+func (h *ParsingHttpHandler) handleError(err error, r *http.Request) (*response.ResponseDescription, error) {
+	args := errorhandler.ErrorHandlerArgs{Error: err, Request: r}
+	if canHandleErr := h.errorHandler.CanHandle(args); canHandleErr != nil {
+		return nil, err
 	}
-	log.Printf("ParsingHttpHandler: handling error: %v", err)
-	return h.errorHandler.HandleSafe(ctx, err, r)
+	return h.errorHandler.Handle(args)
 }
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"strings"
+
+	"solid-go/internal/http/auxiliary"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util"
+)
+
+// rootAcl grants everyone full access to the root container and, by default, everything in it.
+// It only makes a new storage usable, so administrators are expected to replace it.
+const rootAcl = `@prefix acl: <http://www.w3.org/ns/auth/acl#>.
+@prefix foaf: <http://xmlns.com/foaf/0.1/>.
+
+<#public>
+    a acl:Authorization;
+    acl:agentClass foaf:Agent;
+    acl:accessTo <./>;
+    acl:default <./>;
+    acl:mode acl:Read, acl:Write, acl:Append, acl:Control.
+`
+
+// rootAcr is the Access Control Resource equivalent of rootAcl
+const rootAcr = `@prefix acp: <http://www.w3.org/ns/solid/acp#>.
+@prefix acl: <http://www.w3.org/ns/auth/acl#>.
+
+<#root>
+    a acp:AccessControlResource;
+    acp:resource <./>;
+    acp:accessControl <#publicAccess>;
+    acp:memberAccessControl <#publicAccess>.
+
+<#publicAccess>
+    acp:apply <#publicPolicy>.
+
+<#publicPolicy>
+    acp:allow acl:Read, acl:Write, acl:Append, acl:Control;
+    acp:anyOf <#publicMatcher>.
+
+<#publicMatcher>
+    acp:agent acp:PublicAgent.
+`
+
+// initializeRootAuthorization writes the default authorization document of the root container if it has none,
+// since WAC requires the root container to have an ACL document and ACP would otherwise deny all access
+func initializeRootAuthorization(options *ServerOptions, strategy auxiliary.AuxiliaryIdentifierStrategy, document string) error {
+	identifier := strategy.GetAuxiliaryIdentifier(representation.ResourceIdentifier{Path: options.BaseURL})
+	exists, err := options.Storage.HasResource(identifier)
+	if err != nil || exists {
+		return err
+	}
+	metadata := representation.NewRepresentationMetadata(identifier.Path).SetContentType(util.Turtle)
+	if _, err := options.Storage.SetRepresentation(identifier,
+		representation.NewBasicRepresentation(strings.NewReader(document), metadata, true), nil); err != nil {
+		return err
+	}
+	if options.Logger != nil {
+		options.Logger.Warn("Created " + identifier.Path + ", which grants everyone full access to the storage")
+	}
+	return nil
+}
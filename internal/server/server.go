@@ -0,0 +1,367 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"solid-go/internal/authentication"
+	"solid-go/internal/authorization"
+	"solid-go/internal/authorization/access"
+	"solid-go/internal/authorization/permissions"
+	"solid-go/internal/http/auxiliary"
+	"solid-go/internal/http/input"
+	"solid-go/internal/http/input/body"
+	"solid-go/internal/http/input/conditions"
+	"solid-go/internal/http/input/identifier"
+	inputmetadata "solid-go/internal/http/input/metadata"
+	"solid-go/internal/http/input/preferences"
+	"solid-go/internal/http/ldp"
+	"solid-go/internal/http/output"
+	errorhandler "solid-go/internal/http/output/error"
+	"solid-go/internal/http/output/metadata"
+	"solid-go/internal/logging"
+	"solid-go/internal/server/middleware"
+	"solid-go/internal/server/notifications"
+	websocketchannel2023 "solid-go/internal/server/notifications/WebSocketChannel2023"
+	"solid-go/internal/server/notifications/generate"
+	"solid-go/internal/storage"
+	"solid-go/internal/util"
+	"solid-go/internal/util/identifiers"
+	"solid-go/internal/util/vocabularies"
+)
+
+// The supported auth modes
+const (
+	// AuthModeAllowAll grants every agent all access modes on every resource
+	AuthModeAllowAll = "allow-all"
+	// AuthModeDenyAll grants no access modes at all
+	AuthModeDenyAll = "deny-all"
+	// AuthModeWebACL grants the access modes of the Web Access Control documents of the storage
+	AuthModeWebACL = "webacl"
+	// AuthModeACP grants the access modes of the Access Control Policies of the storage
+	AuthModeACP = "acp"
+)
+
+// acpModes are the access modes Access Control Resources can grant
+var acpModes = []string{
+	vocabularies.ACL.Read.Value(), vocabularies.ACL.Append.Value(),
+	vocabularies.ACL.Write.Value(), vocabularies.ACL.Control.Value(),
+}
+
+// acpAttributes are the matcher attributes the ACP reader supports
+var acpAttributes = []string{
+	vocabularies.ACP.Agent.Value(), vocabularies.ACP.Client.Value(), vocabularies.ACP.Issuer.Value(),
+}
+
+// NotificationChannelWebSocket offers WebSocketChannel2023 notification channels
+const NotificationChannelWebSocket = "websocket"
+
+// HealthCheckPath is the path that answers with 200 OK as long as the server is running
+const HealthCheckPath = "/health"
+
+// supportedMethods are the methods advertised in the Allow header
+var supportedMethods = []string{"OPTIONS", "GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"}
+
+// acceptTypes are the media types advertised in the Accept-Patch, Accept-Post and Accept-Put headers,
+// matching the body parsers of the request parser
+var acceptTypes = map[string][]string{
+	"patch": {util.N3, util.SparqlUpdate},
+	"post":  {"*/*"},
+	"put":   {"*/*"},
+}
+
+// ServerOptions configures the Server created by NewServer.
+type ServerOptions struct {
+	Port     int
+	HTTPS    bool
+	CertFile string
+	KeyFile  string
+	// BaseURL is the URL of the root container, defaults to http://localhost:<port>/
+	BaseURL string
+	// AuthMode determines which permissions agents have, defaults to AuthModeAllowAll
+	AuthMode string
+	// Storage contains the resources of the server and is required
+	Storage storage.ResourceStore
+	// MetadataStrategy identifies the description resources of the storage,
+	// which can only be modified with PATCH (optional)
+	MetadataStrategy auxiliary.AuxiliaryIdentifierStrategy
+	// AclStrategy identifies the ACL documents of the storage and is required by AuthModeWebACL
+	AclStrategy auxiliary.AuxiliaryIdentifierStrategy
+	// AcrStrategy identifies the Access Control Resources of the storage and is required by AuthModeACP
+	AcrStrategy auxiliary.AuxiliaryIdentifierStrategy
+	// AuxiliaryStrategy gives the auxiliary resources that are not authorized on their own,
+	// such as description resources, the permissions of their subject resource (optional)
+	AuxiliaryStrategy auxiliary.AuxiliaryStrategy
+	// CredentialsExtractor determines the agent that made a request,
+	// all requests are made by the public when not set
+	CredentialsExtractor authentication.CredentialsExtractor
+	// NotificationChannels are the notification channel types the server offers, none if empty
+	NotificationChannels []string
+	// IdentityProvider answers the requests to its endpoints instead of the storage, if set
+	IdentityProvider IdentityProvider
+	// MaxPatchSize is the size in bytes of the largest N3 Patch or SPARQL Update document accepted,
+	// body.DefaultMaxPatchSize if not set
+	MaxPatchSize int64
+	// ShowStackTrace adds the stack trace of errors to error responses
+	ShowStackTrace bool
+	Logger         logging.Logger
+}
+
+// Server is a Solid server that handles requests with the composed handler graph.
+type Server struct {
+	httpServer *http.Server
+	logger     logging.Logger
+}
+
+// NewServer composes the handler graph described by the options into a Server.
+func NewServer(options *ServerOptions) (*Server, error) {
+	if options == nil || options.Storage == nil {
+		return nil, fmt.Errorf("a storage is required to create a server")
+	}
+	opts := *options
+	if opts.BaseURL == "" {
+		scheme := "http"
+		if opts.HTTPS {
+			scheme = "https"
+		}
+		opts.BaseURL = fmt.Sprintf("%s://localhost:%d/", scheme, opts.Port)
+	}
+	if !strings.HasSuffix(opts.BaseURL, "/") {
+		opts.BaseURL += "/"
+	}
+	if opts.AuthMode == "" {
+		opts.AuthMode = AuthModeAllowAll
+	}
+	if opts.CredentialsExtractor == nil {
+		opts.CredentialsExtractor = authentication.NewPublicCredentialsExtractor()
+	}
+	if opts.Logger == nil {
+		opts.Logger = &logging.VoidLogger{}
+	}
+
+	handler, err := NewHttpHandler(&opts)
+	if err != nil {
+		return nil, err
+	}
+	factory := NewBaseServerFactory(NewHandlerServerConfigurator(handler, opts.ShowStackTrace), &BaseServerFactoryOptions{
+		HTTPS: opts.HTTPS,
+		Key:   opts.KeyFile,
+		Cert:  opts.CertFile,
+	})
+	httpServer, err := factory.CreateServer()
+	if err != nil {
+		return nil, err
+	}
+	httpServer.Addr = fmt.Sprintf(":%d", opts.Port)
+	return &Server{httpServer: httpServer, logger: opts.Logger}, nil
+}
+
+// NewHttpHandler creates the handler that parses requests, authorizes them,
+// executes them on the storage and writes the responses.
+func NewHttpHandler(options *ServerOptions) (HttpHandler, error) {
+	store := options.Storage
+	// Notifications are sent about the changes of every operation on the storage
+	var monitoringStore *storage.MonitoringStore
+	if len(options.NotificationChannels) > 0 {
+		monitoringStore = storage.NewMonitoringStore(store)
+		store = monitoringStore
+	}
+	identifierStrategy := identifiers.NewSingleRootIdentifierStrategy(options.BaseURL)
+	// An unset strategy has to stay a nil interface, so the PUT handler knows there are no description resources
+	var metadataStrategy metadata.AuxiliaryIdentifierStrategy
+	if options.MetadataStrategy != nil {
+		metadataStrategy = options.MetadataStrategy
+	}
+
+	permissionReader, err := newPermissionReader(options, identifierStrategy)
+	if err != nil {
+		return nil, err
+	}
+	modesExtractor := permissions.NewIntermediateCreateExtractor(
+		permissions.NewDeleteParentExtractor(
+			permissions.NewWaterfallModesExtractor(
+				permissions.NewMethodModesExtractor(store),
+				permissions.NewN3PatchModesExtractor(store),
+				permissions.NewSparqlUpdateModesExtractor(store),
+			), store, identifierStrategy),
+		store, identifierStrategy)
+
+	targetExtractor := identifier.NewOriginalUrlExtractor(identifier.OriginalUrlExtractorArgs{
+		IdentifierStrategy: identifierStrategy,
+	})
+	requestParser := input.NewBasicRequestParser(input.BasicRequestParserArgs{
+		TargetExtractor: targetExtractor,
+		PreferenceParser: preferences.NewUnionPreferenceParser([]preferences.PreferenceParser{
+			preferences.NewAcceptPreferenceParser(), preferences.NewRangePreferenceParser(),
+		}),
+		MetadataParser: inputmetadata.NewParallelMetadataParser(
+			inputmetadata.NewContentTypeParser(),
+			inputmetadata.NewSlugParser(),
+			// The type links determine whether POST and PUT create a container or a document
+			inputmetadata.NewLinkRelParser(map[string]inputmetadata.LinkRelObject{
+				"type": {Value: vocabularies.RDF.Type.Value(), Ephemeral: true, AllowList: []string{
+					vocabularies.LDP.Resource.Value(), vocabularies.LDP.Container.Value(), vocabularies.LDP.BasicContainer.Value(),
+				}},
+			}),
+		),
+		ConditionsParser: conditions.NewBasicConditionsParser(conditions.NewBasicETagHandler()),
+		BodyParser: body.NewWaterfallBodyParser(
+			body.NewN3PatchBodyParser(options.MaxPatchSize), body.NewSparqlUpdateBodyParser(options.MaxPatchSize),
+			body.NewRawBodyParser()),
+	})
+
+	responseWriter := output.NewBasicResponseWriter(metadata.NewParallelMetadataWriter(
+		metadata.NewContentTypeMetadataWriter(),
+		metadata.NewModifiedMetadataWriter(conditions.NewBasicETagHandler()),
+		metadata.NewRangeMetadataWriter(),
+		metadata.NewLinkRelMetadataWriter(map[string]string{
+			vocabularies.RDF.Type.Value():             "type",
+			vocabularies.ACL.AccessControl.Value():    "acl",
+			vocabularies.POWDER_S.DescribedBy.Value(): "describedby",
+		}),
+		metadata.NewAllowAcceptHeaderWriter(supportedMethods, acceptTypes).WithMetadataStrategy(metadataStrategy),
+		metadata.NewMappedMetadataWriter(map[string]string{vocabularies.SOLID_HTTP.Location.Value(): "Location"}),
+		metadata.NewWacAllowMetadataWriter(),
+		metadata.NewWwwAuthMetadataWriter(`Bearer scope="openid webid"`),
+		metadata.NewResponseHeadersMetadataWriter(),
+	))
+	errorHandler := errorhandler.NewSafeErrorHandler(errorhandler.NewTargetExtractorErrorHandler(
+		errorhandler.NewWaterfallErrorHandler(
+			errorhandler.NewRedirectingErrorHandler(),
+			errorhandler.NewEmptyErrorHandler([]int{http.StatusNotModified}, false),
+			errorhandler.NewConvertingErrorHandler(preferences.NewAcceptPreferenceParser(), options.ShowStackTrace),
+		), targetExtractor), options.ShowStackTrace)
+
+	operationHandler := NewLdpOperationHttpHandler(
+		ldp.NewOptionsOperationHandler(store),
+		ldp.NewGetOperationHandler(store),
+		ldp.NewHeadOperationHandler(store),
+		ldp.NewPostOperationHandler(store),
+		ldp.NewPutOperationHandler(store, metadataStrategy),
+		ldp.NewPatchOperationHandler(store),
+		ldp.NewDeleteOperationHandler(store),
+	)
+	authorizer := authorization.NewPermissionBasedAuthorizer(store)
+	authorizingHandler := NewAuthorizingHttpHandler(options.CredentialsExtractor, modesExtractor, permissionReader,
+		authorizer, NewWacAllowHttpHandler(modesExtractor, permissionReader, operationHandler))
+
+	var handler HttpHandler = NewParsingHttpHandler(requestParser, errorHandler, responseWriter, authorizingHandler)
+	for _, channel := range options.NotificationChannels {
+		if handler, err = newNotificationHandler(channel, options, monitoringStore, identifierStrategy, permissionReader,
+			authorizer, requestParser, errorHandler, handler); err != nil {
+			return nil, err
+		}
+	}
+	if options.AuthMode == AuthModeACP {
+		handler = NewHeaderHttpHandler(middleware.NewAcpHeaderHandler(targetExtractor, options.AcrStrategy, acpModes, acpAttributes), handler)
+	}
+	if options.IdentityProvider != nil {
+		handler = NewIdentityProviderHttpHandler(options.IdentityProvider, handler)
+	}
+	return NewHealthCheckHttpHandler(HealthCheckPath, handler), nil
+}
+
+// newNotificationHandler creates the handler of the subscription service of the notification channel type,
+// which passes all other requests to the given handler
+func newNotificationHandler(channel string, options *ServerOptions, store *storage.MonitoringStore,
+	identifierStrategy notifications.IdentifierStrategy, permissionReader authorization.PermissionReader,
+	authorizer authorization.Authorizer, requestParser input.RequestParser, errorHandler errorhandler.ErrorHandler,
+	handler HttpHandler) (HttpHandler, error) {
+	if channel != NotificationChannelWebSocket {
+		return nil, fmt.Errorf("unknown notification channel %q", channel)
+	}
+	channelStorage := notifications.NewKeyValueChannelStorage(notifications.DefaultMaxChannels)
+	channelType := websocketchannel2023.NewWebSocketChannel2023Type(options.BaseURL + ".notifications/WebSocketChannel2023/")
+	sockets := websocketchannel2023.NewWebSocketMap()
+	notifications.NewListeningActivityHandler(store, channelStorage, notifications.NewComposedNotificationHandler(
+		generate.NewNotificationGenerator(
+			generate.NewActivityNotificationGenerator(store, conditions.NewBasicETagHandler()),
+			generate.NewDeleteNotificationGenerator()),
+		websocketchannel2023.NewWebSocket2023Emitter(sockets)), options.Logger)
+
+	// Subscription responses are not resources, so they only get the headers of their content and errors
+	responseWriter := output.NewBasicResponseWriter(metadata.NewParallelMetadataWriter(
+		metadata.NewContentTypeMetadataWriter(),
+		metadata.NewWwwAuthMetadataWriter(`Bearer scope="openid webid"`),
+		metadata.NewResponseHeadersMetadataWriter(),
+	))
+	subscriber := notifications.NewNotificationSubscriber(channelType, channelStorage, identifierStrategy, permissionReader, authorizer)
+	listener := websocketchannel2023.NewWebSocket2023Listener(channelStorage, websocketchannel2023.NewWebSocket2023Handler(
+		websocketchannel2023.NewWebSocket2023Storer(sockets, channelStorage)))
+	return NewNotificationHttpHandler(channelType.Path(),
+		NewParsingHttpHandler(requestParser, errorHandler, responseWriter,
+			NewSubscriptionHttpHandler(options.CredentialsExtractor, subscriber)),
+		listener, handler)
+}
+
+// newPermissionReader creates the PermissionReader for the given auth mode
+func newPermissionReader(options *ServerOptions, identifierStrategy authorization.IdentifierStrategy) (authorization.PermissionReader, error) {
+	var reader authorization.PermissionReader
+	switch options.AuthMode {
+	case AuthModeAllowAll:
+		if options.Logger != nil {
+			options.Logger.Warn("The " + AuthModeAllowAll + " auth mode grants everyone full access to the storage, " +
+				"select " + AuthModeWebACL + " or " + AuthModeACP + " authorization to protect it")
+		}
+		reader = authorization.NewAllStaticReader(true)
+	case AuthModeDenyAll:
+		reader = authorization.NewAllStaticReader(false)
+	case AuthModeWebACL:
+		if options.AclStrategy == nil {
+			return nil, fmt.Errorf("the %s auth mode requires an ACL strategy", options.AuthMode)
+		}
+		if err := initializeRootAuthorization(options, options.AclStrategy, rootAcl); err != nil {
+			return nil, fmt.Errorf("error initializing the root ACL: %w", err)
+		}
+		checker := access.NewUnionAccessChecker(
+			access.NewAgentAccessChecker(), access.NewAgentClassAccessChecker(), access.NewAgentGroupAccessChecker())
+		// Creating and deleting a resource depends on its container,
+		// and an ACL document requires control over the resource it applies to
+		reader = authorization.NewParentContainerReader(authorization.NewAuthAuxiliaryReader(
+			authorization.NewWebACLReader(options.AclStrategy, options.Storage, identifierStrategy, checker),
+			options.AclStrategy))
+	case AuthModeACP:
+		if options.AcrStrategy == nil {
+			return nil, fmt.Errorf("the %s auth mode requires an ACR strategy", options.AuthMode)
+		}
+		if err := initializeRootAuthorization(options, options.AcrStrategy, rootAcr); err != nil {
+			return nil, fmt.Errorf("error initializing the root ACR: %w", err)
+		}
+		reader = authorization.NewParentContainerReader(authorization.NewAuthAuxiliaryReader(
+			authorization.NewACPReader(options.AcrStrategy, options.Storage, identifierStrategy),
+			options.AcrStrategy))
+	default:
+		return nil, fmt.Errorf("unknown auth mode %q", options.AuthMode)
+	}
+	if options.AuxiliaryStrategy != nil {
+		reader = authorization.NewSubjectAuxiliaryReader(reader, options.AuxiliaryStrategy)
+	}
+	return reader, nil
+}
+
+// Start listens on the configured port, using TLS when the server was configured for HTTPS.
+// It blocks until the server is shut down, in which case it returns nil.
+func (s *Server) Start() error {
+	var err error
+	if IsHttpsServer(s.httpServer) {
+		err = s.httpServer.ListenAndServeTLS("", "")
+	} else {
+		err = s.httpServer.ListenAndServe()
+	}
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Shutdown gracefully stops the server
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
+// Handler returns the http.Handler that handles all requests of the server
+func (s *Server) Handler() http.Handler {
+	return s.httpServer.Handler
+}
@@ -1,3 +0,0 @@
-package server
-
-// The ServerConfigurator interface is already defined elsewhere in the codebase.
@@ -0,0 +1,724 @@
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"solid-go/internal/authentication"
+	"solid-go/internal/authentication/oidc"
+	"solid-go/internal/http/auxiliary"
+	"solid-go/internal/http/input/identifier"
+	"solid-go/internal/http/output/serialize"
+	"solid-go/internal/logging"
+	"solid-go/internal/storage"
+	"solid-go/internal/storage/conversion"
+	"solid-go/internal/storage/patch"
+)
+
+const baseURL = "http://example.org/"
+
+func newTestHandler(t *testing.T) http.Handler {
+	t.Helper()
+	return newAuthTestHandler(t, AuthModeAllowAll, nil)
+}
+
+// newAuthTestHandler creates a handler for an empty in-memory storage with the given authorization
+func newAuthTestHandler(t *testing.T, authMode string, extractor authentication.CredentialsExtractor) http.Handler {
+	t.Helper()
+	srv, err := NewServer(newTestOptions(baseURL, authMode, extractor))
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	return srv.Handler()
+}
+
+// newTestOptions creates the options of a server with an empty in-memory storage at the base URL
+func newTestOptions(baseURL, authMode string, extractor authentication.CredentialsExtractor) *ServerOptions {
+	converter := conversion.NewRdfConverter(serialize.Options{})
+	acl := auxiliary.NewAclStrategy(converter)
+	acr := auxiliary.NewAcrStrategy(converter)
+	description := auxiliary.NewDescriptionStrategy(converter)
+	authStrategy := acl
+	if authMode == AuthModeACP {
+		authStrategy = acr
+	}
+	auxiliaryStrategy := auxiliary.NewRoutingAuxiliaryStrategy([]auxiliary.AuxiliaryStrategy{authStrategy, description})
+	source := storage.NewDataAccessorBasedStore(storage.NewInMemoryDataAccessor(baseURL), baseURL).
+		WithAuxiliaryStrategy(auxiliaryStrategy).
+		WithMetadataStrategy(description).
+		WithPatcher(patch.NewN3Patcher())
+	store := storage.NewBinarySliceResourceStore(storage.NewRepresentationConvertingStore(source, converter))
+	return &ServerOptions{
+		Port:                 3000,
+		BaseURL:              baseURL,
+		AuthMode:             authMode,
+		Storage:              store,
+		MetadataStrategy:     description,
+		AclStrategy:          acl,
+		AcrStrategy:          acr,
+		AuxiliaryStrategy:    auxiliaryStrategy,
+		CredentialsExtractor: extractor,
+	}
+}
+
+func serve(handler http.Handler, method, path, contentType, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// serveAs sends the request to the resource with the path on behalf of the WebID, the public if it is empty
+func serveAs(handler http.Handler, webID, method, path, contentType, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, baseURL+path, strings.NewReader(body))
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	if webID != "" {
+		request.Header.Set("Authorization", "WebID "+webID)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestNewServer(t *testing.T) {
+	if _, err := NewServer(&ServerOptions{}); err == nil {
+		t.Error("NewServer() without storage should fail")
+	}
+	store := storage.NewDataAccessorBasedStore(storage.NewInMemoryDataAccessor(baseURL), baseURL)
+	if _, err := NewServer(&ServerOptions{Storage: store, AuthMode: "unknown"}); err == nil {
+		t.Error("NewServer() with an unknown auth mode should fail")
+	}
+}
+
+// warningLogger records the warnings that are logged
+type warningLogger struct {
+	logging.VoidLogger
+	warnings []string
+}
+
+func (l *warningLogger) Warn(message string, meta ...interface{}) {
+	l.warnings = append(l.warnings, message)
+}
+
+func TestNewServer_Warnings(t *testing.T) {
+	for authMode, want := range map[string]string{
+		AuthModeAllowAll: "grants everyone full access to the storage",
+		AuthModeWebACL:   "grants everyone full access to the storage",
+		AuthModeDenyAll:  "",
+	} {
+		logger := &warningLogger{}
+		options := newTestOptions(baseURL, authMode, nil)
+		options.Logger = logger
+		if _, err := NewServer(options); err != nil {
+			t.Fatalf("NewServer() error = %v", err)
+		}
+		if got := strings.Join(logger.warnings, "\n"); (want == "") != (got == "") || !strings.Contains(got, want) {
+			t.Errorf("%s: warnings = %q, want one about %q", authMode, got, want)
+		}
+	}
+}
+
+func TestServer_Health(t *testing.T) {
+	result := serve(newTestHandler(t), "GET", baseURL+"health", "", "")
+	if result.Code != 200 || result.Body.String() != "OK\n" {
+		t.Errorf("GET /health = %v %q", result.Code, result.Body.String())
+	}
+}
+
+func TestServer_Resources(t *testing.T) {
+	handler := newTestHandler(t)
+	doc := baseURL + "foo/doc.txt"
+
+	if result := serve(handler, "PUT", doc, "text/plain", "hello"); result.Code != 201 {
+		t.Fatalf("PUT = %v %q", result.Code, result.Body.String())
+	}
+
+	result := serve(handler, "GET", doc, "", "")
+	if result.Code != 200 || result.Body.String() != "hello" {
+		t.Errorf("GET = %v %q", result.Code, result.Body.String())
+	}
+	if got := result.Header().Get("WAC-Allow"); got != `user="append read write",public="append read write"` {
+		t.Errorf("WAC-Allow = %q", got)
+	}
+	if result.Header().Get("ETag") == "" {
+		t.Errorf("missing ETag in %v", result.Header())
+	}
+
+	notModified := httptest.NewRequest("GET", doc, nil)
+	notModified.Header.Set("If-None-Match", result.Header().Get("ETag"))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, notModified)
+	if recorder.Code != 304 || recorder.Body.Len() != 0 {
+		t.Errorf("GET with If-None-Match = %v %q", recorder.Code, recorder.Body.String())
+	}
+
+	if result := serve(handler, "GET", baseURL+"foo/", "", ""); result.Code != 200 || !strings.Contains(result.Body.String(), "doc.txt") {
+		t.Errorf("GET of the intermediate container = %v %q", result.Code, result.Body.String())
+	}
+
+	if result := serve(handler, "DELETE", doc, "", ""); result.Code != 205 {
+		t.Errorf("DELETE = %v %q", result.Code, result.Body.String())
+	}
+	result = serve(handler, "GET", doc, "", "")
+	if result.Code != 404 || result.Header().Get("Content-Type") != "text/plain" {
+		t.Errorf("GET after DELETE = %v %v", result.Code, result.Header())
+	}
+	if body, _ := io.ReadAll(result.Body); len(body) == 0 {
+		t.Error("error responses should describe the error")
+	}
+}
+
+func TestServer_PostContainer(t *testing.T) {
+	handler := newTestHandler(t)
+	request := httptest.NewRequest("POST", baseURL, nil)
+	request.Header.Set("Content-Type", "text/turtle")
+	request.Header.Set("Slug", "sub")
+	request.Header.Set("Link", `<http://www.w3.org/ns/ldp#BasicContainer>; rel="type"`)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	location := recorder.Header().Get("Location")
+	if recorder.Code != 201 || location != baseURL+"sub/" {
+		t.Fatalf("POST of a container = %v %q with Location %q, want a container URL", recorder.Code, recorder.Body.String(), location)
+	}
+	if result := serve(handler, "GET", location, "", ""); result.Code != 200 ||
+		!strings.Contains(strings.Join(result.Header().Values("Link"), ","), "http://www.w3.org/ns/ldp#BasicContainer") {
+		t.Errorf("GET of the posted container = %v %v", result.Code, result.Header())
+	}
+}
+
+func TestServer_AcceptHeaders(t *testing.T) {
+	handler := newTestHandler(t)
+	if result := serve(handler, "PUT", baseURL+"doc.ttl", "text/turtle", "<a> <b> <c>."); result.Code != 201 {
+		t.Fatalf("PUT = %v %q", result.Code, result.Body.String())
+	}
+	tests := []struct {
+		method, path string
+		headers      map[string]string
+	}{
+		{"GET", "doc.ttl", map[string]string{"Accept-Patch": "text/n3, application/sparql-update", "Accept-Put": "*/*", "Accept-Post": ""}},
+		{"GET", "", map[string]string{"Accept-Patch": "text/n3, application/sparql-update", "Accept-Post": "*/*", "Accept-Put": "*/*"}},
+		{"OPTIONS", "", map[string]string{"Allow": "OPTIONS, GET, HEAD, POST, PUT, PATCH, DELETE", "Accept-Post": "*/*"}},
+		{"OPTIONS", "doc.ttl", map[string]string{"Allow": "OPTIONS, GET, HEAD, PUT, PATCH, DELETE", "Accept-Put": "*/*"}},
+		{"OPTIONS", "missing", map[string]string{"Allow": "OPTIONS, PUT, PATCH", "Accept-Put": "*/*", "Content-Type": ""}},
+	}
+	for _, tt := range tests {
+		result := serve(handler, tt.method, baseURL+tt.path, "", "")
+		if tt.method == "OPTIONS" && (result.Code != 204 || result.Body.Len() > 0) {
+			t.Errorf("OPTIONS %s = %v %q, want an empty 204", tt.path, result.Code, result.Body.String())
+		}
+		for header, want := range tt.headers {
+			if got := result.Header().Get(header); got != want {
+				t.Errorf("%s %s: %s = %q, want %q", tt.method, tt.path, header, got, want)
+			}
+		}
+	}
+}
+
+func TestServer_ContentNegotiation(t *testing.T) {
+	handler := newTestHandler(t)
+	tests := []struct {
+		accept, want string
+	}{
+		{"", "text/turtle"},
+		{"*/*", "text/turtle"},
+		{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", "text/turtle"},
+		{"application/ld+json", "application/ld+json"},
+		{"application/n-triples, */*;q=0.5", "application/n-triples"},
+	}
+	for _, tt := range tests {
+		request := httptest.NewRequest("GET", baseURL, nil)
+		if tt.accept != "" {
+			request.Header.Set("Accept", tt.accept)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if got := recorder.Header().Get("Content-Type"); recorder.Code != 200 || got != tt.want {
+			t.Errorf("GET of a container with Accept %q = %v %q, want %q", tt.accept, recorder.Code, got, tt.want)
+		}
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	handler := newTestHandler(t)
+
+	if result := serve(handler, "POST", baseURL+"doc.txt", "text/plain", "hello"); result.Code != 404 {
+		t.Errorf("POST to a missing resource = %v, want 404", result.Code)
+	}
+	serve(handler, "PUT", baseURL+"doc.txt", "text/plain", "hello")
+	result := serve(handler, "POST", baseURL+"doc.txt", "text/plain", "hello")
+	if result.Code != 405 || result.Header().Get("Allow") != "OPTIONS, GET, HEAD, PUT, PATCH, DELETE" {
+		t.Errorf("POST to a document = %v with Allow %q", result.Code, result.Header().Get("Allow"))
+	}
+	if result := serve(handler, "TRACE", baseURL, "", ""); result.Code != 501 {
+		t.Errorf("TRACE = %v, want 501", result.Code)
+	}
+	result = serve(handler, "GET", "http://other.org/", "", "")
+	if result.Code != 400 {
+		t.Errorf("GET outside the identifier space = %v, want 400", result.Code)
+	}
+}
+
+func TestServer_AuxiliaryResources(t *testing.T) {
+	handler := newTestHandler(t)
+	serve(handler, "PUT", baseURL+"doc.ttl", "text/turtle", "<> <http://example.org/ns#p> 1.")
+
+	links := serve(handler, "GET", baseURL+"doc.ttl", "", "").Header().Values("Link")
+	for _, want := range []string{`<http://example.org/doc.ttl.acl>; rel="acl"`, `<http://example.org/doc.ttl.meta>; rel="describedby"`} {
+		if !contains(links, want) {
+			t.Errorf("Link = %v, want %v", links, want)
+		}
+	}
+
+	if result := serve(handler, "PUT", baseURL+"doc.ttl.acl", "text/turtle", "this is not turtle"); result.Code != 400 {
+		t.Errorf("PUT of an invalid ACL = %v, want 400", result.Code)
+	}
+	acl := "<#public> a <http://www.w3.org/ns/auth/acl#Authorization>."
+	if result := serve(handler, "PUT", baseURL+"doc.ttl.acl", "text/turtle", acl); result.Code != 201 {
+		t.Errorf("PUT of an ACL = %v, want 201", result.Code)
+	}
+	if result := serve(handler, "POST", baseURL+"doc.ttl.acl", "text/turtle", acl); result.Code != 405 {
+		t.Errorf("POST to an ACL = %v, want 405", result.Code)
+	}
+	if body := serve(handler, "GET", baseURL+"", "", "").Body.String(); strings.Contains(body, "doc.ttl.acl") {
+		t.Errorf("container lists its auxiliary resources: %v", body)
+	}
+	for _, accept := range []string{"text/turtle", "application/ld+json"} {
+		request := httptest.NewRequest("GET", baseURL, nil)
+		request.Header.Set("Accept", accept)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		for _, leaked := range []string{"accessControl", "describedby", "ResponseMetadata"} {
+			if strings.Contains(recorder.Body.String(), leaked) {
+				t.Errorf("%v body of a container contains response metadata %v: %v", accept, leaked, recorder.Body.String())
+			}
+		}
+	}
+
+	if result := serve(handler, "PUT", baseURL+"doc.ttl.meta", "text/turtle", acl); result.Code != 409 {
+		t.Errorf("PUT of a description = %v, want 409", result.Code)
+	}
+	for _, method := range []string{"GET", "HEAD", "OPTIONS"} {
+		result := serve(handler, method, baseURL+"doc.ttl.meta", "", "")
+		if allow := result.Header().Get("Allow"); allow != "OPTIONS, GET, HEAD, PATCH" || result.Header().Get("Accept-Put") != "" {
+			t.Errorf("%s of a description has Allow %q and Accept-Put %q", method, allow, result.Header().Get("Accept-Put"))
+		}
+	}
+	result := serve(handler, "DELETE", baseURL+"doc.ttl.meta", "", "")
+	if result.Code != 405 || result.Header().Get("Allow") != "OPTIONS, GET, HEAD, PATCH" {
+		t.Errorf("DELETE of a description = %v with Allow %q", result.Code, result.Header().Get("Allow"))
+	}
+	n3Patch := `@prefix solid: <http://www.w3.org/ns/solid/terms#>.
+_:patch a solid:InsertDeletePatch; solid:inserts { <doc.ttl> a <http://example.org/ns#Note>. }.`
+	staleETag := serve(handler, "GET", baseURL+"doc.ttl.meta", "", "").Header().Get("ETag")
+	if result := serve(handler, "PATCH", baseURL+"doc.ttl.meta", "text/n3", n3Patch); result.Code != 205 {
+		t.Errorf("PATCH of a description = %v %v, want 205", result.Code, result.Body.String())
+	}
+	if eTag := serve(handler, "GET", baseURL+"doc.ttl.meta", "", "").Header().Get("ETag"); eTag == "" || eTag == staleETag {
+		t.Errorf("ETag of a description after a PATCH = %q, want a new ETag instead of %q", eTag, staleETag)
+	}
+	for header, want := range map[string]int{"If-None-Match": 200, "If-Match": 412} {
+		method := "GET"
+		if header == "If-Match" {
+			method = "PATCH"
+		}
+		request := httptest.NewRequest(method, baseURL+"doc.ttl.meta", strings.NewReader(n3Patch))
+		request.Header.Set("Content-Type", "text/n3")
+		request.Header.Set(header, staleETag)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Code != want {
+			t.Errorf("%s of a description with the %s header and the ETag before a PATCH = %v, want %v", method, header, recorder.Code, want)
+		}
+	}
+	for _, model := range []string{"http://www.w3.org/ns/ldp#Container", "http://www.w3.org/ns/ldp#BasicContainer"} {
+		typePatch := strings.Replace(n3Patch, "http://example.org/ns#Note", model, 1)
+		if result := serve(handler, "PATCH", baseURL+"doc.ttl.meta", "text/n3", typePatch); result.Code != 409 {
+			t.Errorf("PATCH of a description changing the interaction model to %v = %v, want 409", model, result.Code)
+		}
+	}
+	if body := serve(handler, "GET", baseURL+"doc.ttl.meta", "", "").Body.String(); !strings.Contains(body, "http://example.org/ns#Note") {
+		t.Errorf("description body = %v", body)
+	}
+	links = serve(handler, "GET", baseURL+"doc.ttl", "", "").Header().Values("Link")
+	if !contains(links, `<http://example.org/ns#Note>; rel="type"`) {
+		t.Errorf("Link = %v, want the type added to the description", links)
+	}
+	if result := serve(handler, "GET", baseURL+"missing.meta", "", ""); result.Code != 404 {
+		t.Errorf("GET of the description of a missing resource = %v, want 404", result.Code)
+	}
+
+	if result := serve(handler, "DELETE", baseURL+"doc.ttl", "", ""); result.Code != 205 {
+		t.Fatalf("DELETE = %v", result.Code)
+	}
+	if result := serve(handler, "GET", baseURL+"doc.ttl.acl", "", ""); result.Code != 404 {
+		t.Errorf("GET of the ACL of a deleted resource = %v, want 404", result.Code)
+	}
+}
+
+// contains checks if the values contain the value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestServer_WebACL(t *testing.T) {
+	handler := newAuthTestHandler(t, AuthModeWebACL, authentication.NewUnionCredentialsExtractor(
+		authentication.NewUnsecureWebIdExtractor(), authentication.NewPublicCredentialsExtractor()))
+	alice := "https://alice.example/profile#me"
+	as := func(webID, method, path, contentType, body string) *httptest.ResponseRecorder {
+		return serveAs(handler, webID, method, path, contentType, body)
+	}
+
+	// The initial root ACL grants everyone full access
+	if result := as("", "GET", ".acl", "", ""); result.Code != 200 || !strings.Contains(result.Body.String(), "foaf:Agent") {
+		t.Fatalf("GET of the root ACL = %v %v", result.Code, result.Body.String())
+	}
+	rootAcl := `@prefix acl: <http://www.w3.org/ns/auth/acl#>.
+@prefix foaf: <http://xmlns.com/foaf/0.1/>.
+<#public> a acl:Authorization; acl:agentClass foaf:Agent; acl:accessTo <./>; acl:default <./>; acl:mode acl:Read.
+<#owner> a acl:Authorization; acl:agent <` + alice + `>; acl:accessTo <./>; acl:default <./>; acl:mode acl:Write, acl:Control.`
+	if result := as("", "PUT", ".acl", "text/turtle", rootAcl); result.Code != 205 {
+		t.Fatalf("PUT of the root ACL = %v %v", result.Code, result.Body.String())
+	}
+
+	if result := as("", "PUT", "doc.txt", "text/plain", "hello"); result.Code != 401 {
+		t.Errorf("public PUT = %v, want 401", result.Code)
+	}
+	if result := as(alice, "PUT", "doc.txt", "text/plain", "hello"); result.Code != 201 {
+		t.Errorf("PUT by the owner = %v, want 201", result.Code)
+	}
+	result := as("", "GET", "doc.txt", "", "")
+	if result.Code != 200 || result.Header().Get("WAC-Allow") != `user="read",public="read"` {
+		t.Errorf("public GET = %v with WAC-Allow %q", result.Code, result.Header().Get("WAC-Allow"))
+	}
+	if result := as("", "GET", "doc.txt.acl", "", ""); result.Code != 401 {
+		t.Errorf("public GET of an ACL = %v, want 401", result.Code)
+	}
+
+	// A resource with its own ACL no longer inherits the rules of the root ACL
+	docAcl := `@prefix acl: <http://www.w3.org/ns/auth/acl#>.
+<#owner> a acl:Authorization; acl:agent <` + alice + `>; acl:accessTo <doc.txt>; acl:mode acl:Read.`
+	if result := as(alice, "PUT", "doc.txt.acl", "text/turtle", docAcl); result.Code != 201 {
+		t.Fatalf("PUT of an ACL by the owner = %v %v", result.Code, result.Body.String())
+	}
+	if result := as("", "GET", "doc.txt", "", ""); result.Code != 401 {
+		t.Errorf("public GET of a resource with its own ACL = %v, want 401", result.Code)
+	}
+	if result := as(alice, "GET", "doc.txt.meta", "", ""); result.Code != 200 {
+		t.Errorf("GET of a description = %v, want the permissions of its subject", result.Code)
+	}
+	if result := as(alice, "GET", "doc.txt.acl", "", ""); result.Code != 403 {
+		t.Errorf("GET of an ACL without control = %v, want 403", result.Code)
+	}
+}
+
+func TestServer_WebACLOrigin(t *testing.T) {
+	handler := newAuthTestHandler(t, AuthModeWebACL, authentication.NewOriginExtractor(authentication.NewUnionCredentialsExtractor(
+		authentication.NewUnsecureWebIdExtractor(), authentication.NewPublicCredentialsExtractor())))
+	alice := "https://alice.example/profile#me"
+	fromOrigin := func(origin, method, path, contentType, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, baseURL+path, strings.NewReader(body))
+		request.Header.Set("Authorization", "WebID "+alice)
+		if contentType != "" {
+			request.Header.Set("Content-Type", contentType)
+		}
+		if origin != "" {
+			request.Header.Set("Origin", origin)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	rootAcl := `@prefix acl: <http://www.w3.org/ns/auth/acl#>.
+<#owner> a acl:Authorization; acl:agent <` + alice + `>; acl:accessTo <./>; acl:default <./>; acl:mode acl:Read, acl:Control.
+<#app> a acl:Authorization; acl:agent <` + alice + `>; acl:origin <https://app.example>; acl:accessTo <./>; acl:default <./>; acl:mode acl:Write.`
+	if result := fromOrigin("", "PUT", ".acl", "text/turtle", rootAcl); result.Code != 205 {
+		t.Fatalf("PUT of the root ACL = %v %v", result.Code, result.Body.String())
+	}
+
+	if result := fromOrigin("https://other.example", "PUT", "doc.txt", "text/plain", "hello"); result.Code != 403 {
+		t.Errorf("PUT from another origin = %v, want 403", result.Code)
+	}
+	if result := fromOrigin("https://app.example", "PUT", "doc.txt", "text/plain", "hello"); result.Code != 201 {
+		t.Errorf("PUT from the trusted origin = %v, want 201", result.Code)
+	}
+	if result := fromOrigin("", "PUT", "doc.txt", "text/plain", "hello"); result.Code != 205 {
+		t.Errorf("PUT without origin = %v, want 205", result.Code)
+	}
+}
+
+// dpopClient signs Solid-OIDC access tokens as an issuer and DPoP proofs as a client, both with Ed25519 keys
+type dpopClient struct {
+	t                 *testing.T
+	issuer, client    ed25519.PrivateKey
+	issuerJWK, keyJWK oidc.JWK
+	jti               int
+}
+
+func newDPoPClient(t *testing.T) *dpopClient {
+	t.Helper()
+	newKey := func() (ed25519.PrivateKey, oidc.JWK) {
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return private, oidc.JWK{Kty: "OKP", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(public)}
+	}
+	c := &dpopClient{t: t}
+	c.issuer, c.issuerJWK = newKey()
+	c.client, c.keyJWK = newKey()
+	return c
+}
+
+// GetKeys implements oidc.KeySource with the key of the issuer
+func (c *dpopClient) GetKeys(issuer, kid string) ([]oidc.JWK, error) {
+	return []oidc.JWK{c.issuerJWK}, nil
+}
+
+// sign creates an EdDSA JWS of the header and claims
+func (c *dpopClient) sign(key ed25519.PrivateKey, header oidc.Header, claims oidc.Claims) string {
+	header.Alg = "EdDSA"
+	encode := func(value any) string {
+		data, err := json.Marshal(value)
+		if err != nil {
+			c.t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	input := encode(header) + "." + encode(claims)
+	return input + "." + base64.RawURLEncoding.EncodeToString(ed25519.Sign(key, []byte(input)))
+}
+
+// request creates a request with an access token of the WebID and a new DPoP proof for it
+func (c *dpopClient) request(webID, method, path string) *http.Request {
+	jkt, err := c.keyJWK.Thumbprint()
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	now := time.Now().Unix()
+	token := c.sign(c.issuer, oidc.Header{Typ: "at+jwt"}, oidc.Claims{Issuer: "https://idp.example/",
+		Audience: oidc.Audience{oidc.SolidAudience}, Expiry: now + 60, IssuedAt: now, WebID: webID,
+		Confirmation: &oidc.Confirmation{JKT: jkt}})
+	c.jti++
+	hash := sha256.Sum256([]byte(token))
+	proof := c.sign(c.client, oidc.Header{Typ: "dpop+jwt", JWK: &c.keyJWK}, oidc.Claims{HTM: method, HTU: baseURL + path,
+		IssuedAt: now, JTI: fmt.Sprintf("proof-%d", c.jti), ATH: base64.RawURLEncoding.EncodeToString(hash[:])})
+	request := httptest.NewRequest(method, baseURL+path, nil)
+	request.Header.Set("Authorization", "DPoP "+token)
+	request.Header.Set("DPoP", proof)
+	return request
+}
+
+func TestServer_DPoP(t *testing.T) {
+	client := newDPoPClient(t)
+	urlExtractor := identifier.NewOriginalUrlExtractor(identifier.OriginalUrlExtractorArgs{FixedBaseUrl: baseURL})
+	handler := newAuthTestHandler(t, AuthModeWebACL, authentication.NewUnionCredentialsExtractor(
+		authentication.NewDPoPWebIdExtractor(urlExtractor, oidc.NewTokenVerifier(client, nil, nil)),
+		authentication.NewPublicCredentialsExtractor()))
+	alice := "https://alice.example/profile#me"
+
+	rootAcl := `@prefix acl: <http://www.w3.org/ns/auth/acl#>.
+@prefix foaf: <http://xmlns.com/foaf/0.1/>.
+<#public> a acl:Authorization; acl:agentClass foaf:Agent; acl:accessTo <./>; acl:default <./>; acl:mode acl:Read.
+<#owner> a acl:Authorization; acl:agent <` + alice + `>; acl:accessTo <./>; acl:default <./>; acl:mode acl:Write, acl:Control.`
+	if result := serveAs(handler, "", "PUT", ".acl", "text/turtle", rootAcl); result.Code != 205 {
+		t.Fatalf("PUT of the root ACL = %v %v", result.Code, result.Body.String())
+	}
+
+	// The proof is only verified once, so the WAC-Allow header has the modes of the agent instead of the public
+	for _, method := range []string{"GET", "HEAD"} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, client.request(alice, method, ""))
+		if recorder.Code != 200 || recorder.Header().Get("WAC-Allow") != `user="append control read write",public="read"` {
+			t.Errorf("%s with DPoP = %v with WAC-Allow %q: %v", method, recorder.Code,
+				recorder.Header().Get("WAC-Allow"), recorder.Body.String())
+		}
+	}
+
+	// Rejected tokens are not treated as requests of the public, who could read the container
+	replayed := client.request(alice, "GET", "")
+	handler.ServeHTTP(httptest.NewRecorder(), replayed)
+	forged := client.request(alice, "GET", "")
+	forged.Header.Set("Authorization", forged.Header.Get("Authorization")+"x")
+	for name, request := range map[string]*http.Request{"a replayed proof": replayed, "a forged token": forged} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Code != 401 {
+			t.Errorf("GET with %s = %v, want 401", name, recorder.Code)
+		}
+	}
+}
+
+func TestServer_ACP(t *testing.T) {
+	handler := newAuthTestHandler(t, AuthModeACP, authentication.NewUnionCredentialsExtractor(
+		authentication.NewUnsecureWebIdExtractor(), authentication.NewPublicCredentialsExtractor()))
+	alice := "https://alice.example/profile#me"
+	as := func(webID, method, path, contentType, body string) *httptest.ResponseRecorder {
+		return serveAs(handler, webID, method, path, contentType, body)
+	}
+
+	// The initial root ACR grants everyone full access and advertises the supported modes and attributes
+	result := as("", "GET", ".acr", "", "")
+	if result.Code != 200 || !strings.Contains(result.Body.String(), "PublicAgent") {
+		t.Fatalf("GET of the root ACR = %v %v", result.Code, result.Body.String())
+	}
+	links := result.Header().Values("Link")
+	for _, link := range []string{
+		`<http://www.w3.org/ns/solid/acp#AccessControlResource>; rel="type"`,
+		`<http://www.w3.org/ns/auth/acl#Control>; rel="http://www.w3.org/ns/solid/acp#grant"`,
+		`<http://www.w3.org/ns/solid/acp#agent>; rel="http://www.w3.org/ns/solid/acp#attribute"`,
+	} {
+		if !contains(links, link) {
+			t.Errorf("Link headers of the root ACR %v lack %v", links, link)
+		}
+	}
+	if links := as("", "GET", "", "", "").Header().Values("Link"); !contains(links, `<`+baseURL+`.acr>; rel="acl"`) ||
+		contains(links, `<http://www.w3.org/ns/solid/acp#AccessControlResource>; rel="type"`) {
+		t.Errorf("Link headers of the root container = %v", links)
+	}
+
+	rootAcr := `@prefix acp: <http://www.w3.org/ns/solid/acp#>.
+@prefix acl: <http://www.w3.org/ns/auth/acl#>.
+<#root> acp:accessControl <#access>; acp:memberAccessControl <#access>.
+<#access> acp:apply <#public>, <#owner>.
+<#public> acp:allow acl:Read; acp:anyOf <#anyone>.
+<#anyone> acp:agent acp:PublicAgent.
+<#owner> acp:allow acl:Write, acl:Control; acp:allOf <#alice>.
+<#alice> acp:agent <` + alice + `>.`
+	if result := as("", "PUT", ".acr", "text/turtle", rootAcr); result.Code != 205 {
+		t.Fatalf("PUT of the root ACR = %v %v", result.Code, result.Body.String())
+	}
+
+	if result := as("", "PUT", "doc.txt", "text/plain", "hello"); result.Code != 401 {
+		t.Errorf("public PUT = %v, want 401", result.Code)
+	}
+	if result := as(alice, "PUT", "doc.txt", "text/plain", "hello"); result.Code != 201 {
+		t.Errorf("PUT by the owner = %v, want 201", result.Code)
+	}
+	if result := as("", "GET", "doc.txt", "", ""); result.Code != 200 {
+		t.Errorf("public GET of an inherited policy = %v, want 200", result.Code)
+	}
+	if result := as("", "GET", "doc.txt.acr", "", ""); result.Code != 401 {
+		t.Errorf("public GET of an ACR = %v, want 401", result.Code)
+	}
+
+	// The policies of a resource's own ACR add to the member policies of its ancestors
+	docAcr := `@prefix acp: <http://www.w3.org/ns/solid/acp#>.
+@prefix acl: <http://www.w3.org/ns/auth/acl#>.
+<#doc> acp:accessControl <#access>.
+<#access> acp:apply <#denyPublic>.
+<#denyPublic> acp:deny acl:Read; acp:anyOf <#anyone>; acp:noneOf <#alice>.
+<#anyone> acp:agent acp:PublicAgent.
+<#alice> acp:agent <` + alice + `>.`
+	if result := as(alice, "PUT", "doc.txt.acr", "text/turtle", docAcr); result.Code != 201 {
+		t.Fatalf("PUT of an ACR by the owner = %v %v", result.Code, result.Body.String())
+	}
+	if result := as("", "GET", "doc.txt", "", ""); result.Code != 401 {
+		t.Errorf("public GET of a denied resource = %v, want 401", result.Code)
+	}
+	if result := as(alice, "GET", "doc.txt", "", ""); result.Code != 200 {
+		t.Errorf("GET by an agent excluded from the deny policy = %v, want 200", result.Code)
+	}
+}
+
+func TestServer_WebSocketNotifications(t *testing.T) {
+	testServer := httptest.NewUnstartedServer(nil)
+	base := "http://" + testServer.Listener.Addr().String() + "/"
+	options := newTestOptions(base, AuthModeAllowAll, nil)
+	options.NotificationChannels = []string{NotificationChannelWebSocket}
+	srv, err := NewServer(options)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	testServer.Config.Handler = srv.Handler()
+	testServer.Start()
+	defer testServer.Close()
+	service := base + ".notifications/WebSocketChannel2023/"
+
+	describe, err := http.Get(service)
+	if err != nil {
+		t.Fatalf("GET %s error = %v", service, err)
+	}
+	description, _ := io.ReadAll(describe.Body)
+	describe.Body.Close()
+	if describe.StatusCode != http.StatusOK || !strings.Contains(string(description), `"channelType":"`+
+		"http://www.w3.org/ns/solid/notifications#WebSocketChannel2023"+`"`) {
+		t.Errorf("GET %s = %d %s", service, describe.StatusCode, description)
+	}
+
+	subscribe := func(contentType, topic string) *http.Response {
+		t.Helper()
+		response, err := http.Post(service, contentType, strings.NewReader(
+			`{"@context":["https://www.w3.org/ns/solid/notification/v1"],"type":"WebSocketChannel2023","topic":"`+topic+`"}`))
+		if err != nil {
+			t.Fatalf("POST %s error = %v", service, err)
+		}
+		return response
+	}
+	if response := subscribe("text/turtle", base+"doc.ttl"); response.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("subscribing with Turtle = %d, want %d", response.StatusCode, http.StatusUnsupportedMediaType)
+	}
+	if response := subscribe("application/ld+json", "http://example.com/doc.ttl"); response.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("subscribing to a foreign topic = %d, want %d", response.StatusCode, http.StatusUnprocessableEntity)
+	}
+	response := subscribe("application/ld+json", base+"doc.ttl")
+	var channel struct {
+		Topic       string `json:"topic"`
+		ReceiveFrom string `json:"receiveFrom"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&channel); err != nil || response.StatusCode != http.StatusOK {
+		t.Fatalf("subscribing = %d, %v", response.StatusCode, err)
+	}
+	response.Body.Close()
+	if channel.Topic != base+"doc.ttl" || !strings.HasPrefix(channel.ReceiveFrom, "ws://") {
+		t.Fatalf("channel = %+v", channel)
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(channel.ReceiveFrom, nil)
+	if err != nil {
+		t.Fatalf("Dial(%s) error = %v", channel.ReceiveFrom, err)
+	}
+	defer conn.Close()
+	request, _ := http.NewRequest("PUT", base+"doc.ttl", strings.NewReader("<a> <b> <c>."))
+	request.Header.Set("Content-Type", "text/turtle")
+	put, err := http.DefaultClient.Do(request)
+	if err != nil || put.StatusCode != http.StatusCreated {
+		t.Fatalf("PUT doc.ttl = %v, %v", put, err)
+	}
+	put.Body.Close()
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var notification struct {
+		Type   string `json:"type"`
+		Object string `json:"object"`
+		State  string `json:"state"`
+	}
+	if err := conn.ReadJSON(&notification); err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+	if notification.Type != "Create" || notification.Object != base+"doc.ttl" || notification.State == "" {
+		t.Errorf("notification = %+v", notification)
+	}
+}
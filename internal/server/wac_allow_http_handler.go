@@ -1,136 +1,98 @@
 package server
 
 import (
-	"context"
-	"log"
-)
-
-var validMethods = map[string]bool{"GET": true, "HEAD": true}
-var validAclModes = map[AccessMode]bool{
-	"read":    true,
-	"write":   true,
-	"append":  true,
-	"control": true,
-}
-
-type AclPermissionSet map[AccessMode]bool
+	"sort"
 
-type RepresentationMetadata struct {
-	Headers map[string]string
-}
-
-func (m *RepresentationMetadata) Add(header, value string) {
-	if m.Headers == nil {
-		m.Headers = make(map[string]string)
-	}
-	m.Headers[header] = value
-}
-
-type NotModifiedHttpError struct {
-	Msg string
-}
+	"solid-go/internal/authentication"
+	"solid-go/internal/authorization"
+	"solid-go/internal/authorization/permissions"
+	"solid-go/internal/http/output/response"
+)
 
-func (e *NotModifiedHttpError) Error() string { return e.Msg }
+// wacAllowModes are the access modes that can be advertised in the WAC-Allow header
+var wacAllowModes = []permissions.AccessMode{permissions.Read, permissions.Write, permissions.Append, permissions.Control}
 
-// --- WacAllowHttpHandler implementation ---
+// WacAllowHttpHandler adds the access modes of the current agent and of the public
+// to the metadata of successful GET and HEAD responses,
+// so they can be advertised in the WAC-Allow header.
+// The agent is determined by the credentials of the input, the public if there are none.
 type WacAllowHttpHandler struct {
-	credentialsExtractor CredentialsExtractor
-	modesExtractor       ModesExtractor
-	permissionReader     PermissionReader
-	operationHandler     OperationHttpHandler
+	modesExtractor   permissions.ModesExtractor
+	permissionReader authorization.PermissionReader
+	operationHandler OperationHttpHandler
 }
 
+// NewWacAllowHttpHandler creates a new WacAllowHttpHandler
 func NewWacAllowHttpHandler(
-	credentialsExtractor CredentialsExtractor,
-	modesExtractor ModesExtractor,
-	permissionReader PermissionReader,
+	modesExtractor permissions.ModesExtractor,
+	permissionReader authorization.PermissionReader,
 	operationHandler OperationHttpHandler,
 ) *WacAllowHttpHandler {
 	return &WacAllowHttpHandler{
-		credentialsExtractor: credentialsExtractor,
-		modesExtractor:       modesExtractor,
-		permissionReader:     permissionReader,
-		operationHandler:     operationHandler,
+		modesExtractor:   modesExtractor,
+		permissionReader: permissionReader,
+		operationHandler: operationHandler,
 	}
 }
 
-func (h *WacAllowHttpHandler) Handle(ctx context.Context, input OperationHttpHandlerInput) (ResponseDescription, error) {
-	// Call the wrapped handler first
-	resp, err := h.operationHandler.HandleSafe(ctx, input)
+// Handle implements OperationHttpHandler.Handle
+func (h *WacAllowHttpHandler) Handle(input OperationHttpHandlerInput) (*response.ResponseDescription, error) {
+	description, err := h.operationHandler.Handle(input)
 	if err != nil {
-		if _, ok := err.(*NotModifiedHttpError); ok {
-			// Continue to add WAC-Allow headers to 304 responses
-			log.Printf("WacAllowHttpHandler: NotModifiedHttpError, will add WAC-Allow headers")
-		} else {
-			log.Printf("WacAllowHttpHandler: operation handler error: %v", err)
-			return resp, err
-		}
+		return nil, err
 	}
-
-	// Only add WAC-Allow headers for GET/HEAD requests
-	operation, ok := input.Operation.(map[string]interface{})
-	if !ok {
-		return resp, nil
-	}
-	method, _ := operation["method"].(string)
-	if !validMethods[method] {
-		return resp, nil
+	method := input.Operation.Method
+	if (method != "GET" && method != "HEAD") || description.Metadata == nil {
+		return description, nil
 	}
 
-	metadata := &RepresentationMetadata{}
-	log.Printf("WacAllowHttpHandler: Determining available permissions.")
-	credentials, err := h.credentialsExtractor.HandleSafe(ctx, input.Request)
+	credentials := input.Credentials
+	if credentials == nil {
+		credentials = &authentication.Credentials{}
+	}
+	requestedModes, err := h.modesExtractor.Extract(input.Operation)
 	if err != nil {
-		log.Printf("WacAllowHttpHandler: failed to extract credentials: %v", err)
-		return resp, err
+		return nil, err
 	}
-	requestedModes, err := h.modesExtractor.HandleSafe(ctx, input.Operation)
+	target := input.Operation.Target.Path
+	user, err := h.readPermissions(credentials, requestedModes, target)
 	if err != nil {
-		log.Printf("WacAllowHttpHandler: failed to extract required modes: %v", err)
-		return resp, err
+		return nil, err
+	}
+	// Only read the permissions of the public separately when they can differ from those of the agent
+	everyone := user
+	if !credentials.IsPublic() {
+		if everyone, err = h.readPermissions(&authentication.Credentials{}, requestedModes, target); err != nil {
+			return nil, err
+		}
 	}
-	_, err = h.permissionReader.HandleSafe(ctx, PermissionReaderInput{
+
+	description.Metadata.Store["userMode"] = allowedModes(user)
+	description.Metadata.Store["publicMode"] = allowedModes(everyone)
+	return description, nil
+}
+
+// readPermissions returns the permissions the given credentials have on the target
+func (h *WacAllowHttpHandler) readPermissions(credentials *authentication.Credentials,
+	requestedModes permissions.AccessMap, target string) (permissions.PermissionSet, error) {
+	available, err := h.permissionReader.Read(authorization.PermissionReaderInput{
 		Credentials:    credentials,
 		RequestedModes: requestedModes,
 	})
 	if err != nil {
-		log.Printf("WacAllowHttpHandler: failed to read available permissions: %v", err)
-		return resp, err
+		return nil, err
 	}
-
-	// Simulate permission set extraction
-	permissionSet := AclPermissionSet{"read": true, "write": false} // Placeholder
-	var everyone AclPermissionSet
-	// For demonstration, just use permissionSet for both
-	everyone = permissionSet
-
-	log.Printf("WacAllowHttpHandler: Adding WAC-Allow metadata")
-	h.addWacAllowMetadata(metadata, everyone, permissionSet)
-
-	return resp, nil
+	return available[target], nil
 }
 
-// addWacAllowMetadata adds WAC-Allow headers to the metadata
-func (h *WacAllowHttpHandler) addWacAllowMetadata(metadata *RepresentationMetadata, everyone, user AclPermissionSet) {
-	modes := make(map[AccessMode]bool)
-	for mode := range user {
-		modes[mode] = true
-	}
-	for mode := range everyone {
-		modes[mode] = true
-	}
-	for mode := range modes {
-		if validAclModes[mode] {
-			capitalizedMode := string(mode)
-			if len(capitalizedMode) > 0 {
-				capitalizedMode = string(capitalizedMode[0]-32) + capitalizedMode[1:]
-			}
-			if everyone[mode] {
-				metadata.Add("WAC-Allow-Public", capitalizedMode)
-			}
-			if user[mode] {
-				metadata.Add("WAC-Allow-User", capitalizedMode)
-			}
+// allowedModes returns the sorted WAC-Allow modes that are granted in the set
+func allowedModes(set permissions.PermissionSet) []string {
+	var modes []string
+	for _, mode := range wacAllowModes {
+		if set.Has(mode) {
+			modes = append(modes, string(mode))
 		}
 	}
+	sort.Strings(modes)
+	return modes
 }
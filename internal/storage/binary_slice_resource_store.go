@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+
+	"solid-go/internal/http/input/conditions"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
+
+// maxRanges is the number of ranges a request can have before the Range header is ignored
+const maxRanges = 16
+
+// BinarySliceResourceStore answers range requests on binary resources with the requested slices of the data.
+// A single range results in the slice with unit, start and end in the metadata,
+// multiple ranges result in a multipart/byteranges body with the boundary in the metadata.
+// The content type of the metadata stays that of the resource, so the ETag does not change.
+// Ranges on non-binary representations, in units other than bytes,
+// or that together are longer than the resource, are ignored.
+type BinarySliceResourceStore struct {
+	*PassthroughStore
+}
+
+// NewBinarySliceResourceStore creates a new BinarySliceResourceStore
+func NewBinarySliceResourceStore(source ResourceStore) *BinarySliceResourceStore {
+	return &BinarySliceResourceStore{PassthroughStore: NewPassthroughStore(source)}
+}
+
+// byteRange is a satisfiable range with inclusive bounds
+type byteRange struct {
+	start, end int64
+}
+
+// GetRepresentation implements ResourceStore.GetRepresentation
+func (s *BinarySliceResourceStore) GetRepresentation(identifier representation.ResourceIdentifier,
+	preferences *representation.RepresentationPreferences, conditions conditions.Conditions) (representation.Representation, error) {
+	rep, err := s.Source.GetRepresentation(identifier, preferences, conditions)
+	if err != nil || preferences == nil || preferences.Range == nil || preferences.Range.Unit != "bytes" || !rep.IsBinary() {
+		return rep, err
+	}
+	metadata := rep.GetMetadata()
+	size, ok := ResourceSize(metadata)
+	if !ok {
+		return rep, nil
+	}
+	ranges, valid := resolveRanges(preferences.Range.Parts, size)
+	if !valid {
+		return rep, nil
+	}
+	if len(ranges) == 0 {
+		closeData(rep.GetData())
+		return nil, errors.NewRangeNotSatisfiableError(
+			fmt.Sprintf("none of the requested ranges overlap the %d bytes of %s", size, identifier.Path), size)
+	}
+
+	if len(ranges) == 1 {
+		data, err := sliceData(rep.GetData(), ranges[0])
+		if err != nil {
+			return nil, err
+		}
+		metadata.Add("unit", "bytes").Add("start", ranges[0].start).Add("end", ranges[0].end)
+		return representation.NewBasicRepresentation(data, metadata, true), nil
+	}
+
+	body, boundary, err := multipartBody(rep.GetData(), ranges, metadata.ContentType(), size)
+	if err != nil {
+		return nil, err
+	}
+	metadata.Add("unit", "bytes").Add("boundary", boundary)
+	return representation.NewBasicRepresentation(body, metadata, true), nil
+}
+
+// resolveRanges converts the requested parts to absolute ranges within a resource of the given size.
+// Parts that start beyond the end of the resource are dropped.
+// The result is not valid if a part has its end before its start, in which case the header has to be ignored.
+// So is a request with too many ranges, or ranges longer than the resource,
+// since those only serve to make a small request produce a large response.
+func resolveRanges(parts []representation.RangePart, size int64) ([]byteRange, bool) {
+	if len(parts) > maxRanges {
+		return nil, false
+	}
+	var ranges []byteRange
+	var total int64
+	for _, part := range parts {
+		start := int64(part.Start)
+		end := size - 1
+		if part.Start < 0 {
+			// A suffix range selects the last bytes of the resource
+			start = size + start
+			if start < 0 {
+				start = 0
+			}
+		} else if part.End != nil {
+			if int64(*part.End) < start {
+				return nil, false
+			}
+			if int64(*part.End) < end {
+				end = int64(*part.End)
+			}
+		}
+		if start >= size {
+			continue
+		}
+		ranges = append(ranges, byteRange{start: start, end: end})
+		total += end - start + 1
+	}
+	if len(ranges) > 1 && total > size {
+		return nil, false
+	}
+	return ranges, true
+}
+
+// sliceData returns a reader for a range of the data, seeking if the data supports it
+func sliceData(data io.Reader, r byteRange) (io.Reader, error) {
+	if seeker, ok := data.(io.Seeker); ok {
+		if _, err := seeker.Seek(r.start, io.SeekStart); err != nil {
+			closeData(data)
+			return nil, err
+		}
+	} else if _, err := io.CopyN(io.Discard, data, r.start); err != nil {
+		closeData(data)
+		return nil, err
+	}
+	limited := io.LimitReader(data, r.end-r.start+1)
+	if closer, ok := data.(io.Closer); ok {
+		return struct {
+			io.Reader
+			io.Closer
+		}{limited, closer}, nil
+	}
+	return limited, nil
+}
+
+// multipartBody streams the ranges of the data as a multipart/byteranges body and returns it with its boundary.
+// Data that can not seek is buffered first, since the ranges can be in any order.
+func multipartBody(data io.Reader, ranges []byteRange, contentType string, size int64) (io.Reader, string, error) {
+	seeker, ok := data.(io.ReadSeeker)
+	if !ok {
+		buffer, err := io.ReadAll(data)
+		closeData(data)
+		if err != nil {
+			return nil, "", err
+		}
+		seeker = bytes.NewReader(buffer)
+	}
+
+	reader, pipe := io.Pipe()
+	writer := multipart.NewWriter(pipe)
+	go func() {
+		defer closeData(seeker)
+		pipe.CloseWithError(writeParts(writer, seeker, ranges, contentType, size))
+	}()
+	return reader, writer.Boundary(), nil
+}
+
+// writeParts writes every range of the data as a part with its own Content-Range header
+func writeParts(writer *multipart.Writer, data io.ReadSeeker, ranges []byteRange, contentType string, size int64) error {
+	for _, r := range ranges {
+		header := textproto.MIMEHeader{}
+		if contentType != "" {
+			header.Set("Content-Type", contentType)
+		}
+		header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", r.start, r.end, size))
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if _, err := data.Seek(r.start, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err := io.CopyN(part, data, r.end-r.start+1); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// closeData closes the data of a representation that will not be returned
+func closeData(data io.Reader) {
+	if closer, ok := data.(io.Closer); ok {
+		closer.Close()
+	}
+}
@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
+
+func rangePreferences(parts ...representation.RangePart) *representation.RepresentationPreferences {
+	return &representation.RepresentationPreferences{Range: &representation.Range{Unit: "bytes", Parts: parts}}
+}
+
+func end(value int) *int {
+	return &value
+}
+
+func TestBinarySliceResourceStore(t *testing.T) {
+	source := NewDataAccessorBasedStore(NewInMemoryDataAccessor(baseURL), baseURL)
+	id := identifier("video.mp4")
+	if _, err := source.SetRepresentation(id, textRepresentation("0123456789"), nil); err != nil {
+		t.Fatalf("SetRepresentation() error = %v", err)
+	}
+	store := NewBinarySliceResourceStore(source)
+
+	tests := []struct {
+		name   string
+		parts  []representation.RangePart
+		body   string
+		start  int64
+		end    int64
+		ranged bool
+	}{
+		{"no range", nil, "0123456789", 0, 0, false},
+		{"bounded", []representation.RangePart{{Start: 2, End: end(4)}}, "234", 2, 4, true},
+		{"open ended", []representation.RangePart{{Start: 7}}, "789", 7, 9, true},
+		{"end beyond size", []representation.RangePart{{Start: 8, End: end(100)}}, "89", 8, 9, true},
+		{"suffix", []representation.RangePart{{Start: -3}}, "789", 7, 9, true},
+		{"invalid range is ignored", []representation.RangePart{{Start: 5, End: end(1)}}, "0123456789", 0, 0, false},
+		{"ranges longer than the resource are ignored", []representation.RangePart{{Start: 0}, {Start: 0}}, "0123456789", 0, 0, false},
+		{"too many ranges are ignored", []representation.RangePart{{Start: 0, End: end(0)}, {Start: 1, End: end(1)},
+			{Start: 2, End: end(2)}, {Start: 3, End: end(3)}, {Start: 4, End: end(4)}, {Start: 5, End: end(5)},
+			{Start: 6, End: end(6)}, {Start: 7, End: end(7)}, {Start: 8, End: end(8)}, {Start: 9, End: end(9)},
+			{Start: 0, End: end(0)}, {Start: 1, End: end(1)}, {Start: 2, End: end(2)}, {Start: 3, End: end(3)},
+			{Start: 4, End: end(4)}, {Start: 5, End: end(5)}, {Start: 6, End: end(6)}}, "0123456789", 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var preferences *representation.RepresentationPreferences
+			if tt.parts != nil {
+				preferences = rangePreferences(tt.parts...)
+			}
+			rep, err := store.GetRepresentation(id, preferences, nil)
+			if err != nil {
+				t.Fatalf("GetRepresentation() error = %v", err)
+			}
+			if body, _ := io.ReadAll(rep.GetData()); string(body) != tt.body {
+				t.Errorf("body = %q, want %q", string(body), tt.body)
+			}
+			_, ranged := rep.GetMetadata().Get("unit")
+			if ranged != tt.ranged {
+				t.Fatalf("ranged = %v, want %v", ranged, tt.ranged)
+			}
+			if ranged {
+				start, _ := rep.GetMetadata().Get("start")
+				stop, _ := rep.GetMetadata().Get("end")
+				if start != tt.start || stop != tt.end {
+					t.Errorf("range = %v-%v, want %v-%v", start, stop, tt.start, tt.end)
+				}
+			}
+		})
+	}
+
+	if _, err := store.GetRepresentation(id, rangePreferences(representation.RangePart{Start: 10}), nil); !errors.IsRangeNotSatisfiableError(err) {
+		t.Errorf("GetRepresentation() beyond the end error = %v, want RangeNotSatisfiableError", err)
+	} else if got := errors.ResponseHeaders(err)["Content-Range"]; got != "bytes */10" {
+		t.Errorf("Content-Range = %q, want bytes */10", got)
+	}
+}
+
+func TestBinarySliceResourceStore_Multipart(t *testing.T) {
+	source := NewDataAccessorBasedStore(NewInMemoryDataAccessor(baseURL), baseURL)
+	id := identifier("video.mp4")
+	if _, err := source.SetRepresentation(id, textRepresentation("0123456789"), nil); err != nil {
+		t.Fatalf("SetRepresentation() error = %v", err)
+	}
+	rep, err := NewBinarySliceResourceStore(source).GetRepresentation(id,
+		rangePreferences(representation.RangePart{Start: 0, End: end(1)}, representation.RangePart{Start: -2}), nil)
+	if err != nil {
+		t.Fatalf("GetRepresentation() error = %v", err)
+	}
+	// The metadata keeps the content type of the resource, so the ETag is the same as that of the full representation
+	if got := rep.GetMetadata().ContentType(); !strings.HasPrefix(got, "text/plain") {
+		t.Errorf("ContentType() = %v, want text/plain", got)
+	}
+	boundary, ok := rep.GetMetadata().Get("boundary")
+	if !ok {
+		t.Fatalf("GetRepresentation() has no multipart boundary")
+	}
+
+	reader := multipart.NewReader(rep.GetData(), boundary.(string))
+	want := []struct{ contentRange, body string }{{"bytes 0-1/10", "01"}, {"bytes 8-9/10", "89"}}
+	for _, w := range want {
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Fatalf("NextPart() error = %v", err)
+		}
+		if got := part.Header.Get("Content-Range"); got != w.contentRange {
+			t.Errorf("part Content-Range = %q, want %q", got, w.contentRange)
+		}
+		if got := part.Header.Get("Content-Type"); !strings.HasPrefix(got, "text/plain") {
+			t.Errorf("part Content-Type = %q, want text/plain", got)
+		}
+		if body, _ := io.ReadAll(part); string(body) != w.body {
+			t.Errorf("part body = %q, want %q", string(body), w.body)
+		}
+	}
+	if _, err := reader.NextPart(); err != io.EOF {
+		t.Errorf("NextPart() after the last part error = %v, want EOF", err)
+	}
+}
@@ -0,0 +1,158 @@
+package conversion
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"solid-go/internal/http/output/serialize"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
+
+// defaultMaxSteps limits the number of converters that are chained together
+const defaultMaxSteps = 3
+
+// conversionPath is a sequence of converters and the types they produce
+type conversionPath struct {
+	converters []TypedRepresentationConverter
+	// types starts with the input type and contains the output type of every converter
+	types []string
+	// weight is the product of the weights of all converter steps
+	weight float64
+}
+
+func (p conversionPath) outputType() string {
+	return p.types[len(p.types)-1]
+}
+
+func (p conversionPath) extend(converter TypedRepresentationConverter, outputType string, weight float64) conversionPath {
+	return conversionPath{
+		converters: append(append([]TypedRepresentationConverter(nil), p.converters...), converter),
+		types:      append(append([]string(nil), p.types...), outputType),
+		weight:     weight,
+	}
+}
+
+// ChainedConverter is a registry of typed converters that finds and executes the best conversion path.
+// A path is scored by multiplying the weights of all its steps with the client's preference for the result.
+// If the representation already has an acceptable type that scores at least as well, it is returned unchanged.
+type ChainedConverter struct {
+	converters []TypedRepresentationConverter
+	maxSteps   int
+}
+
+// NewChainedConverter creates a new ChainedConverter.
+// Converters earlier in the list win ties between equally weighted paths.
+func NewChainedConverter(converters []TypedRepresentationConverter) *ChainedConverter {
+	return &ChainedConverter{converters: converters, maxSteps: defaultMaxSteps}
+}
+
+// WithMaxSteps sets the maximum number of converters in a path
+func (c *ChainedConverter) WithMaxSteps(maxSteps int) *ChainedConverter {
+	c.maxSteps = maxSteps
+	return c
+}
+
+// CanHandle implements RepresentationConverter.CanHandle
+func (c *ChainedConverter) CanHandle(args RepresentationConverterArgs) error {
+	_, err := c.findPath(args)
+	return err
+}
+
+// Handle implements RepresentationConverter.Handle
+func (c *ChainedConverter) Handle(args RepresentationConverterArgs) (representation.Representation, error) {
+	path, err := c.findPath(args)
+	if err != nil {
+		return nil, err
+	}
+	result := args.Representation
+	for i, converter := range path.converters {
+		stepArgs := RepresentationConverterArgs{
+			Identifier:     args.Identifier,
+			Representation: result,
+			Preferences:    preferencesForType(args.Preferences, path.types[i+1]),
+		}
+		if result, err = converter.Handle(stepArgs); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// findPath returns the highest scoring conversion path; an empty path means no conversion is needed
+func (c *ChainedConverter) findPath(args RepresentationConverterArgs) (conversionPath, error) {
+	inputType := contentTypeOf(args.Representation)
+	if inputType == "" {
+		return conversionPath{}, errors.NewUnsupportedMediaTypeError("cannot convert data without a content type", nil)
+	}
+	var typePreferences representation.ValuePreferences
+	if args.Preferences != nil {
+		typePreferences = args.Preferences.Type
+	}
+
+	start := conversionPath{types: []string{inputType}, weight: 1}
+	best, bestScore := start, GetTypeWeight(inputType, typePreferences)
+	reached := map[string]float64{inputType: 1}
+	frontier := []conversionPath{start}
+
+	for step := 0; step < c.maxSteps && len(frontier) > 0; step++ {
+		var next []conversionPath
+		for _, path := range frontier {
+			for _, converter := range c.converters {
+				inputWeight := GetTypeWeight(path.outputType(), converter.GetInputTypes())
+				if inputWeight <= 0 {
+					continue
+				}
+				outputTypes := converter.GetOutputTypes(path.outputType())
+				for _, outputType := range sortedTypes(outputTypes) {
+					weight := path.weight * inputWeight * outputTypes[outputType]
+					if previous, ok := reached[outputType]; ok && previous >= weight {
+						continue
+					}
+					reached[outputType] = weight
+					extended := path.extend(converter, outputType, weight)
+					next = append(next, extended)
+					if score := weight * GetTypeWeight(outputType, typePreferences); score > bestScore {
+						best, bestScore = extended, score
+					}
+				}
+			}
+		}
+		frontier = next
+	}
+
+	if bestScore <= 0 {
+		return conversionPath{}, errors.NewNotAcceptableError(fmt.Sprintf(
+			"no conversion from %s to any of %s", inputType, strings.Join(sortedTypes(typePreferences), ", ")), nil)
+	}
+	return best, nil
+}
+
+// preferencesForType returns a copy of the preferences that only accepts the given type
+func preferencesForType(preferences *representation.RepresentationPreferences, contentType string) *representation.RepresentationPreferences {
+	result := &representation.RepresentationPreferences{}
+	if preferences != nil {
+		*result = *preferences
+	}
+	result.Type = representation.ValuePreferences{contentType: 1}
+	return result
+}
+
+func sortedTypes(types representation.ValuePreferences) []string {
+	keys := make([]string, 0, len(types))
+	for key := range types {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// NewRdfConverter creates a ChainedConverter that converts between all supported RDF syntaxes
+// through the internal quads type, e.g. Turtle→quads→JSON-LD.
+func NewRdfConverter(options serialize.Options) *ChainedConverter {
+	return NewChainedConverter([]TypedRepresentationConverter{
+		NewRdfToQuadConverter(),
+		NewQuadToRdfConverter(options),
+	})
+}
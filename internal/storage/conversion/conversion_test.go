@@ -0,0 +1,120 @@
+package conversion
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"solid-go/internal/http/output/serialize"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+)
+
+const turtle = `@prefix ex: <http://example.org/vocab#> .
+<> ex:name "foo" ; ex:knows <bar> .`
+
+func turtleRepresentation() representation.Representation {
+	metadata := representation.NewRepresentationMetadata("http://example.org/foo").SetContentType(util.Turtle)
+	return representation.NewBasicRepresentation(strings.NewReader(turtle), metadata, false)
+}
+
+func preferences(types representation.ValuePreferences) *representation.RepresentationPreferences {
+	return &representation.RepresentationPreferences{Type: types}
+}
+
+func TestGetTypeWeight(t *testing.T) {
+	prefs := representation.ValuePreferences{"text/*": 0.5, "text/turtle": 0.8, "*/*": 0.1}
+	tests := []struct {
+		contentType string
+		want        float64
+	}{
+		{util.Turtle, 0.8},
+		{"text/plain; charset=utf-8", 0.5},
+		{util.JSONLD, 0.1},
+		{util.InternalQuads, 0},
+	}
+	for _, tt := range tests {
+		if got := GetTypeWeight(tt.contentType, prefs); got != tt.want {
+			t.Errorf("GetTypeWeight(%v) = %v, want %v", tt.contentType, got, tt.want)
+		}
+	}
+	if got := GetTypeWeight(util.Turtle, nil); got != 1 {
+		t.Errorf("GetTypeWeight() without preferences = %v, want 1", got)
+	}
+}
+
+func TestGetWeightedPreferences(t *testing.T) {
+	available := representation.ValuePreferences{util.Turtle: 1, util.NTriples: 0.9, util.JSONLD: 1}
+	got := GetWeightedPreferences(available, representation.ValuePreferences{"text/turtle": 0.5, "application/*": 0.8})
+	if len(got) != 3 || got[0].Value != util.JSONLD || got[1].Value != util.NTriples || got[2].Value != util.Turtle {
+		t.Errorf("GetWeightedPreferences() = %v", got)
+	}
+}
+
+func TestChainedConverter_TurtleToJsonLd(t *testing.T) {
+	converter := NewRdfConverter(serialize.Options{})
+	result, err := converter.Handle(RepresentationConverterArgs{
+		Identifier:     representation.ResourceIdentifier{Path: "http://example.org/foo"},
+		Representation: turtleRepresentation(),
+		Preferences:    preferences(representation.ValuePreferences{util.Turtle: 0.5, util.JSONLD: 1}),
+	})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if got := result.GetMetadata().ContentType(); got != util.JSONLD {
+		t.Errorf("ContentType() = %v, want %v", got, util.JSONLD)
+	}
+	data, err := io.ReadAll(result.GetData())
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid JSON-LD %s: %v", data, err)
+	}
+	if doc["@id"] != "http://example.org/foo" {
+		t.Errorf("@id = %v, want the resolved identifier", doc["@id"])
+	}
+}
+
+func TestChainedConverter_KeepsAcceptableType(t *testing.T) {
+	converter := NewRdfConverter(serialize.Options{})
+	input := turtleRepresentation()
+	result, err := converter.Handle(RepresentationConverterArgs{
+		Representation: input,
+		Preferences:    preferences(representation.ValuePreferences{"text/*": 1, util.JSONLD: 1}),
+	})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if result != input {
+		t.Errorf("Handle() converted data that was already acceptable")
+	}
+}
+
+func TestChainedConverter_NotAcceptable(t *testing.T) {
+	converter := NewRdfConverter(serialize.Options{})
+	args := RepresentationConverterArgs{
+		Representation: turtleRepresentation(),
+		Preferences:    preferences(representation.ValuePreferences{util.TextHTML: 1}),
+	}
+	if err := converter.CanHandle(args); !errors.IsNotAcceptableError(err) {
+		t.Errorf("CanHandle() error = %v, want NotAcceptableError", err)
+	}
+	if _, err := converter.Handle(args); errors.StatusCode(err) != 406 {
+		t.Errorf("Handle() status = %v, want 406", errors.StatusCode(err))
+	}
+}
+
+func TestChainedConverter_InvalidRdf(t *testing.T) {
+	metadata := representation.NewRepresentationMetadata("http://example.org/foo").SetContentType(util.Turtle)
+	_, err := NewRdfConverter(serialize.Options{}).Handle(RepresentationConverterArgs{
+		Representation: representation.NewBasicRepresentation(strings.NewReader("<a> <b>"), metadata, false),
+		Preferences:    preferences(representation.ValuePreferences{util.InternalQuads: 1}),
+	})
+	if errors.StatusCode(err) != 400 {
+		t.Errorf("Handle() error = %v, want a 400 error", err)
+	}
+}
@@ -0,0 +1,86 @@
+package conversion
+
+import (
+	"sort"
+	"strings"
+
+	"solid-go/internal/http/representation"
+)
+
+// internalTypePrefix marks content types that are only used inside the server
+const internalTypePrefix = "internal/"
+
+// normalizeType strips parameters and whitespace from a content type
+func normalizeType(contentType string) string {
+	return strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+}
+
+// MatchesMediaType checks if a media range such as "text/*" matches a content type.
+// Wildcards never match internal types, those have to be requested explicitly.
+func MatchesMediaType(mediaRange, contentType string) bool {
+	mediaRange, contentType = normalizeType(mediaRange), normalizeType(contentType)
+	if mediaRange == contentType {
+		return true
+	}
+	if strings.HasPrefix(contentType, internalTypePrefix) {
+		return false
+	}
+	if mediaRange == "*/*" || mediaRange == "*" {
+		return true
+	}
+	rangeMain, rangeSub, _ := strings.Cut(mediaRange, "/")
+	typeMain, _, _ := strings.Cut(contentType, "/")
+	return rangeSub == "*" && rangeMain == typeMain
+}
+
+// specificity ranks media ranges so the most specific matching range determines the weight
+func specificity(mediaRange string) int {
+	switch {
+	case mediaRange == "*/*" || mediaRange == "*":
+		return 0
+	case strings.HasSuffix(mediaRange, "/*"):
+		return 1
+	}
+	return 2
+}
+
+// GetTypeWeight returns the weight the preferences assign to a content type.
+// Without preferences every type has weight 1.
+func GetTypeWeight(contentType string, preferences representation.ValuePreferences) float64 {
+	if len(preferences) == 0 {
+		return 1
+	}
+	weight, best := 0.0, -1
+	for mediaRange, q := range preferences {
+		if !MatchesMediaType(mediaRange, contentType) {
+			continue
+		}
+		if s := specificity(normalizeType(mediaRange)); s > best || (s == best && q > weight) {
+			weight, best = q, s
+		}
+	}
+	return weight
+}
+
+// GetWeightedPreferences returns the types that have a positive weight in both the available
+// types and the preferences, with the product of both weights, and the best one first.
+func GetWeightedPreferences(available, preferences representation.ValuePreferences) []representation.ValuePreference {
+	var result []representation.ValuePreference
+	for contentType, weight := range available {
+		if w := weight * GetTypeWeight(contentType, preferences); w > 0 {
+			result = append(result, representation.ValuePreference{Value: contentType, Weight: w})
+		}
+	}
+	sortPreferences(result)
+	return result
+}
+
+// sortPreferences orders preferences by descending weight and then by value for stable output
+func sortPreferences(preferences []representation.ValuePreference) {
+	sort.Slice(preferences, func(i, j int) bool {
+		if preferences[i].Weight != preferences[j].Weight {
+			return preferences[i].Weight > preferences[j].Weight
+		}
+		return preferences[i].Value < preferences[j].Value
+	})
+}
@@ -0,0 +1,72 @@
+package conversion
+
+import (
+	"io"
+
+	"solid-go/internal/http/output/serialize"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+)
+
+// QuadToRdfConverter serializes in-memory datasets to RDF documents
+type QuadToRdfConverter struct {
+	BaseTypedRepresentationConverter
+	options serialize.Options
+}
+
+// NewQuadToRdfConverter creates a new QuadToRdfConverter.
+// The options configure the prefixes and JSON-LD context of the output.
+func NewQuadToRdfConverter(options serialize.Options) *QuadToRdfConverter {
+	outputTypes := representation.ValuePreferences{}
+	for _, contentType := range serialize.SupportedContentTypes() {
+		outputTypes[contentType] = 0.9
+	}
+	// LDP, §4.3.2.1: servers should default to Turtle when the client accepts anything,
+	// followed by the other human-readable syntaxes
+	outputTypes[util.Turtle] = 1
+	outputTypes[util.NTriples] = 0.8
+	outputTypes[util.NQuads] = 0.8
+	return &QuadToRdfConverter{
+		BaseTypedRepresentationConverter: BaseTypedRepresentationConverter{
+			InputTypes:  representation.ValuePreferences{util.InternalQuads: 1},
+			OutputTypes: outputTypes,
+		},
+		options: options,
+	}
+}
+
+// Handle serializes the dataset into the preferred content type.
+// The output is streamed so large datasets are not buffered in memory.
+func (c *QuadToRdfConverter) Handle(args RepresentationConverterArgs) (representation.Representation, error) {
+	contentType, err := c.OutputType(args.Preferences)
+	if err != nil {
+		return nil, err
+	}
+	serializer, ok := serialize.NewSerializer(contentType, c.options)
+	if !ok {
+		return nil, errors.NewNotAcceptableError("no serializer for "+contentType, nil)
+	}
+	dataset, err := datasetOf(args.Representation)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(serializer.Serialize(writer, dataset))
+	}()
+	return representation.NewBasicRepresentation(reader, convertedMetadata(args.Representation, contentType), false), nil
+}
+
+// datasetOf returns the dataset of an internal/quads representation
+func datasetOf(r representation.Representation) (n3.Store, error) {
+	if dataset, ok := r.(representation.RdfDatasetRepresentation); ok && dataset.Dataset() != nil {
+		return dataset.Dataset(), nil
+	}
+	if r.IsEmpty() {
+		return n3.NewBasicStore(), nil
+	}
+	return nil, errors.NewInternalError("representation of type internal/quads does not contain a dataset", nil)
+}
@@ -0,0 +1,51 @@
+package conversion
+
+import (
+	"fmt"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+)
+
+// RdfToQuadConverter parses RDF documents into an in-memory dataset
+type RdfToQuadConverter struct {
+	BaseTypedRepresentationConverter
+}
+
+// NewRdfToQuadConverter creates a new RdfToQuadConverter
+func NewRdfToQuadConverter() *RdfToQuadConverter {
+	return &RdfToQuadConverter{BaseTypedRepresentationConverter{
+		InputTypes: representation.ValuePreferences{
+			util.Turtle:   1,
+			util.TriG:     1,
+			util.NTriples: 1,
+			util.NQuads:   1,
+		},
+		OutputTypes: representation.ValuePreferences{util.InternalQuads: 1},
+	}}
+}
+
+// Handle parses the data of the representation, resolving relative IRIs against its identifier
+func (c *RdfToQuadConverter) Handle(args RepresentationConverterArgs) (representation.Representation, error) {
+	contentType := contentTypeOf(args.Representation)
+	format, ok := n3.FormatFromContentType(contentType)
+	if !ok {
+		return nil, errors.NewUnsupportedMediaTypeError(fmt.Sprintf("cannot parse %s", contentType), nil)
+	}
+	dataset := n3.NewBasicStore()
+	if data := args.Representation.GetData(); data != nil {
+		parser := n3.NewParser(n3.ParserOptions{Format: format, BaseIRI: args.Identifier.Path})
+		if err := parser.Parse(data, func(quad n3.Quad) error {
+			dataset.AddQuad(quad)
+			return nil
+		}); err != nil {
+			return nil, errors.NewValidationError("invalid RDF data", err)
+		}
+	}
+	return &representation.BasicRdfDatasetRepresentation{
+		Metadata: convertedMetadata(args.Representation, util.InternalQuads),
+		Quads:    dataset,
+	}, nil
+}
@@ -0,0 +1,96 @@
+// Package conversion converts representations between content types based on client preferences.
+package conversion
+
+import (
+	"fmt"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/handlers"
+	"solid-go/internal/util/vocabularies"
+)
+
+// RepresentationConverterArgs are the arguments of a RepresentationConverter
+type RepresentationConverterArgs struct {
+	// Identifier of the resource, used as base IRI
+	Identifier representation.ResourceIdentifier
+	// Representation to convert
+	Representation representation.Representation
+	// Preferences the result should satisfy
+	Preferences *representation.RepresentationPreferences
+}
+
+// RepresentationConverter converts a representation to one that satisfies the preferences
+type RepresentationConverter interface {
+	// CanHandle returns an error if the converter does not support the conversion
+	CanHandle(args RepresentationConverterArgs) error
+	handlers.AsyncHandler[RepresentationConverterArgs, representation.Representation]
+}
+
+// TypedRepresentationConverter is a converter that declares which content types it reads and writes
+type TypedRepresentationConverter interface {
+	RepresentationConverter
+	// GetInputTypes returns the supported input types with their weights
+	GetInputTypes() representation.ValuePreferences
+	// GetOutputTypes returns the output types, with their weights, for the given input type
+	GetOutputTypes(inputType string) representation.ValuePreferences
+}
+
+// BaseTypedRepresentationConverter can be embedded by converters with fixed input and output types
+type BaseTypedRepresentationConverter struct {
+	InputTypes  representation.ValuePreferences
+	OutputTypes representation.ValuePreferences
+}
+
+// GetInputTypes implements TypedRepresentationConverter.GetInputTypes
+func (c *BaseTypedRepresentationConverter) GetInputTypes() representation.ValuePreferences {
+	return c.InputTypes
+}
+
+// GetOutputTypes implements TypedRepresentationConverter.GetOutputTypes
+func (c *BaseTypedRepresentationConverter) GetOutputTypes(string) representation.ValuePreferences {
+	return c.OutputTypes
+}
+
+// CanHandle checks that the input type is supported and at least one output type is acceptable
+func (c *BaseTypedRepresentationConverter) CanHandle(args RepresentationConverterArgs) error {
+	contentType := contentTypeOf(args.Representation)
+	if contentType == "" {
+		return errors.NewUnsupportedMediaTypeError("cannot convert data without a content type", nil)
+	}
+	if GetTypeWeight(contentType, c.InputTypes) <= 0 {
+		return errors.NewUnsupportedMediaTypeError(fmt.Sprintf("cannot convert from %s", contentType), nil)
+	}
+	if _, err := c.OutputType(args.Preferences); err != nil {
+		return err
+	}
+	return nil
+}
+
+// OutputType returns the output type that best matches the preferences
+func (c *BaseTypedRepresentationConverter) OutputType(preferences *representation.RepresentationPreferences) (string, error) {
+	var typePreferences representation.ValuePreferences
+	if preferences != nil {
+		typePreferences = preferences.Type
+	}
+	weighted := GetWeightedPreferences(c.OutputTypes, typePreferences)
+	if len(weighted) == 0 {
+		return "", errors.NewNotAcceptableError("no output type matches the preferences", nil)
+	}
+	return weighted[0].Value, nil
+}
+
+// contentTypeOf returns the content type of a representation
+func contentTypeOf(r representation.Representation) string {
+	if r == nil || r.GetMetadata() == nil {
+		return ""
+	}
+	return normalizeType(r.GetMetadata().ContentType())
+}
+
+// convertedMetadata copies the metadata of a representation with a new content type.
+// The size of the original data no longer applies to the converted data, so it is removed.
+func convertedMetadata(r representation.Representation, contentType string) *representation.RepresentationMetadata {
+	metadata := r.GetMetadata().Clone().SetContentType(contentType)
+	return metadata.RemoveQuads(metadata.Quads(nil, vocabularies.POSIX.Size, nil, nil))
+}
@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"solid-go/internal/http/output/serialize"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
+)
+
+// DataAccessor is the low-level interface to a storage backend.
+// It only reads and writes data and metadata, all LDP semantics such as containment are handled by the store using it.
+type DataAccessor interface {
+	// CanHandle returns an error if the accessor cannot store the representation
+	CanHandle(rep representation.Representation) error
+
+	// GetData returns the data stream of a document, which has to be closed by the caller
+	GetData(identifier representation.ResourceIdentifier) (io.ReadCloser, error)
+
+	// GetMetadata returns the metadata of a resource, including its content type and modification time
+	GetMetadata(identifier representation.ResourceIdentifier) (*representation.RepresentationMetadata, error)
+
+	// GetChildren returns the metadata of all resources in a container
+	GetChildren(identifier representation.ResourceIdentifier) ([]*representation.RepresentationMetadata, error)
+
+	// WriteDocument writes the data and metadata of a document, replacing any existing document
+	WriteDocument(identifier representation.ResourceIdentifier, data io.Reader, metadata *representation.RepresentationMetadata) error
+
+	// WriteContainer creates a container or replaces the metadata of an existing one
+	WriteContainer(identifier representation.ResourceIdentifier, metadata *representation.RepresentationMetadata) error
+
+	// WriteMetadata replaces the metadata of an existing resource and updates its modification time,
+	// so the ETag of the resource and its description changes with the metadata
+	WriteMetadata(identifier representation.ResourceIdentifier, metadata *representation.RepresentationMetadata) error
+
+	// DeleteResource removes a resource and its metadata
+	DeleteResource(identifier representation.ResourceIdentifier) error
+}
+
+// serverManagedPredicates are generated by the accessors and never persisted
+var serverManagedPredicates = map[string]bool{
+	vocabularies.DC.Modified.Value():  true,
+	vocabularies.POSIX.Size.Value():   true,
+	vocabularies.POSIX.Mtime.Value():  true,
+	vocabularies.LDP.Contains.Value(): true,
+	vocabularies.MA.Format.Value():    true,
+	vocabularies.AS.Activity.Value():  true,
+}
+
+// IsContainerIdentifier checks if an identifier refers to a container, i.e. ends with a slash
+func IsContainerIdentifier(identifier representation.ResourceIdentifier) bool {
+	return strings.HasSuffix(identifier.Path, "/")
+}
+
+// checkStorageIdentifier makes sure the identifier is inside of the storage and has no empty or dot segments
+func checkStorageIdentifier(baseURL string, identifier representation.ResourceIdentifier) error {
+	if !strings.HasPrefix(identifier.Path, baseURL) {
+		return errors.NewNotFoundError(fmt.Sprintf("%s is not part of this storage", identifier.Path), nil)
+	}
+	relative := strings.TrimSuffix(strings.TrimPrefix(identifier.Path, baseURL), "/")
+	if relative == "" {
+		return nil
+	}
+	for _, segment := range strings.Split(relative, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return errors.NewValidationError(fmt.Sprintf("disallowed segment %q in %s", segment, identifier.Path), nil)
+		}
+	}
+	return nil
+}
+
+// parentPath returns the path of the container of a resource
+func parentPath(path string) string {
+	trimmed := strings.TrimSuffix(path, "/")
+	return trimmed[:strings.LastIndex(trimmed, "/")+1]
+}
+
+// persistedQuads returns the metadata quads that have to be stored by an accessor.
+// Server-managed and request-specific quads are left out.
+func persistedQuads(metadata *representation.RepresentationMetadata) []n3.Quad {
+	if metadata == nil {
+		return nil
+	}
+	var quads []n3.Quad
+	for _, quad := range metadata.Quads(nil, nil, nil, nil) {
+		if quad.Graph != nil && quad.Graph.Value() == vocabularies.SOLID_META.ResponseMetadata.Value() {
+			continue
+		}
+		if !serverManagedPredicates[quad.Predicate.Value()] && !isResourceType(quad) {
+			quads = append(quads, quad)
+		}
+	}
+	return quads
+}
+
+// isResourceType checks if the quad is one of the LDP types that are derived from the identifier
+func isResourceType(quad n3.Quad) bool {
+	if quad.Predicate.Value() != n3.RDFType {
+		return false
+	}
+	switch quad.Object.Value() {
+	case vocabularies.LDP.Resource.Value(), vocabularies.LDP.Container.Value(), vocabularies.LDP.BasicContainer.Value():
+		return true
+	}
+	return false
+}
+
+// addResourceMetadata adds the LDP types and the modification time of a resource to its metadata
+func addResourceMetadata(metadata *representation.RepresentationMetadata, isContainer bool, modified time.Time) {
+	subject := n3.NewNamedNode(metadata.GetIdentifier())
+	rdfType := vocabularies.RDF.Type
+	metadata.AddQuad(n3.NewQuad(subject, rdfType, vocabularies.LDP.Resource, nil))
+	if isContainer {
+		metadata.AddQuad(n3.NewQuad(subject, rdfType, vocabularies.LDP.Container, nil))
+		metadata.AddQuad(n3.NewQuad(subject, rdfType, vocabularies.LDP.BasicContainer, nil))
+	}
+	if !modified.IsZero() {
+		// The full precision is kept so ETags change with every write, not only once per second
+		modified = modified.UTC()
+		metadata.AddQuad(n3.NewQuad(subject, vocabularies.DC.Modified,
+			n3.NewTypedLiteral(modified.Format(time.RFC3339Nano), n3.NewNamedNode(n3.XSDDateTime)), nil))
+		metadata.AddQuad(n3.NewQuad(subject, vocabularies.POSIX.Mtime,
+			n3.NewTypedLiteral(strconv.FormatInt(modified.Unix(), 10), n3.NewNamedNode(n3.XSDInteger)), nil))
+	}
+}
+
+// addSizeMetadata adds the size of a document to its metadata
+func addSizeMetadata(metadata *representation.RepresentationMetadata, size int64) {
+	metadata.AddQuad(n3.NewQuad(n3.NewNamedNode(metadata.GetIdentifier()), vocabularies.POSIX.Size,
+		n3.NewTypedLiteral(strconv.FormatInt(size, 10), n3.NewNamedNode(n3.XSDInteger)), nil))
+}
+
+// serializeMetadata writes metadata quads as Turtle.
+// The resource itself is written as the relative IRI <> so the storage can be moved to another URL.
+func serializeMetadata(w io.Writer, identifier string, quads []n3.Quad) error {
+	relative := func(term n3.Term) n3.Term {
+		if term.TermType() == n3.NamedNodeType && term.Value() == identifier {
+			return n3.NewNamedNode("")
+		}
+		return term
+	}
+	dataset := n3.NewBasicStore()
+	for _, quad := range quads {
+		dataset.AddQuad(n3.NewQuad(relative(quad.Subject), quad.Predicate, relative(quad.Object), quad.Graph))
+	}
+	return serialize.NewTurtleSerializer(serialize.DefaultPrefixes).Serialize(w, dataset)
+}
+
+// parseMetadata reads metadata quads written by serializeMetadata
+func parseMetadata(r io.Reader, identifier string, callback func(quad n3.Quad) error) error {
+	return n3.NewParser(n3.ParserOptions{Format: n3.FormatTurtle, BaseIRI: identifier}).Parse(r, callback)
+}
+
+// ResourceSize returns the size in bytes stored in the metadata of a document
+func ResourceSize(metadata *representation.RepresentationMetadata) (int64, bool) {
+	if metadata == nil {
+		return 0, false
+	}
+	for _, quad := range metadata.Quads(metadata.GetIdentifier(), vocabularies.POSIX.Size, nil, nil) {
+		if size, err := strconv.ParseInt(quad.Object.Value(), 10, 64); err == nil {
+			return size, true
+		}
+	}
+	return 0, false
+}
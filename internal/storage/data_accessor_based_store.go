@@ -0,0 +1,674 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+
+	"solid-go/internal/http/auxiliary"
+	"solid-go/internal/http/input/conditions"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage/patch"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/identifiers"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
+)
+
+// documentMethods are the methods a document supports, which excludes POST
+var documentMethods = []string{"OPTIONS", "GET", "HEAD", "PUT", "PATCH", "DELETE"}
+
+// rootMethods are the methods the root container supports, which excludes DELETE
+var rootMethods = []string{"OPTIONS", "GET", "HEAD", "POST", "PUT", "PATCH"}
+
+// descriptionMethods are the methods a description resource supports, since it only exists through its subject resource
+var descriptionMethods = []string{"OPTIONS", "GET", "HEAD", "PATCH"}
+
+// DataAccessorBasedStore is a ResourceStore that implements the LDP semantics on top of a DataAccessor.
+// Documents are returned as data streams, containers as internal quads describing the container and its children.
+// Writes are serialized, so their conditions are evaluated against the state they modify.
+type DataAccessorBasedStore struct {
+	accessor          DataAccessor
+	baseURL           string
+	ids               *identifiers.IdentifierUtil
+	auxiliaryStrategy auxiliary.AuxiliaryStrategy
+	metadataStrategy  auxiliary.AuxiliaryIdentifierStrategy
+	patcher           patch.RdfPatcher
+	writeMu           sync.Mutex
+}
+
+// NewDataAccessorBasedStore creates a new DataAccessorBasedStore for the storage rooted at the base URL
+func NewDataAccessorBasedStore(accessor DataAccessor, baseURL string) *DataAccessorBasedStore {
+	return &DataAccessorBasedStore{
+		accessor: accessor,
+		baseURL:  ensureTrailingSlash(baseURL),
+		ids:      identifiers.NewIdentifierUtil(),
+	}
+}
+
+// WithAuxiliaryStrategy sets the strategy of the auxiliary resources, such as ACL and description resources.
+// They are linked from their subject resource, validated when written,
+// hidden from container listings and deleted together with their subject resource.
+func (s *DataAccessorBasedStore) WithAuxiliaryStrategy(strategy auxiliary.AuxiliaryStrategy) *DataAccessorBasedStore {
+	s.auxiliaryStrategy = strategy
+	return s
+}
+
+// WithMetadataStrategy sets the strategy of the description resources.
+// These are not stored themselves but expose the metadata of their subject resource as RDF,
+// so patching a description resource changes the metadata returned with its subject.
+func (s *DataAccessorBasedStore) WithMetadataStrategy(strategy auxiliary.AuxiliaryIdentifierStrategy) *DataAccessorBasedStore {
+	s.metadataStrategy = strategy
+	return s
+}
+
+// WithPatcher sets the patcher used to modify the RDF contents of documents.
+// Without a patcher, ModifyResource is not supported.
+func (s *DataAccessorBasedStore) WithPatcher(patcher patch.RdfPatcher) *DataAccessorBasedStore {
+	s.patcher = patcher
+	return s
+}
+
+// HasResource implements ResourceStore.HasResource
+func (s *DataAccessorBasedStore) HasResource(identifier representation.ResourceIdentifier) (bool, error) {
+	if subject, ok := s.describedResource(identifier); ok {
+		identifier = subject
+	}
+	if _, err := s.accessor.GetMetadata(identifier); err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// GetRepresentation implements ResourceStore.GetRepresentation
+func (s *DataAccessorBasedStore) GetRepresentation(identifier representation.ResourceIdentifier,
+	_ *representation.RepresentationPreferences, _ conditions.Conditions) (representation.Representation, error) {
+	if subject, ok := s.describedResource(identifier); ok {
+		metadata, err := s.subjectMetadata(identifier, subject)
+		if err != nil {
+			return nil, err
+		}
+		description := descriptionMetadata(identifier, subject, metadata)
+		return &representation.BasicRdfDatasetRepresentation{Metadata: description, Quads: datasetOf(persistedQuads(metadata))}, nil
+	}
+	metadata, err := s.accessor.GetMetadata(identifier)
+	if err != nil {
+		return nil, err
+	}
+	// Solid, §4.3: clients discover auxiliary resources through the Link headers of their subject resource
+	if s.auxiliaryStrategy != nil {
+		if err := s.auxiliaryStrategy.AddMetadata(metadata); err != nil {
+			return nil, err
+		}
+	}
+	if !IsContainerIdentifier(identifier) {
+		data, err := s.accessor.GetData(identifier)
+		if err != nil {
+			return nil, err
+		}
+		return representation.NewBasicRepresentation(data, metadata, true), nil
+	}
+
+	children, err := s.accessor.GetChildren(identifier)
+	if err != nil {
+		return nil, err
+	}
+	subject := n3.NewNamedNode(identifier.Path)
+	for _, child := range children {
+		// Auxiliary resources are not contained in the container of their subject
+		if s.isAuxiliary(representation.ResourceIdentifier{Path: child.GetIdentifier()}) {
+			continue
+		}
+		metadata.AddQuad(n3.NewQuad(subject, vocabularies.LDP.Contains, n3.NewNamedNode(child.GetIdentifier()), nil))
+	}
+	metadata.SetContentType(util.InternalQuads)
+	return &representation.BasicRdfDatasetRepresentation{Metadata: metadata, Quads: datasetOf(containerBody(metadata))}, nil
+}
+
+// AddResource implements ResourceStore.AddResource.
+// The new resource is a container if its metadata has the ldp:Container type.
+// Its name is taken from the slug in the metadata, unless that is taken or there is none, in which case a UUID is used.
+func (s *DataAccessorBasedStore) AddResource(container representation.ResourceIdentifier, rep representation.Representation,
+	conditions conditions.Conditions) (ChangeMap, error) {
+	if s.isAuxiliary(container) {
+		allowed := documentMethods
+		if _, ok := s.describedResource(container); ok {
+			allowed = descriptionMethods
+		}
+		return nil, errors.NewMethodNotAllowedError("resources can not be added to auxiliary resources", allowed)
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	metadata, err := s.accessor.GetMetadata(container)
+	if err != nil {
+		return nil, err
+	}
+	if !IsContainerIdentifier(container) {
+		return nil, errors.NewMethodNotAllowedError("resources can only be added to containers", documentMethods)
+	}
+	if conditions != nil {
+		if err := conditions.Evaluate(metadata, false); err != nil {
+			return nil, err
+		}
+	}
+	child, err := s.childIdentifier(container, rep.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.write(child, rep); err != nil {
+		return nil, err
+	}
+	return make(ChangeMap).Add(child, vocabularies.AS.Create).Add(container, vocabularies.AS.Update), nil
+}
+
+// SetRepresentation implements ResourceStore.SetRepresentation.
+// Missing parent containers are created, and a document and a container can not share a path, such as /a and /a/.
+// Existing containers can not be replaced, since their contents are managed by the server.
+func (s *DataAccessorBasedStore) SetRepresentation(identifier representation.ResourceIdentifier, rep representation.Representation,
+	conditions conditions.Conditions) (ChangeMap, error) {
+	if !IsContainerIdentifier(identifier) && isContainerMetadata(rep.GetMetadata()) {
+		return nil, errors.NewValidationError("containers should have a / at the end of their path, resources should not", nil)
+	}
+	if _, ok := s.describedResource(identifier); ok {
+		return nil, errors.NewMethodNotAllowedError("description resources can only be modified with PATCH", descriptionMethods)
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	metadata, err := s.validateConditions(identifier, conditions)
+	if err != nil {
+		return nil, err
+	}
+	changes := make(ChangeMap)
+	if metadata != nil && IsContainerIdentifier(identifier) {
+		empty, err := isEmptyBody(rep)
+		if err != nil {
+			return nil, err
+		}
+		if !empty {
+			return nil, errors.NewConflictError("existing containers can not be updated with PUT", nil)
+		}
+		return changes.Add(identifier, vocabularies.AS.Update), nil
+	}
+	if metadata == nil {
+		if err := s.checkPathAvailable(identifier); err != nil {
+			return nil, err
+		}
+		if identifier.Path != s.baseURL {
+			if err := s.createContainers(representation.ResourceIdentifier{Path: parentPath(identifier.Path)}, changes); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := s.write(identifier, rep); err != nil {
+		return nil, err
+	}
+	if metadata != nil {
+		return changes.Add(identifier, vocabularies.AS.Update), nil
+	}
+	changes.Add(identifier, vocabularies.AS.Create)
+	s.addParentUpdate(identifier, changes)
+	return changes, nil
+}
+
+// DeleteResource implements ResourceStore.DeleteResource.
+// The auxiliary resources of the resource are deleted with it, and containers can only be deleted
+// if they contain nothing but their own auxiliary resources.
+func (s *DataAccessorBasedStore) DeleteResource(identifier representation.ResourceIdentifier, conditions conditions.Conditions) (ChangeMap, error) {
+	if _, ok := s.describedResource(identifier); ok {
+		return nil, errors.NewMethodNotAllowedError("description resources are deleted together with their subject resource", descriptionMethods)
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	metadata, err := s.validateConditions(identifier, conditions)
+	if err != nil {
+		return nil, err
+	}
+	if metadata == nil {
+		return nil, errors.NewNotFoundError(identifier.Path, nil)
+	}
+	// Solid, §5.4: a DELETE of the root container or its required auxiliary resources results in a 405
+	if identifier.Path == s.baseURL {
+		return nil, errors.NewMethodNotAllowedError("the root container can not be deleted", rootMethods)
+	}
+	if s.auxiliaryStrategy != nil && s.auxiliaryStrategy.IsAuxiliaryIdentifier(identifier) &&
+		s.auxiliaryStrategy.IsRequiredInRoot(identifier) {
+		if subject, err := s.auxiliaryStrategy.GetSubjectIdentifier(identifier); err == nil && subject.Path == s.baseURL {
+			return nil, errors.NewMethodNotAllowedError(fmt.Sprintf("%s of the root container can not be deleted", identifier.Path),
+				[]string{"OPTIONS", "GET", "HEAD", "PUT", "PATCH"})
+		}
+	}
+	if IsContainerIdentifier(identifier) {
+		if err := s.checkEmpty(identifier); err != nil {
+			return nil, err
+		}
+	}
+
+	changes := make(ChangeMap)
+	if s.auxiliaryStrategy != nil && !s.auxiliaryStrategy.IsAuxiliaryIdentifier(identifier) {
+		for _, auxiliary := range s.auxiliaryStrategy.GetAuxiliaryIdentifiers(identifier) {
+			if _, ok := s.describedResource(auxiliary); ok {
+				continue
+			}
+			if err := s.accessor.DeleteResource(auxiliary); err != nil {
+				if errors.IsNotFoundError(err) {
+					continue
+				}
+				return nil, err
+			}
+			changes.Add(auxiliary, vocabularies.AS.Delete)
+		}
+	}
+	// The accessor removes the resource from its parent and updates the modification time of the parent in one step
+	if err := s.accessor.DeleteResource(identifier); err != nil {
+		return nil, err
+	}
+	changes.Add(identifier, vocabularies.AS.Delete)
+	s.addParentUpdate(identifier, changes)
+	return changes, nil
+}
+
+// checkEmpty returns a ConflictError if the container has children other than its own auxiliary resources
+func (s *DataAccessorBasedStore) checkEmpty(container representation.ResourceIdentifier) error {
+	children, err := s.accessor.GetChildren(container)
+	if err != nil {
+		return err
+	}
+	auxiliaries := make(map[string]bool)
+	if s.auxiliaryStrategy != nil {
+		for _, auxiliary := range s.auxiliaryStrategy.GetAuxiliaryIdentifiers(container) {
+			auxiliaries[auxiliary.Path] = true
+		}
+	}
+	for _, child := range children {
+		if !auxiliaries[child.GetIdentifier()] {
+			return errors.NewConflictError(fmt.Sprintf("can only delete empty containers, %s is not empty", container.Path), nil)
+		}
+	}
+	return nil
+}
+
+// ModifyResource implements ResourceStore.ModifyResource.
+// The patch is applied to the RDF contents of a document, which is created if it does not exist yet.
+// The document is read, patched and written while holding the write lock, and only written if the entire patch applies.
+func (s *DataAccessorBasedStore) ModifyResource(identifier representation.ResourceIdentifier, p representation.Patch,
+	conditions conditions.Conditions) (ChangeMap, error) {
+	if s.patcher == nil {
+		return nil, errors.NewNotImplementedError("patches are not supported by this store", nil)
+	}
+	args := patch.RdfPatcherArgs{Identifier: identifier, Patch: p}
+	if err := s.patcher.CanHandle(args); err != nil {
+		return nil, err
+	}
+	if IsContainerIdentifier(identifier) {
+		return nil, errors.NewConflictError("containers can not be patched, since their contents are managed by the server", nil)
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if subject, ok := s.describedResource(identifier); ok {
+		return s.modifyDescription(identifier, subject, args, conditions)
+	}
+	metadata, err := s.validateConditions(identifier, conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	created := metadata == nil
+	format, prefixes := n3.FormatTurtle, map[string]string{}
+	if created {
+		if err := s.checkPathAvailable(identifier); err != nil {
+			return nil, err
+		}
+		metadata = representation.NewRepresentationMetadata(identifier.Path).SetContentType(util.Turtle)
+		args.Dataset = n3.NewBasicStore()
+	} else {
+		var ok bool
+		if format, ok = n3.FormatFromContentType(metadata.ContentType()); !ok {
+			return nil, errors.NewConflictError(fmt.Sprintf("only RDF documents can be patched, %s is %s",
+				identifier.Path, metadata.ContentType()), nil)
+		}
+		data, err := s.accessor.GetData(identifier)
+		if err != nil {
+			return nil, err
+		}
+		parser := n3.NewParser(n3.ParserOptions{Format: format, BaseIRI: identifier.Path})
+		args.Dataset, err = parser.ParseToStore(data)
+		closeData(data)
+		if err != nil {
+			return nil, errors.NewInternalError(fmt.Sprintf("the stored data of %s is not valid RDF", identifier.Path), err)
+		}
+		prefixes = parser.Prefixes()
+	}
+	if err := s.patcher.Handle(args); err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	writer := n3.NewWriter(&body, n3.WriterOptions{Format: format, Prefixes: prefixes})
+	if err := writer.AddQuads(n3.GroupQuads(args.Dataset.GetQuads(nil, nil, nil, nil))); err != nil {
+		return nil, err
+	}
+	if err := writer.End(); err != nil {
+		return nil, err
+	}
+	changes := make(ChangeMap)
+	if created && identifier.Path != s.baseURL {
+		if err := s.createContainers(representation.ResourceIdentifier{Path: parentPath(identifier.Path)}, changes); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.accessor.WriteDocument(identifier, &body, metadata.Clone()); err != nil {
+		return nil, err
+	}
+	if !created {
+		return changes.Add(identifier, vocabularies.AS.Update), nil
+	}
+	changes.Add(identifier, vocabularies.AS.Create)
+	s.addParentUpdate(identifier, changes)
+	return changes, nil
+}
+
+// validateConditions returns the metadata of the resource, nil if it does not exist,
+// after checking the conditions of a write against it
+func (s *DataAccessorBasedStore) validateConditions(identifier representation.ResourceIdentifier,
+	conditions conditions.Conditions) (*representation.RepresentationMetadata, error) {
+	metadata, err := s.accessor.GetMetadata(identifier)
+	if err != nil {
+		if !errors.IsNotFoundError(err) {
+			return nil, err
+		}
+		metadata = nil
+	}
+	if conditions != nil {
+		if err := conditions.Evaluate(metadata, false); err != nil {
+			return nil, err
+		}
+	}
+	return metadata, nil
+}
+
+// createContainers creates a container and all its missing ancestors, adding them to the changes
+func (s *DataAccessorBasedStore) createContainers(container representation.ResourceIdentifier, changes ChangeMap) error {
+	exists, err := s.HasResource(container)
+	if err != nil || exists {
+		return err
+	}
+	if err := s.checkPathAvailable(container); err != nil {
+		return err
+	}
+	if container.Path != s.baseURL {
+		if err := s.createContainers(representation.ResourceIdentifier{Path: parentPath(container.Path)}, changes); err != nil {
+			return err
+		}
+	}
+	if err := s.accessor.WriteContainer(container, representation.NewRepresentationMetadata(container.Path)); err != nil {
+		return err
+	}
+	changes.Add(container, vocabularies.AS.Create)
+	s.addParentUpdate(container, changes)
+	return nil
+}
+
+// checkPathAvailable returns a ConflictError if a document exists at the path of a new container, or the other way around
+func (s *DataAccessorBasedStore) checkPathAvailable(identifier representation.ResourceIdentifier) error {
+	counterpart := representation.ResourceIdentifier{Path: identifier.Path + "/"}
+	if IsContainerIdentifier(identifier) {
+		counterpart.Path = strings.TrimSuffix(identifier.Path, "/")
+	}
+	exists, err := s.HasResource(counterpart)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.NewConflictError(fmt.Sprintf("%s already exists, so %s can not be created", counterpart.Path, identifier.Path), nil)
+	}
+	return nil
+}
+
+// addParentUpdate marks the parent container of a new resource as updated, unless it was created itself
+func (s *DataAccessorBasedStore) addParentUpdate(identifier representation.ResourceIdentifier, changes ChangeMap) {
+	if identifier.Path == s.baseURL {
+		return
+	}
+	parent := representation.ResourceIdentifier{Path: parentPath(identifier.Path)}
+	if _, ok := changes.Activity(parent); !ok {
+		changes.Add(parent, vocabularies.AS.Update)
+	}
+}
+
+// childIdentifier creates an identifier for a new resource in the container that does not exist yet,
+// neither as a document nor as a container, based on the slug in the metadata if there is one
+func (s *DataAccessorBasedStore) childIdentifier(container representation.ResourceIdentifier,
+	metadata *representation.RepresentationMetadata) (representation.ResourceIdentifier, error) {
+	suffix := ""
+	if isContainerMetadata(metadata) {
+		suffix = "/"
+	}
+	name, err := slugName(metadata)
+	if err != nil {
+		return representation.ResourceIdentifier{}, err
+	}
+	// Slugs can not be used to create auxiliary resources, since those belong to another resource
+	if name != "" && !s.isAuxiliary(representation.ResourceIdentifier{Path: container.Path + name}) {
+		taken := false
+		for _, path := range []string{container.Path + name, container.Path + name + "/"} {
+			exists, err := s.HasResource(representation.ResourceIdentifier{Path: path})
+			if err != nil {
+				return representation.ResourceIdentifier{}, err
+			}
+			taken = taken || exists
+		}
+		if !taken {
+			return representation.ResourceIdentifier{Path: container.Path + name + suffix}, nil
+		}
+	}
+	name, err = s.ids.GenerateUUID()
+	if err != nil {
+		return representation.ResourceIdentifier{}, err
+	}
+	return representation.ResourceIdentifier{Path: container.Path + name + suffix}, nil
+}
+
+// slugName returns the URL-encoded name requested by the slug in the metadata, or the empty string if there is none.
+// Trailing slashes are ignored since the type of the resource determines if it is a container.
+func slugName(metadata *representation.RepresentationMetadata) (string, error) {
+	if metadata == nil {
+		return "", nil
+	}
+	slugs := metadata.Quads(nil, vocabularies.SOLID_HTTP.Slug, nil, nil)
+	if len(slugs) == 0 {
+		return "", nil
+	}
+	slug := strings.TrimRight(slugs[0].Object.Value(), "/")
+	if strings.Contains(slug, "/") {
+		return "", errors.NewValidationError("slugs can not contain slashes", nil)
+	}
+	if slug == "." || slug == ".." {
+		return "", nil
+	}
+	return url.PathEscape(slug), nil
+}
+
+// write writes a representation as a document or container, depending on the identifier
+func (s *DataAccessorBasedStore) write(identifier representation.ResourceIdentifier, rep representation.Representation) error {
+	metadata := representation.NewRepresentationMetadata(identifier.Path)
+	if rep.GetMetadata() != nil {
+		metadata = rep.GetMetadata().Clone().SetIdentifier(identifier.Path)
+	}
+	if IsContainerIdentifier(identifier) {
+		quads, err := containerQuads(identifier, rep)
+		if err != nil {
+			return err
+		}
+		return s.accessor.WriteContainer(identifier, metadata.AddQuads(quads))
+	}
+	if err := s.accessor.CanHandle(rep); err != nil {
+		return err
+	}
+	if s.isAuxiliary(identifier) {
+		validated := representation.NewBasicRepresentation(rep.GetData(), metadata, rep.IsBinary())
+		if err := s.auxiliaryStrategy.Validate(validated); err != nil {
+			return err
+		}
+		rep = validated
+	}
+	return s.accessor.WriteDocument(identifier, rep.GetData(), metadata)
+}
+
+// containerQuads parses the body of a container, which can only contain RDF describing the container.
+// Server-managed triples such as ldp:contains are rejected, since the server generates those itself.
+func containerQuads(identifier representation.ResourceIdentifier, rep representation.Representation) ([]n3.Quad, error) {
+	data := rep.GetData()
+	if data == nil {
+		return nil, nil
+	}
+	defer closeData(data)
+	body, err := io.ReadAll(data)
+	if err != nil || len(bytes.TrimSpace(body)) == 0 {
+		return nil, err
+	}
+	contentType := ""
+	if rep.GetMetadata() != nil {
+		contentType = rep.GetMetadata().ContentType()
+	}
+	format, ok := n3.FormatFromContentType(contentType)
+	if !ok {
+		return nil, errors.NewUnsupportedMediaTypeError("containers can only be created with RDF data", nil)
+	}
+	quads, err := n3.NewParser(n3.ParserOptions{Format: format, BaseIRI: identifier.Path}).ParseQuads(bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.NewValidationError("invalid RDF data in the container body", err)
+	}
+	for _, quad := range quads {
+		if serverManagedPredicates[quad.Predicate.Value()] {
+			return nil, errors.NewConflictError(fmt.Sprintf("container bodies can not contain server-managed triples such as %s",
+				quad.Predicate.Value()), nil)
+		}
+	}
+	return quads, nil
+}
+
+// isEmptyBody checks if the representation has no data, closing the data
+func isEmptyBody(rep representation.Representation) (bool, error) {
+	data := rep.GetData()
+	if data == nil {
+		return true, nil
+	}
+	defer closeData(data)
+	body, err := io.ReadAll(data)
+	return len(bytes.TrimSpace(body)) == 0, err
+}
+
+// isContainerMetadata checks if the metadata describes a container
+func isContainerMetadata(metadata *representation.RepresentationMetadata) bool {
+	if metadata == nil {
+		return false
+	}
+	for _, containerType := range []n3.Term{vocabularies.LDP.Container, vocabularies.LDP.BasicContainer} {
+		if len(metadata.Quads(nil, vocabularies.RDF.Type, containerType, nil)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isAuxiliary checks if the identifier is that of an auxiliary resource
+func (s *DataAccessorBasedStore) isAuxiliary(identifier representation.ResourceIdentifier) bool {
+	return s.auxiliaryStrategy != nil && s.auxiliaryStrategy.IsAuxiliaryIdentifier(identifier)
+}
+
+// describedResource returns the subject of a description resource,
+// and false if the identifier is not that of a description resource
+func (s *DataAccessorBasedStore) describedResource(identifier representation.ResourceIdentifier) (representation.ResourceIdentifier, bool) {
+	if s.metadataStrategy == nil || !s.metadataStrategy.IsAuxiliaryIdentifier(identifier) {
+		return representation.ResourceIdentifier{}, false
+	}
+	subject, err := s.metadataStrategy.GetSubjectIdentifier(identifier)
+	return subject, err == nil
+}
+
+// subjectMetadata returns the metadata of the subject of a description resource,
+// which only exists as long as its subject does
+func (s *DataAccessorBasedStore) subjectMetadata(identifier, subject representation.ResourceIdentifier) (*representation.RepresentationMetadata, error) {
+	metadata, err := s.accessor.GetMetadata(subject)
+	if errors.IsNotFoundError(err) {
+		return nil, errors.NewNotFoundError(identifier.Path, err)
+	}
+	return metadata, err
+}
+
+// modifyDescription patches the stored metadata of the subject of a description resource.
+// Server-managed triples can not be added, since those are generated from the state of the subject,
+// and neither can LDP types, since the interaction model of a resource is determined by its identifier.
+func (s *DataAccessorBasedStore) modifyDescription(identifier, subject representation.ResourceIdentifier,
+	args patch.RdfPatcherArgs, conditions conditions.Conditions) (ChangeMap, error) {
+	metadata, err := s.subjectMetadata(identifier, subject)
+	if err != nil {
+		return nil, err
+	}
+	if conditions != nil {
+		if err := conditions.Evaluate(descriptionMetadata(identifier, subject, metadata), false); err != nil {
+			return nil, err
+		}
+	}
+	stored := persistedQuads(metadata)
+	args.Dataset = datasetOf(stored)
+	if err := s.patcher.Handle(args); err != nil {
+		return nil, err
+	}
+	quads := args.Dataset.GetQuads(nil, nil, nil, nil)
+	for _, quad := range quads {
+		if serverManagedPredicates[quad.Predicate.Value()] {
+			return nil, errors.NewConflictError(fmt.Sprintf("descriptions can not contain server-managed triples such as %s",
+				quad.Predicate.Value()), nil)
+		}
+		if isResourceType(quad) {
+			return nil, errors.NewConflictError(fmt.Sprintf("descriptions can not change the interaction model to %s",
+				quad.Object.Value()), nil)
+		}
+	}
+	updated := metadata.Clone().RemoveQuads(stored).AddQuads(quads)
+	if err := s.accessor.WriteMetadata(subject, updated); err != nil {
+		return nil, err
+	}
+	return make(ChangeMap).Add(identifier, vocabularies.AS.Update).Add(subject, vocabularies.AS.Update), nil
+}
+
+// descriptionMetadata creates the metadata of a description resource,
+// which is a document sharing the modification time of its subject
+func descriptionMetadata(identifier, subject representation.ResourceIdentifier,
+	subjectMetadata *representation.RepresentationMetadata) *representation.RepresentationMetadata {
+	metadata := representation.NewRepresentationMetadata(identifier.Path).SetContentType(util.InternalQuads)
+	metadata.AddQuad(n3.NewQuad(n3.NewNamedNode(identifier.Path), vocabularies.RDF.Type, vocabularies.LDP.Resource, nil))
+	for _, quad := range subjectMetadata.Quads(n3.NewNamedNode(subject.Path), vocabularies.DC.Modified, nil, nil) {
+		metadata.AddQuad(n3.NewQuad(n3.NewNamedNode(identifier.Path), quad.Predicate, quad.Object, nil))
+	}
+	return metadata
+}
+
+// containerBody returns the quads of the metadata that make up the body of a container.
+// Response metadata, such as the links to auxiliary resources, only ends up in the headers.
+func containerBody(metadata *representation.RepresentationMetadata) []n3.Quad {
+	var quads []n3.Quad
+	for _, quad := range metadata.Quads(nil, nil, nil, nil) {
+		if quad.Graph == nil || quad.Graph.Value() != vocabularies.SOLID_META.ResponseMetadata.Value() {
+			quads = append(quads, quad)
+		}
+	}
+	return quads
+}
+
+// datasetOf creates a dataset holding the quads in the default graph
+func datasetOf(quads []n3.Quad) *n3.BasicStore {
+	dataset := n3.NewBasicStore()
+	for _, quad := range quads {
+		dataset.AddQuad(n3.NewQuad(quad.Subject, quad.Predicate, quad.Object, nil))
+	}
+	return dataset
+}
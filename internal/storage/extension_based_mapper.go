@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"fmt"
+	"mime"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+)
+
+// MetadataExtension is appended to file paths to find the sidecar file holding the metadata of a resource
+const MetadataExtension = ".meta"
+
+// extensionTypes maps file extensions to content types that are not known to the mime package
+var extensionTypes = map[string]string{
+	".ttl":    util.Turtle,
+	".trig":   util.TriG,
+	".nt":     util.NTriples,
+	".nq":     util.NQuads,
+	".jsonld": util.JSONLD,
+	".rdf":    util.RDFXML,
+	".n3":     "text/n3",
+	".md":     util.TextMarkdown,
+	".txt":    util.TextPlain,
+	".html":   util.TextHTML,
+	".json":   util.ApplicationJSON,
+	".yaml":   util.ApplicationYAML,
+	".yml":    util.ApplicationYAML,
+}
+
+// ResourceLink links a resource identifier to the file that stores it
+type ResourceLink struct {
+	Identifier representation.ResourceIdentifier
+	// FilePath is the absolute path of the file or directory
+	FilePath string
+	// ContentType is derived from the file extension, empty for containers
+	ContentType string
+	// IsMetadata is true if the file is the metadata sidecar of the resource
+	IsMetadata bool
+}
+
+// ExtensionBasedMapper maps URLs to paths below a root directory and derives content types from file extensions
+type ExtensionBasedMapper struct {
+	baseURL  string
+	rootPath string
+}
+
+// NewExtensionBasedMapper creates a new ExtensionBasedMapper.
+// The base URL is the URL of the root container, which corresponds to the root directory.
+func NewExtensionBasedMapper(baseURL, rootPath string) (*ExtensionBasedMapper, error) {
+	root, err := filepath.Abs(rootPath)
+	if err != nil {
+		return nil, err
+	}
+	return &ExtensionBasedMapper{baseURL: ensureTrailingSlash(baseURL), rootPath: root}, nil
+}
+
+// MapUrlToFilePath returns the file that stores the resource, or its metadata if isMetadata is set.
+// URLs with dot segments are rejected so no file outside of the root directory can be reached.
+func (m *ExtensionBasedMapper) MapUrlToFilePath(identifier representation.ResourceIdentifier, isMetadata bool) (ResourceLink, error) {
+	relative, err := m.relativePath(identifier)
+	if err != nil {
+		return ResourceLink{}, err
+	}
+	filePath := filepath.Join(m.rootPath, filepath.FromSlash(relative))
+	if filePath != m.rootPath && !strings.HasPrefix(filePath, m.rootPath+string(filepath.Separator)) {
+		return ResourceLink{}, errors.NewValidationError(fmt.Sprintf("%s is outside of the storage root", identifier.Path), nil)
+	}
+	link := ResourceLink{Identifier: identifier, FilePath: filePath, IsMetadata: isMetadata}
+	if !IsContainerIdentifier(identifier) {
+		link.ContentType = ContentTypeFromExtension(filePath)
+	}
+	if isMetadata {
+		if IsContainerIdentifier(identifier) {
+			link.FilePath = filepath.Join(filePath, MetadataExtension)
+		} else {
+			link.FilePath = filePath + MetadataExtension
+		}
+	}
+	return link, nil
+}
+
+// MapFilePathToUrl returns the identifier of the resource stored in the given file
+func (m *ExtensionBasedMapper) MapFilePathToUrl(filePath string, isContainer bool) (ResourceLink, error) {
+	relative, err := filepath.Rel(m.rootPath, filePath)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return ResourceLink{}, errors.NewValidationError(fmt.Sprintf("%s is outside of the storage root", filePath), nil)
+	}
+	isMetadata := strings.HasSuffix(filePath, MetadataExtension)
+	var segments []string
+	if relative != "." {
+		for _, segment := range strings.Split(filepath.ToSlash(strings.TrimSuffix(relative, MetadataExtension)), "/") {
+			segments = append(segments, url.PathEscape(segment))
+		}
+	}
+	path := m.baseURL + strings.Join(segments, "/")
+	if isContainer && len(segments) > 0 {
+		path += "/"
+	}
+	link := ResourceLink{Identifier: representation.ResourceIdentifier{Path: path}, FilePath: filePath, IsMetadata: isMetadata}
+	if !isContainer {
+		link.ContentType = ContentTypeFromExtension(strings.TrimSuffix(filePath, MetadataExtension))
+	}
+	return link, nil
+}
+
+// relativePath returns the decoded path of the identifier relative to the base URL
+func (m *ExtensionBasedMapper) relativePath(identifier representation.ResourceIdentifier) (string, error) {
+	if !strings.HasPrefix(identifier.Path, m.baseURL) && identifier.Path+"/" != m.baseURL {
+		return "", errors.NewNotFoundError(fmt.Sprintf("%s is not part of this storage", identifier.Path), nil)
+	}
+	relative := strings.TrimPrefix(identifier.Path, m.baseURL)
+	segments := strings.Split(relative, "/")
+	for i, segment := range segments {
+		decoded, err := url.PathUnescape(segment)
+		if err != nil {
+			return "", errors.NewValidationError(fmt.Sprintf("invalid URL encoding in %s", identifier.Path), err)
+		}
+		if decoded == "." || decoded == ".." || strings.ContainsAny(decoded, "/\\\x00") {
+			return "", errors.NewValidationError(fmt.Sprintf("disallowed segment %q in %s", decoded, identifier.Path), nil)
+		}
+		segments[i] = decoded
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// ContentTypeFromExtension returns the content type for the extension of a file path
+func ContentTypeFromExtension(filePath string) string {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	if contentType, ok := extensionTypes[ext]; ok {
+		return contentType
+	}
+	if contentType := mime.TypeByExtension(ext); contentType != "" {
+		return strings.Split(contentType, ";")[0]
+	}
+	return util.ApplicationOctetStream
+}
+
+func ensureTrailingSlash(path string) string {
+	if strings.HasSuffix(path, "/") {
+		return path
+	}
+	return path + "/"
+}
@@ -0,0 +1,331 @@
+package storage
+
+import (
+	"bytes"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
+)
+
+// tempFilePrefix marks files that are still being written
+const tempFilePrefix = ".tmp-"
+
+// FileDataAccessor stores resources as files and containers as directories.
+// Metadata that can not be derived from the file system is kept in Turtle sidecar files with the MetadataExtension.
+type FileDataAccessor struct {
+	mapper *ExtensionBasedMapper
+}
+
+// NewFileDataAccessor creates a new FileDataAccessor
+func NewFileDataAccessor(mapper *ExtensionBasedMapper) *FileDataAccessor {
+	return &FileDataAccessor{mapper: mapper}
+}
+
+// CanHandle implements DataAccessor.CanHandle.
+// Only representations with a data stream can be written to files.
+func (a *FileDataAccessor) CanHandle(rep representation.Representation) error {
+	if rep.GetData() == nil {
+		return errors.NewUnsupportedMediaTypeError("only data streams can be stored as files", nil)
+	}
+	return nil
+}
+
+// GetData implements DataAccessor.GetData
+func (a *FileDataAccessor) GetData(identifier representation.ResourceIdentifier) (io.ReadCloser, error) {
+	link, info, err := a.resolve(identifier)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("%s is a container", identifier.Path), nil)
+	}
+	return os.Open(link.FilePath)
+}
+
+// GetMetadata implements DataAccessor.GetMetadata
+func (a *FileDataAccessor) GetMetadata(identifier representation.ResourceIdentifier) (*representation.RepresentationMetadata, error) {
+	link, info, err := a.resolve(identifier)
+	if err != nil {
+		return nil, err
+	}
+	metadata := representation.NewRepresentationMetadata(identifier.Path)
+	if err := a.readMetadata(identifier, metadata); err != nil {
+		return nil, err
+	}
+	addResourceMetadata(metadata, info.IsDir(), info.ModTime())
+	if !info.IsDir() {
+		addSizeMetadata(metadata, info.Size())
+		if metadata.ContentType() == "" {
+			metadata.SetContentType(link.ContentType)
+		}
+	}
+	return metadata, nil
+}
+
+// GetChildren implements DataAccessor.GetChildren.
+// Metadata sidecars and incomplete writes are not part of the result.
+func (a *FileDataAccessor) GetChildren(identifier representation.ResourceIdentifier) ([]*representation.RepresentationMetadata, error) {
+	link, info, err := a.resolve(identifier)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("%s is not a container", identifier.Path), nil)
+	}
+	entries, err := os.ReadDir(link.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	var children []*representation.RepresentationMetadata
+	for _, entry := range entries {
+		name := entry.Name()
+		if isReservedName(name) {
+			continue
+		}
+		childInfo, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if !childInfo.IsDir() && !childInfo.Mode().IsRegular() {
+			continue
+		}
+		childLink, err := a.mapper.MapFilePathToUrl(filepath.Join(link.FilePath, name), childInfo.IsDir())
+		if err != nil {
+			return nil, err
+		}
+		metadata := representation.NewRepresentationMetadata(childLink.Identifier.Path)
+		addResourceMetadata(metadata, childInfo.IsDir(), childInfo.ModTime())
+		if !childInfo.IsDir() {
+			addSizeMetadata(metadata, childInfo.Size())
+			// As in GetMetadata, a content type in the sidecar overrides the one implied by the extension
+			stored := representation.NewRepresentationMetadata(childLink.Identifier.Path)
+			if err := a.readMetadata(childLink.Identifier, stored); err != nil {
+				return nil, err
+			}
+			if stored.ContentType() != "" {
+				metadata.SetContentType(stored.ContentType())
+			} else {
+				metadata.SetContentType(childLink.ContentType)
+			}
+		}
+		children = append(children, metadata)
+	}
+	return children, nil
+}
+
+// WriteDocument implements DataAccessor.WriteDocument.
+// The data is streamed to a temporary file which replaces the document once everything is written.
+func (a *FileDataAccessor) WriteDocument(identifier representation.ResourceIdentifier, data io.Reader,
+	metadata *representation.RepresentationMetadata) error {
+	if IsContainerIdentifier(identifier) {
+		return errors.NewConflictError(fmt.Sprintf("%s is a container", identifier.Path), nil)
+	}
+	link, err := a.link(identifier, false)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(link.FilePath); err == nil && info.IsDir() {
+		return errors.NewConflictError(fmt.Sprintf("a container exists at %s", identifier.Path), nil)
+	}
+	if err := os.MkdirAll(filepath.Dir(link.FilePath), 0755); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(link.FilePath, data); err != nil {
+		return err
+	}
+	return a.writeMetadata(link, metadata)
+}
+
+// WriteContainer implements DataAccessor.WriteContainer
+func (a *FileDataAccessor) WriteContainer(identifier representation.ResourceIdentifier,
+	metadata *representation.RepresentationMetadata) error {
+	if !IsContainerIdentifier(identifier) {
+		return errors.NewConflictError(fmt.Sprintf("%s is not a container", identifier.Path), nil)
+	}
+	link, err := a.link(identifier, false)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(link.FilePath, 0755); err != nil {
+		return err
+	}
+	return a.writeMetadata(link, metadata)
+}
+
+// WriteMetadata implements DataAccessor.WriteMetadata
+func (a *FileDataAccessor) WriteMetadata(identifier representation.ResourceIdentifier,
+	metadata *representation.RepresentationMetadata) error {
+	link, _, err := a.resolve(identifier)
+	if err != nil {
+		return err
+	}
+	if err := a.writeMetadata(link, metadata); err != nil {
+		return err
+	}
+	// The modification time of the file or directory is the modification time of the resource
+	now := time.Now()
+	return os.Chtimes(link.FilePath, now, now)
+}
+
+// DeleteResource implements DataAccessor.DeleteResource.
+// Containers have to be empty before they can be deleted.
+func (a *FileDataAccessor) DeleteResource(identifier representation.ResourceIdentifier) error {
+	link, info, err := a.resolve(identifier)
+	if err != nil {
+		return err
+	}
+	// Check the contents before anything is removed, so the metadata of a non-empty container is kept
+	if info.IsDir() {
+		children, err := a.GetChildren(identifier)
+		if err != nil {
+			return err
+		}
+		if len(children) > 0 {
+			return errors.NewConflictError(fmt.Sprintf("can only delete empty containers, %s is not empty", identifier.Path), nil)
+		}
+	}
+	metadataLink, err := a.link(identifier, true)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(metadataLink.FilePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Remove(link.FilePath); err != nil {
+		if info.IsDir() {
+			return errors.NewConflictError(fmt.Sprintf("can only delete empty containers, %s is not empty", identifier.Path), err)
+		}
+		return err
+	}
+	return nil
+}
+
+// link maps an identifier to its file, refusing identifiers that would collide with metadata sidecars
+// or temporary files, as those are hidden from container listings
+func (a *FileDataAccessor) link(identifier representation.ResourceIdentifier, isMetadata bool) (ResourceLink, error) {
+	relative, err := a.mapper.relativePath(identifier)
+	if err != nil {
+		return ResourceLink{}, err
+	}
+	for _, segment := range strings.Split(relative, "/") {
+		if isReservedName(segment) {
+			return ResourceLink{}, errors.NewConflictError(fmt.Sprintf("%s uses the reserved name %q", identifier.Path, segment), nil)
+		}
+	}
+	return a.mapper.MapUrlToFilePath(identifier, isMetadata)
+}
+
+// isReservedName checks whether a file name is used for metadata sidecars or temporary files
+func isReservedName(name string) bool {
+	return strings.HasSuffix(name, MetadataExtension) || strings.HasPrefix(name, tempFilePrefix)
+}
+
+// resolve maps an identifier to an existing file.
+// Containers have to be directories and documents regular files, so a trailing slash mismatch results in a 404.
+func (a *FileDataAccessor) resolve(identifier representation.ResourceIdentifier) (ResourceLink, os.FileInfo, error) {
+	link, err := a.link(identifier, false)
+	if err != nil {
+		if errors.IsConflictError(err) {
+			return ResourceLink{}, nil, errors.NewNotFoundError(identifier.Path, err)
+		}
+		return ResourceLink{}, nil, err
+	}
+	info, err := os.Stat(link.FilePath)
+	if err != nil {
+		// A path below a document does not exist either
+		if os.IsNotExist(err) || stderrors.Is(err, syscall.ENOTDIR) {
+			return ResourceLink{}, nil, errors.NewNotFoundError(identifier.Path, nil)
+		}
+		return ResourceLink{}, nil, err
+	}
+	if info.IsDir() != IsContainerIdentifier(identifier) || (!info.IsDir() && !info.Mode().IsRegular()) {
+		return ResourceLink{}, nil, errors.NewNotFoundError(identifier.Path, nil)
+	}
+	return link, info, nil
+}
+
+// readMetadata adds the quads of the metadata sidecar, if there is one, to the metadata
+func (a *FileDataAccessor) readMetadata(identifier representation.ResourceIdentifier, metadata *representation.RepresentationMetadata) error {
+	link, err := a.link(identifier, true)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(link.FilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	return parseMetadata(file, identifier.Path, func(quad n3.Quad) error {
+		if quad.Predicate.Equals(vocabularies.MA.Format) && quad.Subject.Value() == identifier.Path {
+			metadata.SetContentType(quad.Object.Value())
+			return nil
+		}
+		metadata.AddQuad(quad)
+		return nil
+	})
+}
+
+// writeMetadata writes the metadata sidecar of a resource, or removes it if there is nothing to store.
+// The content type is only stored if it differs from the one implied by the file extension.
+func (a *FileDataAccessor) writeMetadata(link ResourceLink, metadata *representation.RepresentationMetadata) error {
+	metadataLink, err := a.link(link.Identifier, true)
+	if err != nil {
+		return err
+	}
+	quads := persistedQuads(metadata)
+	if metadata != nil && metadata.ContentType() != "" && metadata.ContentType() != link.ContentType && !IsContainerIdentifier(link.Identifier) {
+		quads = append(quads, n3.NewQuad(n3.NewNamedNode(link.Identifier.Path), vocabularies.MA.Format, n3.NewLiteral(metadata.ContentType()), nil))
+	}
+
+	if len(quads) == 0 {
+		if err := os.Remove(metadataLink.FilePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	// Metadata is small, so it is serialized completely before anything is written
+	var data bytes.Buffer
+	if err := serializeMetadata(&data, link.Identifier.Path, quads); err != nil {
+		return err
+	}
+	return writeFileAtomic(metadataLink.FilePath, &data)
+}
+
+// writeFileAtomic streams the data to a temporary file in the same directory and renames it to the target
+func writeFileAtomic(filePath string, data io.Reader) error {
+	temp, err := os.CreateTemp(filepath.Dir(filePath), tempFilePrefix+"*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := io.Copy(temp, data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Chmod(0644); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), filePath)
+}
@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"solid-go/internal/http/input/conditions"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
+)
+
+const baseURL = "http://example.org/"
+
+func newTestFileAccessor(t *testing.T) (*FileDataAccessor, string) {
+	t.Helper()
+	root := t.TempDir()
+	mapper, err := NewExtensionBasedMapper(baseURL, root)
+	if err != nil {
+		t.Fatalf("NewExtensionBasedMapper() error = %v", err)
+	}
+	return NewFileDataAccessor(mapper), root
+}
+
+func identifier(path string) representation.ResourceIdentifier {
+	return representation.ResourceIdentifier{Path: baseURL + path}
+}
+
+func TestExtensionBasedMapper(t *testing.T) {
+	root := t.TempDir()
+	mapper, _ := NewExtensionBasedMapper(baseURL, root)
+
+	link, err := mapper.MapUrlToFilePath(identifier("foo/bar%20baz.ttl"), false)
+	if err != nil {
+		t.Fatalf("MapUrlToFilePath() error = %v", err)
+	}
+	if want := filepath.Join(root, "foo", "bar baz.ttl"); link.FilePath != want {
+		t.Errorf("FilePath = %v, want %v", link.FilePath, want)
+	}
+	if link.ContentType != util.Turtle {
+		t.Errorf("ContentType = %v, want %v", link.ContentType, util.Turtle)
+	}
+
+	back, err := mapper.MapFilePathToUrl(link.FilePath, false)
+	if err != nil || back.Identifier != identifier("foo/bar%20baz.ttl") {
+		t.Errorf("MapFilePathToUrl() = %v, %v", back.Identifier, err)
+	}
+
+	for _, path := range []string{"../secret", "foo/../../secret", "foo/%2e%2e/secret", "foo/a%2Fb"} {
+		if _, err := mapper.MapUrlToFilePath(identifier(path), false); !errors.IsValidationError(err) {
+			t.Errorf("MapUrlToFilePath(%v) error = %v, want a validation error", path, err)
+		}
+	}
+	if _, err := mapper.MapUrlToFilePath(representation.ResourceIdentifier{Path: "http://other.org/foo"}, false); !errors.IsNotFoundError(err) {
+		t.Errorf("MapUrlToFilePath() of another host error = %v, want NotFoundError", err)
+	}
+}
+
+func TestFileDataAccessor_Document(t *testing.T) {
+	accessor, root := newTestFileAccessor(t)
+	id := identifier("foo/data")
+	metadata := representation.NewRepresentationMetadata(id.Path).SetContentType(util.Turtle)
+	metadata.AddQuad(n3.NewQuad(n3.NewNamedNode(id.Path), n3.NewNamedNode("http://example.org/vocab#tag"), n3.NewLiteral("x"), nil))
+
+	if err := accessor.WriteDocument(id, strings.NewReader("<a> <b> <c> ."), metadata); err != nil {
+		t.Fatalf("WriteDocument() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "foo", "data"+MetadataExtension)); err != nil {
+		t.Errorf("metadata sidecar was not written: %v", err)
+	}
+
+	data, err := accessor.GetData(id)
+	if err != nil {
+		t.Fatalf("GetData() error = %v", err)
+	}
+	body, _ := io.ReadAll(data)
+	data.Close()
+	if string(body) != "<a> <b> <c> ." {
+		t.Errorf("GetData() = %v", string(body))
+	}
+
+	got, err := accessor.GetMetadata(id)
+	if err != nil {
+		t.Fatalf("GetMetadata() error = %v", err)
+	}
+	if got.ContentType() != util.Turtle {
+		t.Errorf("ContentType() = %v, want %v", got.ContentType(), util.Turtle)
+	}
+	if len(got.Quads(id.Path, "http://example.org/vocab#tag", nil, nil)) != 1 {
+		t.Errorf("GetMetadata() lost the stored metadata")
+	}
+	if len(got.Quads(id.Path, vocabularies.POSIX.Size, nil, nil)) != 1 || conditions.ModifiedTime(got).IsZero() {
+		t.Errorf("GetMetadata() is missing the size or modification time")
+	}
+
+	if _, err := accessor.GetMetadata(identifier("foo/data/")); !errors.IsNotFoundError(err) {
+		t.Errorf("GetMetadata() with a trailing slash error = %v, want NotFoundError", err)
+	}
+	if _, err := accessor.GetMetadata(identifier("foo/data.meta")); !errors.IsNotFoundError(err) {
+		t.Errorf("GetMetadata() of a sidecar error = %v, want NotFoundError", err)
+	}
+	for _, path := range []string{"notes.meta", "notes.meta/", "dir.meta/doc", ".tmp-notes", ".tmp-dir/doc"} {
+		if err := accessor.WriteDocument(identifier(path), strings.NewReader("hidden"), nil); !errors.IsConflictError(err) {
+			t.Errorf("WriteDocument(%v) error = %v, want ConflictError", path, err)
+		}
+	}
+}
+
+func TestFileDataAccessor_Containers(t *testing.T) {
+	accessor, _ := newTestFileAccessor(t)
+	container := identifier("foo/")
+	if err := accessor.WriteContainer(container, representation.NewRepresentationMetadata(container.Path)); err != nil {
+		t.Fatalf("WriteContainer() error = %v", err)
+	}
+	document := identifier("foo/page.html")
+	if err := accessor.WriteDocument(document, strings.NewReader("<p>hi</p>"), representation.NewRepresentationMetadata(document.Path).SetContentType(util.TextHTML)); err != nil {
+		t.Fatalf("WriteDocument() error = %v", err)
+	}
+	if err := accessor.WriteContainer(identifier("foo/sub/"), nil); err != nil {
+		t.Fatalf("WriteContainer() error = %v", err)
+	}
+	// The content type of this document can not be derived from its name, so it is stored in the sidecar
+	custom := identifier("foo/data")
+	if err := accessor.WriteDocument(custom, strings.NewReader("{}"), representation.NewRepresentationMetadata(custom.Path).SetContentType("application/x-custom")); err != nil {
+		t.Fatalf("WriteDocument() error = %v", err)
+	}
+
+	children, err := accessor.GetChildren(container)
+	if err != nil {
+		t.Fatalf("GetChildren() error = %v", err)
+	}
+	paths := map[string]string{}
+	for _, child := range children {
+		paths[child.GetIdentifier()] = child.ContentType()
+	}
+	if len(paths) != 3 || paths[document.Path] != util.TextHTML || paths[custom.Path] != "application/x-custom" {
+		t.Errorf("GetChildren() = %v", paths)
+	}
+	if _, ok := paths[identifier("foo/sub/").Path]; !ok {
+		t.Errorf("GetChildren() is missing the sub container: %v", paths)
+	}
+
+	if err := accessor.DeleteResource(container); !errors.IsConflictError(err) {
+		t.Errorf("DeleteResource() of a non-empty container error = %v, want ConflictError", err)
+	}
+	if err := accessor.DeleteResource(document); err != nil {
+		t.Errorf("DeleteResource() error = %v", err)
+	}
+	if _, err := accessor.GetMetadata(document); !errors.IsNotFoundError(err) {
+		t.Errorf("GetMetadata() after delete error = %v, want NotFoundError", err)
+	}
+}
@@ -0,0 +1,258 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
+
+// memoryEntry is a resource stored by the InMemoryDataAccessor
+type memoryEntry struct {
+	data        []byte
+	contentType string
+	metadata    []representation.Quad
+	modified    time.Time
+	// children contains the paths of the resources in a container, nil for documents
+	children map[string]bool
+}
+
+// InMemoryDataAccessor keeps all resources in memory, which makes it useful for tests and ephemeral deployments.
+// It is safe for concurrent use.
+type InMemoryDataAccessor struct {
+	mu      sync.RWMutex
+	baseURL string
+	entries map[string]*memoryEntry
+	now     func() time.Time
+}
+
+// NewInMemoryDataAccessor creates a new InMemoryDataAccessor containing an empty root container at the base URL
+func NewInMemoryDataAccessor(baseURL string) *InMemoryDataAccessor {
+	a := &InMemoryDataAccessor{
+		baseURL: ensureTrailingSlash(baseURL),
+		entries: make(map[string]*memoryEntry),
+		now:     time.Now,
+	}
+	a.entries[a.baseURL] = &memoryEntry{modified: a.now(), children: make(map[string]bool)}
+	return a
+}
+
+// CanHandle implements DataAccessor.CanHandle
+func (a *InMemoryDataAccessor) CanHandle(rep representation.Representation) error {
+	if rep.GetData() == nil {
+		return errors.NewUnsupportedMediaTypeError("only data streams can be stored", nil)
+	}
+	return nil
+}
+
+// GetData implements DataAccessor.GetData
+func (a *InMemoryDataAccessor) GetData(identifier representation.ResourceIdentifier) (io.ReadCloser, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	entry, err := a.entry(identifier)
+	if err != nil {
+		return nil, err
+	}
+	if entry.children != nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("%s is a container", identifier.Path), nil)
+	}
+	// The data slice is never modified after writing, so it can be shared with readers
+	return io.NopCloser(bytes.NewReader(entry.data)), nil
+}
+
+// GetMetadata implements DataAccessor.GetMetadata
+func (a *InMemoryDataAccessor) GetMetadata(identifier representation.ResourceIdentifier) (*representation.RepresentationMetadata, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	entry, err := a.entry(identifier)
+	if err != nil {
+		return nil, err
+	}
+	return entry.toMetadata(identifier.Path, true), nil
+}
+
+// GetChildren implements DataAccessor.GetChildren
+func (a *InMemoryDataAccessor) GetChildren(identifier representation.ResourceIdentifier) ([]*representation.RepresentationMetadata, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	entry, err := a.entry(identifier)
+	if err != nil {
+		return nil, err
+	}
+	if entry.children == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("%s is not a container", identifier.Path), nil)
+	}
+	paths := make([]string, 0, len(entry.children))
+	for path := range entry.children {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	children := make([]*representation.RepresentationMetadata, len(paths))
+	for i, path := range paths {
+		children[i] = a.entries[path].toMetadata(path, false)
+	}
+	return children, nil
+}
+
+// WriteDocument implements DataAccessor.WriteDocument.
+// Missing parent containers are created, similar to the FileDataAccessor.
+func (a *InMemoryDataAccessor) WriteDocument(identifier representation.ResourceIdentifier, data io.Reader,
+	metadata *representation.RepresentationMetadata) error {
+	if IsContainerIdentifier(identifier) {
+		return errors.NewConflictError(fmt.Sprintf("%s is a container", identifier.Path), nil)
+	}
+	if err := checkStorageIdentifier(a.baseURL, identifier); err != nil {
+		return err
+	}
+	// Read the data before locking so slow clients do not block other requests
+	buffer, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.ensureParents(identifier.Path); err != nil {
+		return err
+	}
+	if _, ok := a.entries[identifier.Path+"/"]; ok {
+		return errors.NewConflictError(fmt.Sprintf("a container exists at %s/", identifier.Path), nil)
+	}
+	entry := &memoryEntry{data: buffer, modified: a.now()}
+	entry.setMetadata(metadata)
+	if _, exists := a.entries[identifier.Path]; !exists {
+		a.addChild(identifier.Path)
+	}
+	a.entries[identifier.Path] = entry
+	return nil
+}
+
+// WriteContainer implements DataAccessor.WriteContainer
+func (a *InMemoryDataAccessor) WriteContainer(identifier representation.ResourceIdentifier,
+	metadata *representation.RepresentationMetadata) error {
+	if !IsContainerIdentifier(identifier) {
+		return errors.NewConflictError(fmt.Sprintf("%s is not a container", identifier.Path), nil)
+	}
+	if err := checkStorageIdentifier(a.baseURL, identifier); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	entry, ok := a.entries[identifier.Path]
+	if !ok {
+		if err := a.ensureParents(identifier.Path); err != nil {
+			return err
+		}
+		if _, exists := a.entries[strings.TrimSuffix(identifier.Path, "/")]; exists {
+			return errors.NewConflictError(fmt.Sprintf("a document exists at %s", strings.TrimSuffix(identifier.Path, "/")), nil)
+		}
+		entry = &memoryEntry{children: make(map[string]bool)}
+		a.entries[identifier.Path] = entry
+		a.addChild(identifier.Path)
+	}
+	entry.modified = a.now()
+	entry.setMetadata(metadata)
+	return nil
+}
+
+// WriteMetadata implements DataAccessor.WriteMetadata
+func (a *InMemoryDataAccessor) WriteMetadata(identifier representation.ResourceIdentifier,
+	metadata *representation.RepresentationMetadata) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	entry, err := a.entry(identifier)
+	if err != nil {
+		return err
+	}
+	entry.modified = a.now()
+	entry.setMetadata(metadata)
+	return nil
+}
+
+// DeleteResource implements DataAccessor.DeleteResource
+func (a *InMemoryDataAccessor) DeleteResource(identifier representation.ResourceIdentifier) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	entry, err := a.entry(identifier)
+	if err != nil {
+		return err
+	}
+	if len(entry.children) > 0 {
+		return errors.NewConflictError(fmt.Sprintf("can only delete empty containers, %s is not empty", identifier.Path), nil)
+	}
+	if identifier.Path == a.baseURL {
+		return errors.NewConflictError("the root container can not be deleted", nil)
+	}
+	delete(a.entries, identifier.Path)
+	parent := a.entries[parentPath(identifier.Path)]
+	delete(parent.children, identifier.Path)
+	parent.modified = a.now()
+	return nil
+}
+
+// entry returns the entry of an identifier, the caller has to hold the lock
+func (a *InMemoryDataAccessor) entry(identifier representation.ResourceIdentifier) (*memoryEntry, error) {
+	entry, ok := a.entries[identifier.Path]
+	if !ok {
+		return nil, errors.NewNotFoundError(identifier.Path, nil)
+	}
+	return entry, nil
+}
+
+// ensureParents creates all missing ancestor containers of a path, the caller has to hold the lock
+func (a *InMemoryDataAccessor) ensureParents(path string) error {
+	parent := parentPath(path)
+	if entry, ok := a.entries[parent]; ok {
+		if entry.children == nil {
+			return errors.NewConflictError(fmt.Sprintf("%s is not a container", parent), nil)
+		}
+		return nil
+	}
+	if _, ok := a.entries[strings.TrimSuffix(parent, "/")]; ok {
+		return errors.NewConflictError(fmt.Sprintf("a document exists at %s", strings.TrimSuffix(parent, "/")), nil)
+	}
+	if err := a.ensureParents(parent); err != nil {
+		return err
+	}
+	a.entries[parent] = &memoryEntry{modified: a.now(), children: make(map[string]bool)}
+	a.addChild(parent)
+	return nil
+}
+
+// addChild adds a path to the listing of its container and updates the modification time of the container,
+// the caller has to hold the lock
+func (a *InMemoryDataAccessor) addChild(path string) {
+	parent := a.entries[parentPath(path)]
+	parent.children[path] = true
+	parent.modified = a.now()
+}
+
+// setMetadata stores the persisted quads and content type of the metadata
+func (e *memoryEntry) setMetadata(metadata *representation.RepresentationMetadata) {
+	e.metadata = persistedQuads(metadata)
+	e.contentType = ""
+	if metadata != nil && e.children == nil {
+		e.contentType = metadata.ContentType()
+	}
+}
+
+// toMetadata creates the metadata of the entry, only including the stored quads if full is set
+func (e *memoryEntry) toMetadata(path string, full bool) *representation.RepresentationMetadata {
+	metadata := representation.NewRepresentationMetadata(path)
+	if full {
+		metadata.AddQuads(e.metadata)
+	}
+	addResourceMetadata(metadata, e.children != nil, e.modified)
+	if e.children == nil {
+		addSizeMetadata(metadata, int64(len(e.data)))
+		metadata.SetContentType(e.contentType)
+	}
+	return metadata
+}
@@ -0,0 +1,333 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"solid-go/internal/http/auxiliary"
+	"solid-go/internal/http/input/conditions"
+	"solid-go/internal/http/output/serialize"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage/conversion"
+	"solid-go/internal/storage/patch"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
+)
+
+func textRepresentation(data string) representation.Representation {
+	return representation.NewBasicRepresentation(strings.NewReader(data),
+		representation.NewRepresentationMetadata("").SetContentType(util.TextPlain), true)
+}
+
+func TestInMemoryDataAccessor(t *testing.T) {
+	accessor := NewInMemoryDataAccessor(baseURL)
+	document := identifier("a/b/c.txt")
+	if err := accessor.WriteDocument(document, strings.NewReader("hello"),
+		representation.NewRepresentationMetadata(document.Path).SetContentType(util.TextPlain)); err != nil {
+		t.Fatalf("WriteDocument() error = %v", err)
+	}
+
+	for _, container := range []string{"", "a/", "a/b/"} {
+		metadata, err := accessor.GetMetadata(identifier(container))
+		if err != nil {
+			t.Fatalf("GetMetadata(%v) error = %v", container, err)
+		}
+		if !isContainerMetadata(metadata) {
+			t.Errorf("GetMetadata(%v) is not a container", container)
+		}
+	}
+	children, err := accessor.GetChildren(identifier("a/b/"))
+	if err != nil || len(children) != 1 || children[0].GetIdentifier() != document.Path {
+		t.Errorf("GetChildren() = %v, %v", children, err)
+	}
+	if children[0].ContentType() != util.TextPlain {
+		t.Errorf("child ContentType() = %v, want %v", children[0].ContentType(), util.TextPlain)
+	}
+
+	data, err := accessor.GetData(document)
+	if err != nil {
+		t.Fatalf("GetData() error = %v", err)
+	}
+	if body, _ := io.ReadAll(data); string(body) != "hello" {
+		t.Errorf("GetData() = %v, want hello", string(body))
+	}
+
+	if err := accessor.WriteContainer(identifier("a/b/c.txt/"), nil); !errors.IsConflictError(err) {
+		t.Errorf("WriteContainer() over a document error = %v, want ConflictError", err)
+	}
+	if err := accessor.WriteDocument(identifier("a/../x"), strings.NewReader(""), nil); !errors.IsValidationError(err) {
+		t.Errorf("WriteDocument() with dot segments error = %v, want ValidationError", err)
+	}
+	if err := accessor.DeleteResource(identifier("a/")); !errors.IsConflictError(err) {
+		t.Errorf("DeleteResource() of a non-empty container error = %v, want ConflictError", err)
+	}
+	if err := accessor.DeleteResource(document); err != nil {
+		t.Errorf("DeleteResource() error = %v", err)
+	}
+	if _, err := accessor.GetData(document); !errors.IsNotFoundError(err) {
+		t.Errorf("GetData() after delete error = %v, want NotFoundError", err)
+	}
+}
+
+func TestInMemoryDataAccessor_Concurrent(t *testing.T) {
+	accessor := NewInMemoryDataAccessor(baseURL)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := identifier(fmt.Sprintf("shared/%d", i))
+			if err := accessor.WriteDocument(id, strings.NewReader("x"), nil); err != nil {
+				t.Errorf("WriteDocument() error = %v", err)
+			}
+			if _, err := accessor.GetChildren(identifier("shared/")); err != nil {
+				t.Errorf("GetChildren() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if children, _ := accessor.GetChildren(identifier("shared/")); len(children) != 20 {
+		t.Errorf("GetChildren() returned %v children, want 20", len(children))
+	}
+}
+
+func TestDataAccessorBasedStore(t *testing.T) {
+	store := NewDataAccessorBasedStore(NewInMemoryDataAccessor(baseURL), baseURL)
+	root := identifier("")
+
+	changes, err := store.AddResource(root, textRepresentation("posted"), nil)
+	if err != nil {
+		t.Fatalf("AddResource() error = %v", err)
+	}
+	var created representation.ResourceIdentifier
+	for _, id := range changes.Identifiers() {
+		if activity, _ := changes.Activity(id); activity.Equals(vocabularies.AS.Create) {
+			created = id
+		}
+	}
+	if !strings.HasPrefix(created.Path, baseURL) || IsContainerIdentifier(created) {
+		t.Fatalf("AddResource() created %v", created.Path)
+	}
+	if activity, _ := changes.Activity(root); !activity.Equals(vocabularies.AS.Update) {
+		t.Errorf("AddResource() did not update the container")
+	}
+
+	rep, err := store.GetRepresentation(created, nil, nil)
+	if err != nil {
+		t.Fatalf("GetRepresentation() error = %v", err)
+	}
+	if body, _ := io.ReadAll(rep.GetData()); string(body) != "posted" {
+		t.Errorf("GetRepresentation() = %v, want posted", string(body))
+	}
+
+	container, err := store.GetRepresentation(root, nil, nil)
+	if err != nil {
+		t.Fatalf("GetRepresentation() of the root error = %v", err)
+	}
+	if container.GetMetadata().ContentType() != util.InternalQuads {
+		t.Errorf("container ContentType() = %v, want %v", container.GetMetadata().ContentType(), util.InternalQuads)
+	}
+	if len(container.GetMetadata().Quads(root.Path, vocabularies.LDP.Contains, created.Path, nil)) != 1 {
+		t.Errorf("container does not contain %v", created.Path)
+	}
+
+	changes, err = store.SetRepresentation(created, textRepresentation("replaced"), nil)
+	if err != nil {
+		t.Fatalf("SetRepresentation() error = %v", err)
+	}
+	if activity, _ := changes.Activity(created); !activity.Equals(vocabularies.AS.Update) || len(changes) != 1 {
+		t.Errorf("SetRepresentation() changes = %v", changes.Identifiers())
+	}
+
+	if _, err := store.DeleteResource(created, nil); err != nil {
+		t.Fatalf("DeleteResource() error = %v", err)
+	}
+	if exists, _ := store.HasResource(created); exists {
+		t.Errorf("HasResource() after delete = true")
+	}
+	if _, err := store.ModifyResource(root, nil, nil); !errors.IsNotImplementedError(err) {
+		t.Errorf("ModifyResource() error = %v, want NotImplementedError", err)
+	}
+}
+
+func TestDataAccessorBasedStore_Conditions(t *testing.T) {
+	store := NewDataAccessorBasedStore(NewInMemoryDataAccessor(baseURL), baseURL)
+	id := identifier("doc.txt")
+	parser := conditions.NewBasicConditionsParser(nil)
+	createOnly, _ := parser.Handle(conditionalRequest(id, "If-None-Match", "*"))
+
+	if _, err := store.SetRepresentation(id, textRepresentation("v1"), createOnly); err != nil {
+		t.Fatalf("SetRepresentation() with If-None-Match: * error = %v", err)
+	}
+	if _, err := store.SetRepresentation(id, textRepresentation("v2"), createOnly); !errors.IsPreconditionFailedError(err) {
+		t.Fatalf("second SetRepresentation() with If-None-Match: * error = %v, want PreconditionFailedError", err)
+	}
+
+	rep, _ := store.GetRepresentation(id, nil, nil)
+	eTag := conditions.NewBasicETagHandler().GetETag(rep.GetMetadata())
+	ifMatch, _ := parser.Handle(conditionalRequest(id, "If-Match", eTag))
+	if _, err := store.SetRepresentation(id, textRepresentation("v2"), ifMatch); err != nil {
+		t.Fatalf("SetRepresentation() with a current ETag error = %v", err)
+	}
+	if _, err := store.SetRepresentation(id, textRepresentation("v3"), ifMatch); !errors.IsPreconditionFailedError(err) {
+		t.Errorf("SetRepresentation() with a stale ETag error = %v, want PreconditionFailedError", err)
+	}
+	if _, err := store.DeleteResource(id, ifMatch); !errors.IsPreconditionFailedError(err) {
+		t.Errorf("DeleteResource() with a stale ETag error = %v, want PreconditionFailedError", err)
+	}
+	if body, _ := io.ReadAll(mustGet(t, store, id).GetData()); string(body) != "v2" {
+		t.Errorf("body after failed writes = %v, want v2", string(body))
+	}
+}
+
+func conditionalRequest(id representation.ResourceIdentifier, header, value string) *http.Request {
+	request := httptest.NewRequest("PUT", id.Path, nil)
+	request.Header.Set(header, value)
+	return request
+}
+
+func mustGet(t *testing.T, store ResourceStore, id representation.ResourceIdentifier) representation.Representation {
+	t.Helper()
+	rep, err := store.GetRepresentation(id, nil, nil)
+	if err != nil {
+		t.Fatalf("GetRepresentation() error = %v", err)
+	}
+	return rep
+}
+
+func TestDataAccessorBasedStore_IntermediateContainers(t *testing.T) {
+	fileAccessor, _ := newTestFileAccessor(t)
+	sqlAccessor, err := NewSQLDataAccessor(context.Background(), openTestDB(t), baseURL)
+	if err != nil {
+		t.Fatalf("NewSQLDataAccessor() error = %v", err)
+	}
+	accessors := map[string]DataAccessor{"memory": NewInMemoryDataAccessor(baseURL), "file": fileAccessor, "sql": sqlAccessor}
+	for name, accessor := range accessors {
+		t.Run(name, func(t *testing.T) {
+			store := NewDataAccessorBasedStore(accessor, baseURL)
+			changes, err := store.SetRepresentation(identifier("a/b/c.txt"), textRepresentation("c"), nil)
+			if err != nil {
+				t.Fatalf("SetRepresentation() error = %v", err)
+			}
+			want := map[string]n3.Term{
+				"a/b/c.txt": vocabularies.AS.Create,
+				"a/b/":      vocabularies.AS.Create,
+				"a/":        vocabularies.AS.Create,
+				"":          vocabularies.AS.Update,
+			}
+			for path, activity := range want {
+				if got, _ := changes.Activity(identifier(path)); got == nil || !got.Equals(activity) {
+					t.Errorf("activity of %q = %v, want %v", path, got, activity)
+				}
+			}
+			if len(changes) != len(want) {
+				t.Errorf("changes = %v", changes.Identifiers())
+			}
+			if _, err := store.SetRepresentation(identifier("a/b"), textRepresentation("b"), nil); !errors.IsConflictError(err) {
+				t.Errorf("SetRepresentation() of a document next to a container error = %v", err)
+			}
+			if _, err := store.SetRepresentation(identifier("a/b/c.txt/d/e"), textRepresentation("e"), nil); !errors.IsConflictError(err) {
+				t.Errorf("SetRepresentation() below a document error = %v", err)
+			}
+			if exists, _ := store.HasResource(identifier("a/b/c.txt/")); exists {
+				t.Errorf("a failed write created a container")
+			}
+		})
+	}
+}
+
+func TestDataAccessorBasedStore_AuxiliaryResources(t *testing.T) {
+	fileAccessor, _ := newTestFileAccessor(t)
+	for name, accessor := range map[string]DataAccessor{"memory": NewInMemoryDataAccessor(baseURL), "file": fileAccessor} {
+		t.Run(name, func(t *testing.T) {
+			converter := conversion.NewRdfConverter(serialize.Options{})
+			description := auxiliary.NewDescriptionStrategy(converter)
+			store := NewDataAccessorBasedStore(accessor, baseURL).
+				WithAuxiliaryStrategy(auxiliary.NewRoutingAuxiliaryStrategy([]auxiliary.AuxiliaryStrategy{
+					auxiliary.NewAclStrategy(converter), description})).
+				WithMetadataStrategy(description).
+				WithPatcher(patch.NewN3Patcher())
+			if _, err := store.SetRepresentation(identifier("doc.txt"), textRepresentation("doc"), nil); err != nil {
+				t.Fatalf("SetRepresentation() error = %v", err)
+			}
+			doc := n3.NewNamedNode(baseURL + "doc.txt")
+
+			metadata := mustGet(t, store, identifier("doc.txt")).GetMetadata()
+			for predicate, object := range map[n3.Term]string{
+				vocabularies.ACL.AccessControl:    "doc.txt.acl",
+				vocabularies.POWDER_S.DescribedBy: "doc.txt.meta",
+			} {
+				if quads := metadata.Quads(doc, predicate, n3.NewNamedNode(baseURL+object), nil); len(quads) != 1 {
+					t.Errorf("metadata does not link to %v", object)
+				}
+			}
+
+			turtle := representation.NewBasicRepresentation(strings.NewReader("<#a> <#b> <#c>."),
+				representation.NewRepresentationMetadata("").SetContentType(util.Turtle), true)
+			if _, err := store.SetRepresentation(identifier("doc.txt.acl"), textRepresentation("not RDF"), nil); !errors.IsValidationError(err) {
+				t.Errorf("SetRepresentation() of an invalid ACL error = %v, want ValidationError", err)
+			}
+			if _, err := store.SetRepresentation(identifier("doc.txt.acl"), turtle, nil); err != nil {
+				t.Fatalf("SetRepresentation() of an ACL error = %v", err)
+			}
+			if _, err := store.AddResource(identifier("doc.txt.acl"), textRepresentation("child"), nil); !errors.IsMethodNotAllowedError(err) {
+				t.Errorf("AddResource() to an ACL error = %v, want MethodNotAllowedError", err)
+			}
+			root := mustGet(t, store, identifier("")).(*representation.BasicRdfDatasetRepresentation)
+			if quads := root.Quads.GetQuads(nil, vocabularies.LDP.Contains, n3.NewNamedNode(baseURL+"doc.txt.acl"), nil); len(quads) > 0 {
+				t.Errorf("the root container contains the ACL resource")
+			}
+			for _, predicate := range []n3.Term{vocabularies.ACL.AccessControl, vocabularies.POWDER_S.DescribedBy} {
+				if quads := root.Quads.GetQuads(nil, predicate, nil, nil); len(quads) > 0 {
+					t.Errorf("the body of the root container contains the response metadata %v", quads)
+				}
+				if quads := root.GetMetadata().Quads(baseURL, predicate, nil, nil); len(quads) != 1 {
+					t.Errorf("the metadata of the root container does not link to its %v", predicate.Value())
+				}
+			}
+
+			eTags := conditions.NewBasicETagHandler()
+			subjectETag := eTags.GetETag(mustGet(t, store, identifier("doc.txt")).GetMetadata())
+			descriptionETag := eTags.GetETag(mustGet(t, store, identifier("doc.txt.meta")).GetMetadata())
+			note := n3.NewNamedNode("http://example.org/ns#Note")
+			insert := representation.NewBasicN3Patch(textRepresentation("").(*representation.BasicRepresentation), nil,
+				[]n3.Quad{n3.NewQuad(doc, vocabularies.RDF.Type, note, nil)}, nil)
+			if _, err := store.ModifyResource(identifier("doc.txt.meta"), insert, nil); err != nil {
+				t.Fatalf("ModifyResource() of the description error = %v", err)
+			}
+			if quads := mustGet(t, store, identifier("doc.txt")).GetMetadata().Quads(doc, vocabularies.RDF.Type, note, nil); len(quads) != 1 {
+				t.Errorf("the subject metadata does not contain the patched triple")
+			}
+			described := mustGet(t, store, identifier("doc.txt.meta")).(*representation.BasicRdfDatasetRepresentation)
+			if quads := described.Quads.GetQuads(doc, vocabularies.RDF.Type, note, nil); len(quads) != 1 {
+				t.Errorf("the description does not contain the patched triple")
+			}
+			if eTag := eTags.GetETag(described.GetMetadata()); eTag == descriptionETag {
+				t.Errorf("the ETag %v of the description did not change after patching it", eTag)
+			}
+			if eTag := eTags.GetETag(mustGet(t, store, identifier("doc.txt")).GetMetadata()); eTag == subjectETag {
+				t.Errorf("the ETag %v of the subject did not change after patching its description", eTag)
+			}
+			if _, err := store.DeleteResource(identifier("doc.txt.meta"), nil); !errors.IsMethodNotAllowedError(err) {
+				t.Errorf("DeleteResource() of the description error = %v, want MethodNotAllowedError", err)
+			}
+
+			if _, err := store.DeleteResource(identifier("doc.txt"), nil); err != nil {
+				t.Fatalf("DeleteResource() error = %v", err)
+			}
+			for _, path := range []string{"doc.txt.acl", "doc.txt.meta"} {
+				if exists, _ := store.HasResource(identifier(path)); exists {
+					t.Errorf("%v still exists after deleting its subject", path)
+				}
+			}
+		})
+	}
+}
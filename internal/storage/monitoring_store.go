@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"sync"
+
+	"solid-go/internal/http/input/conditions"
+	"solid-go/internal/http/representation"
+)
+
+// ChangeListener is called with the resources that were changed by a successful operation
+type ChangeListener func(changes ChangeMap)
+
+// MonitoringStore passes the changes of every successful modifying operation on its source to its listeners,
+// so other components such as notifications can react to them.
+// The listeners are called before the operation returns, in the order they were added.
+type MonitoringStore struct {
+	*PassthroughStore
+
+	mu        sync.RWMutex
+	listeners []ChangeListener
+}
+
+// NewMonitoringStore creates a new MonitoringStore
+func NewMonitoringStore(source ResourceStore) *MonitoringStore {
+	return &MonitoringStore{PassthroughStore: NewPassthroughStore(source)}
+}
+
+// OnChange adds a listener that is called with the changes of every modifying operation
+func (s *MonitoringStore) OnChange(listener ChangeListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, listener)
+}
+
+// AddResource implements ResourceStore.AddResource
+func (s *MonitoringStore) AddResource(container representation.ResourceIdentifier, rep representation.Representation,
+	conditions conditions.Conditions) (ChangeMap, error) {
+	return s.emit(s.Source.AddResource(container, rep, conditions))
+}
+
+// SetRepresentation implements ResourceStore.SetRepresentation
+func (s *MonitoringStore) SetRepresentation(identifier representation.ResourceIdentifier, rep representation.Representation,
+	conditions conditions.Conditions) (ChangeMap, error) {
+	return s.emit(s.Source.SetRepresentation(identifier, rep, conditions))
+}
+
+// DeleteResource implements ResourceStore.DeleteResource
+func (s *MonitoringStore) DeleteResource(identifier representation.ResourceIdentifier, conditions conditions.Conditions) (ChangeMap, error) {
+	return s.emit(s.Source.DeleteResource(identifier, conditions))
+}
+
+// ModifyResource implements ResourceStore.ModifyResource
+func (s *MonitoringStore) ModifyResource(identifier representation.ResourceIdentifier, patch representation.Patch,
+	conditions conditions.Conditions) (ChangeMap, error) {
+	return s.emit(s.Source.ModifyResource(identifier, patch, conditions))
+}
+
+// emit passes the changes of a successful operation to the listeners and returns the result of the operation
+func (s *MonitoringStore) emit(changes ChangeMap, err error) (ChangeMap, error) {
+	if err != nil || len(changes) == 0 {
+		return changes, err
+	}
+	s.mu.RLock()
+	listeners := s.listeners
+	s.mu.RUnlock()
+	for _, listener := range listeners {
+		listener(changes)
+	}
+	return changes, nil
+}
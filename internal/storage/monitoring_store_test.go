@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/vocabularies"
+)
+
+func TestMonitoringStore(t *testing.T) {
+	base := "http://example.org/"
+	store := NewMonitoringStore(NewDataAccessorBasedStore(NewInMemoryDataAccessor(base), base))
+	var emitted []ChangeMap
+	store.OnChange(func(changes ChangeMap) { emitted = append(emitted, changes) })
+
+	doc := representation.ResourceIdentifier{Path: base + "doc.txt"}
+	metadata := representation.NewRepresentationMetadata("").SetContentType("text/plain")
+	if _, err := store.SetRepresentation(doc, representation.NewBasicRepresentation(strings.NewReader("hello"), metadata, true), nil); err != nil {
+		t.Fatalf("SetRepresentation() error = %v", err)
+	}
+	if len(emitted) != 1 {
+		t.Fatalf("emitted %d change maps, want 1", len(emitted))
+	}
+	if activity, ok := emitted[0].Activity(doc); !ok || !activity.Equals(vocabularies.AS.Create) {
+		t.Errorf("activity of the new resource = %v, want %v", activity, vocabularies.AS.Create)
+	}
+
+	// Failed operations are not emitted
+	if _, err := store.DeleteResource(representation.ResourceIdentifier{Path: base + "missing"}, nil); err == nil {
+		t.Fatal("DeleteResource() of a missing resource should fail")
+	}
+	if _, err := store.DeleteResource(doc, nil); err != nil {
+		t.Fatalf("DeleteResource() error = %v", err)
+	}
+	if len(emitted) != 2 {
+		t.Fatalf("emitted %d change maps, want 2", len(emitted))
+	}
+	if activity, ok := emitted[1].Activity(doc); !ok || !activity.Equals(vocabularies.AS.Delete) {
+		t.Errorf("activity of the deleted resource = %v, want %v", activity, vocabularies.AS.Delete)
+	}
+}
@@ -0,0 +1,154 @@
+package patch
+
+import (
+	"fmt"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+)
+
+// maxBindings is the number of solutions after which matching stops, since only a single solution is valid
+const maxBindings = 2
+
+// N3Patcher applies N3 Patches following the Solid N3 Patch semantics
+type N3Patcher struct{}
+
+// NewN3Patcher creates a new N3Patcher
+func NewN3Patcher() *N3Patcher {
+	return &N3Patcher{}
+}
+
+// CanHandle implements RdfPatcher.CanHandle
+func (p *N3Patcher) CanHandle(args RdfPatcherArgs) error {
+	if !representation.IsN3Patch(args.Patch) {
+		return errors.NewNotImplementedError("only N3 Patches are supported", nil)
+	}
+	return nil
+}
+
+// Handle implements RdfPatcher.Handle
+func (p *N3Patcher) Handle(args RdfPatcherArgs) error {
+	patch := args.Patch.(representation.N3Patch)
+
+	// Solid, §5.3.1: the conditions must have exactly one variable mapping to triples in the document
+	solution := binding{}
+	if conditions := patch.GetConditions(); len(conditions) > 0 {
+		bindings := findBindings(args.Dataset, conditions, binding{}, maxBindings, nil)
+		if len(bindings) != 1 {
+			return errors.NewConflictError(fmt.Sprintf(
+				"The document does not contain exactly one match for the N3 Patch solid:where condition, found %s",
+				describeCount(len(bindings))), nil)
+		}
+		solution = bindings[0]
+	}
+
+	// Solid, §5.3.1: all resulting deletions must be present in the document
+	deletes := solution.instantiate(patch.GetDeletes())
+	for _, quad := range deletes {
+		if !args.Dataset.Has(quad) {
+			return errors.NewConflictError(
+				"The document does not contain all triples the N3 Patch requests to delete, which is required for patching.", nil)
+		}
+	}
+	inserts := solution.instantiate(patch.GetInserts())
+
+	for _, quad := range deletes {
+		args.Dataset.RemoveQuad(quad)
+	}
+	args.Dataset.AddQuads(inserts)
+	return nil
+}
+
+// binding maps variable names to the terms they are bound to
+type binding map[string]n3.Term
+
+// isPlaceholder checks if the term matches any term in a condition.
+// Blank nodes in conditions act as variables whose values are never exposed.
+func isPlaceholder(term n3.Term) bool {
+	return term.TermType() == n3.VariableType || term.TermType() == n3.BlankNodeType
+}
+
+// resolve returns the bound value of a term, or nil if it is an unbound placeholder
+func (b binding) resolve(term n3.Term) n3.Term {
+	if !isPlaceholder(term) {
+		return term
+	}
+	return b[term.String()]
+}
+
+// extend binds the terms of the pattern to those of the quad, returning false if they conflict
+func (b binding) extend(pattern, quad n3.Quad) (binding, bool) {
+	result := make(binding, len(b)+3)
+	for key, value := range b {
+		result[key] = value
+	}
+	pairs := [][2]n3.Term{{pattern.Subject, quad.Subject}, {pattern.Predicate, quad.Predicate}, {pattern.Object, quad.Object}}
+	for _, pair := range pairs {
+		if !isPlaceholder(pair[0]) {
+			continue
+		}
+		if bound, ok := result[pair[0].String()]; ok && !bound.Equals(pair[1]) {
+			return nil, false
+		}
+		result[pair[0].String()] = pair[1]
+	}
+	return result, true
+}
+
+// instantiate replaces the variables of the templates with their bound values.
+// Templates with unbound variables are left out, as in SPARQL.
+func (b binding) instantiate(quads []n3.Quad) []n3.Quad {
+	result := make([]n3.Quad, 0, len(quads))
+	for _, quad := range quads {
+		terms := []n3.Term{quad.Subject, quad.Predicate, quad.Object}
+		complete := true
+		for i, term := range terms {
+			if term.TermType() == n3.VariableType {
+				terms[i] = b[term.String()]
+				complete = complete && terms[i] != nil
+			}
+		}
+		if complete {
+			result = append(result, n3.NewQuad(terms[0], terms[1], terms[2], nil))
+		}
+	}
+	return result
+}
+
+// findBindings returns the solutions of the patterns in the default graph of the dataset,
+// stopping once limit solutions are found if limit is positive
+func findBindings(dataset *n3.BasicStore, patterns []n3.Quad, current binding, limit int, found []binding) []binding {
+	if len(patterns) == 0 {
+		return append(found, current)
+	}
+	pattern := patterns[0]
+	var subject, predicate, object interface{}
+	if term := current.resolve(pattern.Subject); term != nil {
+		subject = term
+	}
+	if term := current.resolve(pattern.Predicate); term != nil {
+		predicate = term
+	}
+	if term := current.resolve(pattern.Object); term != nil {
+		object = term
+	}
+	for _, quad := range dataset.GetQuads(subject, predicate, object, n3.DefaultGraph()) {
+		next, ok := current.extend(pattern, quad)
+		if !ok {
+			continue
+		}
+		if found = findBindings(dataset, patterns[1:], next, limit, found); limit > 0 && len(found) >= limit {
+			return found
+		}
+	}
+	return found
+}
+
+// describeCount describes the number of solutions in an error message
+func describeCount(count int) string {
+	if count == 0 {
+		return "none"
+	}
+	return "multiple"
+}
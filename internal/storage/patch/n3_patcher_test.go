@@ -0,0 +1,95 @@
+package patch
+
+import (
+	"strings"
+	"testing"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+)
+
+const ex = "http://example.org/"
+
+func triple(s, p, o n3.Term) n3.Quad {
+	return n3.NewQuad(s, p, o, nil)
+}
+
+func node(name string) n3.Term {
+	return n3.NewNamedNode(ex + name)
+}
+
+func dataset(t *testing.T) *n3.BasicStore {
+	t.Helper()
+	store, err := n3.NewParser(n3.ParserOptions{BaseIRI: ex}).ParseToStore(strings.NewReader(
+		`<alice> <name> "Alice"; <knows> <bob>. <bob> <name> "Bob". <carol> <knows> <bob>.`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return store
+}
+
+func n3Patch(deletes, inserts, conditions []n3.Quad) representation.Patch {
+	return representation.NewBasicN3Patch(representation.NewBasicRepresentation(nil, nil, true), deletes, inserts, conditions)
+}
+
+func TestN3Patcher(t *testing.T) {
+	patcher := NewN3Patcher()
+	person, name := n3.NewVariable("person"), n3.NewVariable("name")
+	bobName := triple(node("bob"), node("name"), n3.NewLiteral("Bob"))
+	tests := []struct {
+		name       string
+		deletes    []n3.Quad
+		inserts    []n3.Quad
+		conditions []n3.Quad
+		want       func(error) bool
+		added      n3.Quad
+		removed    *n3.Quad
+	}{
+		{"insert without conditions", nil, []n3.Quad{triple(node("dave"), node("name"), n3.NewLiteral("Dave"))}, nil,
+			nil, triple(node("dave"), node("name"), n3.NewLiteral("Dave")), nil},
+		{"single binding", []n3.Quad{triple(person, node("name"), name)},
+			[]n3.Quad{triple(person, node("name"), n3.NewLiteral("Robert"))},
+			[]n3.Quad{triple(person, node("name"), name), triple(node("alice"), node("knows"), person)},
+			nil, triple(node("bob"), node("name"), n3.NewLiteral("Robert")), &bobName},
+		{"blank node in conditions", nil, []n3.Quad{triple(node("alice"), node("seen"), n3.NewLiteral("yes"))},
+			[]n3.Quad{triple(n3.NewBlankNode("x"), node("name"), n3.NewLiteral("Alice"))},
+			nil, triple(node("alice"), node("seen"), n3.NewLiteral("yes")), nil},
+		{"no binding", nil, nil, []n3.Quad{triple(person, node("name"), n3.NewLiteral("Zed"))}, errors.IsConflictError, n3.Quad{}, nil},
+		{"multiple bindings", nil, nil, []n3.Quad{triple(person, node("knows"), node("bob"))}, errors.IsConflictError, n3.Quad{}, nil},
+		{"missing deletion", []n3.Quad{triple(node("alice"), node("name"), n3.NewLiteral("Bob"))}, nil, nil,
+			errors.IsConflictError, n3.Quad{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := dataset(t)
+			args := RdfPatcherArgs{Dataset: store, Patch: n3Patch(tt.deletes, tt.inserts, tt.conditions)}
+			if err := patcher.CanHandle(args); err != nil {
+				t.Fatalf("CanHandle() error = %v", err)
+			}
+			err := patcher.Handle(args)
+			if tt.want != nil {
+				if !tt.want(err) {
+					t.Errorf("Handle() error = %v", err)
+				}
+				if store.Size() != dataset(t).Size() {
+					t.Errorf("a failed patch modified the dataset")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+			if !store.Has(tt.added) {
+				t.Errorf("dataset does not contain %v", tt.added)
+			}
+			if tt.removed != nil && store.Has(*tt.removed) {
+				t.Errorf("dataset still contains %v", *tt.removed)
+			}
+		})
+	}
+
+	if err := patcher.CanHandle(RdfPatcherArgs{Patch: representation.NewBasicRepresentation(nil, nil, true)}); !errors.IsNotImplementedError(err) {
+		t.Errorf("CanHandle() of another patch error = %v, want NotImplementedError", err)
+	}
+}
@@ -0,0 +1,63 @@
+// Package patch applies patches to the RDF contents of resources.
+package patch
+
+import (
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+)
+
+// RdfPatcherArgs are the arguments of an RdfPatcher
+type RdfPatcherArgs struct {
+	// Identifier of the patched resource, used to resolve relative IRIs
+	Identifier representation.ResourceIdentifier
+	// Dataset holds the current contents of the resource and is modified in place
+	Dataset *n3.BasicStore
+	// Patch to apply
+	Patch representation.Patch
+}
+
+// RdfPatcher applies a patch to the RDF dataset of a resource.
+// Patches are atomic: the dataset is only modified if the entire patch applies.
+type RdfPatcher interface {
+	// CanHandle returns an error if the patcher does not support the patch
+	CanHandle(args RdfPatcherArgs) error
+	// Handle applies the patch to the dataset
+	Handle(args RdfPatcherArgs) error
+}
+
+// WaterfallRdfPatcher applies a patch with the first of its patchers that supports it
+type WaterfallRdfPatcher struct {
+	patchers []RdfPatcher
+}
+
+// NewWaterfallRdfPatcher creates a new WaterfallRdfPatcher
+func NewWaterfallRdfPatcher(patchers ...RdfPatcher) *WaterfallRdfPatcher {
+	return &WaterfallRdfPatcher{patchers: patchers}
+}
+
+// CanHandle implements RdfPatcher.CanHandle
+func (p *WaterfallRdfPatcher) CanHandle(args RdfPatcherArgs) error {
+	_, err := p.find(args)
+	return err
+}
+
+// Handle implements RdfPatcher.Handle
+func (p *WaterfallRdfPatcher) Handle(args RdfPatcherArgs) error {
+	patcher, err := p.find(args)
+	if err != nil {
+		return err
+	}
+	return patcher.Handle(args)
+}
+
+// find returns the first patcher that supports the patch, or the error of the last one
+func (p *WaterfallRdfPatcher) find(args RdfPatcherArgs) (RdfPatcher, error) {
+	err := errors.NewNotImplementedError("no patcher supports this patch", nil)
+	for _, patcher := range p.patchers {
+		if err = patcher.CanHandle(args); err == nil {
+			return patcher, nil
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,69 @@
+package patch
+
+import (
+	"fmt"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+)
+
+// SparqlUpdatePatcher applies SPARQL Update patches.
+// The parser only accepts updates of the default graph with basic graph patterns,
+// so every operation applies and a patch is never applied halfway.
+type SparqlUpdatePatcher struct{}
+
+// NewSparqlUpdatePatcher creates a new SparqlUpdatePatcher
+func NewSparqlUpdatePatcher() *SparqlUpdatePatcher {
+	return &SparqlUpdatePatcher{}
+}
+
+// CanHandle implements RdfPatcher.CanHandle
+func (p *SparqlUpdatePatcher) CanHandle(args RdfPatcherArgs) error {
+	if patch, ok := args.Patch.(representation.SparqlUpdatePatch); !ok || patch.Algebra() == nil {
+		return errors.NewNotImplementedError("only SPARQL Update patches are supported", nil)
+	}
+	return nil
+}
+
+// Handle implements RdfPatcher.Handle
+func (p *SparqlUpdatePatcher) Handle(args RdfPatcherArgs) error {
+	algebra := args.Patch.(representation.SparqlUpdatePatch).Algebra()
+	for _, operation := range algebra.Operations {
+		switch operation.Type {
+		case n3.InsertData:
+			args.Dataset.AddQuads(operation.Insert)
+		case n3.DeleteData:
+			for _, quad := range operation.Delete {
+				args.Dataset.RemoveQuad(quad)
+			}
+		case n3.DeleteInsert:
+			// All solutions are computed before the dataset changes
+			var deletes, inserts []n3.Quad
+			for i, solution := range findBindings(args.Dataset, operation.Where, binding{}, 0, nil) {
+				deletes = append(deletes, solution.instantiate(operation.Delete)...)
+				inserts = append(inserts, freshBlankNodes(solution.instantiate(operation.Insert), i)...)
+			}
+			for _, quad := range deletes {
+				args.Dataset.RemoveQuad(quad)
+			}
+			args.Dataset.AddQuads(inserts)
+		}
+	}
+	return nil
+}
+
+// freshBlankNodes relabels the blank nodes of an instantiated template,
+// since every solution of the WHERE pattern creates new blank nodes
+func freshBlankNodes(quads []n3.Quad, solution int) []n3.Quad {
+	relabel := func(term n3.Term) n3.Term {
+		if term.TermType() != n3.BlankNodeType {
+			return term
+		}
+		return n3.NewBlankNode(fmt.Sprintf("%s_%d", term.Value(), solution))
+	}
+	for i, quad := range quads {
+		quads[i] = n3.NewQuad(relabel(quad.Subject), quad.Predicate, relabel(quad.Object), nil)
+	}
+	return quads
+}
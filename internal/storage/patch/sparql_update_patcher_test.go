@@ -0,0 +1,65 @@
+package patch
+
+import (
+	"strings"
+	"testing"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+)
+
+func sparqlPatch(t *testing.T, query string) representation.Patch {
+	t.Helper()
+	algebra, err := n3.ParseSparqlUpdate(strings.NewReader(query), ex)
+	if err != nil {
+		t.Fatalf("ParseSparqlUpdate() error = %v", err)
+	}
+	return representation.NewBasicSparqlUpdatePatch(representation.NewBasicRepresentation(nil, nil, true), algebra)
+}
+
+func TestSparqlUpdatePatcher(t *testing.T) {
+	patcher := NewWaterfallRdfPatcher(NewN3Patcher(), NewSparqlUpdatePatcher())
+	store := dataset(t)
+	args := RdfPatcherArgs{Dataset: store, Patch: sparqlPatch(t, `
+INSERT DATA { <dave> <name> "Dave" } ;
+DELETE DATA { <carol> <knows> <bob> } ;
+DELETE { ?person <name> ?name } INSERT { ?person <label> ?name ; <friend> [ <name> "anonymous" ] } WHERE { ?person <name> ?name } ;
+DELETE WHERE { <alice> <knows> ?someone }`)}
+	if err := patcher.CanHandle(args); err != nil {
+		t.Fatalf("CanHandle() error = %v", err)
+	}
+	if err := patcher.Handle(args); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	for _, quad := range []n3.Quad{
+		triple(node("dave"), node("label"), n3.NewLiteral("Dave")),
+		triple(node("alice"), node("label"), n3.NewLiteral("Alice")),
+		triple(node("bob"), node("label"), n3.NewLiteral("Bob")),
+	} {
+		if !store.Has(quad) {
+			t.Errorf("dataset does not contain %v", quad)
+		}
+	}
+	if got := store.CountQuads(nil, node("name"), nil, nil); got != 3 {
+		t.Errorf("name CountQuads() = %v, want only the 3 anonymous friends", got)
+	}
+	if got := len(store.GetObjects(nil, node("friend"), nil)); got != 3 {
+		t.Errorf("every solution should insert its own blank node, got %v", got)
+	}
+	if got := store.CountQuads(nil, node("knows"), nil, nil); got != 0 {
+		t.Errorf("knows CountQuads() = %v, want 0", got)
+	}
+
+	unmatched := RdfPatcherArgs{Dataset: store, Patch: sparqlPatch(t, `DELETE { ?s <p> ?o } WHERE { ?s <missing> ?o }`)}
+	size := store.Size()
+	if err := patcher.Handle(unmatched); err != nil || store.Size() != size {
+		t.Errorf("a WHERE without solutions should not change the dataset: %v", err)
+	}
+
+	n3Args := RdfPatcherArgs{Dataset: store, Patch: n3Patch(nil, nil, nil)}
+	if err := NewSparqlUpdatePatcher().CanHandle(n3Args); !errors.IsNotImplementedError(err) {
+		t.Errorf("CanHandle() of an N3 Patch error = %v, want NotImplementedError", err)
+	}
+}
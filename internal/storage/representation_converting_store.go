@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"strings"
+
+	"solid-go/internal/http/input/conditions"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/storage/conversion"
+	"solid-go/internal/util"
+)
+
+// defaultTypePreferences are used to serialize internal data when a request has no Accept header
+var defaultTypePreferences = representation.ValuePreferences{util.Turtle: 1, "*/*": 0.8}
+
+// RepresentationConvertingStore converts the representations of the source store to the preferred content type.
+// Internal content types are never returned, so containers are serialized to an RDF syntax.
+type RepresentationConvertingStore struct {
+	*PassthroughStore
+	converter conversion.RepresentationConverter
+}
+
+// NewRepresentationConvertingStore creates a new RepresentationConvertingStore
+func NewRepresentationConvertingStore(source ResourceStore, converter conversion.RepresentationConverter) *RepresentationConvertingStore {
+	return &RepresentationConvertingStore{PassthroughStore: NewPassthroughStore(source), converter: converter}
+}
+
+// GetRepresentation implements ResourceStore.GetRepresentation
+func (s *RepresentationConvertingStore) GetRepresentation(identifier representation.ResourceIdentifier,
+	preferences *representation.RepresentationPreferences, conditions conditions.Conditions) (representation.Representation, error) {
+	rep, err := s.Source.GetRepresentation(identifier, preferences, conditions)
+	if err != nil || rep.GetMetadata() == nil || rep.GetMetadata().ContentType() == "" {
+		return rep, err
+	}
+	// Without type preferences, data is returned as stored unless it is internal
+	hasTypes := preferences != nil && len(preferences.Type) > 0
+	if !hasTypes && !strings.HasPrefix(rep.GetMetadata().ContentType(), "internal/") {
+		return rep, nil
+	}
+	args := conversion.RepresentationConverterArgs{
+		Identifier:     identifier,
+		Representation: rep,
+		Preferences:    outputPreferences(preferences),
+	}
+	converted, err := s.converter.Handle(args)
+	if err != nil {
+		closeData(rep.GetData())
+		return nil, err
+	}
+	return converted, nil
+}
+
+// outputPreferences copies the preferences, applying the default types and excluding the internal types
+func outputPreferences(preferences *representation.RepresentationPreferences) *representation.RepresentationPreferences {
+	result := &representation.RepresentationPreferences{}
+	if preferences != nil {
+		*result = *preferences
+	}
+	types := make(representation.ValuePreferences)
+	source := result.Type
+	if len(source) == 0 {
+		source = defaultTypePreferences
+	}
+	for contentType, weight := range source {
+		types[contentType] = weight
+	}
+	if _, ok := types[util.InternalAll]; !ok {
+		types[util.InternalAll] = 0
+	}
+	result.Type = types
+	return result
+}
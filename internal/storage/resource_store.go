@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"sort"
+
+	"solid-go/internal/http/input/conditions"
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/n3"
+	"solid-go/internal/util/vocabularies"
+)
+
+// ChangeMap contains the metadata of every resource that was changed by an operation, keyed by identifier path.
+// The activity that happened to a resource is stored in its metadata under the AS.Activity key.
+type ChangeMap map[string]*representation.RepresentationMetadata
+
+// Add records that the given activity happened to a resource and returns the map for chaining
+func (m ChangeMap) Add(identifier representation.ResourceIdentifier, activity n3.Term) ChangeMap {
+	metadata := representation.NewRepresentationMetadata(identifier.Path)
+	metadata.Add(vocabularies.AS.Activity.Value(), activity)
+	m[identifier.Path] = metadata
+	return m
+}
+
+// Activity returns the activity that happened to a resource
+func (m ChangeMap) Activity(identifier representation.ResourceIdentifier) (n3.Term, bool) {
+	metadata, ok := m[identifier.Path]
+	if !ok {
+		return nil, false
+	}
+	value, _ := metadata.Get(vocabularies.AS.Activity.Value())
+	activity, ok := value.(n3.Term)
+	return activity, ok
+}
+
+// Merge adds all entries of the other map, overwriting existing entries
+func (m ChangeMap) Merge(other ChangeMap) ChangeMap {
+	for path, metadata := range other {
+		m[path] = metadata
+	}
+	return m
+}
+
+// Identifiers returns the identifiers of all changed resources in sorted order
+func (m ChangeMap) Identifiers() []representation.ResourceIdentifier {
+	paths := make([]string, 0, len(m))
+	for path := range m {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	identifiers := make([]representation.ResourceIdentifier, len(paths))
+	for i, path := range paths {
+		identifiers[i] = representation.ResourceIdentifier{Path: path}
+	}
+	return identifiers
+}
+
+// ResourceStore is a Solid resource store that works on representations instead of raw bytes.
+// All modifying operations return the resources that were changed, so other components such as
+// notifications and authorization can react to them.
+type ResourceStore interface {
+	// HasResource checks whether a resource exists in this store
+	HasResource(identifier representation.ResourceIdentifier) (bool, error)
+
+	// GetRepresentation retrieves a representation of a resource
+	GetRepresentation(identifier representation.ResourceIdentifier, preferences *representation.RepresentationPreferences,
+		conditions conditions.Conditions) (representation.Representation, error)
+
+	// AddResource creates a new resource in the given container
+	AddResource(container representation.ResourceIdentifier, rep representation.Representation,
+		conditions conditions.Conditions) (ChangeMap, error)
+
+	// SetRepresentation creates a resource or replaces its representation
+	SetRepresentation(identifier representation.ResourceIdentifier, rep representation.Representation,
+		conditions conditions.Conditions) (ChangeMap, error)
+
+	// DeleteResource deletes a resource
+	DeleteResource(identifier representation.ResourceIdentifier, conditions conditions.Conditions) (ChangeMap, error)
+
+	// ModifyResource partially updates a resource with a patch
+	ModifyResource(identifier representation.ResourceIdentifier, patch representation.Patch,
+		conditions conditions.Conditions) (ChangeMap, error)
+}
+
+// PassthroughStore forwards all calls to a source store.
+// It can be embedded by stores that only need to change some of the operations.
+type PassthroughStore struct {
+	Source ResourceStore
+}
+
+// NewPassthroughStore creates a new PassthroughStore
+func NewPassthroughStore(source ResourceStore) *PassthroughStore {
+	return &PassthroughStore{Source: source}
+}
+
+// HasResource implements ResourceStore.HasResource
+func (s *PassthroughStore) HasResource(identifier representation.ResourceIdentifier) (bool, error) {
+	return s.Source.HasResource(identifier)
+}
+
+// GetRepresentation implements ResourceStore.GetRepresentation
+func (s *PassthroughStore) GetRepresentation(identifier representation.ResourceIdentifier,
+	preferences *representation.RepresentationPreferences, conditions conditions.Conditions) (representation.Representation, error) {
+	return s.Source.GetRepresentation(identifier, preferences, conditions)
+}
+
+// AddResource implements ResourceStore.AddResource
+func (s *PassthroughStore) AddResource(container representation.ResourceIdentifier, rep representation.Representation,
+	conditions conditions.Conditions) (ChangeMap, error) {
+	return s.Source.AddResource(container, rep, conditions)
+}
+
+// SetRepresentation implements ResourceStore.SetRepresentation
+func (s *PassthroughStore) SetRepresentation(identifier representation.ResourceIdentifier, rep representation.Representation,
+	conditions conditions.Conditions) (ChangeMap, error) {
+	return s.Source.SetRepresentation(identifier, rep, conditions)
+}
+
+// DeleteResource implements ResourceStore.DeleteResource
+func (s *PassthroughStore) DeleteResource(identifier representation.ResourceIdentifier, conditions conditions.Conditions) (ChangeMap, error) {
+	return s.Source.DeleteResource(identifier, conditions)
+}
+
+// ModifyResource implements ResourceStore.ModifyResource
+func (s *PassthroughStore) ModifyResource(identifier representation.ResourceIdentifier, patch representation.Patch,
+	conditions conditions.Conditions) (ChangeMap, error) {
+	return s.Source.ModifyResource(identifier, patch, conditions)
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"testing"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/vocabularies"
+)
+
+func TestChangeMap(t *testing.T) {
+	container := representation.ResourceIdentifier{Path: "http://example.org/foo/"}
+	resource := representation.ResourceIdentifier{Path: "http://example.org/foo/bar"}
+
+	changes := make(ChangeMap).Add(resource, vocabularies.AS.Create)
+	changes.Merge(make(ChangeMap).Add(container, vocabularies.AS.Update))
+
+	if activity, ok := changes.Activity(resource); !ok || !activity.Equals(vocabularies.AS.Create) {
+		t.Errorf("Activity(%v) = %v, want %v", resource.Path, activity, vocabularies.AS.Create)
+	}
+	if activity, ok := changes.Activity(container); !ok || !activity.Equals(vocabularies.AS.Update) {
+		t.Errorf("Activity(%v) = %v, want %v", container.Path, activity, vocabularies.AS.Update)
+	}
+	if _, ok := changes.Activity(representation.ResourceIdentifier{Path: "http://example.org/"}); ok {
+		t.Errorf("Activity() found an unchanged resource")
+	}
+	identifiers := changes.Identifiers()
+	if len(identifiers) != 2 || identifiers[0] != container || identifiers[1] != resource {
+		t.Errorf("Identifiers() = %v, want [%v %v]", identifiers, container, resource)
+	}
+}
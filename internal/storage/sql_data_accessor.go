@@ -0,0 +1,403 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/init/migration"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+
+	// Registers the pure Go "sqlite" driver so no cgo is needed
+	_ "modernc.org/sqlite"
+)
+
+// sqlMigrations are the schema versions of the SQL backends
+var sqlMigrations = []migration.SQLMigration{
+	{
+		Version:     1,
+		Description: "create resources and blobs tables",
+		Statements: []string{
+			`CREATE TABLE resources (
+				path TEXT PRIMARY KEY,
+				parent TEXT,
+				is_container INTEGER NOT NULL DEFAULT 0,
+				content_type TEXT NOT NULL DEFAULT '',
+				data BLOB,
+				size INTEGER NOT NULL DEFAULT 0,
+				metadata TEXT NOT NULL DEFAULT '',
+				modified INTEGER NOT NULL
+			)`,
+			`CREATE INDEX resources_parent ON resources (parent)`,
+			`CREATE TABLE blobs (
+				path TEXT PRIMARY KEY,
+				parent TEXT NOT NULL,
+				is_dir INTEGER NOT NULL DEFAULT 0,
+				data BLOB
+			)`,
+			`CREATE INDEX blobs_parent ON blobs (parent)`,
+		},
+	},
+}
+
+// OpenSQLite opens a SQLite database file and brings its schema up to date
+func OpenSQLite(ctx context.Context, path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, err
+	}
+	// SQLite only allows a single writer, a single connection avoids lock errors between transactions
+	db.SetMaxOpenConns(1)
+	if err := migration.NewSQLMigrator(db, sqlMigrations).Run(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// sqlQuerier is implemented by both *sql.DB and *sql.Tx
+type sqlQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// SQLDataAccessor stores resources in a SQL database.
+// Every write happens in a transaction, containers are listed through an index on the parent column.
+type SQLDataAccessor struct {
+	db      *sql.DB
+	baseURL string
+	now     func() time.Time
+}
+
+// NewSQLDataAccessor creates a new SQLDataAccessor on a database opened with OpenSQLite.
+// The root container is created if it does not exist yet.
+func NewSQLDataAccessor(ctx context.Context, db *sql.DB, baseURL string) (*SQLDataAccessor, error) {
+	a := &SQLDataAccessor{db: db, baseURL: ensureTrailingSlash(baseURL), now: time.Now}
+	if _, err := db.ExecContext(ctx, `INSERT OR IGNORE INTO resources (path, parent, is_container, modified) VALUES (?, NULL, 1, ?)`,
+		a.baseURL, a.now().UnixNano()); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// CanHandle implements DataAccessor.CanHandle
+func (a *SQLDataAccessor) CanHandle(rep representation.Representation) error {
+	if rep.GetData() == nil {
+		return errors.NewUnsupportedMediaTypeError("only data streams can be stored", nil)
+	}
+	return nil
+}
+
+// GetData implements DataAccessor.GetData
+func (a *SQLDataAccessor) GetData(identifier representation.ResourceIdentifier) (io.ReadCloser, error) {
+	var isContainer bool
+	var data []byte
+	err := a.db.QueryRow(`SELECT is_container, data FROM resources WHERE path = ?`, identifier.Path).Scan(&isContainer, &data)
+	if err != nil {
+		return nil, notFound(identifier, err)
+	}
+	if isContainer {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("%s is a container", identifier.Path), nil)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// GetMetadata implements DataAccessor.GetMetadata
+func (a *SQLDataAccessor) GetMetadata(identifier representation.ResourceIdentifier) (*representation.RepresentationMetadata, error) {
+	var row sqlResourceRow
+	err := a.db.QueryRow(`SELECT path, is_container, content_type, size, metadata, modified FROM resources WHERE path = ?`,
+		identifier.Path).Scan(&row.path, &row.isContainer, &row.contentType, &row.size, &row.metadata, &row.modified)
+	if err != nil {
+		return nil, notFound(identifier, err)
+	}
+	metadata := row.toMetadata()
+	if row.metadata != "" {
+		if err := parseMetadata(strings.NewReader(row.metadata), identifier.Path, func(quad n3.Quad) error {
+			metadata.AddQuad(quad)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return metadata, nil
+}
+
+// GetChildren implements DataAccessor.GetChildren
+func (a *SQLDataAccessor) GetChildren(identifier representation.ResourceIdentifier) ([]*representation.RepresentationMetadata, error) {
+	var isContainer bool
+	if err := a.db.QueryRow(`SELECT is_container FROM resources WHERE path = ?`, identifier.Path).Scan(&isContainer); err != nil {
+		return nil, notFound(identifier, err)
+	}
+	if !isContainer {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("%s is not a container", identifier.Path), nil)
+	}
+	rows, err := a.db.Query(`SELECT path, is_container, content_type, size, modified FROM resources WHERE parent = ? ORDER BY path`,
+		identifier.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var children []*representation.RepresentationMetadata
+	for rows.Next() {
+		var row sqlResourceRow
+		if err := rows.Scan(&row.path, &row.isContainer, &row.contentType, &row.size, &row.modified); err != nil {
+			return nil, err
+		}
+		children = append(children, row.toMetadata())
+	}
+	return children, rows.Err()
+}
+
+// WriteDocument implements DataAccessor.WriteDocument
+func (a *SQLDataAccessor) WriteDocument(identifier representation.ResourceIdentifier, data io.Reader,
+	metadata *representation.RepresentationMetadata) error {
+	if IsContainerIdentifier(identifier) {
+		return errors.NewConflictError(fmt.Sprintf("%s is a container", identifier.Path), nil)
+	}
+	if err := checkStorageIdentifier(a.baseURL, identifier); err != nil {
+		return err
+	}
+	buffer, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	serialized, err := a.serializeMetadata(identifier, metadata)
+	if err != nil {
+		return err
+	}
+	contentType := ""
+	if metadata != nil {
+		contentType = metadata.ContentType()
+	}
+
+	return a.transaction(func(ctx context.Context, tx *sql.Tx) error {
+		if err := a.ensureParents(ctx, tx, identifier.Path); err != nil {
+			return err
+		}
+		if exists, err := rowExists(ctx, tx, identifier.Path+"/"); err != nil || exists {
+			return orConflict(err, fmt.Sprintf("a container exists at %s/", identifier.Path))
+		}
+		existed, err := rowExists(ctx, tx, identifier.Path)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO resources (path, parent, is_container, content_type, data, size, metadata, modified)
+			VALUES (?, ?, 0, ?, ?, ?, ?, ?)
+			ON CONFLICT (path) DO UPDATE SET content_type = excluded.content_type, data = excluded.data,
+				size = excluded.size, metadata = excluded.metadata, modified = excluded.modified`,
+			identifier.Path, parentPath(identifier.Path), contentType, buffer, len(buffer), serialized, a.now().UnixNano()); err != nil {
+			return err
+		}
+		if !existed {
+			return a.touch(ctx, tx, parentPath(identifier.Path))
+		}
+		return nil
+	})
+}
+
+// WriteContainer implements DataAccessor.WriteContainer
+func (a *SQLDataAccessor) WriteContainer(identifier representation.ResourceIdentifier,
+	metadata *representation.RepresentationMetadata) error {
+	if !IsContainerIdentifier(identifier) {
+		return errors.NewConflictError(fmt.Sprintf("%s is not a container", identifier.Path), nil)
+	}
+	if err := checkStorageIdentifier(a.baseURL, identifier); err != nil {
+		return err
+	}
+	serialized, err := a.serializeMetadata(identifier, metadata)
+	if err != nil {
+		return err
+	}
+
+	return a.transaction(func(ctx context.Context, tx *sql.Tx) error {
+		existed, err := rowExists(ctx, tx, identifier.Path)
+		if err != nil {
+			return err
+		}
+		if existed {
+			_, err := tx.ExecContext(ctx, `UPDATE resources SET metadata = ?, modified = ? WHERE path = ?`,
+				serialized, a.now().UnixNano(), identifier.Path)
+			return err
+		}
+		if err := a.ensureParents(ctx, tx, identifier.Path); err != nil {
+			return err
+		}
+		document := strings.TrimSuffix(identifier.Path, "/")
+		if exists, err := rowExists(ctx, tx, document); err != nil || exists {
+			return orConflict(err, fmt.Sprintf("a document exists at %s", document))
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO resources (path, parent, is_container, metadata, modified) VALUES (?, ?, 1, ?, ?)`,
+			identifier.Path, parentPath(identifier.Path), serialized, a.now().UnixNano()); err != nil {
+			return err
+		}
+		return a.touch(ctx, tx, parentPath(identifier.Path))
+	})
+}
+
+// WriteMetadata implements DataAccessor.WriteMetadata
+func (a *SQLDataAccessor) WriteMetadata(identifier representation.ResourceIdentifier,
+	metadata *representation.RepresentationMetadata) error {
+	serialized, err := a.serializeMetadata(identifier, metadata)
+	if err != nil {
+		return err
+	}
+	modified := a.now().UnixNano()
+	query := `UPDATE resources SET metadata = ?, modified = ? WHERE path = ?`
+	args := []interface{}{serialized, modified, identifier.Path}
+	if !IsContainerIdentifier(identifier) && metadata != nil {
+		query = `UPDATE resources SET metadata = ?, content_type = ?, modified = ? WHERE path = ?`
+		args = []interface{}{serialized, metadata.ContentType(), modified, identifier.Path}
+	}
+	result, err := a.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return orNotFound(err, identifier)
+	}
+	return nil
+}
+
+// DeleteResource implements DataAccessor.DeleteResource
+func (a *SQLDataAccessor) DeleteResource(identifier representation.ResourceIdentifier) error {
+	if identifier.Path == a.baseURL {
+		return errors.NewConflictError("the root container can not be deleted", nil)
+	}
+	return a.transaction(func(ctx context.Context, tx *sql.Tx) error {
+		if exists, err := rowExists(ctx, tx, identifier.Path); err != nil || !exists {
+			return orNotFound(err, identifier)
+		}
+		var hasChildren bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM resources WHERE parent = ?)`, identifier.Path).Scan(&hasChildren); err != nil {
+			return err
+		}
+		if hasChildren {
+			return errors.NewConflictError(fmt.Sprintf("can only delete empty containers, %s is not empty", identifier.Path), nil)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM resources WHERE path = ?`, identifier.Path); err != nil {
+			return err
+		}
+		return a.touch(ctx, tx, parentPath(identifier.Path))
+	})
+}
+
+// ensureParents creates all missing ancestor containers of a path
+func (a *SQLDataAccessor) ensureParents(ctx context.Context, tx *sql.Tx, path string) error {
+	parent := parentPath(path)
+	var isContainer bool
+	err := tx.QueryRowContext(ctx, `SELECT is_container FROM resources WHERE path = ?`, parent).Scan(&isContainer)
+	if err == nil {
+		if !isContainer {
+			return errors.NewConflictError(fmt.Sprintf("%s is not a container", parent), nil)
+		}
+		return nil
+	}
+	if err != sql.ErrNoRows {
+		return err
+	}
+	document := strings.TrimSuffix(parent, "/")
+	if exists, err := rowExists(ctx, tx, document); err != nil || exists {
+		return orConflict(err, fmt.Sprintf("a document exists at %s", document))
+	}
+	if err := a.ensureParents(ctx, tx, parent); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO resources (path, parent, is_container, modified) VALUES (?, ?, 1, ?)`,
+		parent, parentPath(parent), a.now().UnixNano()); err != nil {
+		return err
+	}
+	return a.touch(ctx, tx, parentPath(parent))
+}
+
+// touch updates the modification time of a container after its listing changed
+func (a *SQLDataAccessor) touch(ctx context.Context, q sqlQuerier, path string) error {
+	_, err := q.ExecContext(ctx, `UPDATE resources SET modified = ? WHERE path = ?`, a.now().UnixNano(), path)
+	return err
+}
+
+// transaction runs the function in a transaction that is committed if it returns no error
+func (a *SQLDataAccessor) transaction(run func(ctx context.Context, tx *sql.Tx) error) error {
+	ctx := context.Background()
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := run(ctx, tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// serializeMetadata returns the persisted metadata quads as Turtle, or the empty string if there are none
+func (a *SQLDataAccessor) serializeMetadata(identifier representation.ResourceIdentifier,
+	metadata *representation.RepresentationMetadata) (string, error) {
+	quads := persistedQuads(metadata)
+	if len(quads) == 0 {
+		return "", nil
+	}
+	var buffer bytes.Buffer
+	if err := serializeMetadata(&buffer, identifier.Path, quads); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// sqlResourceRow contains the columns of the resources table that make up the metadata
+type sqlResourceRow struct {
+	path        string
+	isContainer bool
+	contentType string
+	size        int64
+	metadata    string
+	modified    int64
+}
+
+// toMetadata creates the metadata of the row without the stored quads
+func (r sqlResourceRow) toMetadata() *representation.RepresentationMetadata {
+	metadata := representation.NewRepresentationMetadata(r.path)
+	addResourceMetadata(metadata, r.isContainer, time.Unix(0, r.modified))
+	if !r.isContainer {
+		addSizeMetadata(metadata, r.size)
+		metadata.SetContentType(r.contentType)
+	}
+	return metadata
+}
+
+// rowExists checks if there is a resource with the given path
+func rowExists(ctx context.Context, q sqlQuerier, path string) (bool, error) {
+	var exists bool
+	err := q.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM resources WHERE path = ?)`, path).Scan(&exists)
+	return exists, err
+}
+
+// notFound converts a missing row into a NotFoundError
+func notFound(identifier representation.ResourceIdentifier, err error) error {
+	if err == sql.ErrNoRows {
+		return errors.NewNotFoundError(identifier.Path, nil)
+	}
+	return err
+}
+
+// orNotFound returns err if it is set and a NotFoundError otherwise
+func orNotFound(err error, identifier representation.ResourceIdentifier) error {
+	if err != nil {
+		return err
+	}
+	return errors.NewNotFoundError(identifier.Path, nil)
+}
+
+// orConflict returns err if it is set and a ConflictError with the message otherwise
+func orConflict(err error, message string) error {
+	if err != nil {
+		return err
+	}
+	return errors.NewConflictError(message, nil)
+}
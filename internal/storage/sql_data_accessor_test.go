@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util"
+	"solid-go/internal/util/errors"
+	"solid-go/internal/util/n3"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := OpenSQLite(context.Background(), filepath.Join(t.TempDir(), "solid.db"))
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestSQLDataAccessor(t *testing.T) {
+	db := openTestDB(t)
+	accessor, err := NewSQLDataAccessor(context.Background(), db, baseURL)
+	if err != nil {
+		t.Fatalf("NewSQLDataAccessor() error = %v", err)
+	}
+	document := identifier("a/b/c.txt")
+	metadata := representation.NewRepresentationMetadata(document.Path).SetContentType(util.TextPlain)
+	metadata.AddQuad(n3.NewQuad(n3.NewNamedNode(document.Path), n3.NewNamedNode("http://purl.org/dc/terms/title"),
+		n3.NewLiteral("C"), nil))
+	if err := accessor.WriteDocument(document, strings.NewReader("hello"), metadata); err != nil {
+		t.Fatalf("WriteDocument() error = %v", err)
+	}
+
+	for _, container := range []string{"", "a/", "a/b/"} {
+		metadata, err := accessor.GetMetadata(identifier(container))
+		if err != nil {
+			t.Fatalf("GetMetadata(%v) error = %v", container, err)
+		}
+		if !isContainerMetadata(metadata) {
+			t.Errorf("GetMetadata(%v) is not a container", container)
+		}
+	}
+	children, err := accessor.GetChildren(identifier("a/b/"))
+	if err != nil || len(children) != 1 || children[0].GetIdentifier() != document.Path {
+		t.Fatalf("GetChildren() = %v, %v", children, err)
+	}
+
+	stored, err := accessor.GetMetadata(document)
+	if err != nil {
+		t.Fatalf("GetMetadata() error = %v", err)
+	}
+	if stored.ContentType() != util.TextPlain {
+		t.Errorf("ContentType() = %v, want %v", stored.ContentType(), util.TextPlain)
+	}
+	if len(stored.Quads(document.Path, "http://purl.org/dc/terms/title", nil, nil)) != 1 {
+		t.Errorf("GetMetadata() did not return the stored title")
+	}
+	data, err := accessor.GetData(document)
+	if err != nil {
+		t.Fatalf("GetData() error = %v", err)
+	}
+	if body, _ := io.ReadAll(data); string(body) != "hello" {
+		t.Errorf("GetData() = %v, want hello", string(body))
+	}
+
+	if err := accessor.WriteContainer(identifier("a/b/c.txt/"), nil); !errors.IsConflictError(err) {
+		t.Errorf("WriteContainer() over a document error = %v, want ConflictError", err)
+	}
+	if err := accessor.WriteDocument(identifier("a/b/c.txt/d"), strings.NewReader(""), nil); !errors.IsConflictError(err) {
+		t.Errorf("WriteDocument() below a document error = %v, want ConflictError", err)
+	}
+	if _, err := accessor.GetMetadata(identifier("a/b/c.txt/")); !errors.IsNotFoundError(err) {
+		t.Errorf("failed write was not rolled back, error = %v", err)
+	}
+	if err := accessor.DeleteResource(identifier("a/")); !errors.IsConflictError(err) {
+		t.Errorf("DeleteResource() of a non-empty container error = %v, want ConflictError", err)
+	}
+	if err := accessor.DeleteResource(identifier("")); !errors.IsConflictError(err) {
+		t.Errorf("DeleteResource() of the root error = %v, want ConflictError", err)
+	}
+	if err := accessor.DeleteResource(document); err != nil {
+		t.Errorf("DeleteResource() error = %v", err)
+	}
+	if _, err := accessor.GetData(document); !errors.IsNotFoundError(err) {
+		t.Errorf("GetData() after delete error = %v, want NotFoundError", err)
+	}
+}
+
+func TestSQLDataAccessor_Store(t *testing.T) {
+	accessor, err := NewSQLDataAccessor(context.Background(), openTestDB(t), baseURL)
+	if err != nil {
+		t.Fatalf("NewSQLDataAccessor() error = %v", err)
+	}
+	store := NewDataAccessorBasedStore(accessor, baseURL)
+	id := identifier("notes/today.txt")
+	if _, err := store.SetRepresentation(id, textRepresentation("note"), nil); err != nil {
+		t.Fatalf("SetRepresentation() error = %v", err)
+	}
+	rep, err := store.GetRepresentation(identifier("notes/"), nil, nil)
+	if err != nil {
+		t.Fatalf("GetRepresentation() error = %v", err)
+	}
+	if len(rep.GetMetadata().Quads(nil, nil, id.Path, nil)) != 1 {
+		t.Errorf("container does not contain %v", id.Path)
+	}
+}
+
+func TestSQLStorage(t *testing.T) {
+	ctx := context.Background()
+	s := NewSQLStorage(openTestDB(t))
+	if err := s.Put(ctx, "a/b/c.txt", []byte("hello")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if data, err := s.Get(ctx, "/a/b/c.txt"); err != nil || string(data) != "hello" {
+		t.Errorf("Get() = %v, %v", string(data), err)
+	}
+	if list, err := s.List(ctx, "a"); err != nil || !reflect.DeepEqual(list, []string{"a/b/"}) {
+		t.Errorf("List() = %v, %v", list, err)
+	}
+	if exists, _ := s.Exists(ctx, "a/b"); !exists {
+		t.Errorf("Exists() of an intermediate directory = false")
+	}
+	if err := s.Put(ctx, "a/b", nil); !errors.IsConflictError(err) {
+		t.Errorf("Put() over a directory error = %v, want ConflictError", err)
+	}
+	if err := s.Delete(ctx, "a/b"); !errors.IsConflictError(err) {
+		t.Errorf("Delete() of a non-empty directory error = %v, want ConflictError", err)
+	}
+	if _, err := s.Get(ctx, "../a/b/c.txt"); !errors.IsValidationError(err) {
+		t.Errorf("Get() with dot segments error = %v, want ValidationError", err)
+	}
+	if err := s.Delete(ctx, "a/b/c.txt"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if exists, _ := s.Exists(ctx, "a/b/c.txt"); exists {
+		t.Errorf("Exists() after delete = true")
+	}
+}
+
+func TestOpenSQLite_Reopen(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "solid.db")
+	db, err := OpenSQLite(ctx, file)
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	if err := NewSQLStorage(db).Put(ctx, "kept", []byte("x")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	db.Close()
+
+	db, err = OpenSQLite(ctx, file)
+	if err != nil {
+		t.Fatalf("OpenSQLite() on an existing database error = %v", err)
+	}
+	defer db.Close()
+	if exists, _ := NewSQLStorage(db).Exists(ctx, "kept"); !exists {
+		t.Errorf("data did not survive reopening the database")
+	}
+}
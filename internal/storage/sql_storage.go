@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path"
+	"strings"
+
+	"solid-go/internal/util/errors"
+)
+
+// SQLStorage implements Storage on the blobs table of a database opened with OpenSQLite.
+// Directories are stored as rows as well, so listings use the index on the parent column.
+type SQLStorage struct {
+	db *sql.DB
+}
+
+// NewSQLStorage creates a new SQLStorage instance
+func NewSQLStorage(db *sql.DB) *SQLStorage {
+	return &SQLStorage{db: db}
+}
+
+// key normalizes a path to the key of its row, the root directory has the empty key
+func (s *SQLStorage) key(p string) (string, error) {
+	for _, segment := range strings.Split(p, "/") {
+		if segment == ".." {
+			return "", errors.NewValidationError(fmt.Sprintf("disallowed segment %q in %s", segment, p), nil)
+		}
+	}
+	return strings.TrimPrefix(path.Clean("/"+p), "/"), nil
+}
+
+// Get implements Storage.Get
+func (s *SQLStorage) Get(ctx context.Context, p string) ([]byte, error) {
+	key, err := s.key(p)
+	if err != nil {
+		return nil, err
+	}
+	var isDir bool
+	var data []byte
+	err = s.db.QueryRowContext(ctx, `SELECT is_dir, data FROM blobs WHERE path = ?`, key).Scan(&isDir, &data)
+	if err == sql.ErrNoRows || (err == nil && isDir) {
+		return nil, errors.NewNotFoundError(p, nil)
+	}
+	return data, err
+}
+
+// Put implements Storage.Put
+func (s *SQLStorage) Put(ctx context.Context, p string, data []byte) error {
+	key, err := s.key(p)
+	if err != nil {
+		return err
+	}
+	if key == "" {
+		return errors.NewConflictError("the root is a directory", nil)
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Create the ancestor directories, like os.MkdirAll does for the FileStorage
+	for dir := parentKey(key); dir != ""; dir = parentKey(dir) {
+		var isDir bool
+		err := tx.QueryRowContext(ctx, `SELECT is_dir FROM blobs WHERE path = ?`, dir).Scan(&isDir)
+		if err == nil && !isDir {
+			return errors.NewConflictError(fmt.Sprintf("%s is not a directory", dir), nil)
+		}
+		if err == nil {
+			break
+		}
+		if err != sql.ErrNoRows {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO blobs (path, parent, is_dir) VALUES (?, ?, 1)`, dir, parentKey(dir)); err != nil {
+			return err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `INSERT INTO blobs (path, parent, is_dir, data) VALUES (?, ?, 0, ?)
+		ON CONFLICT (path) DO UPDATE SET data = excluded.data WHERE is_dir = 0`, key, parentKey(key), data)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return orConflict(err, fmt.Sprintf("%s is a directory", p))
+	}
+	return tx.Commit()
+}
+
+// Delete implements Storage.Delete
+func (s *SQLStorage) Delete(ctx context.Context, p string) error {
+	key, err := s.key(p)
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var hasChildren bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM blobs WHERE parent = ?)`, key).Scan(&hasChildren); err != nil {
+		return err
+	}
+	if hasChildren {
+		return errors.NewConflictError(fmt.Sprintf("directory %s is not empty", p), nil)
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM blobs WHERE path = ?`, key)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.NewNotFoundError(p, nil)
+	}
+	return tx.Commit()
+}
+
+// List implements Storage.List
+func (s *SQLStorage) List(ctx context.Context, p string) ([]string, error) {
+	key, err := s.key(p)
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		var isDir bool
+		err := s.db.QueryRowContext(ctx, `SELECT is_dir FROM blobs WHERE path = ?`, key).Scan(&isDir)
+		if err == sql.ErrNoRows || (err == nil && !isDir) {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("directory %s", p), nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT path, is_dir FROM blobs WHERE parent = ? ORDER BY path`, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var resources []string
+	for rows.Next() {
+		var child string
+		var isDir bool
+		if err := rows.Scan(&child, &isDir); err != nil {
+			return nil, err
+		}
+		if isDir {
+			child += "/"
+		}
+		resources = append(resources, child)
+	}
+	return resources, rows.Err()
+}
+
+// Exists implements Storage.Exists
+func (s *SQLStorage) Exists(ctx context.Context, p string) (bool, error) {
+	key, err := s.key(p)
+	if err != nil {
+		return false, err
+	}
+	if key == "" {
+		return true, nil
+	}
+	var exists bool
+	err = s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM blobs WHERE path = ?)`, key).Scan(&exists)
+	return exists, err
+}
+
+// parentKey returns the key of the directory containing a key
+func parentKey(key string) string {
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		return key[:i]
+	}
+	return ""
+}
@@ -2,9 +2,13 @@ package storage
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"solid-go/internal/util/errors"
 )
 
 // Storage defines the interface for storage operations
@@ -42,15 +46,31 @@ func NewFileStorage(rootPath string) (*FileStorage, error) {
 	}, nil
 }
 
+// resolve joins the path to the root path, rejecting paths that would escape the root
+func (s *FileStorage) resolve(path string) (string, error) {
+	for _, segment := range strings.Split(filepath.ToSlash(path), "/") {
+		if segment == ".." {
+			return "", errors.NewValidationError(fmt.Sprintf("disallowed segment %q in %s", segment, path), nil)
+		}
+	}
+	return filepath.Join(s.rootPath, path), nil
+}
+
 // Get implements Storage.Get
 func (s *FileStorage) Get(ctx context.Context, path string) ([]byte, error) {
-	fullPath := filepath.Join(s.rootPath, path)
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return nil, err
+	}
 	return ioutil.ReadFile(fullPath)
 }
 
 // Put implements Storage.Put
 func (s *FileStorage) Put(ctx context.Context, path string, data []byte) error {
-	fullPath := filepath.Join(s.rootPath, path)
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return err
+	}
 
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(fullPath)
@@ -63,13 +83,19 @@ func (s *FileStorage) Put(ctx context.Context, path string, data []byte) error {
 
 // Delete implements Storage.Delete
 func (s *FileStorage) Delete(ctx context.Context, path string) error {
-	fullPath := filepath.Join(s.rootPath, path)
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return err
+	}
 	return os.Remove(fullPath)
 }
 
 // List implements Storage.List
 func (s *FileStorage) List(ctx context.Context, path string) ([]string, error) {
-	fullPath := filepath.Join(s.rootPath, path)
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return nil, err
+	}
 
 	// Read directory contents
 	entries, err := ioutil.ReadDir(fullPath)
@@ -92,8 +118,11 @@ func (s *FileStorage) List(ctx context.Context, path string) ([]string, error) {
 
 // Exists implements Storage.Exists
 func (s *FileStorage) Exists(ctx context.Context, path string) (bool, error) {
-	fullPath := filepath.Join(s.rootPath, path)
-	_, err := os.Stat(fullPath)
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(fullPath)
 	if err == nil {
 		return true, nil
 	}
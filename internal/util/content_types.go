@@ -62,7 +62,15 @@ const (
 	NQuads   = "application/n-quads"
 	Turtle   = "text/turtle"
 	TriG     = "application/trig"
+	N3       = "text/n3"
 	JSONLD   = "application/ld+json"
+
+	// Patch content types
+	SparqlUpdate = "application/sparql-update"
+
+	// Internal content types, never sent to clients
+	InternalQuads = "internal/quads"
+	InternalAll   = "internal/*"
 )
 
 // IsText checks if a content type is a text type
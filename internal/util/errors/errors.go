@@ -1,7 +1,9 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 )
 
@@ -16,13 +18,44 @@ const (
 	ForbiddenError    ErrorType = "ForbiddenError"
 	ConflictError     ErrorType = "ConflictError"
 	InternalError     ErrorType = "InternalError"
+
+	NotAcceptableError        ErrorType = "NotAcceptableError"
+	UnsupportedMediaTypeError ErrorType = "UnsupportedMediaTypeError"
+	NotImplementedError       ErrorType = "NotImplementedError"
+	RangeNotSatisfiableError  ErrorType = "RangeNotSatisfiableError"
+	NotModifiedError          ErrorType = "NotModifiedError"
+	PreconditionFailedError   ErrorType = "PreconditionFailedError"
+	MethodNotAllowedError     ErrorType = "MethodNotAllowedError"
+	UnprocessableEntityError  ErrorType = "UnprocessableEntityError"
+	PayloadTooLargeError      ErrorType = "PayloadTooLargeError"
 )
 
+// statusCodes maps error types to the HTTP status code of the response
+var statusCodes = map[ErrorType]int{
+	ValidationError:           http.StatusBadRequest,
+	NotFoundError:             http.StatusNotFound,
+	UnauthorizedError:         http.StatusUnauthorized,
+	ForbiddenError:            http.StatusForbidden,
+	ConflictError:             http.StatusConflict,
+	InternalError:             http.StatusInternalServerError,
+	NotAcceptableError:        http.StatusNotAcceptable,
+	UnsupportedMediaTypeError: http.StatusUnsupportedMediaType,
+	NotImplementedError:       http.StatusNotImplemented,
+	RangeNotSatisfiableError:  http.StatusRequestedRangeNotSatisfiable,
+	NotModifiedError:          http.StatusNotModified,
+	PreconditionFailedError:   http.StatusPreconditionFailed,
+	MethodNotAllowedError:     http.StatusMethodNotAllowed,
+	UnprocessableEntityError:  http.StatusUnprocessableEntity,
+	PayloadTooLargeError:      http.StatusRequestEntityTooLarge,
+}
+
 // CustomError represents a custom error with type and message
 type CustomError struct {
 	Type    ErrorType
 	Message string
 	Err     error
+	// Headers are added to the error response, e.g. Content-Range for unsatisfiable ranges
+	Headers map[string]string
 }
 
 // Error implements the error interface
@@ -92,6 +125,75 @@ func NewInternalError(message string, err error) error {
 	}
 }
 
+// NewNotAcceptableError creates a new error for when no representation satisfies the preferences
+func NewNotAcceptableError(message string, err error) error {
+	return &CustomError{
+		Type:    NotAcceptableError,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// NewUnsupportedMediaTypeError creates a new error for input in an unsupported content type
+func NewUnsupportedMediaTypeError(message string, err error) error {
+	return &CustomError{
+		Type:    UnsupportedMediaTypeError,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// NewNotImplementedError creates a new error for functionality the server does not support
+func NewNotImplementedError(message string, err error) error {
+	return &CustomError{
+		Type:    NotImplementedError,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// NewRangeNotSatisfiableError creates a new error for a range request none of whose ranges overlap the resource.
+// The response indicates the size of the resource with an unsatisfied-range Content-Range header.
+func NewRangeNotSatisfiableError(message string, size int64) error {
+	return &CustomError{
+		Type:    RangeNotSatisfiableError,
+		Message: message,
+		Headers: map[string]string{"Content-Range": fmt.Sprintf("bytes */%d", size)},
+	}
+}
+
+// NewNotModifiedError creates a new error for a conditional read of a representation the client already has.
+// The ETag of the representation is included in the response, if there is one.
+func NewNotModifiedError(message string, eTag string) error {
+	err := &CustomError{
+		Type:    NotModifiedError,
+		Message: message,
+	}
+	if eTag != "" {
+		err.Headers = map[string]string{"ETag": eTag}
+	}
+	return err
+}
+
+// NewPreconditionFailedError creates a new error for a request whose conditions do not hold
+func NewPreconditionFailedError(message string, err error) error {
+	return &CustomError{
+		Type:    PreconditionFailedError,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// NewMethodNotAllowedError creates a new error for a method the target resource does not support.
+// The methods the resource does support are listed in the Allow header of the response.
+func NewMethodNotAllowedError(message string, allowed []string) error {
+	return &CustomError{
+		Type:    MethodNotAllowedError,
+		Message: message,
+		Headers: map[string]string{"Allow": strings.Join(allowed, ", ")},
+	}
+}
+
 // IsValidationError checks if an error is a validation error
 func IsValidationError(err error) bool {
 	return isErrorType(err, ValidationError)
@@ -122,12 +224,76 @@ func IsInternalError(err error) bool {
 	return isErrorType(err, InternalError)
 }
 
+// IsNotAcceptableError checks if an error is a not acceptable error
+func IsNotAcceptableError(err error) bool {
+	return isErrorType(err, NotAcceptableError)
+}
+
+// IsUnsupportedMediaTypeError checks if an error is an unsupported media type error
+func IsUnsupportedMediaTypeError(err error) bool {
+	return isErrorType(err, UnsupportedMediaTypeError)
+}
+
+// IsNotImplementedError checks if an error is a not implemented error
+func IsNotImplementedError(err error) bool {
+	return isErrorType(err, NotImplementedError)
+}
+
+// IsRangeNotSatisfiableError checks if an error is a range not satisfiable error
+func IsRangeNotSatisfiableError(err error) bool {
+	return isErrorType(err, RangeNotSatisfiableError)
+}
+
+// IsNotModifiedError checks if an error is a not modified error
+func IsNotModifiedError(err error) bool {
+	return isErrorType(err, NotModifiedError)
+}
+
+// NewUnprocessableEntityError creates a new error for a well-formed request body with invalid semantics
+func NewUnprocessableEntityError(message string, err error) error {
+	return &CustomError{
+		Type:    UnprocessableEntityError,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// IsPreconditionFailedError checks if an error is a precondition failed error
+func IsPreconditionFailedError(err error) bool {
+	return isErrorType(err, PreconditionFailedError)
+}
+
+// IsMethodNotAllowedError checks if an error is a method not allowed error
+func IsMethodNotAllowedError(err error) bool {
+	return isErrorType(err, MethodNotAllowedError)
+}
+
+// IsUnprocessableEntityError checks if an error is an unprocessable entity error
+func IsUnprocessableEntityError(err error) bool {
+	return isErrorType(err, UnprocessableEntityError)
+}
+
+// NewPayloadTooLargeError creates a new error for a request body that is larger than the server accepts
+func NewPayloadTooLargeError(message string, err error) error {
+	return &CustomError{
+		Type:    PayloadTooLargeError,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// IsPayloadTooLargeError checks if an error is a payload too large error
+func IsPayloadTooLargeError(err error) bool {
+	return isErrorType(err, PayloadTooLargeError)
+}
+
 // isErrorType checks if an error is of a specific type
 func isErrorType(err error, errorType ErrorType) bool {
 	if err == nil {
 		return false
 	}
-	if customErr, ok := err.(*CustomError); ok {
+	var customErr *CustomError
+	if errors.As(err, &customErr) {
 		return customErr.Type == errorType
 	}
 	return false
@@ -135,12 +301,30 @@ func isErrorType(err error, errorType ErrorType) bool {
 
 // GetErrorType returns the type of an error
 func GetErrorType(err error) ErrorType {
-	if customErr, ok := err.(*CustomError); ok {
+	var customErr *CustomError
+	if errors.As(err, &customErr) {
 		return customErr.Type
 	}
 	return InternalError
 }
 
+// StatusCode returns the HTTP status code corresponding to an error
+func StatusCode(err error) int {
+	if code, ok := statusCodes[GetErrorType(err)]; ok {
+		return code
+	}
+	return http.StatusInternalServerError
+}
+
+// ResponseHeaders returns the headers an error adds to its response
+func ResponseHeaders(err error) map[string]string {
+	var customErr *CustomError
+	if errors.As(err, &customErr) {
+		return customErr.Headers
+	}
+	return nil
+}
+
 // GetErrorMessage returns the message of an error
 func GetErrorMessage(err error) string {
 	if err == nil {
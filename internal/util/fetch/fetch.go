@@ -1,15 +1,25 @@
 package fetch
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
+
+	"solid-go/internal/util/n3"
 )
 
-// Representation represents a fetched dataset
+// maxDocumentSize limits the size of the documents that are fetched
+const maxDocumentSize = 1 << 20
+
+// client fetches the documents, without letting a slow server block the request that needs them
+var client = &http.Client{Timeout: 10 * time.Second}
+
+// Representation represents a fetched dataset.
+// The body is parsed while it is read, so only the parsed dataset is kept.
 type Representation struct {
-	Data io.Reader
+	ContentType string
+	Dataset     n3.Store
 }
 
 // FetchError represents an error that occurred during fetching
@@ -24,7 +34,15 @@ func (e *FetchError) Error() string {
 
 // FetchDataset fetches a dataset from the given URL
 func FetchDataset(url string) (*Representation, error) {
-	resp, err := http.Get(url)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, &FetchError{
+			StatusCode: 0,
+			Message:    fmt.Sprintf("invalid URL: %v", err),
+		}
+	}
+	request.Header.Set("Accept", "text/turtle")
+	resp, err := client.Do(request)
 	if err != nil {
 		return nil, &FetchError{
 			StatusCode: 0,
@@ -50,16 +68,26 @@ func FetchDataset(url string) (*Representation, error) {
 		}
 	}
 
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
+	format, ok := n3.FormatFromContentType(contentType)
+	if !ok {
+		return nil, &FetchError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("unsupported content type: %s", contentType),
+		}
+	}
+
+	// Parse the body, resolving relative IRIs against the URL the document was found at after redirects
+	body := io.LimitReader(resp.Body, maxDocumentSize)
+	dataset, err := n3.NewParser(n3.ParserOptions{Format: format, BaseIRI: resp.Request.URL.String()}).ParseToStore(body)
 	if err != nil {
 		return nil, &FetchError{
 			StatusCode: resp.StatusCode,
-			Message:    fmt.Sprintf("failed to read response body: %v", err),
+			Message:    fmt.Sprintf("failed to parse dataset: %v", err),
 		}
 	}
 
 	return &Representation{
-		Data: bytes.NewReader(body),
+		ContentType: contentType,
+		Dataset:     dataset,
 	}, nil
 }
@@ -0,0 +1,45 @@
+package fetch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"solid-go/internal/util/n3"
+)
+
+func TestFetchDataset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/groups/friends", http.StatusFound)
+		case "/groups/friends":
+			w.Header().Set("Content-Type", "text/turtle")
+			w.Write([]byte(`<#us> <http://www.w3.org/2006/vcard/ns#hasMember> <../alice#me>.`))
+		case "/large":
+			w.Header().Set("Content-Type", "text/turtle")
+			w.Write([]byte(`<#s> <#p> "` + strings.Repeat("a", maxDocumentSize) + `".`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	representation, err := FetchDataset(server.URL + "/moved")
+	if err != nil {
+		t.Fatalf("FetchDataset() error = %v", err)
+	}
+	// Relative IRIs are resolved against the URL after the redirect
+	group, member := n3.NewNamedNode(server.URL+"/groups/friends#us"), n3.NewNamedNode(server.URL+"/alice#me")
+	if representation.Dataset.CountQuads(group, nil, member, nil) != 1 {
+		t.Errorf("FetchDataset() = %v, want the membership of %v", representation.Dataset.GetQuads(nil, nil, nil, nil), member)
+	}
+
+	if _, err := FetchDataset(server.URL + "/large"); err == nil {
+		t.Error("FetchDataset() of a document larger than the limit should fail")
+	}
+	if _, err := FetchDataset(server.URL + "/missing"); err == nil {
+		t.Error("FetchDataset() of a missing document should fail")
+	}
+}
@@ -0,0 +1,47 @@
+package identifiers
+
+import (
+	"strings"
+
+	"solid-go/internal/http/representation"
+	"solid-go/internal/util/errors"
+)
+
+// SingleRootIdentifierStrategy supports all identifiers below a single base URL,
+// which is then the only root container.
+type SingleRootIdentifierStrategy struct {
+	baseURL string
+}
+
+// NewSingleRootIdentifierStrategy creates a new SingleRootIdentifierStrategy
+func NewSingleRootIdentifierStrategy(baseURL string) *SingleRootIdentifierStrategy {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	return &SingleRootIdentifierStrategy{baseURL: baseURL}
+}
+
+// SupportsIdentifier checks if the identifier is the base URL or a descendant of it
+func (s *SingleRootIdentifierStrategy) SupportsIdentifier(identifier representation.ResourceIdentifier) bool {
+	return strings.HasPrefix(identifier.Path, s.baseURL)
+}
+
+// IsRootContainer checks if the identifier is the base URL
+func (s *SingleRootIdentifierStrategy) IsRootContainer(identifier representation.ResourceIdentifier) bool {
+	return identifier.Path == s.baseURL
+}
+
+// GetParentContainer returns the identifier of the container the resource is in.
+// The root container has no parent.
+func (s *SingleRootIdentifierStrategy) GetParentContainer(identifier representation.ResourceIdentifier) (representation.ResourceIdentifier, error) {
+	if !s.SupportsIdentifier(identifier) {
+		return representation.ResourceIdentifier{}, errors.NewValidationError(
+			"The identifier "+identifier.Path+" is outside the configured identifier space.", nil)
+	}
+	if s.IsRootContainer(identifier) {
+		return representation.ResourceIdentifier{}, errors.NewInternalError(
+			"Cannot obtain the parent of "+identifier.Path+" because it is a root container.", nil)
+	}
+	trimmed := strings.TrimSuffix(identifier.Path, "/")
+	return representation.ResourceIdentifier{Path: trimmed[:strings.LastIndex(trimmed, "/")+1]}, nil
+}
@@ -0,0 +1,173 @@
+package n3
+
+import (
+	"strings"
+)
+
+// iriParts holds the components of an IRI reference as defined in RFC 3986
+type iriParts struct {
+	scheme       string
+	hasAuthority bool
+	authority    string
+	path         string
+	hasQuery     bool
+	query        string
+	hasFragment  bool
+	fragment     string
+}
+
+func splitIRI(iri string) iriParts {
+	var p iriParts
+	if i := strings.IndexByte(iri, '#'); i >= 0 {
+		p.hasFragment, p.fragment, iri = true, iri[i+1:], iri[:i]
+	}
+	if i := strings.IndexByte(iri, '?'); i >= 0 {
+		p.hasQuery, p.query, iri = true, iri[i+1:], iri[:i]
+	}
+	if i := strings.IndexAny(iri, ":/"); i > 0 && iri[i] == ':' && isScheme(iri[:i]) {
+		p.scheme, iri = iri[:i], iri[i+1:]
+	}
+	if strings.HasPrefix(iri, "//") {
+		iri = iri[2:]
+		p.hasAuthority = true
+		if i := strings.IndexByte(iri, '/'); i >= 0 {
+			p.authority, iri = iri[:i], iri[i:]
+		} else {
+			p.authority, iri = iri, ""
+		}
+	}
+	p.path = iri
+	return p
+}
+
+func isScheme(s string) bool {
+	for i, c := range s {
+		isAlpha := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !isAlpha && (i == 0 || !(c >= '0' && c <= '9' || c == '+' || c == '-' || c == '.')) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func (p iriParts) String() string {
+	var b strings.Builder
+	if p.scheme != "" {
+		b.WriteString(p.scheme)
+		b.WriteByte(':')
+	}
+	if p.hasAuthority {
+		b.WriteString("//")
+		b.WriteString(p.authority)
+	}
+	b.WriteString(p.path)
+	if p.hasQuery {
+		b.WriteByte('?')
+		b.WriteString(p.query)
+	}
+	if p.hasFragment {
+		b.WriteByte('#')
+		b.WriteString(p.fragment)
+	}
+	return b.String()
+}
+
+// IsAbsoluteIRI returns true if the IRI has a scheme
+func IsAbsoluteIRI(iri string) bool {
+	return splitIRI(iri).scheme != ""
+}
+
+// ResolveIRI resolves a relative IRI reference against a base IRI following RFC 3986 section 5.2.
+// If the base is empty the reference is returned unchanged.
+func ResolveIRI(base, ref string) string {
+	if base == "" {
+		return ref
+	}
+	r := splitIRI(ref)
+	if r.scheme != "" {
+		r.path = removeDotSegments(r.path)
+		return r.String()
+	}
+	b := splitIRI(base)
+	t := iriParts{scheme: b.scheme, hasFragment: r.hasFragment, fragment: r.fragment}
+	switch {
+	case r.hasAuthority:
+		t.hasAuthority, t.authority = true, r.authority
+		t.path = removeDotSegments(r.path)
+		t.hasQuery, t.query = r.hasQuery, r.query
+	case r.path == "":
+		t.hasAuthority, t.authority = b.hasAuthority, b.authority
+		t.path = b.path
+		if r.hasQuery {
+			t.hasQuery, t.query = true, r.query
+		} else {
+			t.hasQuery, t.query = b.hasQuery, b.query
+		}
+	default:
+		t.hasAuthority, t.authority = b.hasAuthority, b.authority
+		if strings.HasPrefix(r.path, "/") {
+			t.path = removeDotSegments(r.path)
+		} else {
+			t.path = removeDotSegments(mergePaths(b, r.path))
+		}
+		t.hasQuery, t.query = r.hasQuery, r.query
+	}
+	return t.String()
+}
+
+func mergePaths(base iriParts, ref string) string {
+	if base.hasAuthority && base.path == "" {
+		return "/" + ref
+	}
+	if i := strings.LastIndexByte(base.path, '/'); i >= 0 {
+		return base.path[:i+1] + ref
+	}
+	return ref
+}
+
+// removeDotSegments implements the algorithm of RFC 3986 section 5.2.4
+func removeDotSegments(path string) string {
+	if !strings.Contains(path, ".") {
+		return path
+	}
+	var out []string
+	in := path
+	for in != "" {
+		switch {
+		case strings.HasPrefix(in, "../"):
+			in = in[3:]
+		case strings.HasPrefix(in, "./"):
+			in = in[2:]
+		case strings.HasPrefix(in, "/./"):
+			in = in[2:]
+		case in == "/.":
+			in = "/"
+		case strings.HasPrefix(in, "/../"):
+			in = in[3:]
+			if len(out) > 0 {
+				out = out[:len(out)-1]
+			}
+		case in == "/..":
+			in = "/"
+			if len(out) > 0 {
+				out = out[:len(out)-1]
+			}
+		case in == "." || in == "..":
+			in = ""
+		default:
+			start := 0
+			if in[0] == '/' {
+				start = 1
+			}
+			end := strings.IndexByte(in[start:], '/')
+			if end < 0 {
+				end = len(in)
+			} else {
+				end += start
+			}
+			out = append(out, in[:end])
+			in = in[end:]
+		}
+	}
+	return strings.Join(out, "")
+}
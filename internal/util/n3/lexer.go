@@ -0,0 +1,493 @@
+package n3
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// tokenType identifies the kind of a lexical token
+type tokenType int
+
+const (
+	tokEOF tokenType = iota
+	tokIRI
+	tokPrefixedName
+	tokBlankNode
+	tokString
+	tokLangTag
+	tokDatatypeMark
+	tokInteger
+	tokDecimal
+	tokDouble
+	tokBoolean
+	tokA
+	tokSparqlPrefix
+	tokSparqlBase
+	tokGraph
+	tokVariable
+	tokPunctuation
+	tokKeyword
+)
+
+// token is a single lexical token with its position in the input
+type token struct {
+	typ    tokenType
+	value  string
+	prefix string
+	line   int
+	column int
+}
+
+func (t token) String() string {
+	switch t.typ {
+	case tokEOF:
+		return "end of input"
+	case tokIRI:
+		return "<" + t.value + ">"
+	case tokPrefixedName:
+		return t.prefix + ":" + t.value
+	case tokBlankNode:
+		return "_:" + t.value
+	case tokString:
+		return strconv.Quote(t.value)
+	case tokLangTag:
+		return "@" + t.value
+	case tokVariable:
+		return "?" + t.value
+	case tokKeyword:
+		return t.value
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+// ParseError is returned when an RDF document is syntactically invalid
+type ParseError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s on line %d, column %d", e.Message, e.Line, e.Column)
+}
+
+// lexer splits an RDF document into tokens while tracking line and column numbers
+type lexer struct {
+	reader    *bufio.Reader
+	lookahead []rune
+	line      int
+	column    int
+	readErr   error
+	// keywords enables SPARQL keywords and $-variables
+	keywords bool
+}
+
+func newLexer(reader io.Reader) *lexer {
+	return &lexer{reader: bufio.NewReader(reader), line: 1, column: 1}
+}
+
+// peek returns the rune at the given offset without consuming it, or -1 at the end of the input
+func (l *lexer) peek(offset int) rune {
+	for len(l.lookahead) <= offset {
+		if l.readErr != nil {
+			return -1
+		}
+		r, _, err := l.reader.ReadRune()
+		if err != nil {
+			l.readErr = err
+			return -1
+		}
+		l.lookahead = append(l.lookahead, r)
+	}
+	return l.lookahead[offset]
+}
+
+// next consumes and returns the next rune
+func (l *lexer) next() rune {
+	r := l.peek(0)
+	if r < 0 {
+		return r
+	}
+	l.lookahead = l.lookahead[1:]
+	if r == '\n' {
+		l.line++
+		l.column = 1
+	} else {
+		l.column++
+	}
+	return r
+}
+
+func (l *lexer) errorf(line, column int, format string, args ...interface{}) *ParseError {
+	return &ParseError{Line: line, Column: column, Message: fmt.Sprintf(format, args...)}
+}
+
+// ioError returns the underlying read error, ignoring the end of the input
+func (l *lexer) ioError() error {
+	if l.readErr != nil && l.readErr != io.EOF {
+		return l.readErr
+	}
+	return nil
+}
+
+// skipWhitespace skips whitespace and comments
+func (l *lexer) skipWhitespace() {
+	for {
+		r := l.peek(0)
+		switch {
+		case r == '#':
+			for r = l.peek(0); r >= 0 && r != '\n' && r != '\r'; r = l.peek(0) {
+				l.next()
+			}
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			l.next()
+		default:
+			return
+		}
+	}
+}
+
+// nextToken reads the next token from the input
+func (l *lexer) nextToken() (token, error) {
+	l.skipWhitespace()
+	tok := token{line: l.line, column: l.column}
+	r := l.peek(0)
+	switch {
+	case r < 0:
+		if err := l.ioError(); err != nil {
+			return tok, err
+		}
+		tok.typ = tokEOF
+		return tok, nil
+	case r == '<':
+		return l.readIRI(tok)
+	case r == '"' || r == '\'':
+		return l.readString(tok)
+	case r == '@':
+		return l.readLangTag(tok)
+	case r == '^':
+		if l.peek(1) != '^' {
+			return tok, l.errorf(tok.line, tok.column, "unexpected \"^\"")
+		}
+		l.next()
+		l.next()
+		tok.typ, tok.value = tokDatatypeMark, "^^"
+		return tok, nil
+	case r == '_' && l.peek(1) == ':':
+		return l.readBlankNode(tok)
+	case r == '?' || (r == '$' && l.keywords):
+		return l.readVariable(tok)
+	case isDigit(r) || r == '+' || r == '-' || (r == '.' && isDigit(l.peek(1))):
+		return l.readNumber(tok)
+	case strings.ContainsRune(".;,[](){}", r):
+		l.next()
+		tok.typ, tok.value = tokPunctuation, string(r)
+		return tok, nil
+	case r == ':' || isNameStartChar(r):
+		return l.readName(tok)
+	}
+	return tok, l.errorf(tok.line, tok.column, "unexpected %q", r)
+}
+
+func (l *lexer) readIRI(tok token) (token, error) {
+	l.next()
+	var b strings.Builder
+	for {
+		r := l.next()
+		switch {
+		case r == '>':
+			tok.typ, tok.value = tokIRI, b.String()
+			return tok, nil
+		case r == '\\':
+			u, err := l.readUnicodeEscape(tok)
+			if err != nil {
+				return tok, err
+			}
+			b.WriteRune(u)
+		case r < 0 || r <= 0x20 || strings.ContainsRune("<\"{}|^`", r):
+			if r < 0 {
+				return tok, l.errorf(tok.line, tok.column, "unterminated IRI")
+			}
+			return tok, l.errorf(l.line, l.column-1, "invalid character %q in IRI", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+}
+
+// readUnicodeEscape reads the part of a \u or \U escape after the backslash
+func (l *lexer) readUnicodeEscape(tok token) (rune, error) {
+	line, column := l.line, l.column-1
+	var size int
+	switch l.next() {
+	case 'u':
+		size = 4
+	case 'U':
+		size = 8
+	default:
+		return 0, l.errorf(line, column, "invalid escape sequence")
+	}
+	digits := make([]rune, 0, size)
+	for i := 0; i < size; i++ {
+		digits = append(digits, l.next())
+	}
+	code, err := strconv.ParseUint(string(digits), 16, 32)
+	if err != nil {
+		return 0, l.errorf(line, column, "invalid escape sequence \\%s", string(digits))
+	}
+	return rune(code), nil
+}
+
+func (l *lexer) readString(tok token) (token, error) {
+	quote := l.next()
+	long := l.peek(0) == quote && l.peek(1) == quote
+	if long {
+		l.next()
+		l.next()
+	}
+	var b strings.Builder
+	for {
+		r := l.next()
+		switch {
+		case r < 0:
+			return tok, l.errorf(tok.line, tok.column, "unterminated string literal")
+		case r == quote && !long:
+			tok.typ, tok.value = tokString, b.String()
+			return tok, nil
+		case r == quote && l.peek(0) == quote && l.peek(1) == quote:
+			l.next()
+			l.next()
+			// Quotes directly before the closing delimiter belong to the string
+			for l.peek(0) == quote {
+				b.WriteRune(l.next())
+			}
+			tok.typ, tok.value = tokString, b.String()
+			return tok, nil
+		case (r == '\n' || r == '\r') && !long:
+			return tok, l.errorf(tok.line, tok.column, "line break in single-line string literal")
+		case r == '\\':
+			escaped, err := l.readStringEscape(tok)
+			if err != nil {
+				return tok, err
+			}
+			b.WriteRune(escaped)
+		default:
+			b.WriteRune(r)
+		}
+	}
+}
+
+func (l *lexer) readStringEscape(tok token) (rune, error) {
+	switch r := l.peek(0); r {
+	case 't':
+		l.next()
+		return '\t', nil
+	case 'b':
+		l.next()
+		return '\b', nil
+	case 'n':
+		l.next()
+		return '\n', nil
+	case 'r':
+		l.next()
+		return '\r', nil
+	case 'f':
+		l.next()
+		return '\f', nil
+	case '"', '\'', '\\':
+		l.next()
+		return r, nil
+	}
+	return l.readUnicodeEscape(tok)
+}
+
+func (l *lexer) readLangTag(tok token) (token, error) {
+	l.next()
+	var b strings.Builder
+	for r := l.peek(0); isASCIILetter(r) || (b.Len() > 0 && (r == '-' || isDigit(r))); r = l.peek(0) {
+		b.WriteRune(l.next())
+	}
+	if b.Len() == 0 || strings.HasSuffix(b.String(), "-") {
+		return tok, l.errorf(tok.line, tok.column, "invalid language tag")
+	}
+	tok.typ, tok.value = tokLangTag, b.String()
+	return tok, nil
+}
+
+func (l *lexer) readBlankNode(tok token) (token, error) {
+	l.next()
+	l.next()
+	r := l.peek(0)
+	if !isNameStartChar(r) && !isDigit(r) {
+		return tok, l.errorf(tok.line, tok.column, "invalid blank node label")
+	}
+	tok.typ, tok.value = tokBlankNode, l.readNameChars(false)
+	return tok, nil
+}
+
+func (l *lexer) readVariable(tok token) (token, error) {
+	l.next()
+	r := l.peek(0)
+	if !isNameStartChar(r) && !isDigit(r) {
+		return tok, l.errorf(tok.line, tok.column, "invalid variable name")
+	}
+	var b strings.Builder
+	for r = l.peek(0); isNameChar(r) && r != '.'; r = l.peek(0) {
+		b.WriteRune(l.next())
+	}
+	tok.typ, tok.value = tokVariable, b.String()
+	return tok, nil
+}
+
+func (l *lexer) readNumber(tok token) (token, error) {
+	var b strings.Builder
+	if r := l.peek(0); r == '+' || r == '-' {
+		b.WriteRune(l.next())
+	}
+	for isDigit(l.peek(0)) {
+		b.WriteRune(l.next())
+	}
+	tok.typ = tokInteger
+	if l.peek(0) == '.' && isDigit(l.peek(1)) {
+		tok.typ = tokDecimal
+		b.WriteRune(l.next())
+		for isDigit(l.peek(0)) {
+			b.WriteRune(l.next())
+		}
+	}
+	if r := l.peek(0); r == 'e' || r == 'E' {
+		offset := 1
+		if s := l.peek(1); s == '+' || s == '-' {
+			offset = 2
+		}
+		if isDigit(l.peek(offset)) {
+			tok.typ = tokDouble
+			for i := 0; i < offset; i++ {
+				b.WriteRune(l.next())
+			}
+			for isDigit(l.peek(0)) {
+				b.WriteRune(l.next())
+			}
+		}
+	}
+	value := b.String()
+	if value == "+" || value == "-" || value == "" {
+		return tok, l.errorf(tok.line, tok.column, "invalid number")
+	}
+	tok.value = value
+	return tok, nil
+}
+
+// readName reads a prefixed name or a bare keyword
+func (l *lexer) readName(tok token) (token, error) {
+	prefix := ""
+	if l.peek(0) != ':' {
+		prefix = l.readNameChars(false)
+	}
+	if l.peek(0) != ':' {
+		switch {
+		case prefix == "a":
+			tok.typ = tokA
+		case prefix == "true" || prefix == "false":
+			tok.typ = tokBoolean
+		case strings.EqualFold(prefix, "prefix"):
+			tok.typ = tokSparqlPrefix
+		case strings.EqualFold(prefix, "base"):
+			tok.typ = tokSparqlBase
+		case strings.EqualFold(prefix, "graph"):
+			tok.typ = tokGraph
+		case l.keywords:
+			tok.typ, prefix = tokKeyword, strings.ToUpper(prefix)
+		default:
+			return tok, l.errorf(tok.line, tok.column, "unexpected %q", prefix)
+		}
+		tok.value = prefix
+		return tok, nil
+	}
+	l.next()
+	local := ""
+	if r := l.peek(0); isNameStartChar(r) || isDigit(r) || r == ':' || r == '%' || r == '\\' {
+		local = l.readNameChars(true)
+	}
+	if strings.Contains(local, "\\") {
+		var err error
+		if local, err = unescapeLocalName(local); err != nil {
+			return tok, l.errorf(tok.line, tok.column, "%s", err.Error())
+		}
+	}
+	tok.typ, tok.prefix, tok.value = tokPrefixedName, prefix, local
+	return tok, nil
+}
+
+// readNameChars reads name characters, allowing but not ending on dots.
+// Local names additionally allow colons and percent or backslash escapes.
+func (l *lexer) readNameChars(local bool) string {
+	var b strings.Builder
+	accept := func(r rune) bool {
+		return isNameChar(r) || (local && r == ':')
+	}
+	for {
+		r := l.peek(0)
+		switch {
+		case r == '.':
+			// A dot is only part of the name when followed by another name character
+			offset := 1
+			for l.peek(offset) == '.' {
+				offset++
+			}
+			if next := l.peek(offset); !accept(next) && !(local && (next == '%' || next == '\\')) {
+				return b.String()
+			}
+			b.WriteRune(l.next())
+		case local && r == '\\':
+			b.WriteRune(l.next())
+			if next := l.peek(0); next >= 0 {
+				b.WriteRune(l.next())
+			}
+		case local && r == '%':
+			b.WriteRune(l.next())
+		case accept(r):
+			b.WriteRune(l.next())
+		default:
+			return b.String()
+		}
+	}
+}
+
+// unescapeLocalName resolves reserved character escapes in a local name
+func unescapeLocalName(local string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(local); i++ {
+		if local[i] != '\\' {
+			b.WriteByte(local[i])
+			continue
+		}
+		if i+1 >= len(local) || !strings.ContainsRune("_~.-!$&'()*+,;=/?#@%", rune(local[i+1])) {
+			return "", fmt.Errorf("invalid escape in local name %q", local)
+		}
+		i++
+		b.WriteByte(local[i])
+	}
+	return b.String(), nil
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isNameStartChar(r rune) bool {
+	return r == '_' || (r > 0 && unicode.IsLetter(r))
+}
+
+func isNameChar(r rune) bool {
+	return isNameStartChar(r) || isDigit(r) || r == '-' || r == 0xB7 ||
+		(r >= 0x300 && r <= 0x36F) || r == 0x203F || r == 0x2040 || r == '.'
+}
@@ -0,0 +1,588 @@
+package n3
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+
+	"solid-go/internal/util"
+)
+
+const rdfNS = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+
+// Format is an RDF serialization format supported by the parser
+type Format int
+
+const (
+	// FormatTurtle is Turtle (text/turtle)
+	FormatTurtle Format = iota
+	// FormatTriG is TriG (application/trig)
+	FormatTriG
+	// FormatNTriples is N-Triples (application/n-triples)
+	FormatNTriples
+	// FormatNQuads is N-Quads (application/n-quads)
+	FormatNQuads
+	// FormatN3 is the subset of Notation3 (text/n3) with formulas and variables, as used by N3 Patch
+	FormatN3
+)
+
+// FormatFromContentType returns the format matching the given content type.
+// Parameters such as charset are ignored.
+func FormatFromContentType(contentType string) (Format, bool) {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	switch mediaType {
+	case util.Turtle, "turtle":
+		return FormatTurtle, true
+	case util.TriG, "trig":
+		return FormatTriG, true
+	case util.NTriples, "n-triples", "ntriples":
+		return FormatNTriples, true
+	case util.NQuads, "n-quads", "nquads":
+		return FormatNQuads, true
+	case util.N3, "n3":
+		return FormatN3, true
+	}
+	return 0, false
+}
+
+// IsSupportedContentType returns true if the parser can handle the given content type
+func IsSupportedContentType(contentType string) bool {
+	_, ok := FormatFromContentType(contentType)
+	return ok
+}
+
+// ParserOptions configures a Parser
+type ParserOptions struct {
+	// Format is the syntax of the input
+	Format Format
+	// BaseIRI is used to resolve relative IRIs
+	BaseIRI string
+}
+
+// blankNodeScope makes blank node labels unique across parsed documents
+var blankNodeScope uint64
+
+// Parser is a streaming parser for Turtle, TriG, N-Triples, N-Quads and N3.
+// The triples of an N3 formula are emitted in a graph named by a fresh blank node,
+// which is the term that represents the formula in the enclosing triple.
+type Parser struct {
+	format   Format
+	base     string
+	prefixes map[string]string
+
+	lex          *lexer
+	tok          token
+	callback     func(Quad) error
+	graph        Term
+	blankPrefix  string
+	blankCounter int
+	// sparql is set while parsing SPARQL Update requests
+	sparql bool
+}
+
+// NewParser creates a new Parser
+func NewParser(options ParserOptions) *Parser {
+	return &Parser{
+		format:   options.Format,
+		base:     options.BaseIRI,
+		prefixes: make(map[string]string),
+	}
+}
+
+// Prefixes returns the prefixes declared in the parsed documents
+func (p *Parser) Prefixes() map[string]string {
+	return p.prefixes
+}
+
+// Parse reads quads from the reader and passes them to the callback as soon as they are complete.
+// Parsing stops at the first syntax error or at the first error returned by the callback.
+func (p *Parser) Parse(reader io.Reader, callback func(quad Quad) error) error {
+	if err := p.start(reader, callback); err != nil {
+		return err
+	}
+	for p.tok.typ != tokEOF {
+		if err := p.statement(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// start prepares the parser for a new input and reads its first token
+func (p *Parser) start(reader io.Reader, callback func(quad Quad) error) error {
+	p.lex = newLexer(reader)
+	p.lex.keywords = p.sparql
+	p.callback = callback
+	p.graph = DefaultGraph()
+	p.blankPrefix = fmt.Sprintf("b%d_", atomic.AddUint64(&blankNodeScope, 1))
+	return p.advance()
+}
+
+// ParseQuads parses the entire input and returns all quads
+func (p *Parser) ParseQuads(reader io.Reader) ([]Quad, error) {
+	var quads []Quad
+	err := p.Parse(reader, func(quad Quad) error {
+		quads = append(quads, quad)
+		return nil
+	})
+	return quads, err
+}
+
+// ParseToStore parses the entire input into a new store
+func (p *Parser) ParseToStore(reader io.Reader) (*BasicStore, error) {
+	store := NewBasicStore()
+	err := p.Parse(reader, func(quad Quad) error {
+		store.AddQuad(quad)
+		return nil
+	})
+	return store, err
+}
+
+func (p *Parser) advance() error {
+	tok, err := p.lex.nextToken()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *Parser) errorf(tok token, format string, args ...interface{}) error {
+	return &ParseError{Line: tok.line, Column: tok.column, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *Parser) unexpected() error {
+	return p.errorf(p.tok, "unexpected %s", p.tok)
+}
+
+func (p *Parser) isPunctuation(value string) bool {
+	return p.tok.typ == tokPunctuation && p.tok.value == value
+}
+
+func (p *Parser) expect(value string) error {
+	if !p.isPunctuation(value) {
+		return p.errorf(p.tok, "expected %q but got %s", value, p.tok)
+	}
+	return p.advance()
+}
+
+// allowsVariables checks if variables can be used as terms, which is the case in N3 and SPARQL patterns
+func (p *Parser) allowsVariables() bool {
+	return p.format == FormatN3 || p.sparql
+}
+
+func (p *Parser) isLineBased() bool {
+	return p.format == FormatNTriples || p.format == FormatNQuads
+}
+
+func (p *Parser) emit(subject, predicate, object Term) error {
+	return p.callback(NewQuad(subject, predicate, object, p.graph))
+}
+
+func (p *Parser) newBlankNode() BlankNode {
+	p.blankCounter++
+	return NewBlankNode(fmt.Sprintf("%s%d", p.blankPrefix, p.blankCounter))
+}
+
+// statement parses a directive, a block of triples or a graph block
+func (p *Parser) statement() error {
+	if p.isLineBased() {
+		return p.lineStatement()
+	}
+	switch p.tok.typ {
+	case tokLangTag:
+		if p.tok.value == "prefix" || p.tok.value == "base" {
+			return p.directive(true)
+		}
+	case tokSparqlPrefix, tokSparqlBase:
+		return p.directive(false)
+	case tokGraph:
+		if p.format != FormatTriG {
+			return p.unexpected()
+		}
+		if err := p.advance(); err != nil {
+			return err
+		}
+		graph, err := p.graphLabel()
+		if err != nil {
+			return err
+		}
+		return p.graphBlock(graph)
+	case tokPunctuation:
+		if p.tok.value == "{" && p.format == FormatTriG {
+			return p.graphBlock(DefaultGraph())
+		}
+	}
+
+	subject, isPropertyList, err := p.subject()
+	if err != nil {
+		return err
+	}
+	if p.format == FormatTriG && p.isPunctuation("{") && !isPropertyList {
+		return p.graphBlock(subject)
+	}
+	if err := p.triples(subject, isPropertyList); err != nil {
+		return err
+	}
+	return p.expect(".")
+}
+
+// directive parses a prefix or base declaration, either in Turtle or SPARQL style
+func (p *Parser) directive(turtleStyle bool) error {
+	isPrefix := p.tok.value == "prefix" || p.tok.typ == tokSparqlPrefix
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if isPrefix {
+		if p.tok.typ != tokPrefixedName || p.tok.value != "" {
+			return p.errorf(p.tok, "expected a prefix name but got %s", p.tok)
+		}
+		prefix := p.tok.prefix
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if p.tok.typ != tokIRI {
+			return p.errorf(p.tok, "expected an IRI but got %s", p.tok)
+		}
+		p.prefixes[prefix] = ResolveIRI(p.base, p.tok.value)
+	} else {
+		if p.tok.typ != tokIRI {
+			return p.errorf(p.tok, "expected an IRI but got %s", p.tok)
+		}
+		p.base = ResolveIRI(p.base, p.tok.value)
+	}
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if turtleStyle {
+		return p.expect(".")
+	}
+	return nil
+}
+
+// graphLabel parses the name of a TriG graph
+func (p *Parser) graphLabel() (Term, error) {
+	switch p.tok.typ {
+	case tokIRI, tokPrefixedName, tokBlankNode:
+		return p.iriOrBlankNode()
+	case tokPunctuation:
+		if p.tok.value == "[" {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			return p.newBlankNode(), nil
+		}
+	}
+	return nil, p.errorf(p.tok, "expected a graph name but got %s", p.tok)
+}
+
+// graphBlock parses the triples between braces of a TriG graph or an N3 formula
+func (p *Parser) graphBlock(graph Term) error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	previous := p.graph
+	p.graph = graph
+	defer func() { p.graph = previous }()
+
+	for !p.isPunctuation("}") {
+		subject, isPropertyList, err := p.subject()
+		if err != nil {
+			return err
+		}
+		if err := p.triples(subject, isPropertyList); err != nil {
+			return err
+		}
+		if p.isPunctuation("}") {
+			break
+		}
+		if err := p.expect("."); err != nil {
+			return err
+		}
+	}
+	return p.advance()
+}
+
+// triples parses the predicate-object list following a subject
+func (p *Parser) triples(subject Term, isPropertyList bool) error {
+	// A blank node property list may be used as a complete statement
+	if isPropertyList && (p.isPunctuation(".") || p.isPunctuation("}")) {
+		return nil
+	}
+	return p.predicateObjectList(subject)
+}
+
+// subject parses the subject of a statement.
+// The boolean result is true if the subject was a non-empty blank node property list.
+func (p *Parser) subject() (Term, bool, error) {
+	switch p.tok.typ {
+	case tokIRI, tokPrefixedName, tokBlankNode:
+		term, err := p.iriOrBlankNode()
+		return term, false, err
+	case tokPunctuation:
+		switch p.tok.value {
+		case "[":
+			return p.blankNodePropertyList()
+		case "(":
+			term, err := p.collection()
+			return term, false, err
+		case "{":
+			if p.format == FormatN3 {
+				term, err := p.formula()
+				return term, false, err
+			}
+		}
+	case tokVariable:
+		if p.allowsVariables() {
+			term, err := p.variable()
+			return term, false, err
+		}
+	}
+	return nil, false, p.errorf(p.tok, "expected a subject but got %s", p.tok)
+}
+
+func (p *Parser) predicateObjectList(subject Term) error {
+	for {
+		predicate, err := p.verb()
+		if err != nil {
+			return err
+		}
+		if err := p.objectList(subject, predicate); err != nil {
+			return err
+		}
+		if !p.isPunctuation(";") {
+			return nil
+		}
+		for p.isPunctuation(";") {
+			if err := p.advance(); err != nil {
+				return err
+			}
+		}
+		if p.isPunctuation(".") || p.isPunctuation("]") || p.isPunctuation("}") || p.tok.typ == tokEOF {
+			return nil
+		}
+	}
+}
+
+func (p *Parser) verb() (Term, error) {
+	switch p.tok.typ {
+	case tokA:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return NewNamedNode(RDFType), nil
+	case tokIRI, tokPrefixedName:
+		return p.iriOrBlankNode()
+	case tokVariable:
+		if p.allowsVariables() {
+			return p.variable()
+		}
+	}
+	return nil, p.errorf(p.tok, "expected a predicate but got %s", p.tok)
+}
+
+func (p *Parser) objectList(subject, predicate Term) error {
+	for {
+		object, err := p.object()
+		if err != nil {
+			return err
+		}
+		if err := p.emit(subject, predicate, object); err != nil {
+			return err
+		}
+		if !p.isPunctuation(",") {
+			return nil
+		}
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *Parser) object() (Term, error) {
+	switch p.tok.typ {
+	case tokIRI, tokPrefixedName, tokBlankNode:
+		return p.iriOrBlankNode()
+	case tokString, tokInteger, tokDecimal, tokDouble, tokBoolean:
+		return p.literal()
+	case tokPunctuation:
+		switch p.tok.value {
+		case "[":
+			term, _, err := p.blankNodePropertyList()
+			return term, err
+		case "(":
+			return p.collection()
+		case "{":
+			if p.format == FormatN3 {
+				return p.formula()
+			}
+		}
+	case tokVariable:
+		if p.allowsVariables() {
+			return p.variable()
+		}
+	}
+	return nil, p.errorf(p.tok, "expected an object but got %s", p.tok)
+}
+
+// variable converts the current N3 variable token into a term
+func (p *Parser) variable() (Term, error) {
+	term := NewVariable(p.tok.value)
+	return term, p.advance()
+}
+
+// formula parses an N3 formula "{ ... }" into the graph of a new blank node
+func (p *Parser) formula() (Term, error) {
+	node := p.newBlankNode()
+	return node, p.graphBlock(node)
+}
+
+// iriOrBlankNode converts the current IRI, prefixed name or blank node token into a term
+func (p *Parser) iriOrBlankNode() (Term, error) {
+	tok := p.tok
+	var term Term
+	switch tok.typ {
+	case tokIRI:
+		if p.isLineBased() && !IsAbsoluteIRI(tok.value) {
+			return nil, p.errorf(tok, "relative IRI %s is not allowed", tok)
+		}
+		term = NewNamedNode(ResolveIRI(p.base, tok.value))
+	case tokPrefixedName:
+		namespace, ok := p.prefixes[tok.prefix]
+		if !ok {
+			return nil, p.errorf(tok, "undefined prefix %q", tok.prefix+":")
+		}
+		term = NewNamedNode(namespace + tok.value)
+	case tokBlankNode:
+		term = NewBlankNode(p.blankPrefix + tok.value)
+	default:
+		return nil, p.unexpected()
+	}
+	return term, p.advance()
+}
+
+func (p *Parser) literal() (Term, error) {
+	tok := p.tok
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	switch tok.typ {
+	case tokInteger:
+		return NewTypedLiteral(tok.value, NewNamedNode(XSDInteger)), nil
+	case tokDecimal:
+		return NewTypedLiteral(tok.value, NewNamedNode(XSDDecimal)), nil
+	case tokDouble:
+		return NewTypedLiteral(tok.value, NewNamedNode(XSDDouble)), nil
+	case tokBoolean:
+		return NewTypedLiteral(tok.value, NewNamedNode(XSDBoolean)), nil
+	}
+
+	switch p.tok.typ {
+	case tokLangTag:
+		language := p.tok.value
+		return NewLangLiteral(tok.value, language), p.advance()
+	case tokDatatypeMark:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.typ != tokIRI && p.tok.typ != tokPrefixedName {
+			return nil, p.errorf(p.tok, "expected a datatype IRI but got %s", p.tok)
+		}
+		datatype, err := p.iriOrBlankNode()
+		if err != nil {
+			return nil, err
+		}
+		return NewTypedLiteral(tok.value, datatype.(NamedNode)), nil
+	}
+	return NewLiteral(tok.value), nil
+}
+
+// blankNodePropertyList parses "[ ... ]", returning whether it contained any properties
+func (p *Parser) blankNodePropertyList() (Term, bool, error) {
+	if err := p.expect("["); err != nil {
+		return nil, false, err
+	}
+	node := p.newBlankNode()
+	if p.isPunctuation("]") {
+		return node, false, p.advance()
+	}
+	if err := p.predicateObjectList(node); err != nil {
+		return nil, false, err
+	}
+	return node, true, p.expect("]")
+}
+
+// collection parses an RDF list and returns its head
+func (p *Parser) collection() (Term, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	first, rest, nilNode := NewNamedNode(rdfNS+"first"), NewNamedNode(rdfNS+"rest"), NewNamedNode(rdfNS+"nil")
+	var head, previous Term = nilNode, nil
+	for !p.isPunctuation(")") {
+		item, err := p.object()
+		if err != nil {
+			return nil, err
+		}
+		node := p.newBlankNode()
+		if previous == nil {
+			head = node
+		} else if err := p.emit(previous, rest, node); err != nil {
+			return nil, err
+		}
+		if err := p.emit(node, first, item); err != nil {
+			return nil, err
+		}
+		previous = node
+	}
+	if previous != nil {
+		if err := p.emit(previous, rest, nilNode); err != nil {
+			return nil, err
+		}
+	}
+	return head, p.advance()
+}
+
+// lineStatement parses a single N-Triples or N-Quads statement
+func (p *Parser) lineStatement() error {
+	if p.tok.typ != tokIRI && p.tok.typ != tokBlankNode {
+		return p.errorf(p.tok, "expected a subject but got %s", p.tok)
+	}
+	subject, err := p.iriOrBlankNode()
+	if err != nil {
+		return err
+	}
+	if p.tok.typ != tokIRI {
+		return p.errorf(p.tok, "expected a predicate but got %s", p.tok)
+	}
+	predicate, err := p.iriOrBlankNode()
+	if err != nil {
+		return err
+	}
+	var object Term
+	switch p.tok.typ {
+	case tokIRI, tokBlankNode:
+		object, err = p.iriOrBlankNode()
+	case tokString:
+		object, err = p.literal()
+	default:
+		return p.errorf(p.tok, "expected an object but got %s", p.tok)
+	}
+	if err != nil {
+		return err
+	}
+	graph := Term(DefaultGraph())
+	if p.format == FormatNQuads && (p.tok.typ == tokIRI || p.tok.typ == tokBlankNode) {
+		if graph, err = p.iriOrBlankNode(); err != nil {
+			return err
+		}
+	}
+	if err := p.expect("."); err != nil {
+		return err
+	}
+	return p.callback(NewQuad(subject, predicate, object, graph))
+}
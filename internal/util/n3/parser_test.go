@@ -0,0 +1,240 @@
+package n3
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func parse(t *testing.T, format Format, base, input string) *BasicStore {
+	t.Helper()
+	store, err := NewParser(ParserOptions{Format: format, BaseIRI: base}).ParseToStore(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return store
+}
+
+func TestParser_Turtle(t *testing.T) {
+	input := `@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+PREFIX ex: <http://example.org/>
+@base <http://example.org/people/> .
+
+<alice> a foaf:Person ;
+    foaf:name "Alice Smith", "Alicia"@ES ;
+    foaf:knows <bob>, [ foaf:name 'Carol' ] ;
+    ex:age 42 ;
+    ex:height 1.70 ;
+    ex:weight 6.5e1 ;
+    ex:active true ;
+    ex:bio """Line one
+Line "two\""""" ;
+    ex:list ( 1 ex:two "three" ) ;
+    ex:escaped "tab\there" ;
+    ex:typed "2024-01-01"^^<http://www.w3.org/2001/XMLSchema#date> ;
+.
+_:x foaf:name "X" .
+[ foaf:name "anonymous" ] .
+`
+	store := parse(t, FormatTurtle, "", input)
+	alice := NewNamedNode("http://example.org/people/alice")
+
+	tests := []struct {
+		name      string
+		predicate string
+		object    Term
+	}{
+		{"rdf:type via a", RDFType, NewNamedNode("http://xmlns.com/foaf/0.1/Person")},
+		{"literal with spaces", "http://xmlns.com/foaf/0.1/name", NewLiteral("Alice Smith")},
+		{"language tag", "http://xmlns.com/foaf/0.1/name", NewLangLiteral("Alicia", "es")},
+		{"relative IRI", "http://xmlns.com/foaf/0.1/knows", NewNamedNode("http://example.org/people/bob")},
+		{"integer", "http://example.org/age", NewTypedLiteral("42", NewNamedNode(XSDInteger))},
+		{"decimal", "http://example.org/height", NewTypedLiteral("1.70", NewNamedNode(XSDDecimal))},
+		{"double", "http://example.org/weight", NewTypedLiteral("6.5e1", NewNamedNode(XSDDouble))},
+		{"boolean", "http://example.org/active", NewTypedLiteral("true", NewNamedNode(XSDBoolean))},
+		{"long string", "http://example.org/bio", NewLiteral("Line one\nLine \"two\"\"")},
+		{"escape", "http://example.org/escaped", NewLiteral("tab\there")},
+		{"datatype", "http://example.org/typed", NewTypedLiteral("2024-01-01", NewNamedNode("http://www.w3.org/2001/XMLSchema#date"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := store.CountQuads(alice, tt.predicate, tt.object, nil); got != 1 {
+				t.Errorf("CountQuads(%v) = %v, want %v", tt.object, got, 1)
+			}
+		})
+	}
+
+	knows := store.GetObjects(alice, "http://xmlns.com/foaf/0.1/knows", nil)
+	if len(knows) != 2 || knows[1].TermType() != BlankNodeType {
+		t.Fatalf("GetObjects(foaf:knows) = %v, want a named node and a blank node", knows)
+	}
+	if got := store.CountQuads(knows[1], "http://xmlns.com/foaf/0.1/name", NewLiteral("Carol"), nil); got != 1 {
+		t.Errorf("blank node property list CountQuads() = %v, want %v", got, 1)
+	}
+
+	list := store.GetObjects(alice, "http://example.org/list", nil)
+	if len(list) != 1 {
+		t.Fatalf("GetObjects(ex:list) = %v, want 1 list head", list)
+	}
+	if got := store.CountQuads(nil, rdfNS+"first", nil, nil); got != 3 {
+		t.Errorf("rdf:first CountQuads() = %v, want %v", got, 3)
+	}
+	if got := store.CountQuads(nil, rdfNS+"rest", NewNamedNode(rdfNS+"nil"), nil); got != 1 {
+		t.Errorf("rdf:nil CountQuads() = %v, want %v", got, 1)
+	}
+	if got := store.CountQuads(nil, "http://xmlns.com/foaf/0.1/name", NewLiteral("anonymous"), nil); got != 1 {
+		t.Errorf("standalone property list CountQuads() = %v, want %v", got, 1)
+	}
+}
+
+func TestParser_BlankNodeLabelsAreScopedPerDocument(t *testing.T) {
+	input := `_:a <http://example.org/p> "1" .`
+	first := parse(t, FormatTurtle, "", input).GetSubjects(nil, nil, nil)
+	second := parse(t, FormatTurtle, "", input).GetSubjects(nil, nil, nil)
+	if first[0].Equals(second[0]) {
+		t.Errorf("blank nodes of different documents should differ, got %v twice", first[0])
+	}
+}
+
+func TestParser_TriG(t *testing.T) {
+	input := `@prefix ex: <http://example.org/> .
+{ ex:a ex:p ex:b }
+ex:g1 { ex:a ex:p ex:c . ex:a ex:p ex:d }
+GRAPH ex:g2 { ex:a ex:p ex:e . }
+`
+	store := parse(t, FormatTriG, "", input)
+	tests := []struct {
+		graph interface{}
+		want  int
+	}{
+		{DefaultGraph(), 1},
+		{"http://example.org/g1", 2},
+		{"http://example.org/g2", 1},
+	}
+	for _, tt := range tests {
+		if got := store.CountQuads(nil, nil, nil, tt.graph); got != tt.want {
+			t.Errorf("CountQuads(graph %v) = %v, want %v", tt.graph, got, tt.want)
+		}
+	}
+}
+
+func TestParser_N3(t *testing.T) {
+	input := `@prefix solid: <http://www.w3.org/ns/solid/terms#> .
+@prefix ex: <http://example.org/> .
+_:patch a solid:InsertDeletePatch ;
+  solid:where { ?person ex:name ?name } ;
+  solid:inserts { ?person ex:alias ?name . ex:a ex:p ex:b } ;
+  solid:deletes { } .
+`
+	store := parse(t, FormatN3, "", input)
+	where := store.GetObjects(nil, "http://www.w3.org/ns/solid/terms#where", DefaultGraph())
+	if len(where) != 1 || where[0].TermType() != BlankNodeType {
+		t.Fatalf("formula term = %v, want a blank node", where)
+	}
+	conditions := store.GetQuads(nil, nil, nil, where[0])
+	if len(conditions) != 1 || !conditions[0].Subject.Equals(NewVariable("person")) || !conditions[0].Object.Equals(NewVariable("name")) {
+		t.Errorf("formula quads = %v, want the pattern with variables", conditions)
+	}
+	inserts := store.GetObjects(nil, "http://www.w3.org/ns/solid/terms#inserts", nil)
+	if got := store.CountQuads(nil, nil, nil, inserts[0]); got != 2 {
+		t.Errorf("inserts CountQuads() = %v, want %v", got, 2)
+	}
+	deletes := store.GetObjects(nil, "http://www.w3.org/ns/solid/terms#deletes", nil)
+	if len(deletes) != 1 || store.CountQuads(nil, nil, nil, deletes[0]) != 0 {
+		t.Errorf("empty formula = %v", deletes)
+	}
+
+	if _, err := NewParser(ParserOptions{Format: FormatTurtle}).ParseQuads(strings.NewReader(input)); err == nil {
+		t.Errorf("Parse() of formulas in Turtle should fail")
+	}
+}
+
+func TestParser_NTriplesAndNQuads(t *testing.T) {
+	input := `<http://example.org/a> <http://example.org/p> "with spaces and \"quotes\""@en .
+# comment
+_:b <http://example.org/p> <http://example.org/c> .
+`
+	store := parse(t, FormatNTriples, "", input)
+	if got := store.Size(); got != 2 {
+		t.Errorf("Size() = %v, want %v", got, 2)
+	}
+
+	quads := parse(t, FormatNQuads, "", input+`<http://example.org/a> <http://example.org/p> "x" <http://example.org/g> .`)
+	if got := quads.CountQuads(nil, nil, nil, "http://example.org/g"); got != 1 {
+		t.Errorf("CountQuads(graph) = %v, want %v", got, 1)
+	}
+
+	_, err := NewParser(ParserOptions{Format: FormatNTriples}).ParseQuads(strings.NewReader(`<a> <http://example.org/p> "x" .`))
+	if err == nil {
+		t.Errorf("Parse() of relative IRI in N-Triples should fail")
+	}
+}
+
+func TestParser_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		line   int
+		column int
+	}{
+		{"undefined prefix", "<a> <b> <c> .\n  ex:a <b> <c> .", 2, 3},
+		{"missing dot", "<a> <b> <c>\n<d> <e> <f> .", 2, 1},
+		{"unterminated string", `<a> <b> "abc`, 1, 9},
+		{"missing object", "<a> <b> .", 1, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser(ParserOptions{Format: FormatTurtle}).ParseQuads(strings.NewReader(tt.input))
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Parse() error = %v, want a ParseError", err)
+			}
+			if parseErr.Line != tt.line || parseErr.Column != tt.column {
+				t.Errorf("Parse() error at %d:%d, want %d:%d (%v)", parseErr.Line, parseErr.Column, tt.line, tt.column, err)
+			}
+		})
+	}
+}
+
+func TestParser_Streaming(t *testing.T) {
+	input := "<http://example.org/a> <http://example.org/p> 1 .\n<http://example.org/a> <http://example.org/p> ."
+	count := 0
+	err := NewParser(ParserOptions{Format: FormatTurtle}).Parse(strings.NewReader(input), func(Quad) error {
+		count++
+		return nil
+	})
+	if err == nil {
+		t.Fatalf("Parse() error = nil, want an error")
+	}
+	if count != 1 {
+		t.Errorf("Parse() emitted %v quads before the error, want %v", count, 1)
+	}
+}
+
+func TestResolveIRI(t *testing.T) {
+	base := "http://a/b/c/d;p?q"
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"g:h", "g:h"},
+		{"g", "http://a/b/c/g"},
+		{"./g", "http://a/b/c/g"},
+		{"g/", "http://a/b/c/g/"},
+		{"/g", "http://a/g"},
+		{"//g", "http://g"},
+		{"?y", "http://a/b/c/d;p?y"},
+		{"#s", "http://a/b/c/d;p?q#s"},
+		{"", "http://a/b/c/d;p?q"},
+		{".", "http://a/b/c/"},
+		{"..", "http://a/b/"},
+		{"../g", "http://a/b/g"},
+		{"../../../g", "http://a/g"},
+		{"g;x=1/../y", "http://a/b/c/y"},
+	}
+	for _, tt := range tests {
+		if got := ResolveIRI(base, tt.ref); got != tt.want {
+			t.Errorf("ResolveIRI(%q) = %v, want %v", tt.ref, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package n3
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrUnsupportedUpdate is wrapped by the errors for valid SPARQL Update features the parser does not support
+var ErrUnsupportedUpdate = errors.New("unsupported SPARQL Update feature")
+
+// UpdateOperationType identifies the kind of a SPARQL Update operation
+type UpdateOperationType int
+
+const (
+	// InsertData adds the triples of an INSERT DATA operation
+	InsertData UpdateOperationType = iota
+	// DeleteData removes the triples of a DELETE DATA operation
+	DeleteData
+	// DeleteInsert instantiates the templates of a DELETE/INSERT or DELETE WHERE operation
+	// for every solution of its WHERE pattern
+	DeleteInsert
+)
+
+// UpdateOperation is a single operation of a SPARQL Update request.
+// All triples are in the default graph.
+type UpdateOperation struct {
+	Type UpdateOperationType
+	// Delete holds the triples or templates to delete
+	Delete []Quad
+	// Insert holds the triples or templates to insert
+	Insert []Quad
+	// Where is the basic graph pattern whose solutions bind the variables of the templates
+	Where []Quad
+}
+
+// SparqlUpdate is a parsed SPARQL Update request, whose operations are applied in order
+type SparqlUpdate struct {
+	Operations []UpdateOperation
+}
+
+// ParseSparqlUpdate parses the subset of SPARQL 1.1 Update used by Solid apps:
+// INSERT DATA, DELETE DATA, DELETE/INSERT WHERE and DELETE WHERE with basic graph patterns,
+// and PREFIX and BASE declarations.
+// Features outside that subset, such as GRAPH or FILTER, result in an error wrapping ErrUnsupportedUpdate.
+func ParseSparqlUpdate(reader io.Reader, baseIRI string) (*SparqlUpdate, error) {
+	p := NewParser(ParserOptions{Format: FormatTurtle, BaseIRI: baseIRI})
+	p.sparql = true
+	if err := p.start(reader, nil); err != nil {
+		return nil, err
+	}
+	update := &SparqlUpdate{}
+	for {
+		for p.tok.typ == tokSparqlPrefix || p.tok.typ == tokSparqlBase {
+			if err := p.directive(false); err != nil {
+				return nil, err
+			}
+		}
+		if p.tok.typ == tokEOF {
+			return update, nil
+		}
+		operation, err := p.updateOperation()
+		if err != nil {
+			return nil, err
+		}
+		update.Operations = append(update.Operations, operation)
+		if p.tok.typ == tokEOF {
+			return update, nil
+		}
+		if err := p.expect(";"); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *Parser) isKeyword(value string) bool {
+	return p.tok.typ == tokKeyword && p.tok.value == value
+}
+
+func (p *Parser) unsupported(tok token) error {
+	return fmt.Errorf("%w: %s on line %d, column %d", ErrUnsupportedUpdate, tok, tok.line, tok.column)
+}
+
+// updateOperation parses a single INSERT or DELETE operation
+func (p *Parser) updateOperation() (UpdateOperation, error) {
+	start := p.tok
+	if start.typ != tokKeyword {
+		return UpdateOperation{}, p.errorf(start, "expected an update operation but got %s", start)
+	}
+	if start.value != "INSERT" && start.value != "DELETE" {
+		return UpdateOperation{}, p.unsupported(start)
+	}
+	if err := p.advance(); err != nil {
+		return UpdateOperation{}, err
+	}
+
+	if p.isKeyword("DATA") {
+		if err := p.advance(); err != nil {
+			return UpdateOperation{}, err
+		}
+		quads, err := p.groupPattern()
+		if err != nil {
+			return UpdateOperation{}, err
+		}
+		// SPARQL 1.1 Update, §3.1.1 and §3.1.2: data can not contain variables, deleted data no blank nodes
+		if err := p.checkTerms(start, start.value+" DATA", quads, VariableType); err != nil {
+			return UpdateOperation{}, err
+		}
+		if start.value == "INSERT" {
+			return UpdateOperation{Type: InsertData, Insert: quads}, nil
+		}
+		if err := p.checkTerms(start, "DELETE DATA", quads, BlankNodeType); err != nil {
+			return UpdateOperation{}, err
+		}
+		return UpdateOperation{Type: DeleteData, Delete: quads}, nil
+	}
+
+	operation := UpdateOperation{Type: DeleteInsert}
+	if start.value == "DELETE" && p.isKeyword("WHERE") {
+		if err := p.advance(); err != nil {
+			return UpdateOperation{}, err
+		}
+		pattern, err := p.groupPattern()
+		if err != nil {
+			return UpdateOperation{}, err
+		}
+		operation.Delete, operation.Where = pattern, pattern
+		return operation, p.checkTerms(start, "DELETE WHERE", pattern, BlankNodeType)
+	}
+
+	var err error
+	if start.value == "DELETE" {
+		if operation.Delete, err = p.groupPattern(); err != nil {
+			return UpdateOperation{}, err
+		}
+		if err := p.checkTerms(start, "DELETE", operation.Delete, BlankNodeType); err != nil {
+			return UpdateOperation{}, err
+		}
+		if p.isKeyword("INSERT") {
+			if err := p.advance(); err != nil {
+				return UpdateOperation{}, err
+			}
+		}
+	}
+	if start.value == "INSERT" || p.isPunctuation("{") {
+		if operation.Insert, err = p.groupPattern(); err != nil {
+			return UpdateOperation{}, err
+		}
+	}
+	if p.isKeyword("USING") {
+		return UpdateOperation{}, p.unsupported(p.tok)
+	}
+	if !p.isKeyword("WHERE") {
+		return UpdateOperation{}, p.errorf(p.tok, "expected WHERE but got %s", p.tok)
+	}
+	if err := p.advance(); err != nil {
+		return UpdateOperation{}, err
+	}
+	operation.Where, err = p.groupPattern()
+	return operation, err
+}
+
+// groupPattern parses the triples between braces, which must form a basic graph pattern
+func (p *Parser) groupPattern() ([]Quad, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var quads []Quad
+	p.callback = func(quad Quad) error {
+		quads = append(quads, quad)
+		return nil
+	}
+	for !p.isPunctuation("}") {
+		if p.isGroupSyntax() {
+			return nil, p.unsupported(p.tok)
+		}
+		subject, isPropertyList, err := p.subject()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.triples(subject, isPropertyList); err != nil {
+			return nil, err
+		}
+		if p.isPunctuation("}") {
+			break
+		}
+		if p.isGroupSyntax() {
+			return nil, p.unsupported(p.tok)
+		}
+		if err := p.expect("."); err != nil {
+			return nil, err
+		}
+	}
+	return quads, p.advance()
+}
+
+// isGroupSyntax checks if the current token starts a part of a group pattern other than triples,
+// such as a FILTER, OPTIONAL, GRAPH or nested group
+func (p *Parser) isGroupSyntax() bool {
+	return p.tok.typ == tokGraph || p.tok.typ == tokKeyword || p.isPunctuation("{")
+}
+
+// checkTerms returns an error if any of the quads of the clause contains a term of the given type
+func (p *Parser) checkTerms(start token, clause string, quads []Quad, termType TermType) error {
+	for _, quad := range quads {
+		for _, term := range []Term{quad.Subject, quad.Predicate, quad.Object} {
+			if term.TermType() == termType {
+				return p.errorf(start, "%s can not contain %s", clause, term)
+			}
+		}
+	}
+	return nil
+}
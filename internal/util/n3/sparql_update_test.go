@@ -0,0 +1,76 @@
+package n3
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseSparqlUpdate(t *testing.T) {
+	input := `PREFIX ex: <http://example.org/terms#>
+BASE <http://example.org/doc>
+INSERT DATA { <#a> ex:name "A"; ex:knows [ ex:name "B" ] } ;
+delete data { <#a> ex:age 3 . } ;
+DELETE { ?s ex:name ?o } INSERT { ?s ex:name "C" } WHERE { ?s ex:name ?o . $s a ex:Person } ;
+INSERT { <#a> ex:seen true } WHERE { } ;
+DELETE WHERE { ?s ex:tmp ?o } ;
+`
+	update, err := ParseSparqlUpdate(strings.NewReader(input), "")
+	if err != nil {
+		t.Fatalf("ParseSparqlUpdate() error = %v", err)
+	}
+	if len(update.Operations) != 5 {
+		t.Fatalf("operations = %v, want 5", len(update.Operations))
+	}
+	ops := update.Operations
+	if ops[0].Type != InsertData || len(ops[0].Insert) != 3 {
+		t.Errorf("INSERT DATA = %+v", ops[0])
+	}
+	if !ops[0].Insert[0].Subject.Equals(NewNamedNode("http://example.org/doc#a")) {
+		t.Errorf("subject = %v, want an IRI resolved against BASE", ops[0].Insert[0].Subject)
+	}
+	if ops[1].Type != DeleteData || len(ops[1].Delete) != 1 {
+		t.Errorf("DELETE DATA = %+v", ops[1])
+	}
+	if ops[2].Type != DeleteInsert || len(ops[2].Delete) != 1 || len(ops[2].Insert) != 1 || len(ops[2].Where) != 2 {
+		t.Errorf("DELETE/INSERT WHERE = %+v", ops[2])
+	}
+	if !ops[2].Where[1].Subject.Equals(NewVariable("s")) {
+		t.Errorf("$s = %v, want the variable ?s", ops[2].Where[1].Subject)
+	}
+	if ops[3].Type != DeleteInsert || len(ops[3].Insert) != 1 || len(ops[3].Where) != 0 {
+		t.Errorf("INSERT WHERE = %+v", ops[3])
+	}
+	if ops[4].Type != DeleteInsert || len(ops[4].Delete) != 1 || len(ops[4].Where) != 1 {
+		t.Errorf("DELETE WHERE = %+v", ops[4])
+	}
+}
+
+func TestParseSparqlUpdate_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		unsupported bool
+	}{
+		{"variable in data", `INSERT DATA { ?s <http://example.org/p> 1 }`, false},
+		{"blank node in deleted data", `DELETE DATA { _:b <http://example.org/p> 1 }`, false},
+		{"blank node in delete template", `DELETE { [] <http://example.org/p> ?o } WHERE { ?s <http://example.org/p> ?o }`, false},
+		{"missing where", `INSERT { <http://example.org/a> <http://example.org/p> 1 }`, false},
+		{"missing separator", `INSERT DATA { } INSERT DATA { }`, false},
+		{"graph", `INSERT DATA { GRAPH <http://example.org/g> { <http://example.org/a> <http://example.org/p> 1 } }`, true},
+		{"filter", `DELETE { ?s ?p ?o } WHERE { ?s ?p ?o FILTER (?o > 1) }`, true},
+		{"other operation", `CLEAR ALL`, true},
+		{"using", `DELETE { ?s ?p ?o } USING <http://example.org/g> WHERE { ?s ?p ?o }`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSparqlUpdate(strings.NewReader(tt.input), "http://example.org/")
+			if err == nil {
+				t.Fatalf("ParseSparqlUpdate() error = nil")
+			}
+			if got := errors.Is(err, ErrUnsupportedUpdate); got != tt.unsupported {
+				t.Errorf("ParseSparqlUpdate() error = %v, unsupported = %v", err, got)
+			}
+		})
+	}
+}
@@ -1,90 +1,363 @@
 package n3
 
-// Store represents an N3 store that can contain and query RDF triples
+import (
+	"sort"
+	"sync"
+)
+
+// Store represents an N3 store that can contain and query RDF quads.
+//
+// Query methods take patterns for every position: nil matches any term,
+// a Term matches an equal term and a string matches the named node with that IRI.
+// A nil graph pattern matches all graphs, DefaultGraph() only the default graph.
 type Store interface {
 	// CountQuads counts the number of quads matching the given pattern
 	CountQuads(subject, predicate, object, graph interface{}) int
-	// GetObjects returns all objects matching the given subject and predicate
+	// GetQuads returns all quads matching the given pattern in insertion order
+	GetQuads(subject, predicate, object, graph interface{}) []Quad
+	// GetSubjects returns the distinct subjects matching the given predicate and object
+	GetSubjects(predicate, object, graph interface{}) []Term
+	// GetObjects returns the distinct objects matching the given subject and predicate
 	GetObjects(subject, predicate, graph interface{}) []Term
-	// AddQuad adds a quad to the store
+	// ForEach calls the callback for every matching quad until it returns false
+	ForEach(callback func(quad Quad) bool, subject, predicate, object, graph interface{})
+	// Has returns true if the store contains the given quad
+	Has(quad Quad) bool
+	// AddQuad adds a quad to the store; adding an existing quad has no effect
 	AddQuad(quad Quad)
+	// AddQuads adds multiple quads to the store
+	AddQuads(quads []Quad)
+	// RemoveQuad removes a quad from the store and returns true if it was present
+	RemoveQuad(quad Quad) bool
+	// RemoveMatches removes all quads matching the pattern and returns how many were removed
+	RemoveMatches(subject, predicate, object, graph interface{}) int
+	// Size returns the number of quads in the store
+	Size() int
 }
 
-// Term represents an RDF term (subject, predicate, object, or graph)
-type Term interface {
-	// Value returns the string value of the term
-	Value() string
+// entry is a quad stored in the indexes together with its insertion sequence
+type entry struct {
+	quad Quad
+	seq  uint64
 }
 
-// BasicTerm is a simple implementation of the Term interface
-type BasicTerm struct {
-	value string
+// index is a three-level index from term ids to stored quads
+type index map[string]map[string]map[string]*entry
+
+func (idx index) add(a, b, c string, e *entry) {
+	level1, ok := idx[a]
+	if !ok {
+		level1 = make(map[string]map[string]*entry)
+		idx[a] = level1
+	}
+	level2, ok := level1[b]
+	if !ok {
+		level2 = make(map[string]*entry)
+		level1[b] = level2
+	}
+	level2[c] = e
 }
 
-// Value implements Term.Value
-func (t *BasicTerm) Value() string {
-	return t.value
+func (idx index) remove(a, b, c string) {
+	level1 := idx[a]
+	level2 := level1[b]
+	delete(level2, c)
+	if len(level2) == 0 {
+		delete(level1, b)
+	}
+	if len(level1) == 0 {
+		delete(idx, a)
+	}
 }
 
-// BasicStore is a simple implementation of the Store interface
-type BasicStore struct {
-	quads []Quad
+// walk visits all entries matching the given keys, where an empty key matches everything
+func (idx index) walk(a, b, c string, visit func(*entry)) {
+	visitLevel2 := func(level2 map[string]*entry) {
+		if c != "" {
+			if e, ok := level2[c]; ok {
+				visit(e)
+			}
+			return
+		}
+		for _, e := range level2 {
+			visit(e)
+		}
+	}
+	visitLevel1 := func(level1 map[string]map[string]*entry) {
+		if b != "" {
+			if level2, ok := level1[b]; ok {
+				visitLevel2(level2)
+			}
+			return
+		}
+		for _, level2 := range level1 {
+			visitLevel2(level2)
+		}
+	}
+	if a != "" {
+		if level1, ok := idx[a]; ok {
+			visitLevel1(level1)
+		}
+		return
+	}
+	for _, level1 := range idx {
+		visitLevel1(level1)
+	}
+}
+
+// graphIndex holds the subject-, predicate- and object-first indexes of a single graph
+type graphIndex struct {
+	spo index
+	pos index
+	osp index
+}
+
+func newGraphIndex() *graphIndex {
+	return &graphIndex{spo: index{}, pos: index{}, osp: index{}}
 }
 
-// Quad represents an RDF quad (subject, predicate, object, graph)
-type Quad struct {
-	Subject   Term
-	Predicate Term
-	Object    Term
-	Graph     Term
+// BasicStore is an indexed in-memory implementation of the Store interface.
+// Quads are kept with set semantics and returned in insertion order.
+// It is safe for concurrent use.
+type BasicStore struct {
+	mu     sync.RWMutex
+	graphs map[string]*graphIndex
+	size   int
+	seq    uint64
 }
 
 // NewBasicStore creates a new BasicStore
 func NewBasicStore() *BasicStore {
 	return &BasicStore{
-		quads: make([]Quad, 0),
+		graphs: make(map[string]*graphIndex),
 	}
 }
 
-// AddQuad adds a quad to the store
+// NewBasicStoreWithQuads creates a new BasicStore containing the given quads
+func NewBasicStoreWithQuads(quads []Quad) *BasicStore {
+	store := NewBasicStore()
+	store.AddQuads(quads)
+	return store
+}
+
+// AddQuad implements Store.AddQuad
 func (s *BasicStore) AddQuad(quad Quad) {
-	s.quads = append(s.quads, quad)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addQuad(quad)
+}
+
+// AddQuads implements Store.AddQuads
+func (s *BasicStore) AddQuads(quads []Quad) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, quad := range quads {
+		s.addQuad(quad)
+	}
+}
+
+func (s *BasicStore) addQuad(quad Quad) {
+	quad.Graph = graphOrDefault(quad.Graph)
+	sub, pred, obj, graph := quad.Subject.String(), quad.Predicate.String(), quad.Object.String(), termID(quad.Graph)
+
+	g, ok := s.graphs[graph]
+	if !ok {
+		g = newGraphIndex()
+		s.graphs[graph] = g
+	}
+	if _, exists := g.spo[sub][pred][obj]; exists {
+		return
+	}
+	s.seq++
+	e := &entry{quad: quad, seq: s.seq}
+	g.spo.add(sub, pred, obj, e)
+	g.pos.add(pred, obj, sub, e)
+	g.osp.add(obj, sub, pred, e)
+	s.size++
+}
+
+// RemoveQuad implements Store.RemoveQuad
+func (s *BasicStore) RemoveQuad(quad Quad) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.removeQuad(quad)
+}
+
+func (s *BasicStore) removeQuad(quad Quad) bool {
+	sub, pred, obj, graph := quad.Subject.String(), quad.Predicate.String(), quad.Object.String(), termID(graphOrDefault(quad.Graph))
+	g, ok := s.graphs[graph]
+	if !ok {
+		return false
+	}
+	if _, exists := g.spo[sub][pred][obj]; !exists {
+		return false
+	}
+	g.spo.remove(sub, pred, obj)
+	g.pos.remove(pred, obj, sub)
+	g.osp.remove(obj, sub, pred)
+	if len(g.spo) == 0 {
+		delete(s.graphs, graph)
+	}
+	s.size--
+	return true
+}
+
+// RemoveMatches implements Store.RemoveMatches
+func (s *BasicStore) RemoveMatches(subject, predicate, object, graph interface{}) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	removed := 0
+	for _, quad := range s.match(subject, predicate, object, graph) {
+		if s.removeQuad(quad) {
+			removed++
+		}
+	}
+	return removed
+}
+
+// Has implements Store.Has
+func (s *BasicStore) Has(quad Quad) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	g, ok := s.graphs[termID(graphOrDefault(quad.Graph))]
+	if !ok {
+		return false
+	}
+	_, exists := g.spo[quad.Subject.String()][quad.Predicate.String()][quad.Object.String()]
+	return exists
+}
+
+// Size implements Store.Size
+func (s *BasicStore) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.size
 }
 
 // CountQuads implements Store.CountQuads
 func (s *BasicStore) CountQuads(subject, predicate, object, graph interface{}) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	count := 0
-	for _, quad := range s.quads {
-		if matches(quad.Subject, subject) &&
-			matches(quad.Predicate, predicate) &&
-			matches(quad.Object, object) &&
-			matches(quad.Graph, graph) {
-			count++
-		}
-	}
+	s.walk(subject, predicate, object, graph, func(*entry) { count++ })
 	return count
 }
 
+// GetQuads implements Store.GetQuads
+func (s *BasicStore) GetQuads(subject, predicate, object, graph interface{}) []Quad {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.match(subject, predicate, object, graph)
+}
+
+// GetSubjects implements Store.GetSubjects
+func (s *BasicStore) GetSubjects(predicate, object, graph interface{}) []Term {
+	return distinct(s.GetQuads(nil, predicate, object, graph), func(q Quad) Term { return q.Subject })
+}
+
 // GetObjects implements Store.GetObjects
 func (s *BasicStore) GetObjects(subject, predicate, graph interface{}) []Term {
-	var objects []Term
-	for _, quad := range s.quads {
-		if matches(quad.Subject, subject) &&
-			matches(quad.Predicate, predicate) &&
-			matches(quad.Graph, graph) {
-			objects = append(objects, quad.Object)
+	return distinct(s.GetQuads(subject, predicate, nil, graph), func(q Quad) Term { return q.Object })
+}
+
+// ForEach implements Store.ForEach.
+// The callback operates on a snapshot so it may safely modify the store.
+func (s *BasicStore) ForEach(callback func(quad Quad) bool, subject, predicate, object, graph interface{}) {
+	for _, quad := range s.GetQuads(subject, predicate, object, graph) {
+		if !callback(quad) {
+			return
+		}
+	}
+}
+
+// match returns the matching quads in insertion order; the caller must hold the lock
+func (s *BasicStore) match(subject, predicate, object, graph interface{}) []Quad {
+	var entries []*entry
+	s.walk(subject, predicate, object, graph, func(e *entry) { entries = append(entries, e) })
+	sort.Slice(entries, func(i, j int) bool { return entries[i].seq < entries[j].seq })
+	quads := make([]Quad, len(entries))
+	for i, e := range entries {
+		quads[i] = e.quad
+	}
+	return quads
+}
+
+// walk visits all entries matching the pattern using the most selective index
+func (s *BasicStore) walk(subject, predicate, object, graph interface{}, visit func(*entry)) {
+	sub, okS := patternID(subject)
+	pred, okP := patternID(predicate)
+	obj, okO := patternID(object)
+	if !okS || !okP || !okO {
+		return
+	}
+
+	visitGraph := func(g *graphIndex) {
+		switch {
+		case sub != "" && (pred != "" || obj == ""):
+			g.spo.walk(sub, pred, obj, visit)
+		case sub != "":
+			g.osp.walk(obj, sub, "", visit)
+		case pred != "":
+			g.pos.walk(pred, obj, "", visit)
+		case obj != "":
+			g.osp.walk(obj, "", "", visit)
+		default:
+			g.spo.walk("", "", "", visit)
+		}
+	}
+
+	if graph == nil {
+		for _, g := range s.graphs {
+			visitGraph(g)
 		}
+		return
+	}
+	graphID, ok := patternID(graph)
+	if !ok {
+		return
+	}
+	if g, exists := s.graphs[graphID]; exists {
+		visitGraph(g)
 	}
-	return objects
 }
 
-// matches checks if a term matches a pattern
-func matches(term Term, pattern interface{}) bool {
-	if pattern == nil {
-		return true
+// patternID converts a pattern to a term id, returning the empty string for wildcards.
+// The boolean is false if the pattern can never match.
+func patternID(pattern interface{}) (string, bool) {
+	switch p := pattern.(type) {
+	case nil:
+		return "", true
+	case Term:
+		return termID(p), true
+	case string:
+		return NewNamedNode(p).String(), true
+	default:
+		return "", false
 	}
-	if p, ok := pattern.(Term); ok {
-		return term.Value() == p.Value()
+}
+
+// defaultGraphID is never produced by Term.String so it is safe as a sentinel
+const defaultGraphID = "\x00default"
+
+// termID returns the index key of a term.
+// The default graph serializes to the empty string, which would otherwise act as a wildcard.
+func termID(term Term) string {
+	if term.TermType() == DefaultGraphType {
+		return defaultGraphID
+	}
+	return term.String()
+}
+
+func distinct(quads []Quad, pick func(Quad) Term) []Term {
+	seen := make(map[string]bool, len(quads))
+	var terms []Term
+	for _, quad := range quads {
+		term := pick(quad)
+		id := term.String()
+		if !seen[id] {
+			seen[id] = true
+			terms = append(terms, term)
+		}
 	}
-	return false
+	return terms
 }
@@ -0,0 +1,166 @@
+package n3
+
+import (
+	"testing"
+)
+
+var (
+	alice   = NewNamedNode("http://example.org/alice")
+	bob     = NewNamedNode("http://example.org/bob")
+	knows   = NewNamedNode("http://xmlns.com/foaf/0.1/knows")
+	name    = NewNamedNode("http://xmlns.com/foaf/0.1/name")
+	graphG1 = NewNamedNode("http://example.org/g1")
+)
+
+func TestTerm_Equals(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Term
+		want bool
+	}{
+		{"same named node", alice, NewNamedNode("http://example.org/alice"), true},
+		{"different named node", alice, bob, false},
+		{"named node vs literal", alice, NewLiteral("http://example.org/alice"), false},
+		{"named node vs blank node", NewNamedNode("b0"), NewBlankNode("b0"), false},
+		{"literal language", NewLangLiteral("chat", "fr"), NewLangLiteral("chat", "FR"), true},
+		{"literal language mismatch", NewLangLiteral("chat", "fr"), NewLangLiteral("chat", "en"), false},
+		{"literal datatype mismatch", NewLiteral("1"), NewTypedLiteral("1", NewNamedNode(XSDInteger)), false},
+		{"plain literal is xsd:string", NewLiteral("a"), NewTypedLiteral("a", NewNamedNode(XSDString)), true},
+		{"variable", NewVariable("x"), NewVariable("x"), true},
+		{"default graph", DefaultGraph(), DefaultGraph(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equals(tt.b); got != tt.want {
+				t.Errorf("Equals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTerm_String(t *testing.T) {
+	tests := []struct {
+		term Term
+		want string
+	}{
+		{alice, "<http://example.org/alice>"},
+		{NewBlankNode("b0"), "_:b0"},
+		{NewLiteral("say \"hi\"\n"), `"say \"hi\"\n"`},
+		{NewLangLiteral("chat", "fr"), `"chat"@fr`},
+		{NewTypedLiteral("1", NewNamedNode(XSDInteger)), `"1"^^<http://www.w3.org/2001/XMLSchema#integer>`},
+		{NewVariable("x"), "?x"},
+	}
+	for _, tt := range tests {
+		if got := tt.term.String(); got != tt.want {
+			t.Errorf("String() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestBasicStore_SetSemantics(t *testing.T) {
+	store := NewBasicStore()
+	store.AddQuad(NewQuad(alice, knows, bob, nil))
+	store.AddQuad(NewQuad(alice, knows, bob, DefaultGraph()))
+	store.AddQuad(NewQuad(alice, knows, bob, graphG1))
+	if got := store.Size(); got != 2 {
+		t.Errorf("Size() = %v, want %v", got, 2)
+	}
+	if !store.Has(Quad{Subject: alice, Predicate: knows, Object: bob}) {
+		t.Errorf("Has() = false, want true")
+	}
+}
+
+func TestBasicStore_Match(t *testing.T) {
+	store := NewBasicStoreWithQuads([]Quad{
+		NewQuad(alice, knows, bob, nil),
+		NewQuad(alice, name, NewLiteral("Alice"), nil),
+		NewQuad(bob, knows, alice, graphG1),
+		NewQuad(bob, name, NewLangLiteral("Bob", "en"), graphG1),
+	})
+
+	tests := []struct {
+		name                          string
+		subject, predicate, object, g interface{}
+		want                          int
+	}{
+		{"all", nil, nil, nil, nil, 4},
+		{"subject", alice, nil, nil, nil, 2},
+		{"subject as string", "http://example.org/alice", nil, nil, nil, 2},
+		{"predicate", nil, knows, nil, nil, 2},
+		{"object", nil, nil, alice, nil, 1},
+		{"subject and object", bob, nil, alice, nil, 1},
+		{"predicate and object", nil, knows, bob, nil, 1},
+		{"full pattern", alice, knows, bob, nil, 1},
+		{"default graph only", nil, nil, nil, DefaultGraph(), 2},
+		{"named graph only", nil, nil, nil, graphG1, 2},
+		{"literal is not an IRI", nil, nil, "Alice", nil, 0},
+		{"typed literal", nil, nil, NewLiteral("Alice"), nil, 1},
+		{"language mismatch", nil, nil, NewLiteral("Bob"), nil, 0},
+		{"unsupported pattern", 42, nil, nil, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := store.CountQuads(tt.subject, tt.predicate, tt.object, tt.g); got != tt.want {
+				t.Errorf("CountQuads() = %v, want %v", got, tt.want)
+			}
+			if got := len(store.GetQuads(tt.subject, tt.predicate, tt.object, tt.g)); got != tt.want {
+				t.Errorf("GetQuads() = %v quads, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBasicStore_InsertionOrder(t *testing.T) {
+	store := NewBasicStore()
+	objects := []Term{NewLiteral("c"), NewLiteral("a"), NewLiteral("b")}
+	for _, o := range objects {
+		store.AddQuad(NewQuad(alice, name, o, nil))
+	}
+	got := store.GetObjects(alice, name, nil)
+	if len(got) != len(objects) {
+		t.Fatalf("GetObjects() = %v, want %v", got, objects)
+	}
+	for i := range objects {
+		if !got[i].Equals(objects[i]) {
+			t.Errorf("GetObjects()[%d] = %v, want %v", i, got[i], objects[i])
+		}
+	}
+}
+
+func TestBasicStore_Remove(t *testing.T) {
+	store := NewBasicStoreWithQuads([]Quad{
+		NewQuad(alice, knows, bob, nil),
+		NewQuad(alice, name, NewLiteral("Alice"), nil),
+		NewQuad(bob, knows, alice, graphG1),
+	})
+
+	if !store.RemoveQuad(NewQuad(alice, knows, bob, nil)) {
+		t.Errorf("RemoveQuad() = false, want true")
+	}
+	if store.RemoveQuad(NewQuad(alice, knows, bob, nil)) {
+		t.Errorf("RemoveQuad() on missing quad = true, want false")
+	}
+	if got := store.CountQuads(nil, knows, nil, nil); got != 1 {
+		t.Errorf("CountQuads() = %v, want %v", got, 1)
+	}
+	if got := store.RemoveMatches(nil, nil, nil, graphG1); got != 1 {
+		t.Errorf("RemoveMatches() = %v, want %v", got, 1)
+	}
+	if got := store.Size(); got != 1 {
+		t.Errorf("Size() = %v, want %v", got, 1)
+	}
+}
+
+func TestBasicStore_ForEachAllowsModification(t *testing.T) {
+	store := NewBasicStoreWithQuads([]Quad{
+		NewQuad(alice, knows, bob, nil),
+		NewQuad(bob, knows, alice, nil),
+	})
+	store.ForEach(func(q Quad) bool {
+		store.RemoveQuad(q)
+		return true
+	}, nil, knows, nil, nil)
+	if got := store.Size(); got != 0 {
+		t.Errorf("Size() = %v, want %v", got, 0)
+	}
+}
@@ -0,0 +1,293 @@
+package n3
+
+import (
+	"strings"
+)
+
+// TermType identifies the kind of an RDF term
+type TermType int
+
+const (
+	// NamedNodeType is the type of IRI terms
+	NamedNodeType TermType = iota
+	// BlankNodeType is the type of blank node terms
+	BlankNodeType
+	// LiteralType is the type of literal terms
+	LiteralType
+	// VariableType is the type of variable terms
+	VariableType
+	// DefaultGraphType is the type of the default graph term
+	DefaultGraphType
+)
+
+// Well-known datatype IRIs
+const (
+	XSDString     = "http://www.w3.org/2001/XMLSchema#string"
+	XSDBoolean    = "http://www.w3.org/2001/XMLSchema#boolean"
+	XSDInteger    = "http://www.w3.org/2001/XMLSchema#integer"
+	XSDDecimal    = "http://www.w3.org/2001/XMLSchema#decimal"
+	XSDDouble     = "http://www.w3.org/2001/XMLSchema#double"
+	XSDDateTime   = "http://www.w3.org/2001/XMLSchema#dateTime"
+	RDFLangString = "http://www.w3.org/1999/02/22-rdf-syntax-ns#langString"
+	RDFType       = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+)
+
+// Term represents an RDF term (subject, predicate, object, or graph)
+type Term interface {
+	// Value returns the string value of the term
+	Value() string
+	// TermType returns the kind of the term
+	TermType() TermType
+	// Equals returns true if both terms are of the same kind and have the same content
+	Equals(other Term) bool
+	// String returns the N-Triples/N-Quads notation of the term
+	String() string
+}
+
+// NamedNode is an IRI term
+type NamedNode struct {
+	iri string
+}
+
+// NewNamedNode creates a new NamedNode
+func NewNamedNode(iri string) NamedNode {
+	return NamedNode{iri: iri}
+}
+
+// Value implements Term.Value
+func (n NamedNode) Value() string { return n.iri }
+
+// TermType implements Term.TermType
+func (n NamedNode) TermType() TermType { return NamedNodeType }
+
+// Equals implements Term.Equals
+func (n NamedNode) Equals(other Term) bool {
+	return other != nil && other.TermType() == NamedNodeType && other.Value() == n.iri
+}
+
+// String implements Term.String
+func (n NamedNode) String() string { return "<" + escapeIRI(n.iri) + ">" }
+
+// BlankNode is a blank node term
+type BlankNode struct {
+	id string
+}
+
+// NewBlankNode creates a new BlankNode with the given label
+func NewBlankNode(id string) BlankNode {
+	return BlankNode{id: id}
+}
+
+// Value implements Term.Value
+func (b BlankNode) Value() string { return b.id }
+
+// TermType implements Term.TermType
+func (b BlankNode) TermType() TermType { return BlankNodeType }
+
+// Equals implements Term.Equals
+func (b BlankNode) Equals(other Term) bool {
+	return other != nil && other.TermType() == BlankNodeType && other.Value() == b.id
+}
+
+// String implements Term.String
+func (b BlankNode) String() string { return "_:" + b.id }
+
+// Literal is a literal term with an optional language tag and a datatype
+type Literal struct {
+	value    string
+	language string
+	datatype NamedNode
+}
+
+// NewLiteral creates a new xsd:string literal
+func NewLiteral(value string) Literal {
+	return Literal{value: value, datatype: NewNamedNode(XSDString)}
+}
+
+// NewLangLiteral creates a new language-tagged literal
+func NewLangLiteral(value, language string) Literal {
+	return Literal{value: value, language: strings.ToLower(language), datatype: NewNamedNode(RDFLangString)}
+}
+
+// NewTypedLiteral creates a new literal with the given datatype
+func NewTypedLiteral(value string, datatype NamedNode) Literal {
+	if datatype.iri == "" {
+		datatype = NewNamedNode(XSDString)
+	}
+	return Literal{value: value, datatype: datatype}
+}
+
+// Value implements Term.Value
+func (l Literal) Value() string { return l.value }
+
+// TermType implements Term.TermType
+func (l Literal) TermType() TermType { return LiteralType }
+
+// Language returns the language tag of the literal, or the empty string
+func (l Literal) Language() string { return l.language }
+
+// Datatype returns the datatype of the literal
+func (l Literal) Datatype() NamedNode { return l.datatype }
+
+// Equals implements Term.Equals
+func (l Literal) Equals(other Term) bool {
+	o, ok := other.(Literal)
+	return ok && o.value == l.value && o.language == l.language && o.datatype.iri == l.datatype.iri
+}
+
+// String implements Term.String
+func (l Literal) String() string {
+	s := `"` + EscapeString(l.value) + `"`
+	switch {
+	case l.language != "":
+		return s + "@" + l.language
+	case l.datatype.iri != XSDString:
+		return s + "^^" + l.datatype.String()
+	}
+	return s
+}
+
+// Variable is a variable term as used in N3 and SPARQL patterns
+type Variable struct {
+	name string
+}
+
+// NewVariable creates a new Variable with the given name (without leading '?')
+func NewVariable(name string) Variable {
+	return Variable{name: name}
+}
+
+// Value implements Term.Value
+func (v Variable) Value() string { return v.name }
+
+// TermType implements Term.TermType
+func (v Variable) TermType() TermType { return VariableType }
+
+// Equals implements Term.Equals
+func (v Variable) Equals(other Term) bool {
+	return other != nil && other.TermType() == VariableType && other.Value() == v.name
+}
+
+// String implements Term.String
+func (v Variable) String() string { return "?" + v.name }
+
+// DefaultGraphTerm is the term representing the default graph
+type DefaultGraphTerm struct{}
+
+// DefaultGraph returns the default graph term
+func DefaultGraph() DefaultGraphTerm {
+	return DefaultGraphTerm{}
+}
+
+// Value implements Term.Value
+func (DefaultGraphTerm) Value() string { return "" }
+
+// TermType implements Term.TermType
+func (DefaultGraphTerm) TermType() TermType { return DefaultGraphType }
+
+// Equals implements Term.Equals
+func (DefaultGraphTerm) Equals(other Term) bool {
+	return other != nil && other.TermType() == DefaultGraphType
+}
+
+// String implements Term.String
+func (DefaultGraphTerm) String() string { return "" }
+
+// Quad represents an RDF quad (subject, predicate, object, graph)
+type Quad struct {
+	Subject   Term
+	Predicate Term
+	Object    Term
+	Graph     Term
+}
+
+// NewQuad creates a new Quad, using the default graph if graph is nil
+func NewQuad(subject, predicate, object, graph Term) Quad {
+	if graph == nil {
+		graph = DefaultGraph()
+	}
+	return Quad{Subject: subject, Predicate: predicate, Object: object, Graph: graph}
+}
+
+// Equals returns true if both quads contain the same terms
+func (q Quad) Equals(other Quad) bool {
+	return termEquals(q.Subject, other.Subject) &&
+		termEquals(q.Predicate, other.Predicate) &&
+		termEquals(q.Object, other.Object) &&
+		termEquals(graphOrDefault(q.Graph), graphOrDefault(other.Graph))
+}
+
+// String returns the N-Quads notation of the quad, without the final newline
+func (q Quad) String() string {
+	parts := []string{q.Subject.String(), q.Predicate.String(), q.Object.String()}
+	if g := graphOrDefault(q.Graph); g.TermType() != DefaultGraphType {
+		parts = append(parts, g.String())
+	}
+	return strings.Join(parts, " ") + " ."
+}
+
+func termEquals(a, b Term) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equals(b)
+}
+
+func graphOrDefault(graph Term) Term {
+	if graph == nil {
+		return DefaultGraph()
+	}
+	return graph
+}
+
+// EscapeString escapes a string for use inside a double-quoted N-Triples or Turtle literal
+func EscapeString(value string) string {
+	if !strings.ContainsAny(value, "\"\\\n\r\t\b\f") {
+		return value
+	}
+	var b strings.Builder
+	for _, r := range value {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// escapeIRI escapes the characters that are not allowed inside an IRIREF
+func escapeIRI(iri string) string {
+	if !strings.ContainsAny(iri, "<>\"{}|^`\\ ") {
+		return iri
+	}
+	var b strings.Builder
+	for _, r := range iri {
+		switch r {
+		case '<', '>', '"', '{', '}', '|', '^', '`', '\\', ' ':
+			b.WriteString(`\u00`)
+			b.WriteString(strings.ToUpper(hex2(byte(r))))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func hex2(c byte) string {
+	const digits = "0123456789abcdef"
+	return string([]byte{digits[c>>4], digits[c&0x0f]})
+}
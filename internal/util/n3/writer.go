@@ -0,0 +1,275 @@
+package n3
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// localNamePattern matches local names that can be written without escaping
+var localNamePattern = regexp.MustCompile(`^(?:[A-Za-z_][\w\-]*)?$`)
+
+// shorthandPatterns match the lexical forms that Turtle can write without a datatype
+var shorthandPatterns = map[string]*regexp.Regexp{
+	XSDInteger: regexp.MustCompile(`^[+-]?\d+$`),
+	XSDDecimal: regexp.MustCompile(`^[+-]?\d*\.\d+$`),
+	XSDDouble:  regexp.MustCompile(`^[+-]?(?:\d+\.\d*|\.?\d+)[eE][+-]?\d+$`),
+	XSDBoolean: regexp.MustCompile(`^(?:true|false)$`),
+}
+
+// WriterOptions configures a Writer
+type WriterOptions struct {
+	// Format is the syntax of the output
+	Format Format
+	// Prefixes maps prefix names to namespaces used to compact IRIs in Turtle and TriG
+	Prefixes map[string]string
+}
+
+// Writer serializes quads to Turtle, TriG, N-Triples or N-Quads as they are added.
+// Consecutive quads sharing a subject or predicate are grouped in Turtle and TriG.
+type Writer struct {
+	out        *bufio.Writer
+	format     Format
+	prefixes   map[string]string
+	namespaces []string
+
+	started   bool
+	subject   Term
+	predicate Term
+	graph     Term
+	err       error
+}
+
+// NewWriter creates a new Writer
+func NewWriter(w io.Writer, options WriterOptions) *Writer {
+	writer := &Writer{
+		out:      bufio.NewWriter(w),
+		format:   options.Format,
+		prefixes: make(map[string]string),
+	}
+	if !writer.isLineBased() {
+		for prefix, namespace := range options.Prefixes {
+			writer.prefixes[namespace] = prefix
+			writer.namespaces = append(writer.namespaces, namespace)
+		}
+		// Longer namespaces first so the most specific prefix wins
+		sort.Slice(writer.namespaces, func(i, j int) bool {
+			if len(writer.namespaces[i]) != len(writer.namespaces[j]) {
+				return len(writer.namespaces[i]) > len(writer.namespaces[j])
+			}
+			return writer.namespaces[i] < writer.namespaces[j]
+		})
+	}
+	return writer
+}
+
+func (w *Writer) isLineBased() bool {
+	return w.format == FormatNTriples || w.format == FormatNQuads
+}
+
+func (w *Writer) write(parts ...string) {
+	for _, part := range parts {
+		if w.err != nil {
+			return
+		}
+		_, w.err = w.out.WriteString(part)
+	}
+}
+
+// writePrefixes writes the prefix declarations before the first statement
+func (w *Writer) writePrefixes() {
+	w.started = true
+	if len(w.namespaces) == 0 {
+		return
+	}
+	sorted := append([]string(nil), w.namespaces...)
+	sort.Slice(sorted, func(i, j int) bool { return w.prefixes[sorted[i]] < w.prefixes[sorted[j]] })
+	for _, namespace := range sorted {
+		w.write("@prefix ", w.prefixes[namespace], ": ", NewNamedNode(namespace).String(), " .\n")
+	}
+	w.write("\n")
+}
+
+// AddQuad writes a single quad
+func (w *Writer) AddQuad(quad Quad) error {
+	if w.err != nil {
+		return w.err
+	}
+	graph := graphOrDefault(quad.Graph)
+	switch w.format {
+	case FormatNTriples:
+		w.write(quad.Subject.String(), " ", quad.Predicate.String(), " ", quad.Object.String(), " .\n")
+	case FormatNQuads:
+		w.write(quad.String(), "\n")
+	default:
+		if !w.started {
+			w.writePrefixes()
+		}
+		if w.format == FormatTurtle {
+			graph = DefaultGraph()
+		}
+		w.addGroupedQuad(quad, graph)
+	}
+	return w.err
+}
+
+func (w *Writer) addGroupedQuad(quad Quad, graph Term) {
+	switch {
+	case w.subject != nil && graph.Equals(w.graph) && quad.Subject.Equals(w.subject):
+		if quad.Predicate.Equals(w.predicate) {
+			w.write(", ", w.encodeObject(quad.Object))
+		} else {
+			w.predicate = quad.Predicate
+			w.write(" ;\n", w.indent(graph), "    ", w.encodePredicate(quad.Predicate), " ", w.encodeObject(quad.Object))
+		}
+		return
+	case w.subject != nil && graph.Equals(w.graph):
+		w.write(" .\n")
+	default:
+		w.closeGraph()
+		if graph.TermType() != DefaultGraphType {
+			w.write(w.encodeSubject(graph), " {\n")
+		}
+	}
+	w.graph, w.subject, w.predicate = graph, quad.Subject, quad.Predicate
+	w.write(w.indent(graph), w.encodeSubject(quad.Subject), " ", w.encodePredicate(quad.Predicate), " ", w.encodeObject(quad.Object))
+}
+
+// indent returns the indentation of statements inside the given graph
+func (w *Writer) indent(graph Term) string {
+	if graph.TermType() != DefaultGraphType {
+		return "    "
+	}
+	return ""
+}
+
+// closeGraph terminates the current statement and graph block
+func (w *Writer) closeGraph() {
+	if w.subject == nil {
+		return
+	}
+	w.write(" .\n")
+	if w.graph.TermType() != DefaultGraphType {
+		w.write("}\n")
+	}
+	w.subject = nil
+}
+
+// AddQuads writes multiple quads
+func (w *Writer) AddQuads(quads []Quad) error {
+	for _, quad := range quads {
+		if err := w.AddQuad(quad); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// End terminates the output and flushes it to the underlying writer
+func (w *Writer) End() error {
+	if !w.isLineBased() {
+		if !w.started {
+			w.writePrefixes()
+		}
+		w.closeGraph()
+	}
+	if w.err != nil {
+		return w.err
+	}
+	return w.out.Flush()
+}
+
+func (w *Writer) encodeSubject(term Term) string {
+	return w.encodeIRI(term)
+}
+
+func (w *Writer) encodePredicate(term Term) string {
+	if term.TermType() == NamedNodeType && term.Value() == RDFType {
+		return "a"
+	}
+	return w.encodeIRI(term)
+}
+
+func (w *Writer) encodeObject(term Term) string {
+	literal, ok := term.(Literal)
+	if !ok {
+		return w.encodeIRI(term)
+	}
+	if literal.language == "" {
+		datatype := literal.datatype.Value()
+		if pattern, ok := shorthandPatterns[datatype]; ok && pattern.MatchString(literal.value) {
+			return literal.value
+		}
+		if datatype != XSDString {
+			return `"` + EscapeString(literal.value) + `"^^` + w.encodeIRI(literal.datatype)
+		}
+	}
+	return literal.String()
+}
+
+// encodeIRI compacts named nodes using the known prefixes
+func (w *Writer) encodeIRI(term Term) string {
+	if term.TermType() != NamedNodeType {
+		return term.String()
+	}
+	iri := term.Value()
+	for _, namespace := range w.namespaces {
+		if strings.HasPrefix(iri, namespace) && localNamePattern.MatchString(iri[len(namespace):]) {
+			return w.prefixes[namespace] + ":" + iri[len(namespace):]
+		}
+	}
+	return term.String()
+}
+
+// GroupQuads orders quads by graph and subject, keeping the order in which they first appear.
+// Writing grouped quads produces the most compact Turtle and TriG output.
+func GroupQuads(quads []Quad) []Quad {
+	type key struct{ graph, subject string }
+	graphOrder := make(map[string]int)
+	subjectOrder := make(map[key]int)
+	keyOf := func(quad Quad) key {
+		return key{termID(graphOrDefault(quad.Graph)), quad.Subject.String()}
+	}
+	for _, quad := range quads {
+		k := keyOf(quad)
+		if _, ok := graphOrder[k.graph]; !ok {
+			graphOrder[k.graph] = len(graphOrder)
+		}
+		if _, ok := subjectOrder[k]; !ok {
+			subjectOrder[k] = len(subjectOrder)
+		}
+	}
+	grouped := append([]Quad(nil), quads...)
+	sort.SliceStable(grouped, func(i, j int) bool {
+		ki, kj := keyOf(grouped[i]), keyOf(grouped[j])
+		if graphOrder[ki.graph] != graphOrder[kj.graph] {
+			return graphOrder[ki.graph] < graphOrder[kj.graph]
+		}
+		return subjectOrder[ki] < subjectOrder[kj]
+	})
+	// Within a subject, keep quads with the same predicate together
+	for start := 0; start < len(grouped); {
+		end := start + 1
+		for end < len(grouped) && grouped[end].Subject.Equals(grouped[start].Subject) &&
+			graphOrDefault(grouped[end].Graph).Equals(graphOrDefault(grouped[start].Graph)) {
+			end++
+		}
+		groupByPredicate(grouped[start:end])
+		start = end
+	}
+	return grouped
+}
+
+func groupByPredicate(quads []Quad) {
+	order := make(map[string]int)
+	for _, quad := range quads {
+		if _, ok := order[quad.Predicate.String()]; !ok {
+			order[quad.Predicate.String()] = len(order)
+		}
+	}
+	sort.SliceStable(quads, func(i, j int) bool {
+		return order[quads[i].Predicate.String()] < order[quads[j].Predicate.String()]
+	})
+}
@@ -4,37 +4,203 @@ import "solid-go/internal/util/n3"
 
 // ACL contains Web Access Control vocabulary terms
 var ACL = struct {
+	AccessControl      n3.Term
+	AccessTo           n3.Term
 	Agent              n3.Term
 	AgentClass         n3.Term
 	AgentGroup         n3.Term
+	Append             n3.Term
 	AuthenticatedAgent n3.Term
+	Authorization      n3.Term
+	Control            n3.Term
+	Default            n3.Term
+	Mode               n3.Term
+	Origin             n3.Term
+	Read               n3.Term
+	Write              n3.Term
 }{
-	Agent:              &BasicTerm{value: "http://www.w3.org/ns/auth/acl#agent"},
-	AgentClass:         &BasicTerm{value: "http://www.w3.org/ns/auth/acl#agentClass"},
-	AgentGroup:         &BasicTerm{value: "http://www.w3.org/ns/auth/acl#agentGroup"},
-	AuthenticatedAgent: &BasicTerm{value: "http://www.w3.org/ns/auth/acl#AuthenticatedAgent"},
+	AccessControl:      n3.NewNamedNode("http://www.w3.org/ns/auth/acl#accessControl"),
+	AccessTo:           n3.NewNamedNode("http://www.w3.org/ns/auth/acl#accessTo"),
+	Agent:              n3.NewNamedNode("http://www.w3.org/ns/auth/acl#agent"),
+	AgentClass:         n3.NewNamedNode("http://www.w3.org/ns/auth/acl#agentClass"),
+	AgentGroup:         n3.NewNamedNode("http://www.w3.org/ns/auth/acl#agentGroup"),
+	Append:             n3.NewNamedNode("http://www.w3.org/ns/auth/acl#Append"),
+	AuthenticatedAgent: n3.NewNamedNode("http://www.w3.org/ns/auth/acl#AuthenticatedAgent"),
+	Authorization:      n3.NewNamedNode("http://www.w3.org/ns/auth/acl#Authorization"),
+	Control:            n3.NewNamedNode("http://www.w3.org/ns/auth/acl#Control"),
+	Default:            n3.NewNamedNode("http://www.w3.org/ns/auth/acl#default"),
+	Mode:               n3.NewNamedNode("http://www.w3.org/ns/auth/acl#mode"),
+	Origin:             n3.NewNamedNode("http://www.w3.org/ns/auth/acl#origin"),
+	Read:               n3.NewNamedNode("http://www.w3.org/ns/auth/acl#Read"),
+	Write:              n3.NewNamedNode("http://www.w3.org/ns/auth/acl#Write"),
+}
+
+// ACP contains Access Control Policy vocabulary terms
+var ACP = struct {
+	AccessControl         n3.Term
+	AccessControlResource n3.Term
+	Agent                 n3.Term
+	AllOf                 n3.Term
+	Allow                 n3.Term
+	AnyOf                 n3.Term
+	Apply                 n3.Term
+	Attribute             n3.Term
+	AuthenticatedAgent    n3.Term
+	Client                n3.Term
+	CreatorAgent          n3.Term
+	Deny                  n3.Term
+	Grant                 n3.Term
+	Issuer                n3.Term
+	MemberAccessControl   n3.Term
+	NoneOf                n3.Term
+	OwnerAgent            n3.Term
+	PublicAgent           n3.Term
+	PublicClient          n3.Term
+	PublicIssuer          n3.Term
+	Vc                    n3.Term
+}{
+	AccessControl:         n3.NewNamedNode("http://www.w3.org/ns/solid/acp#accessControl"),
+	AccessControlResource: n3.NewNamedNode("http://www.w3.org/ns/solid/acp#AccessControlResource"),
+	Agent:                 n3.NewNamedNode("http://www.w3.org/ns/solid/acp#agent"),
+	AllOf:                 n3.NewNamedNode("http://www.w3.org/ns/solid/acp#allOf"),
+	Allow:                 n3.NewNamedNode("http://www.w3.org/ns/solid/acp#allow"),
+	AnyOf:                 n3.NewNamedNode("http://www.w3.org/ns/solid/acp#anyOf"),
+	Apply:                 n3.NewNamedNode("http://www.w3.org/ns/solid/acp#apply"),
+	Attribute:             n3.NewNamedNode("http://www.w3.org/ns/solid/acp#attribute"),
+	AuthenticatedAgent:    n3.NewNamedNode("http://www.w3.org/ns/solid/acp#AuthenticatedAgent"),
+	Client:                n3.NewNamedNode("http://www.w3.org/ns/solid/acp#client"),
+	CreatorAgent:          n3.NewNamedNode("http://www.w3.org/ns/solid/acp#CreatorAgent"),
+	Deny:                  n3.NewNamedNode("http://www.w3.org/ns/solid/acp#deny"),
+	Grant:                 n3.NewNamedNode("http://www.w3.org/ns/solid/acp#grant"),
+	Issuer:                n3.NewNamedNode("http://www.w3.org/ns/solid/acp#issuer"),
+	MemberAccessControl:   n3.NewNamedNode("http://www.w3.org/ns/solid/acp#memberAccessControl"),
+	NoneOf:                n3.NewNamedNode("http://www.w3.org/ns/solid/acp#noneOf"),
+	OwnerAgent:            n3.NewNamedNode("http://www.w3.org/ns/solid/acp#OwnerAgent"),
+	PublicAgent:           n3.NewNamedNode("http://www.w3.org/ns/solid/acp#PublicAgent"),
+	PublicClient:          n3.NewNamedNode("http://www.w3.org/ns/solid/acp#PublicClient"),
+	PublicIssuer:          n3.NewNamedNode("http://www.w3.org/ns/solid/acp#PublicIssuer"),
+	Vc:                    n3.NewNamedNode("http://www.w3.org/ns/solid/acp#vc"),
 }
 
 // FOAF contains Friend of a Friend vocabulary terms
 var FOAF = struct {
 	Agent n3.Term
 }{
-	Agent: &BasicTerm{value: "http://xmlns.com/foaf/0.1/Agent"},
+	Agent: n3.NewNamedNode("http://xmlns.com/foaf/0.1/Agent"),
 }
 
 // VCARD contains vCard vocabulary terms
 var VCARD = struct {
 	HasMember n3.Term
 }{
-	HasMember: &BasicTerm{value: "http://www.w3.org/2006/vcard/ns#hasMember"},
+	HasMember: n3.NewNamedNode("http://www.w3.org/2006/vcard/ns#hasMember"),
+}
+
+// AS contains Activity Streams vocabulary terms used to describe resource changes
+var AS = struct {
+	Activity n3.Term
+	Create   n3.Term
+	Update   n3.Term
+	Delete   n3.Term
+}{
+	Activity: n3.NewNamedNode("https://www.w3.org/ns/activitystreams#activity"),
+	Create:   n3.NewNamedNode("https://www.w3.org/ns/activitystreams#Create"),
+	Update:   n3.NewNamedNode("https://www.w3.org/ns/activitystreams#Update"),
+	Delete:   n3.NewNamedNode("https://www.w3.org/ns/activitystreams#Delete"),
+}
+
+// LDP contains Linked Data Platform vocabulary terms
+var LDP = struct {
+	Resource       n3.Term
+	Container      n3.Term
+	BasicContainer n3.Term
+	Contains       n3.Term
+}{
+	Resource:       n3.NewNamedNode("http://www.w3.org/ns/ldp#Resource"),
+	Container:      n3.NewNamedNode("http://www.w3.org/ns/ldp#Container"),
+	BasicContainer: n3.NewNamedNode("http://www.w3.org/ns/ldp#BasicContainer"),
+	Contains:       n3.NewNamedNode("http://www.w3.org/ns/ldp#contains"),
 }
 
-// BasicTerm is a simple implementation of the Term interface
-type BasicTerm struct {
-	value string
+// DC contains Dublin Core terms
+var DC = struct {
+	Modified n3.Term
+}{
+	Modified: n3.NewNamedNode("http://purl.org/dc/terms/modified"),
 }
 
-// Value implements n3.Term.Value
-func (t *BasicTerm) Value() string {
-	return t.value
+// NOTIFY contains Solid Notifications Protocol vocabulary terms
+var NOTIFY = struct {
+	WebSocketChannel2023 n3.Term
+}{
+	WebSocketChannel2023: n3.NewNamedNode("http://www.w3.org/ns/solid/notifications#WebSocketChannel2023"),
+}
+
+// POSIX contains POSIX stat vocabulary terms
+var POSIX = struct {
+	Size  n3.Term
+	Mtime n3.Term
+}{
+	Size:  n3.NewNamedNode("http://www.w3.org/ns/posix/stat#size"),
+	Mtime: n3.NewNamedNode("http://www.w3.org/ns/posix/stat#mtime"),
+}
+
+// MA contains Media Annotations vocabulary terms, used to persist content types
+var MA = struct {
+	Format n3.Term
+}{
+	Format: n3.NewNamedNode("http://www.w3.org/ns/ma-ont#format"),
+}
+
+// PIM contains Workspace vocabulary terms
+var PIM = struct {
+	Storage n3.Term
+}{
+	Storage: n3.NewNamedNode("http://www.w3.org/ns/pim/space#Storage"),
+}
+
+// POWDER_S contains Protocol for Web Description Resources terms
+var POWDER_S = struct {
+	DescribedBy n3.Term
+}{
+	DescribedBy: n3.NewNamedNode("http://www.w3.org/2007/05/powder-s#describedby"),
+}
+
+// RDF contains RDF vocabulary terms
+var RDF = struct {
+	Type n3.Term
+}{
+	Type: n3.NewNamedNode(n3.RDFType),
+}
+
+// SOLID contains Solid vocabulary terms
+var SOLID = struct {
+	InsertDeletePatch n3.Term
+	Deletes           n3.Term
+	Inserts           n3.Term
+	OidcIssuer        n3.Term
+	Where             n3.Term
+}{
+	InsertDeletePatch: n3.NewNamedNode("http://www.w3.org/ns/solid/terms#InsertDeletePatch"),
+	Deletes:           n3.NewNamedNode("http://www.w3.org/ns/solid/terms#deletes"),
+	Inserts:           n3.NewNamedNode("http://www.w3.org/ns/solid/terms#inserts"),
+	OidcIssuer:        n3.NewNamedNode("http://www.w3.org/ns/solid/terms#oidcIssuer"),
+	Where:             n3.NewNamedNode("http://www.w3.org/ns/solid/terms#where"),
+}
+
+// SOLID_HTTP contains terms for metadata that is written as HTTP headers
+var SOLID_HTTP = struct {
+	Location n3.Term
+	Slug     n3.Term
+}{
+	Location: n3.NewNamedNode("urn:npm:solid:community-server:http:location"),
+	Slug:     n3.NewNamedNode("urn:npm:solid:community-server:http:slug"),
+}
+
+// SOLID_META contains terms for metadata that is only relevant to the current request
+var SOLID_META = struct {
+	// ResponseMetadata is the graph of metadata that is never stored
+	ResponseMetadata n3.Term
+}{
+	ResponseMetadata: n3.NewNamedNode("urn:npm:solid:community-server:meta:ResponseMetadata"),
 }